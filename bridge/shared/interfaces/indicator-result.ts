@@ -1,6 +1,13 @@
 import { ResultTypes } from '../models/result-types';
 
-export type Target = { criteria: string; targetValue: number; violated: boolean };
+export type Target = {
+  criteria: string;
+  targetValue: number;
+  violated: boolean;
+  // range the value has to be in for statistical criteria (e.g. zscore<=3)
+  lowerBound?: number;
+  upperBound?: number;
+};
 
 export interface IndicatorResult {
   value: {
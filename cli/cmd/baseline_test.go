@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+)
+
+func TestSetBaselineCmd(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+	checkEndPointStatusMock = true
+
+	cmd := "set baseline --project=sockshop --stage=staging --service=carts --keptn-context=my-context --mock"
+	_, err := executeActionCommandC(cmd)
+	if err != nil {
+		t.Errorf(unexpectedErrMsg, err)
+	}
+}
+
+func TestDeleteBaselineCmd(t *testing.T) {
+	credentialmanager.MockAuthCreds = true
+	checkEndPointStatusMock = true
+
+	cmd := "delete baseline --project=sockshop --stage=staging --service=carts --mock"
+	_, err := executeActionCommandC(cmd)
+	if err != nil {
+		t.Errorf(unexpectedErrMsg, err)
+	}
+}
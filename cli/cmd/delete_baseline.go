@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	apiutils "github.com/keptn/go-utils/pkg/api/utils"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+var deleteBaselineParams *baselineCmdParams
+
+var delBaselineCmd = &cobra.Command{
+	Use:   "baseline --project=PROJECTNAME --stage=STAGE --service=SERVICENAME",
+	Short: "Deletes the evaluation baseline of a service in a stage",
+	Long: `Deletes the evaluation baseline of a service in a stage, which has been set using *keptn set baseline*.
+Evaluations using *compare_with: "baseline"* in their SLO file are not compared with a previous evaluation until a new baseline is set.
+`,
+	Example:      `keptn delete baseline --project=sockshop --stage=staging --service=carts`,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		endPoint, apiToken, err := credentialmanager.NewCredentialManager(assumeYes).GetCreds(namespace)
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+
+		if endPointErr := CheckEndpointStatus(endPoint.String()); endPointErr != nil {
+			return fmt.Errorf("Error connecting to server: %s"+endPointErrorReasons,
+				endPointErr)
+		}
+
+		logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+
+		if mocking {
+			fmt.Println("Skipping delete baseline due to mocking flag set to true")
+			return nil
+		}
+
+		resourceHandler := apiutils.NewAuthenticatedResourceHandler(endPoint.String(), apiToken, "x-token", nil, endPoint.Scheme)
+		if err := resourceHandler.DeleteServiceResource(*deleteBaselineParams.Project, *deleteBaselineParams.Stage, *deleteBaselineParams.Service, baselineResourceURI); err != nil {
+			return fmt.Errorf("Baseline could not be deleted: %v", err)
+		}
+
+		logging.PrintLog("Baseline has been deleted.", logging.InfoLevel)
+		return nil
+	},
+}
+
+func init() {
+	deleteCmd.AddCommand(delBaselineCmd)
+	deleteBaselineParams = &baselineCmdParams{}
+	deleteBaselineParams.Project = delBaselineCmd.Flags().StringP("project", "p", "", "The project containing the service")
+	delBaselineCmd.MarkFlagRequired("project")
+	deleteBaselineParams.Stage = delBaselineCmd.Flags().StringP("stage", "s", "", "The stage the baseline is deleted for")
+	delBaselineCmd.MarkFlagRequired("stage")
+	deleteBaselineParams.Service = delBaselineCmd.Flags().StringP("service", "", "", "The service the baseline is deleted for")
+	delBaselineCmd.MarkFlagRequired("service")
+}
@@ -0,0 +1,14 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+// evaluateCmd implements the command evaluate
+var evaluateCmd = &cobra.Command{
+	Use:   "evaluate [slo]",
+	Short: "Evaluates SLI values locally without sending events to Keptn",
+	Long:  "Evaluates SLI values locally without sending events to Keptn.",
+}
+
+func init() {
+	rootCmd.AddCommand(evaluateCmd)
+}
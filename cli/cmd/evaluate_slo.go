@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	keptnutils "github.com/keptn/kubernetes-utils/pkg"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+type evaluateSLOCmdParams struct {
+	SLO             *string
+	SLIValues       *string
+	PreviousResults *string
+	Output          *string
+}
+
+// dryRunEvaluationPath is the path of the dry-run evaluation endpoint of the lighthouse-service exposed by the API of Keptn
+const dryRunEvaluationPath = "/lighthouse/v1/evaluate"
+
+// dryRunEvaluationRequest is the body of a request to the dry-run evaluation endpoint of the lighthouse-service
+type dryRunEvaluationRequest struct {
+	SLO             string               `json:"slo"`
+	SLIValues       map[string]float64   `json:"sliValues"`
+	PreviousResults []map[string]float64 `json:"previousResults,omitempty"`
+}
+
+// dryRunEvaluationResult is the response of the dry-run evaluation endpoint. The evaluation details of the lighthouse-service extend
+// keptnv2.EvaluationDetails, the additional fields are only contained in the JSON and YAML output
+type dryRunEvaluationResult struct {
+	Evaluation keptnv2.EvaluationDetails `json:"evaluation"`
+	Message    string                    `json:"message,omitempty"`
+}
+
+var evaluateSLOParams *evaluateSLOCmdParams
+
+// evaluateSLOCmd implements the evaluate slo command
+var evaluateSLOCmd = &cobra.Command{
+	Use:   "slo --slo=SLO_FILE --sli-values=SLI_VALUES_FILE",
+	Short: "Evaluates SLI values against an SLO file without sending events to Keptn",
+	Long: `Evaluates SLI values against an SLO file without sending events to Keptn.
+
+The evaluation is conducted by the lighthouse-service as a dry-run, i.e., no events are sent and the evaluation is not added to the
+results used for comparisons, e.g., to test changes of an SLO file before they are added to a project. The SLI values are provided as a
+YAML or JSON file mapping the names of the SLIs to their values. Relative criteria, e.g., "<=+10%", are evaluated against the optional
+previous results, which contain the SLI values of previous evaluations starting with the most recent one.
+`,
+	Example: `keptn evaluate slo --slo=./slo.yaml --sli-values=./sli-values.yaml
+
+keptn evaluate slo --slo=./slo.yaml --sli-values=./sli-values.yaml --previous-results=./previous-results.yaml --output=json`,
+	SilenceUsage: true,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		output := strings.ToLower(*evaluateSLOParams.Output)
+		if output != "" && output != "yaml" && output != "json" {
+			return errors.New("Invalid output format, only yaml or json allowed")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		request, err := getDryRunEvaluationRequest(evaluateSLOParams)
+		if err != nil {
+			return err
+		}
+
+		var endPoint url.URL
+		var apiToken string
+		if !mocking {
+			endPoint, apiToken, err = credentialmanager.NewCredentialManager(assumeYes).GetCreds(namespace)
+		} else {
+			endPointPtr, _ := url.Parse(os.Getenv("MOCK_SERVER"))
+			endPoint = *endPointPtr
+			apiToken = ""
+		}
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+
+		if endPointErr := CheckEndpointStatus(endPoint.String()); endPointErr != nil {
+			return fmt.Errorf("Error connecting to server: %s"+endPointErrorReasons,
+				endPointErr)
+		}
+		logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+
+		response, err := sendDryRunEvaluation(endPoint.String(), apiToken, request)
+		if err != nil {
+			return fmt.Errorf("could not evaluate SLO: %w", err)
+		}
+		return printDryRunEvaluationResult(response, strings.ToLower(*evaluateSLOParams.Output))
+	},
+}
+
+func getDryRunEvaluationRequest(params *evaluateSLOCmdParams) (*dryRunEvaluationRequest, error) {
+	sloContent, err := ioutil.ReadFile(keptnutils.ExpandTilde(*params.SLO))
+	if err != nil {
+		return nil, fmt.Errorf("could not read SLO file: %w", err)
+	}
+	request := &dryRunEvaluationRequest{SLO: string(sloContent)}
+
+	sliValues, err := ioutil.ReadFile(keptnutils.ExpandTilde(*params.SLIValues))
+	if err != nil {
+		return nil, fmt.Errorf("could not read SLI values: %w", err)
+	}
+	if err := yaml.Unmarshal(sliValues, &request.SLIValues); err != nil {
+		return nil, fmt.Errorf("could not parse SLI values: %w", err)
+	}
+
+	if *params.PreviousResults != "" {
+		previousResults, err := ioutil.ReadFile(keptnutils.ExpandTilde(*params.PreviousResults))
+		if err != nil {
+			return nil, fmt.Errorf("could not read previous results: %w", err)
+		}
+		if err := yaml.Unmarshal(previousResults, &request.PreviousResults); err != nil {
+			return nil, fmt.Errorf("could not parse previous results: %w", err)
+		}
+	}
+	return request, nil
+}
+
+// sendDryRunEvaluation sends the request to the dry-run evaluation endpoint and returns the JSON response
+func sendDryRunEvaluation(endPoint string, apiToken string, request *dryRunEvaluationRequest) ([]byte, error) {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(endPoint, "/")+dryRunEvaluationPath, bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-token", apiToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		respErr := apimodels.Error{}
+		if err := json.Unmarshal(body, &respErr); err != nil || respErr.GetMessage() == "" {
+			return nil, errors.New(resp.Status)
+		}
+		return nil, errors.New(respErr.GetMessage())
+	}
+	return body, nil
+}
+
+func printDryRunEvaluationResult(response []byte, output string) error {
+	switch output {
+	case "yaml":
+		// convert the JSON response to use the same field names in both formats
+		var content interface{}
+		if err := json.Unmarshal(response, &content); err != nil {
+			return err
+		}
+		yamlBytes, err := yaml.Marshal(content)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(yamlBytes))
+		return nil
+	case "json":
+		var content bytes.Buffer
+		if err := json.Indent(&content, response, "", "   "); err != nil {
+			return err
+		}
+		fmt.Println(content.String())
+		return nil
+	}
+
+	result := &dryRunEvaluationResult{}
+	if err := json.Unmarshal(response, result); err != nil {
+		return fmt.Errorf("could not parse evaluation result: %w", err)
+	}
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 10, 8, 2, '\t', 0)
+	fmt.Fprintln(w, "SLI\tVALUE\tSTATUS\tSCORE")
+	for _, indicatorResult := range result.Evaluation.IndicatorResults {
+		value := fmt.Sprintf("%v", indicatorResult.Value.Value)
+		if !indicatorResult.Value.Success {
+			value = "n/a"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%v\n", indicatorResult.Value.Metric, value, indicatorResult.Status, indicatorResult.Score)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("\nResult: %s (score: %.2f)\n", result.Evaluation.Result, result.Evaluation.Score)
+	if result.Message != "" {
+		fmt.Println(result.Message)
+	}
+	return nil
+}
+
+func init() {
+	evaluateCmd.AddCommand(evaluateSLOCmd)
+	evaluateSLOParams = &evaluateSLOCmdParams{}
+
+	evaluateSLOParams.SLO = evaluateSLOCmd.Flags().StringP("slo", "", "", "The SLO file")
+	evaluateSLOCmd.MarkFlagRequired("slo")
+	evaluateSLOParams.SLIValues = evaluateSLOCmd.Flags().StringP("sli-values", "", "", "A YAML or JSON file containing the values of the SLIs")
+	evaluateSLOCmd.MarkFlagRequired("sli-values")
+	evaluateSLOParams.PreviousResults = evaluateSLOCmd.Flags().StringP("previous-results", "", "",
+		"A YAML or JSON file containing a list of SLI values of previous evaluations, starting with the most recent one")
+	evaluateSLOParams.Output = evaluateSLOCmd.Flags().StringP("output", "o", "", "Output format. One of json|yaml")
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const evaluateSLOFile = `---
+spec_version: "1.0"
+comparison:
+  compare_with: "several_results"
+  number_of_comparison_results: 2
+objectives:
+  - sli: "response_time"
+    pass:
+      - criteria:
+          - "<=+10%"
+          - "<600"
+  - sli: "error_rate"
+    pass:
+      - criteria:
+          - "<5"
+total_score:
+  pass: "90%"
+  warning: "50%"
+`
+
+func writeEvaluateSLOFiles(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "evaluate-slo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"slo.yaml":              evaluateSLOFile,
+		"sli-values.yaml":       "response_time: 500\nerror_rate: 1\n",
+		"previous-results.json": `[{"response_time": 400}, {"response_time": 400}]`,
+		"invalid.yaml":          "response_time: [",
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+const evaluateSLOResponse = `{
+  "evaluation": {
+    "result": "pass",
+    "score": 100,
+    "indicatorResults": [
+      {"value": {"metric": "error_rate", "value": 1, "success": true}, "score": 1, "status": "pass", "passTargets": [{"criteria": "<5", "targetValue": 5, "violated": false}]},
+      {"value": {"metric": "response_time", "value": 500, "success": true}, "score": 1, "status": "pass", "passTargets": [{"criteria": "<600", "targetValue": 600, "violated": false}]}
+    ]
+  }
+}`
+
+func TestEvaluateSLOCmd(t *testing.T) {
+	dir := writeEvaluateSLOFiles(t)
+	defer os.RemoveAll(dir)
+
+	var receivedRequest dryRunEvaluationRequest
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			return
+		}
+		if r.Method != http.MethodPost || r.URL.Path != "/lighthouse/v1/evaluate" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		receivedRequest = dryRunEvaluationRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&receivedRequest); err != nil || receivedRequest.SLO != evaluateSLOFile {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":400,"message":"could not parse SLO"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(evaluateSLOResponse))
+	}))
+	defer ts.Close()
+
+	mocking = true
+	os.Setenv("MOCK_SERVER", ts.URL)
+
+	tests := []struct {
+		name                string
+		args                string
+		slo                 string
+		wantOutput          []string
+		wantPreviousResults int
+		wantErr             bool
+	}{
+		{
+			name:       "evaluation passes",
+			args:       "--output=",
+			wantOutput: []string{"response_time", "error_rate", "Result: pass (score: 100.00)"},
+		},
+		{
+			name:                "evaluation with previous results",
+			args:                fmt.Sprintf("--previous-results=%s --output=", filepath.Join(dir, "previous-results.json")),
+			wantOutput:          []string{"Result: pass (score: 100.00)"},
+			wantPreviousResults: 2,
+		},
+		{
+			name:       "json output",
+			args:       "--previous-results= --output=json",
+			wantOutput: []string{`"result": "pass"`, `"indicatorResults"`, `"passTargets"`},
+		},
+		{
+			name:       "yaml output",
+			args:       "--output=yaml",
+			wantOutput: []string{"result: pass", "indicatorResults:", "passTargets:"},
+		},
+		{
+			name:    "invalid SLO",
+			args:    "--output=",
+			slo:     filepath.Join(dir, "invalid.yaml"),
+			wantErr: true,
+		},
+		{
+			name:    "invalid output format",
+			args:    "--output=xml",
+			wantErr: true,
+		},
+		{
+			name:    "invalid previous results",
+			args:    fmt.Sprintf("--previous-results=%s --output=", filepath.Join(dir, "invalid.yaml")),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slo := tt.slo
+			if slo == "" {
+				slo = filepath.Join(dir, "slo.yaml")
+			}
+			cmd := fmt.Sprintf("evaluate slo --slo=%s --sli-values=%s --previous-results= %s", slo, filepath.Join(dir, "sli-values.yaml"), tt.args)
+
+			r := newRedirector()
+			r.redirectStdOut()
+			_, err := executeActionCommandC(cmd)
+			out := r.revertStdOut()
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf(unexpectedErrMsg, err)
+			}
+			if len(receivedRequest.PreviousResults) != tt.wantPreviousResults {
+				t.Errorf("expected %d previous results to be sent, but got %d", tt.wantPreviousResults, len(receivedRequest.PreviousResults))
+			}
+			if receivedRequest.SLIValues["response_time"] != 500 || receivedRequest.SLIValues["error_rate"] != 1 {
+				t.Errorf("unexpected SLI values sent: %v", receivedRequest.SLIValues)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(out, want) {
+					t.Errorf("output does not contain %s:\n%s", want, out)
+				}
+			}
+		})
+	}
+}
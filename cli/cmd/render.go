@@ -0,0 +1,14 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+// renderCmd implements the command render
+var renderCmd = &cobra.Command{
+	Use:   "render [webhook]",
+	Short: "Renders configuration files locally without sending events to Keptn",
+	Long:  "Renders configuration files locally without sending events to Keptn.",
+}
+
+func init() {
+	rootCmd.AddCommand(renderCmd)
+}
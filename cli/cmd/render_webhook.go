@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/keptn/keptn/go-sdk/pkg/sdk"
+	"github.com/keptn/keptn/webhook-service/lib"
+	keptnutils "github.com/keptn/kubernetes-utils/pkg"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+type renderWebhookCmdParams struct {
+	WebhookConfig  *string
+	Event          *string
+	SubscriptionID *string
+	Secrets        *[]string
+}
+
+var renderWebhookParams *renderWebhookCmdParams
+
+// renderWebhookCmd implements the render webhook command
+var renderWebhookCmd = &cobra.Command{
+	Use:   "webhook --webhook-config=WEBHOOK_CONFIG_FILE --event=EVENT_FILE",
+	Short: "Renders the requests of a webhook against a sample event without executing them",
+	Long: `Renders the requests of a webhook against a sample event without executing them.
+
+The templates of the requests are rendered locally, using the same template functions as the webhook service, e.g., to debug a webhook
+configuration before it is added to a project. The event is provided as a JSON file containing a Keptn CloudEvent. The webhook is selected by the
+subscription ID, or by the type of the event if no subscription ID is given. Secrets are not read from the Keptn installation,
+instead their values can be set using the --secret flag. Secrets without a value are rendered as '***'.
+`,
+	Example: `keptn render webhook --webhook-config=./webhook.yaml --event=./deployment.triggered.json
+
+keptn render webhook --webhook-config=./webhook.yaml --event=./deployment.triggered.json --subscription-id=my-subscription-id --secret=token=my-token`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		webhookConfig, err := ioutil.ReadFile(keptnutils.ExpandTilde(*renderWebhookParams.WebhookConfig))
+		if err != nil {
+			return fmt.Errorf("could not read webhook config: %w", err)
+		}
+		eventContent, err := ioutil.ReadFile(keptnutils.ExpandTilde(*renderWebhookParams.Event))
+		if err != nil {
+			return fmt.Errorf("could not read event: %w", err)
+		}
+		event := sdk.KeptnEvent{}
+		if err := json.Unmarshal(eventContent, &event); err != nil {
+			return fmt.Errorf("could not parse event: %w", err)
+		}
+		secrets, err := parseRenderWebhookSecrets(*renderWebhookParams.Secrets)
+		if err != nil {
+			return err
+		}
+
+		requests, err := lib.RenderWebhook(webhookConfig, event, *renderWebhookParams.SubscriptionID, secrets)
+		if err != nil {
+			return fmt.Errorf("could not render webhook: %w", err)
+		}
+		output, err := yaml.Marshal(requests)
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(output))
+		return nil
+	},
+}
+
+func parseRenderWebhookSecrets(values []string) (map[string]string, error) {
+	secrets := map[string]string{}
+	for _, value := range values {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("invalid secret '%s', the format needs to be NAME=VALUE", value)
+		}
+		secrets[parts[0]] = parts[1]
+	}
+	return secrets, nil
+}
+
+func init() {
+	renderCmd.AddCommand(renderWebhookCmd)
+	renderWebhookParams = &renderWebhookCmdParams{}
+
+	renderWebhookParams.WebhookConfig = renderWebhookCmd.Flags().StringP("webhook-config", "", "", "The webhook configuration file")
+	renderWebhookCmd.MarkFlagRequired("webhook-config")
+	renderWebhookParams.Event = renderWebhookCmd.Flags().StringP("event", "", "", "A JSON file containing the event the webhook is rendered against")
+	renderWebhookCmd.MarkFlagRequired("event")
+	renderWebhookParams.SubscriptionID = renderWebhookCmd.Flags().StringP("subscription-id", "", "", "The subscription ID of the webhook to render")
+	renderWebhookParams.Secrets = renderWebhookCmd.Flags().StringArrayP("secret", "", []string{},
+		"The value of a secret referenced within 'envFrom' in the format NAME=VALUE, can be used multiple times")
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const renderWebhookConfigFile = `apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.deployment.triggered"
+      subscriptionID: "my-subscription-id"
+      envFrom:
+        - name: token
+          secretRef:
+            name: my-secret
+            key: token
+      requests:
+        - "curl --fail-with-body -H 'Authorization: {{.env.token}}' https://my.hook.com/{{.data.project | urlEncode}}"
+        - method: POST
+          url: "https://my.hook.com/{{.data.stage}}"
+          payload: '{"service": "{{.data.service | upper}}"}'
+`
+
+const renderWebhookEvent = `{
+  "type": "sh.keptn.event.deployment.triggered",
+  "specversion": "1.0",
+  "source": "test",
+  "data": {"project": "my project", "stage": "dev", "service": "carts"}
+}`
+
+func writeRenderWebhookFiles(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "render-webhook")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"webhook.yaml": renderWebhookConfigFile,
+		"event.json":   renderWebhookEvent,
+		"invalid.json": "{",
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestRenderWebhookCmd(t *testing.T) {
+	dir := writeRenderWebhookFiles(t)
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name       string
+		args       string
+		wantOutput []string
+		wantErr    bool
+	}{
+		{
+			name: "render webhook selected by event type",
+			args: fmt.Sprintf("--event=%s --subscription-id=", filepath.Join(dir, "event.json")),
+			wantOutput: []string{
+				"Authorization: ***",
+				"https://my.hook.com/my+project",
+				"url: https://my.hook.com/dev",
+				`payload: '{"service": "CARTS"}'`,
+			},
+		},
+		{
+			name:       "render webhook with secret",
+			args:       fmt.Sprintf("--event=%s --subscription-id=my-subscription-id --secret=token=my-token", filepath.Join(dir, "event.json")),
+			wantOutput: []string{"Authorization: my-token"},
+		},
+		{
+			name:    "unknown subscription ID",
+			args:    fmt.Sprintf("--event=%s --subscription-id=unknown", filepath.Join(dir, "event.json")),
+			wantErr: true,
+		},
+		{
+			name:    "invalid event",
+			args:    fmt.Sprintf("--event=%s --subscription-id=", filepath.Join(dir, "invalid.json")),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := fmt.Sprintf("render webhook --webhook-config=%s %s", filepath.Join(dir, "webhook.yaml"), tt.args)
+
+			r := newRedirector()
+			r.redirectStdOut()
+			_, err := executeActionCommandC(cmd)
+			out := r.revertStdOut()
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf(unexpectedErrMsg, err)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(out, want) {
+					t.Errorf("output does not contain %s:\n%s", want, out)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRenderWebhookSecrets(t *testing.T) {
+	secrets, err := parseRenderWebhookSecrets([]string{"token=my=token", "user="})
+	if err != nil {
+		t.Errorf(unexpectedErrMsg, err)
+	}
+	if secrets["token"] != "my=token" || secrets["user"] != "" {
+		t.Errorf("unexpected secrets: %v", secrets)
+	}
+	if _, err := parseRenderWebhookSecrets([]string{"token"}); err == nil {
+		t.Errorf("expected an error, but got none")
+	}
+}
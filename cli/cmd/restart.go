@@ -0,0 +1,12 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+var restartCmd = &cobra.Command{
+	Use:   "restart [ sequence ]",
+	Short: "Restarts the execution of a sequence",
+}
+
+func init() {
+	rootCmd.AddCommand(restartCmd)
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+)
+
+var restartSequenceParams restartSequenceStruct
+
+var restartSequenceCmd = &cobra.Command{
+	Use:   "sequence",
+	Short: "Restarts a finished sequence",
+	Long: `Restarts a finished sequence within the same Keptn context. The sequence continues with the task provided via --from-task,
+using the results of the tasks before it. If no task is provided, the sequence continues with the first task that has not been successful.`,
+	Example:      `keptn restart sequence --project <my-project> --keptn-context <keptn-context> --stage <my-stage> --from-task <task>`,
+	SilenceUsage: true,
+	Args: func(cmd *cobra.Command, args []string) error {
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := RestartSequence(restartSequenceParams); err != nil {
+			return err
+		}
+		fmt.Println("Successfully restarted sequence")
+		return nil
+	},
+}
+
+func init() {
+	restartCmd.AddCommand(restartSequenceCmd)
+	restartSequenceParams.keptnContext = restartSequenceCmd.Flags().StringP("keptn-context", "c", "",
+		"The Keptn context the sequence execution is bound to")
+	restartSequenceParams.project = restartSequenceCmd.Flags().StringP("project", "p", "",
+		"The Keptn project the sequence belongs to")
+	restartSequenceParams.stage = restartSequenceCmd.Flags().StringP("stage", "s", "",
+		"The Keptn stage in which the sequence shall be restarted")
+	restartSequenceParams.fromTask = restartSequenceCmd.Flags().StringP("from-task", "", "",
+		"The task the sequence continues with. Defaults to the first task that has not been successful")
+	restartSequenceCmd.MarkFlagRequired("keptn-context")
+	restartSequenceCmd.MarkFlagRequired("project")
+	restartSequenceCmd.MarkFlagRequired("stage")
+}
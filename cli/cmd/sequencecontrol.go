@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
 	apiutils "github.com/keptn/go-utils/pkg/api/utils"
 	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"io/ioutil"
+	"net/http"
 )
 
 type sequenceControlStruct struct {
@@ -13,12 +18,26 @@ type sequenceControlStruct struct {
 	stage        *string
 }
 
+type restartSequenceStruct struct {
+	sequenceControlStruct
+	fromTask *string
+}
+
+// restartSequenceBody extends the sequence control command with the task a restarted sequence continues with
+type restartSequenceBody struct {
+	apiutils.SequenceControlBody
+	FromTask string `json:"fromTask,omitempty"`
+}
+
+const sequenceControlPath = "/v1/sequence/%s/%s/control"
+
 type SequenceState string
 
 const (
-	pauseSequence  SequenceState = "pause"
-	resumeSequence SequenceState = "resume"
-	abortSequence  SequenceState = "abort"
+	pauseSequence   SequenceState = "pause"
+	resumeSequence  SequenceState = "resume"
+	abortSequence   SequenceState = "abort"
+	restartSequence SequenceState = "restart"
 )
 
 func AbortSequence(params sequenceControlStruct) error {
@@ -33,16 +52,71 @@ func ResumeSequence(params sequenceControlStruct) error {
 	return controlSequence(resumeSequence, params)
 }
 
-func controlSequence(sequenceState SequenceState, params sequenceControlStruct) error {
+// RestartSequence restarts a finished sequence, either from the given task, or from the first task that has not been successful
+func RestartSequence(params restartSequenceStruct) error {
+	sequenceControlHandler, err := getSequenceControlHandler()
+	if err != nil {
+		return err
+	}
+	// the fromTask property is not part of the sequence control body of the go-utils, therefore the request is sent directly
+	return sendRestartSequence(sequenceControlHandler, params)
+}
+
+func sendRestartSequence(sequenceControlHandler *apiutils.SequenceControlHandler, params restartSequenceStruct) error {
+	payload, err := json.Marshal(restartSequenceBody{
+		SequenceControlBody: apiutils.SequenceControlBody{
+			Stage: *params.stage,
+			State: string(restartSequence),
+		},
+		FromTask: *params.fromTask,
+	})
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s://%s"+sequenceControlPath, sequenceControlHandler.Scheme, sequenceControlHandler.BaseURL, *params.project, *params.keptnContext)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(sequenceControlHandler.AuthHeader, sequenceControlHandler.AuthToken)
+
+	resp, err := sequenceControlHandler.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode <= 204 {
+		return nil
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	respErr := apimodels.Error{}
+	if err := json.Unmarshal(body, &respErr); err != nil || respErr.GetMessage() == "" {
+		return fmt.Errorf("could not restart sequence: %s", resp.Status)
+	}
+	return errors.New(respErr.GetMessage())
+}
+
+func getSequenceControlHandler() (*apiutils.SequenceControlHandler, error) {
 	endPoint, apiToken, err := credentialmanager.NewCredentialManager(assumeYes).GetCreds(namespace)
 	if err != nil {
-		return errors.New(authErrorMsg)
+		return nil, errors.New(authErrorMsg)
 	}
 	if endPointErr := CheckEndpointStatus(endPoint.String()); endPointErr != nil {
-		return fmt.Errorf("Error connecting to server: %s"+endPointErrorReasons,
+		return nil, fmt.Errorf("Error connecting to server: %s"+endPointErrorReasons,
 			endPointErr)
 	}
-	sequenceControlHandler := apiutils.NewAuthenticatedSequenceControlHandler(endPoint.String(), apiToken, "x-token", nil, endPoint.Scheme)
+	return apiutils.NewAuthenticatedSequenceControlHandler(endPoint.String(), apiToken, "x-token", nil, endPoint.Scheme), nil
+}
+
+func controlSequence(sequenceState SequenceState, params sequenceControlStruct) error {
+	sequenceControlHandler, err := getSequenceControlHandler()
+	if err != nil {
+		return err
+	}
 	controlParams := apiutils.SequenceControlParams{
 		Project:      *params.project,
 		KeptnContext: *params.keptnContext,
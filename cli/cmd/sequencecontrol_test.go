@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"encoding/json"
+	apiutils "github.com/keptn/go-utils/pkg/api/utils"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSendRestartSequence(t *testing.T) {
+	tests := []struct {
+		name           string
+		fromTask       string
+		responseStatus int
+		responseBody   string
+		wantErr        string
+	}{
+		{
+			name:           "restart from task",
+			fromTask:       "test",
+			responseStatus: http.StatusOK,
+			responseBody:   `{}`,
+		},
+		{
+			name:           "sequence still running",
+			responseStatus: http.StatusConflict,
+			responseBody:   `{"code":409,"message":"Could not restart sequence: sequence is still running"}`,
+			wantErr:        "Could not restart sequence: sequence is still running",
+		},
+		{
+			name:           "error without message",
+			responseStatus: http.StatusInternalServerError,
+			wantErr:        "could not restart sequence: 500 Internal Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var receivedBody map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				require.Equal(t, http.MethodPost, request.Method)
+				require.Equal(t, "/controlPlane/v1/sequence/my-project/my-context/control", request.URL.Path)
+				require.Equal(t, "my-token", request.Header.Get("x-token"))
+				body, _ := ioutil.ReadAll(request.Body)
+				require.Nil(t, json.Unmarshal(body, &receivedBody))
+				writer.WriteHeader(tt.responseStatus)
+				writer.Write([]byte(tt.responseBody))
+			}))
+			defer server.Close()
+
+			handler := apiutils.NewAuthenticatedSequenceControlHandler(server.URL, "my-token", "x-token", nil, "http")
+			err := sendRestartSequence(handler, restartSequenceStruct{
+				sequenceControlStruct: sequenceControlStruct{
+					keptnContext: stringp("my-context"),
+					project:      stringp("my-project"),
+					stage:        stringp("dev"),
+				},
+				fromTask: stringp(tt.fromTask),
+			})
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+			} else {
+				require.Nil(t, err)
+			}
+
+			require.Equal(t, "restart", receivedBody["state"])
+			require.Equal(t, "dev", receivedBody["stage"])
+			if tt.fromTask != "" {
+				require.Equal(t, tt.fromTask, receivedBody["fromTask"])
+			} else {
+				require.NotContains(t, receivedBody, "fromTask")
+			}
+		})
+	}
+}
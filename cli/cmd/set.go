@@ -4,9 +4,9 @@ import "github.com/spf13/cobra"
 
 // setCmd implements the command set
 var setCmd = &cobra.Command{
-	Use:   "set [config]",
-	Short: `Sets flags of the CLI configuration`,
-	Long:  `Sets flags of the CLI configuration.`,
+	Use:   "set [config|baseline]",
+	Short: `Sets flags of the CLI configuration or the evaluation baseline of a service`,
+	Long:  `Sets flags of the CLI configuration or the evaluation baseline of a service.`,
 }
 
 func init() {
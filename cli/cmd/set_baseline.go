@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	apimodels "github.com/keptn/go-utils/pkg/api/models"
+	apiutils "github.com/keptn/go-utils/pkg/api/utils"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/cli/pkg/credentialmanager"
+	"github.com/keptn/keptn/cli/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+// baselineResourceURI is the resource of a service the lighthouse-service reads the evaluation baseline from
+const baselineResourceURI = "lighthouse/baseline.yaml"
+
+type baselineCmdParams struct {
+	Project      *string
+	Stage        *string
+	Service      *string
+	KeptnContext *string
+}
+
+var setBaselineParams *baselineCmdParams
+
+var setBaselineCmd = &cobra.Command{
+	Use:   "baseline --project=PROJECTNAME --stage=STAGE --service=SERVICENAME --keptn-context=KEPTN_CONTEXT",
+	Short: "Pins an evaluation as the baseline of a service in a stage",
+	Long: `Pins the evaluation identified by its Keptn context as the baseline of a service in a stage.
+Evaluations using *compare_with: "baseline"* in their SLO file are compared with this evaluation until the baseline is replaced or deleted.
+
+The baseline is stored as the resource *` + baselineResourceURI + `* of the service.
+`,
+	Example:      `keptn set baseline --project=sockshop --stage=staging --service=carts --keptn-context=2a9ab1d5-1f38-4a26-8c5c-4b4b4cbfc4cb`,
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		endPoint, apiToken, err := credentialmanager.NewCredentialManager(assumeYes).GetCreds(namespace)
+		if err != nil {
+			return errors.New(authErrorMsg)
+		}
+
+		if endPointErr := CheckEndpointStatus(endPoint.String()); endPointErr != nil {
+			return fmt.Errorf("Error connecting to server: %s"+endPointErrorReasons,
+				endPointErr)
+		}
+
+		logging.PrintLog(fmt.Sprintf("Connecting to server %s", endPoint.String()), logging.VerboseLevel)
+
+		if mocking {
+			fmt.Println("Skipping set baseline due to mocking flag set to true")
+			return nil
+		}
+
+		eventHandler := apiutils.NewAuthenticatedEventHandler(endPoint.String(), apiToken, "x-token", nil, endPoint.Scheme)
+		evaluations, errorObj := eventHandler.GetEvents(&apiutils.EventFilter{
+			KeptnContext: *setBaselineParams.KeptnContext,
+			EventType:    keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName),
+			Project:      *setBaselineParams.Project,
+			Stage:        *setBaselineParams.Stage,
+			Service:      *setBaselineParams.Service,
+		})
+		if errorObj != nil {
+			return fmt.Errorf("Could not retrieve evaluation: %s", *errorObj.Message)
+		}
+		if len(evaluations) == 0 {
+			return fmt.Errorf("No evaluation of service %s in stage %s found for Keptn context %s",
+				*setBaselineParams.Service, *setBaselineParams.Stage, *setBaselineParams.KeptnContext)
+		}
+
+		resourceURI := baselineResourceURI
+		resourceHandler := apiutils.NewAuthenticatedResourceHandler(endPoint.String(), apiToken, "x-token", nil, endPoint.Scheme)
+		_, errorObj = resourceHandler.CreateResources(*setBaselineParams.Project, *setBaselineParams.Stage, *setBaselineParams.Service, []*apimodels.Resource{
+			{
+				ResourceURI:     &resourceURI,
+				ResourceContent: fmt.Sprintf("keptnContext: %s\n", *setBaselineParams.KeptnContext),
+			},
+		})
+		if errorObj != nil {
+			return fmt.Errorf("Baseline could not be set: %s", *errorObj.Message)
+		}
+
+		logging.PrintLog("Baseline has been set.", logging.InfoLevel)
+		return nil
+	},
+}
+
+func init() {
+	setCmd.AddCommand(setBaselineCmd)
+	setBaselineParams = &baselineCmdParams{}
+	setBaselineParams.Project = setBaselineCmd.Flags().StringP("project", "p", "", "The project containing the service")
+	setBaselineCmd.MarkFlagRequired("project")
+	setBaselineParams.Stage = setBaselineCmd.Flags().StringP("stage", "s", "", "The stage the baseline is set for")
+	setBaselineCmd.MarkFlagRequired("stage")
+	setBaselineParams.Service = setBaselineCmd.Flags().StringP("service", "", "", "The service the baseline is set for")
+	setBaselineCmd.MarkFlagRequired("service")
+	setBaselineParams.KeptnContext = setBaselineCmd.Flags().StringP("keptn-context", "c", "", "The Keptn context of the evaluation to use as baseline")
+	setBaselineCmd.MarkFlagRequired("keptn-context")
+}
@@ -10,6 +10,8 @@ require (
 	github.com/google/uuid v1.3.0
 	github.com/hashicorp/go-version v1.2.0
 	github.com/keptn/go-utils v0.11.1-0.20211215105940-5626bf92b8c6
+	github.com/keptn/keptn/go-sdk v0.0.0-20211215141221-491a19a96c50
+	github.com/keptn/keptn/webhook-service v0.0.0-00010101000000-000000000000
 	github.com/keptn/kubernetes-utils v0.10.1-0.20211102080304-e59377afdc8b
 	github.com/mattn/go-shellwords v1.0.12
 	github.com/mitchellh/mapstructure v1.4.3
@@ -31,3 +33,6 @@ replace (
 	github.com/docker/distribution => github.com/docker/distribution v0.0.0-20191216044856-a8371794149d
 	github.com/docker/docker => github.com/moby/moby v17.12.0-ce-rc1.0.20200618181300-9dc6525e6118+incompatible
 )
+
+// the webhook templates of the webhook-service are rendered locally
+replace github.com/keptn/keptn/webhook-service => ../webhook-service
@@ -35,6 +35,18 @@ type EnvConfig struct {
 	K8sNamespace         string `envconfig:"K8S_NAMESPACE" default:""`
 	K8sPodName           string `envconfig:"K8S_POD_NAME" default:""`
 	K8sNodeName          string `envconfig:"K8S_NODE_NAME" default:""`
+
+	// the JetStream settings are only used if PUBSUB_JETSTREAM is enabled
+	PubSubJetStream            bool   `envconfig:"PUBSUB_JETSTREAM" default:"false"`
+	JetStreamStream            string `envconfig:"JETSTREAM_STREAM" default:"keptn"`
+	JetStreamMaxAge            string `envconfig:"JETSTREAM_MAX_AGE" default:"24h"`
+	JetStreamMaxBytes          int64  `envconfig:"JETSTREAM_MAX_BYTES" default:"1073741824"`
+	JetStreamMaxMsgs           int64  `envconfig:"JETSTREAM_MAX_MSGS" default:"1000000"`
+	JetStreamAckWait           string `envconfig:"JETSTREAM_ACK_WAIT" default:"1m"`
+	JetStreamMaxDeliver        int    `envconfig:"JETSTREAM_MAX_DELIVER" default:"5"`
+	JetStreamInitialBackoff    string `envconfig:"JETSTREAM_INITIAL_BACKOFF" default:"1s"`
+	JetStreamMaxBackoff        string `envconfig:"JETSTREAM_MAX_BACKOFF" default:"30s"`
+	JetStreamDeadLetterSubject string `envconfig:"JETSTREAM_DEAD_LETTER_SUBJECT" default:"keptn.deadletter"`
 }
 
 func GetRegistrationInterval(env EnvConfig) time.Duration {
@@ -46,6 +58,24 @@ func GetRegistrationInterval(env EnvConfig) time.Duration {
 	return duration
 }
 
+// GetJetStreamDurations returns the max age of the events in the stream, the time after which unacknowledged events are redelivered,
+// as well as the initial and max backoff between the deliveries of events that could not be forwarded
+func GetJetStreamDurations(env EnvConfig) (maxAge, ackWait, initialBackoff, maxBackoff time.Duration) {
+	return parseDuration("JETSTREAM_MAX_AGE", env.JetStreamMaxAge, 24*time.Hour),
+		parseDuration("JETSTREAM_ACK_WAIT", env.JetStreamAckWait, time.Minute),
+		parseDuration("JETSTREAM_INITIAL_BACKOFF", env.JetStreamInitialBackoff, time.Second),
+		parseDuration("JETSTREAM_MAX_BACKOFF", env.JetStreamMaxBackoff, 30*time.Second)
+}
+
+func parseDuration(name, value string, defaultDuration time.Duration) time.Duration {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		logger.Warnf("Could not parse %s environment variable as duration: %s", name, value)
+		return defaultDuration
+	}
+	return duration
+}
+
 func GetPubSubConnectionType() ConnectionType {
 	if Global.KeptnAPIEndpoint == "" {
 		// if no Keptn API URL has been defined, this means that run inside the Keptn cluster -> we can subscribe to events directly via NATS
@@ -0,0 +1,250 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/keptn/keptn/distributor/pkg/config"
+	"github.com/nats-io/nats.go"
+	logger "github.com/sirupsen/logrus"
+)
+
+// keptnEventSubjects are the subjects of the events stored in the JetStream stream
+var keptnEventSubjects = []string{"sh.keptn.>"}
+
+// DeadLetterReasonHeader contains the reason why an event has been moved to the dead-letter subject
+const DeadLetterReasonHeader = "Keptn-Dead-Letter-Reason"
+
+// DeadLetterDeliveriesHeader contains the number of deliveries of an event before it has been moved to the dead-letter subject
+const DeadLetterDeliveriesHeader = "Keptn-Dead-Letter-Deliveries"
+
+// InvalidMessageError indicates that a message does not contain a valid Keptn event, and can therefore never be forwarded
+type InvalidMessageError struct {
+	err error
+}
+
+func (e *InvalidMessageError) Error() string {
+	return fmt.Sprintf("invalid message: %s", e.err.Error())
+}
+
+func (e *InvalidMessageError) Unwrap() error {
+	return e.err
+}
+
+// JetStreamConfig contains the settings of the stream storing the Keptn events, and of the durable consumers the events are received from
+type JetStreamConfig struct {
+	StreamName        string
+	MaxAge            time.Duration
+	MaxBytes          int64
+	MaxMsgs           int64
+	AckWait           time.Duration
+	MaxDeliver        int
+	InitialBackoff    time.Duration
+	MaxBackoff        time.Duration
+	DeadLetterSubject string
+}
+
+func NewJetStreamConfigFromEnv(env config.EnvConfig) JetStreamConfig {
+	maxAge, ackWait, initialBackoff, maxBackoff := config.GetJetStreamDurations(env)
+	return JetStreamConfig{
+		StreamName:        env.JetStreamStream,
+		MaxAge:            maxAge,
+		MaxBytes:          env.JetStreamMaxBytes,
+		MaxMsgs:           env.JetStreamMaxMsgs,
+		AckWait:           ackWait,
+		MaxDeliver:        env.JetStreamMaxDeliver,
+		InitialBackoff:    initialBackoff,
+		MaxBackoff:        maxBackoff,
+		DeadLetterSubject: env.JetStreamDeadLetterSubject,
+	}
+}
+
+// getBackoff returns the time to wait before an event that has been delivered the given number of times is redelivered.
+// The backoff is doubled for each delivery, and is kept below the ack wait to avoid a redelivery by the server in the meantime
+func (c JetStreamConfig) getBackoff(numDelivered uint64) time.Duration {
+	backoff := c.InitialBackoff
+	for i := uint64(1); i < numDelivered && backoff < c.MaxBackoff; i++ {
+		backoff = backoff * 2
+	}
+	if backoff > c.MaxBackoff {
+		backoff = c.MaxBackoff
+	}
+	if backoff >= c.AckWait {
+		backoff = c.AckWait / 2
+	}
+	return backoff
+}
+
+// EnableJetStream ensures that the stream storing the Keptn events, as well as the stream of the dead-letter subject exist.
+// Afterwards, topics are subscribed to using durable consumers, which keep track of the events that have not yet been acknowledged
+func (nch *NatsConnectionHandler) EnableJetStream(jetStreamConfig JetStreamConfig) error {
+	nch.mux.Lock()
+	defer nch.mux.Unlock()
+	if nch.natsConnection == nil {
+		return errors.New("could not enable JetStream, because not connected to NATS")
+	}
+	js, err := nch.natsConnection.JetStream()
+	if err != nil {
+		return fmt.Errorf("could not create JetStream context: %w", err)
+	}
+	if err := ensureStream(js, jetStreamConfig.streamConfig(jetStreamConfig.StreamName, keptnEventSubjects)); err != nil {
+		return err
+	}
+	if err := ensureStream(js, jetStreamConfig.streamConfig(jetStreamConfig.StreamName+"-dead-letter", []string{jetStreamConfig.DeadLetterSubject + ".>"})); err != nil {
+		return err
+	}
+	nch.jetStream = js
+	nch.jetStreamConfig = &jetStreamConfig
+	return nil
+}
+
+// streamConfig returns the configuration of a stream for the given subjects. If the max age, size or number of events of
+// the stream is exceeded, the oldest events are discarded
+func (c JetStreamConfig) streamConfig(name string, subjects []string) *nats.StreamConfig {
+	return &nats.StreamConfig{
+		Name:     name,
+		Subjects: subjects,
+		Storage:  nats.FileStorage,
+		Discard:  nats.DiscardOld,
+		MaxAge:   c.MaxAge,
+		MaxBytes: c.MaxBytes,
+		MaxMsgs:  c.MaxMsgs,
+	}
+}
+
+// ensureStream creates the stream if it does not exist yet, or updates the limits of an existing stream
+func ensureStream(js nats.JetStreamContext, streamConfig *nats.StreamConfig) error {
+	if info, err := js.StreamInfo(streamConfig.Name); err == nil {
+		return updateStreamLimits(js, info.Config, streamConfig)
+	} else if !errors.Is(err, nats.ErrStreamNotFound) {
+		return fmt.Errorf("could not get stream %s: %w", streamConfig.Name, err)
+	}
+	logger.Infof("Creating JetStream stream %s for subjects %v", streamConfig.Name, streamConfig.Subjects)
+	if _, err := js.AddStream(streamConfig); err != nil {
+		// the stream might have been created by another distributor in the meantime
+		if _, infoErr := js.StreamInfo(streamConfig.Name); infoErr == nil {
+			return nil
+		}
+		return fmt.Errorf("could not create stream %s: %w", streamConfig.Name, err)
+	}
+	return nil
+}
+
+func updateStreamLimits(js nats.JetStreamContext, current nats.StreamConfig, streamConfig *nats.StreamConfig) error {
+	if current.MaxAge == streamConfig.MaxAge && current.MaxBytes == streamConfig.MaxBytes && current.MaxMsgs == streamConfig.MaxMsgs && current.Discard == streamConfig.Discard {
+		return nil
+	}
+	logger.Infof("Updating limits of JetStream stream %s", streamConfig.Name)
+	current.MaxAge = streamConfig.MaxAge
+	current.MaxBytes = streamConfig.MaxBytes
+	current.MaxMsgs = streamConfig.MaxMsgs
+	current.Discard = streamConfig.Discard
+	if _, err := js.UpdateStream(&current); err != nil {
+		return fmt.Errorf("could not update stream %s: %w", streamConfig.Name, err)
+	}
+	return nil
+}
+
+// jetStreamSubscribe subscribes to the topic using the durable consumer of the queue group. The consumer is created explicitly,
+// so that it is kept when unsubscribing, and events published in the meantime are delivered once the topic is subscribed to again
+func (nch *NatsConnectionHandler) jetStreamSubscribe(topic, queueGroup string) (*nats.Subscription, error) {
+	durable := durableName(queueGroup, topic)
+	if _, err := nch.jetStream.ConsumerInfo(nch.jetStreamConfig.StreamName, durable); errors.Is(err, nats.ErrConsumerNotFound) {
+		logger.Infof("Creating JetStream consumer %s for topic '%s'", durable, topic)
+		_, err := nch.jetStream.AddConsumer(nch.jetStreamConfig.StreamName, &nats.ConsumerConfig{
+			Durable:        durable,
+			DeliverSubject: "_keptn.deliver." + durable,
+			DeliverGroup:   queueGroup,
+			DeliverPolicy:  nats.DeliverNewPolicy,
+			FilterSubject:  topic,
+			AckPolicy:      nats.AckExplicitPolicy,
+			AckWait:        nch.jetStreamConfig.AckWait,
+			// the number of deliveries is limited by moving the event to the dead-letter subject
+			MaxDeliver: -1,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not create consumer %s: %w", durable, err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("could not get consumer %s: %w", durable, err)
+	}
+	return nch.jetStream.QueueSubscribe(topic, queueGroup, func(m *nats.Msg) {
+		nch.jetStreamMessageHandler(topic, m)
+	}, nats.Bind(nch.jetStreamConfig.StreamName, durable), nats.ManualAck())
+}
+
+// deleteUnusedConsumers deletes the durable consumers of the queue group for topics that are no longer subscribed to.
+// Otherwise, they would be kept by the NATS server, since the consumers are not removed when unsubscribing
+func (nch *NatsConnectionHandler) deleteUnusedConsumers(topics []string, queueGroup string) {
+	durables := map[string]bool{}
+	for _, topic := range topics {
+		durables[durableName(queueGroup, topic)] = true
+	}
+	for consumer := range nch.jetStream.ConsumersInfo(nch.jetStreamConfig.StreamName) {
+		if consumer.Config.DeliverGroup != queueGroup || durables[consumer.Name] {
+			continue
+		}
+		logger.Infof("Deleting JetStream consumer %s for topic '%s', which is no longer subscribed to", consumer.Name, consumer.Config.FilterSubject)
+		if err := nch.jetStream.DeleteConsumer(nch.jetStreamConfig.StreamName, consumer.Name); err != nil && !errors.Is(err, nats.ErrConsumerNotFound) {
+			logger.WithError(err).Errorf("Could not delete JetStream consumer %s", consumer.Name)
+		}
+	}
+}
+
+// durableName returns the name of the consumer of the queue group for the topic, which must not contain '.', '*' or '>'
+func durableName(queueGroup, topic string) string {
+	return strings.NewReplacer(".", "_", "*", "all", ">", "rest").Replace(queueGroup + "-" + topic)
+}
+
+// SettleMessage acknowledges a message received via JetStream once it has been processed. If forwarding the event failed,
+// the message is redelivered after a backoff. Invalid messages, and messages that have reached the max number of deliveries,
+// are moved to the dead-letter subject
+func (nch *NatsConnectionHandler) SettleMessage(m *nats.Msg, processingErr error) {
+	if processingErr == nil {
+		if err := m.Ack(); err != nil {
+			logger.WithError(err).Errorf("Could not acknowledge message for topic [%s]", m.Subject)
+		}
+		return
+	}
+	var numDelivered uint64 = 1
+	if metadata, err := m.Metadata(); err == nil {
+		numDelivered = metadata.NumDelivered
+	}
+	var invalidMessageErr *InvalidMessageError
+	if errors.As(processingErr, &invalidMessageErr) || numDelivered >= uint64(nch.jetStreamConfig.MaxDeliver) {
+		nch.moveToDeadLetterSubject(m, processingErr, numDelivered)
+		return
+	}
+
+	// the delay of negative acknowledgements is not supported by NATS servers < 2.7, therefore the backoff is applied here
+	backoff := nch.jetStreamConfig.getBackoff(numDelivered)
+	logger.Warnf("Could not forward event for topic [%s] (deliveries: %d), redelivering in %s: %v", m.Subject, numDelivered, backoff, processingErr)
+	if err := m.InProgress(); err != nil {
+		logger.WithError(err).Errorf("Could not extend ack wait of message for topic [%s]", m.Subject)
+	}
+	time.AfterFunc(backoff, func() {
+		if err := m.Nak(); err != nil {
+			logger.WithError(err).Errorf("Could not request redelivery of message for topic [%s]", m.Subject)
+		}
+	})
+}
+
+func (nch *NatsConnectionHandler) moveToDeadLetterSubject(m *nats.Msg, processingErr error, numDelivered uint64) {
+	deadLetter := nats.NewMsg(nch.jetStreamConfig.DeadLetterSubject + "." + m.Subject)
+	deadLetter.Data = m.Data
+	deadLetter.Header.Set(DeadLetterReasonHeader, processingErr.Error())
+	deadLetter.Header.Set(DeadLetterDeliveriesHeader, strconv.FormatUint(numDelivered, 10))
+	if _, err := nch.jetStream.PublishMsg(deadLetter); err != nil {
+		// keep the message, so that it is not lost if the dead-letter subject is not available
+		logger.WithError(err).Errorf("Could not move message for topic [%s] to dead-letter subject", m.Subject)
+		_ = m.Nak()
+		return
+	}
+	logger.Errorf("Moved message for topic [%s] to dead-letter subject %s after %d deliveries: %v", m.Subject, deadLetter.Subject, numDelivered, processingErr)
+	if err := m.Term(); err != nil {
+		logger.WithError(err).Errorf("Could not terminate message for topic [%s]", m.Subject)
+	}
+}
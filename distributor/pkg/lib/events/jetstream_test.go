@@ -0,0 +1,300 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/keptn/go-utils/pkg/api/models"
+	keptnfake "github.com/keptn/go-utils/pkg/lib/v0_2_0/fake"
+	"github.com/keptn/keptn/distributor/pkg/config"
+	"github.com/nats-io/nats-server/v2/server"
+	natsserver "github.com/nats-io/nats-server/v2/test"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func RunJetStreamServerOnPort(t *testing.T, port int) (*server.Server, func()) {
+	opts := natsserver.DefaultTestOptions
+	opts.Port = port
+	opts.JetStream = true
+	opts.StoreDir = t.TempDir()
+	svr := RunServerWithOptions(&opts)
+	return svr, func() { svr.Shutdown() }
+}
+
+func newJetStreamEnvConfig(natsURL string) config.EnvConfig {
+	return config.EnvConfig{
+		PubSubRecipient:            "http://127.0.0.1",
+		PubSubURL:                  natsURL,
+		PubSubGroup:                "my-receiver",
+		PubSubJetStream:            true,
+		JetStreamStream:            "keptn",
+		JetStreamMaxAge:            "1h",
+		JetStreamMaxBytes:          1024 * 1024,
+		JetStreamMaxMsgs:           1000,
+		JetStreamAckWait:           "5s",
+		JetStreamMaxDeliver:        3,
+		JetStreamInitialBackoff:    "10ms",
+		JetStreamMaxBackoff:        "50ms",
+		JetStreamDeadLetterSubject: "keptn.deadletter",
+	}
+}
+
+// startJetStreamReceiver starts a receiver subscribed to the task.triggered events and returns a function stopping it again
+func startJetStreamReceiver(t *testing.T, envConfig config.EnvConfig, eventSender EventSender) func() {
+	receiver := NewNATSEventReceiver(envConfig, eventSender, true)
+	ctx, cancelReceiver := context.WithCancel(context.Background())
+	executionContext := NewExecutionContext(ctx, 1)
+	go receiver.Start(executionContext)
+
+	require.Eventually(t, func() bool {
+		receiver.natsConnectionHandler.mux.Lock()
+		defer receiver.natsConnectionHandler.mux.Unlock()
+		return receiver.natsConnectionHandler.jetStream != nil
+	}, 5*time.Second, 100*time.Millisecond)
+	receiver.UpdateSubscriptions([]models.EventSubscription{
+		{
+			ID:    "id1",
+			Event: "sh.keptn.event.task.triggered",
+		},
+	})
+	return func() {
+		cancelReceiver()
+		executionContext.Wg.Wait()
+	}
+}
+
+func TestJetStreamReceiver_DeliversEventsPublishedWhileStopped(t *testing.T) {
+	natsURL := fmt.Sprintf("nats://127.0.0.1:%d", TEST_PORT)
+	_, shutdownNats := RunJetStreamServerOnPort(t, TEST_PORT)
+	defer shutdownNats()
+	natsPublisher, _ := nats.Connect(natsURL)
+	defer natsPublisher.Close()
+
+	// an event pushed to the consumer while the previous subscription is being removed is redelivered after the ack wait
+	envConfig := newJetStreamEnvConfig(natsURL)
+	envConfig.JetStreamAckWait = "1s"
+
+	eventSender := &keptnfake.EventSender{}
+	stopReceiver := startJetStreamReceiver(t, envConfig, eventSender)
+	require.Nil(t, natsPublisher.Publish("sh.keptn.event.task.triggered", []byte(task1TriggerEvent)))
+	require.Eventually(t, func() bool {
+		return len(eventSender.SentEvents) == 1
+	}, 5*time.Second, 100*time.Millisecond)
+	stopReceiver()
+
+	// the event is stored by the stream until the durable consumer is subscribed to again
+	require.Nil(t, natsPublisher.Publish("sh.keptn.event.task.triggered", []byte(task2TriggerEventForTask1)))
+
+	restartedEventSender := &keptnfake.EventSender{}
+	stopReceiver = startJetStreamReceiver(t, envConfig, restartedEventSender)
+	defer stopReceiver()
+	require.Eventually(t, func() bool {
+		return len(restartedEventSender.SentEvents) == 1
+	}, 5*time.Second, 100*time.Millisecond)
+	assert.Equal(t, "sockshop", getProject(t, restartedEventSender.SentEvents[0]))
+}
+
+func TestJetStreamReceiver_RedeliversFailedEvents(t *testing.T) {
+	natsURL := fmt.Sprintf("nats://127.0.0.1:%d", TEST_PORT)
+	_, shutdownNats := RunJetStreamServerOnPort(t, TEST_PORT)
+	defer shutdownNats()
+	natsPublisher, _ := nats.Connect(natsURL)
+	defer natsPublisher.Close()
+
+	var attempts int32
+	eventSender := &keptnfake.EventSender{}
+	eventSender.AddReactor("*", func(event cloudevents.Event) error {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			return errors.New("service not available")
+		}
+		return nil
+	})
+	stopReceiver := startJetStreamReceiver(t, newJetStreamEnvConfig(natsURL), eventSender)
+	defer stopReceiver()
+
+	require.Nil(t, natsPublisher.Publish("sh.keptn.event.task.triggered", []byte(task1TriggerEvent)))
+	require.Eventually(t, func() bool {
+		return len(eventSender.SentEvents) == 1
+	}, 5*time.Second, 100*time.Millisecond)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+
+	// the event is acknowledged once it has been sent
+	js, err := natsPublisher.JetStream()
+	require.Nil(t, err)
+	require.Eventually(t, func() bool {
+		info, err := js.ConsumerInfo("keptn", durableName("my-receiver", "sh.keptn.event.task.triggered"))
+		return err == nil && info.NumAckPending == 0 && info.NumRedelivered == 0
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestJetStreamReceiver_MovesPoisonMessagesToDeadLetterSubject(t *testing.T) {
+	natsURL := fmt.Sprintf("nats://127.0.0.1:%d", TEST_PORT)
+	_, shutdownNats := RunJetStreamServerOnPort(t, TEST_PORT)
+	defer shutdownNats()
+	natsPublisher, _ := nats.Connect(natsURL)
+	defer natsPublisher.Close()
+
+	var attempts int32
+	eventSender := &keptnfake.EventSender{}
+	eventSender.AddReactor("*", func(event cloudevents.Event) error {
+		atomic.AddInt32(&attempts, 1)
+		return errors.New("service not available")
+	})
+	stopReceiver := startJetStreamReceiver(t, newJetStreamEnvConfig(natsURL), eventSender)
+	defer stopReceiver()
+
+	deadLetters := make(chan *nats.Msg, 2)
+	_, err := natsPublisher.ChanSubscribe("keptn.deadletter.>", deadLetters)
+	require.Nil(t, err)
+
+	// invalid messages are moved to the dead-letter subject without being redelivered
+	require.Nil(t, natsPublisher.Publish("sh.keptn.event.task.triggered", []byte("invalid")))
+	select {
+	case deadLetter := <-deadLetters:
+		assert.Equal(t, "keptn.deadletter.sh.keptn.event.task.triggered", deadLetter.Subject)
+		assert.Equal(t, "invalid", string(deadLetter.Data))
+		assert.Equal(t, "1", deadLetter.Header.Get(DeadLetterDeliveriesHeader))
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for dead letter")
+	}
+
+	// events that cannot be sent are moved to the dead-letter subject after the max number of deliveries
+	require.Nil(t, natsPublisher.Publish("sh.keptn.event.task.triggered", []byte(task1TriggerEvent)))
+	select {
+	case deadLetter := <-deadLetters:
+		assert.Equal(t, task1TriggerEvent, string(deadLetter.Data))
+		assert.Equal(t, "3", deadLetter.Header.Get(DeadLetterDeliveriesHeader))
+		assert.Contains(t, deadLetter.Header.Get(DeadLetterReasonHeader), "service not available")
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for dead letter")
+	}
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+	assert.Empty(t, eventSender.SentEvents)
+
+	// the dead letters are stored in their own stream
+	js, err := natsPublisher.JetStream()
+	require.Nil(t, err)
+	info, err := js.StreamInfo("keptn-dead-letter")
+	require.Nil(t, err)
+	assert.Equal(t, uint64(2), info.State.Msgs)
+}
+
+func TestJetStreamReceiver_DeletesConsumersOfUnsubscribedTopics(t *testing.T) {
+	natsURL := fmt.Sprintf("nats://127.0.0.1:%d", TEST_PORT)
+	_, shutdownNats := RunJetStreamServerOnPort(t, TEST_PORT)
+	defer shutdownNats()
+	natsClient, _ := nats.Connect(natsURL)
+	defer natsClient.Close()
+	js, err := natsClient.JetStream()
+	require.Nil(t, err)
+
+	receiver := NewNATSEventReceiver(newJetStreamEnvConfig(natsURL), &keptnfake.EventSender{}, true)
+	ctx, cancelReceiver := context.WithCancel(context.Background())
+	executionContext := NewExecutionContext(ctx, 1)
+	go receiver.Start(executionContext)
+	defer func() {
+		cancelReceiver()
+		executionContext.Wg.Wait()
+	}()
+	require.Eventually(t, func() bool {
+		receiver.natsConnectionHandler.mux.Lock()
+		defer receiver.natsConnectionHandler.mux.Unlock()
+		return receiver.natsConnectionHandler.jetStream != nil
+	}, 5*time.Second, 100*time.Millisecond)
+
+	// a consumer of another queue group is kept
+	_, err = js.AddConsumer("keptn", &nats.ConsumerConfig{
+		Durable:        durableName("other-receiver", "sh.keptn.event.task.triggered"),
+		DeliverSubject: "_keptn.deliver.other",
+		DeliverGroup:   "other-receiver",
+		FilterSubject:  "sh.keptn.event.task.triggered",
+		AckPolicy:      nats.AckExplicitPolicy,
+	})
+	require.Nil(t, err)
+
+	receiver.UpdateSubscriptions([]models.EventSubscription{
+		{ID: "id1", Event: "sh.keptn.event.task.triggered"},
+	})
+	_, err = js.ConsumerInfo("keptn", durableName("my-receiver", "sh.keptn.event.task.triggered"))
+	require.Nil(t, err)
+
+	receiver.UpdateSubscriptions([]models.EventSubscription{
+		{ID: "id2", Event: "sh.keptn.event.other-task.triggered"},
+	})
+	_, err = js.ConsumerInfo("keptn", durableName("my-receiver", "sh.keptn.event.other-task.triggered"))
+	require.Nil(t, err)
+	_, err = js.ConsumerInfo("keptn", durableName("my-receiver", "sh.keptn.event.task.triggered"))
+	require.ErrorIs(t, err, nats.ErrConsumerNotFound)
+	_, err = js.ConsumerInfo("keptn", durableName("other-receiver", "sh.keptn.event.task.triggered"))
+	require.Nil(t, err)
+}
+
+func TestJetStreamReceiver_LimitsStreams(t *testing.T) {
+	natsURL := fmt.Sprintf("nats://127.0.0.1:%d", TEST_PORT)
+	_, shutdownNats := RunJetStreamServerOnPort(t, TEST_PORT)
+	defer shutdownNats()
+	natsClient, _ := nats.Connect(natsURL)
+	defer natsClient.Close()
+	js, err := natsClient.JetStream()
+	require.Nil(t, err)
+
+	// the limits of an existing stream are updated
+	_, err = js.AddStream(&nats.StreamConfig{Name: "keptn", Subjects: []string{"sh.keptn.>"}, Storage: nats.FileStorage})
+	require.Nil(t, err)
+
+	stopReceiver := startJetStreamReceiver(t, newJetStreamEnvConfig(natsURL), &keptnfake.EventSender{})
+	defer stopReceiver()
+
+	for _, stream := range []string{"keptn", "keptn-dead-letter"} {
+		info, err := js.StreamInfo(stream)
+		require.Nil(t, err)
+		assert.Equal(t, time.Hour, info.Config.MaxAge)
+		assert.Equal(t, int64(1024*1024), info.Config.MaxBytes)
+		assert.Equal(t, int64(1000), info.Config.MaxMsgs)
+		assert.Equal(t, nats.DiscardOld, info.Config.Discard)
+	}
+}
+
+func TestJetStreamReceiver_RequiresPubSubGroup(t *testing.T) {
+	natsURL := fmt.Sprintf("nats://127.0.0.1:%d", TEST_PORT)
+	_, shutdownNats := RunJetStreamServerOnPort(t, TEST_PORT)
+	defer shutdownNats()
+
+	envConfig := newJetStreamEnvConfig(natsURL)
+	envConfig.PubSubGroup = ""
+	receiver := NewNATSEventReceiver(envConfig, &keptnfake.EventSender{}, true)
+	require.NotNil(t, receiver.Start(NewExecutionContext(context.Background(), 1)))
+}
+
+func TestJetStreamConfig_GetBackoff(t *testing.T) {
+	jetStreamConfig := JetStreamConfig{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second, AckWait: time.Minute}
+	assert.Equal(t, time.Second, jetStreamConfig.getBackoff(1))
+	assert.Equal(t, 2*time.Second, jetStreamConfig.getBackoff(2))
+	assert.Equal(t, 4*time.Second, jetStreamConfig.getBackoff(3))
+	assert.Equal(t, 5*time.Second, jetStreamConfig.getBackoff(4))
+
+	// the backoff is kept below the ack wait
+	jetStreamConfig.AckWait = 4 * time.Second
+	assert.Equal(t, 2*time.Second, jetStreamConfig.getBackoff(4))
+}
+
+func Test_durableName(t *testing.T) {
+	assert.Equal(t, "my-receiver-sh_keptn_event_task_triggered", durableName("my-receiver", "sh.keptn.event.task.triggered"))
+	assert.Equal(t, "my-receiver-sh_keptn_event_all_triggered", durableName("my-receiver", "sh.keptn.event.*.triggered"))
+	assert.Equal(t, "my-receiver-sh_keptn_rest", durableName("my-receiver", "sh.keptn.>"))
+}
+
+const task2TriggerEventForTask1 = `{"data": {"project" : "sockshop","stage" : "dev","service" : "service"},"id": "7de83495-4f83-481c-8dbe-fcceb2e0243b","source": "shipyard-controller","specversion": "1.0","type": "sh.keptn.event.task.triggered","shkeptncontext": "3c9ffbbb-6e1d-4789-9fee-6e63b4bcc1fc"}`
+
+func getProject(t *testing.T, event cloudevents.Event) string {
+	data := map[string]interface{}{}
+	require.Nil(t, event.DataAs(&data))
+	return fmt.Sprint(data["project"])
+}
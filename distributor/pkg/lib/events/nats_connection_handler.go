@@ -16,6 +16,10 @@ type NatsConnectionHandler struct {
 	natsURL        string
 	messageHandler func(m *nats.Msg)
 	mux            sync.Mutex
+
+	jetStream               nats.JetStreamContext
+	jetStreamConfig         *JetStreamConfig
+	jetStreamMessageHandler func(topic string, m *nats.Msg)
 }
 
 // NewNatsConnectionHandler creates a new NATS connection handler to a NATS
@@ -86,12 +90,21 @@ func (nch *NatsConnectionHandler) QueueSubscribeToTopics(topics []string, queueG
 
 		for _, topic := range nch.topics {
 			logger.Infof("Subscribing to topic '%s' with queue group '%s'", topic, queueGroup)
-			sub, err := nch.natsConnection.QueueSubscribe(topic, queueGroup, nch.messageHandler)
+			var sub *nats.Subscription
+			var err error
+			if nch.jetStream != nil {
+				sub, err = nch.jetStreamSubscribe(topic, queueGroup)
+			} else {
+				sub, err = nch.natsConnection.QueueSubscribe(topic, queueGroup, nch.messageHandler)
+			}
 			if err != nil {
 				return errors.New("failed to subscribe to topic: " + err.Error())
 			}
 			nch.subscriptions = append(nch.subscriptions, sub)
 		}
+		if nch.jetStream != nil {
+			nch.deleteUnusedConsumers(nch.topics, queueGroup)
+		}
 	}
 	return nil
 }
@@ -56,6 +56,15 @@ func (n *NATSEventReceiver) Start(ctx *ExecutionContext) error {
 	if err := n.natsConnectionHandler.Connect(); err != nil {
 		return fmt.Errorf("could not Start NatsEventReceiver: %w", err)
 	}
+	if n.env.PubSubJetStream {
+		if n.env.PubSubGroup == "" {
+			return errors.New("could not start NatsEventReceiver: JetStream requires a pubsub group to identify the durable consumers")
+		}
+		if err := n.natsConnectionHandler.EnableJetStream(NewJetStreamConfigFromEnv(n.env)); err != nil {
+			return fmt.Errorf("could not Start NatsEventReceiver: %w", err)
+		}
+		n.natsConnectionHandler.jetStreamMessageHandler = n.handleJetStreamMessage
+	}
 	n.natsConnectionHandler.messageHandler = n.handleMessage
 	err := n.natsConnectionHandler.QueueSubscribeToTopics(n.env.GetPubSubTopics(), n.env.PubSubGroup)
 	if err != nil {
@@ -89,38 +98,52 @@ func (n *NATSEventReceiver) UpdateSubscriptions(subscriptions []models.EventSubs
 
 func (n *NATSEventReceiver) handleMessage(m *nats.Msg) {
 	go func() {
-		n.mutex.Lock()
-		defer n.mutex.Unlock()
-		logger.Infof("Received a message for topic [%s]\n", m.Subject)
-
-		// decode to cloudevent
-		cloudEvent, err := DecodeNATSMessage(m.Data)
-		if err != nil {
-			return
-		}
-
-		// decode to keptn event
-		keptnEvent, err := v0_2_0.ToKeptnEvent(*cloudEvent)
-		if err != nil {
-			return
+		if err := n.processMessage(m.Sub.Subject, m); err != nil {
+			logger.Errorf("Could not send cloud event: %v", err)
 		}
+	}()
+}
 
-		// determine subscription for the received message
-		subscriptions := n.getSubscriptionsFromReceivedMessage(m, *cloudEvent)
-		if len(subscriptions) > 0 {
-			if err := n.sendEventForSubscriptions(subscriptions, keptnEvent); err != nil {
-				logger.Errorf("Could not send cloud event: %v", err)
-			}
-		} else if !n.pullSubscriptions {
-			// forward keptn event
-			if err := n.sendEvent(keptnEvent, nil); err != nil {
-				logger.Errorf("Could not send cloud event: %v", err)
-			}
-		}
+// handleJetStreamMessage processes a message received via a JetStream durable consumer, which is only acknowledged
+// once the event has been forwarded successfully
+func (n *NATSEventReceiver) handleJetStreamMessage(topic string, m *nats.Msg) {
+	go func() {
+		n.natsConnectionHandler.SettleMessage(m, n.processMessage(topic, m))
 	}()
 }
 
+// processMessage forwards the event of a message received for the given topic, which is used to determine the subscriptions of the event
+func (n *NATSEventReceiver) processMessage(topic string, m *nats.Msg) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	logger.Infof("Received a message for topic [%s]\n", m.Subject)
+
+	// decode to cloudevent
+	cloudEvent, err := DecodeNATSMessage(m.Data)
+	if err != nil {
+		return &InvalidMessageError{err: err}
+	}
+
+	// decode to keptn event
+	keptnEvent, err := v0_2_0.ToKeptnEvent(*cloudEvent)
+	if err != nil {
+		return &InvalidMessageError{err: err}
+	}
+
+	// determine subscription for the received message
+	subscriptions := n.getSubscriptionsFromReceivedMessage(topic, *cloudEvent)
+	if len(subscriptions) > 0 {
+		return n.sendEventForSubscriptions(subscriptions, keptnEvent)
+	} else if !n.pullSubscriptions {
+		// forward keptn event
+		return n.sendEvent(keptnEvent, nil)
+	}
+	return nil
+}
+
 func (n *NATSEventReceiver) sendEventForSubscriptions(subscriptions []models.EventSubscription, keptnEvent models.KeptnContextExtendedCE) error {
+	failedSubscriptions := []string{}
+	var sendErr error
 	for i, subscription := range subscriptions {
 		// check if the event with the given ID has already been sent for the subscription
 		if n.ceCache.Contains(subscription.ID, keptnEvent.ID) {
@@ -146,15 +169,22 @@ func (n *NATSEventReceiver) sendEventForSubscriptions(subscriptions []models.Eve
 		// forward keptn event
 		if err := n.sendEvent(keptnEvent, &subscriptions[i]); err != nil {
 			logger.Errorf("Could not send event for subscription %s: %v", subscription.ID, err)
+			// allow sending the event again if it is redelivered
+			n.ceCache.Remove(subscription.ID, keptnEvent.ID)
+			failedSubscriptions = append(failedSubscriptions, subscription.ID)
+			sendErr = err
 		}
 	}
+	if len(failedSubscriptions) > 0 {
+		return fmt.Errorf("could not send event %s for subscriptions %v: %w", keptnEvent.ID, failedSubscriptions, sendErr)
+	}
 	return nil
 }
 
-func (n *NATSEventReceiver) getSubscriptionsFromReceivedMessage(m *nats.Msg, event cloudevents.Event) []models.EventSubscription {
+func (n *NATSEventReceiver) getSubscriptionsFromReceivedMessage(topic string, event cloudevents.Event) []models.EventSubscription {
 	subscriptionsForTopic := []models.EventSubscription{}
 	for _, subscription := range n.currentSubscriptions {
-		if subscription.Event == m.Sub.Subject { // need to check against the name of the subscription because this can be a wildcard as well
+		if subscription.Event == topic { // need to check against the name of the subscription because this can be a wildcard as well
 			matcher := NewEventMatcherFromSubscription(subscription)
 			if matcher.Matches(event) {
 				subscriptionsForTopic = append(subscriptionsForTopic, subscription)
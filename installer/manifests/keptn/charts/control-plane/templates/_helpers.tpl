@@ -131,4 +131,12 @@ lifecycle:
     fieldRef:
       fieldPath: metadata.labels['app.kubernetes.io/name']
 {{- end }}
+{{- if .Values.distributor.config.jetStream.enabled }}
+- name: PUBSUB_JETSTREAM
+  value: "true"
+- name: JETSTREAM_MAX_BYTES
+  value: {{ .Values.distributor.config.jetStream.maxBytes | quote }}
+- name: JETSTREAM_MAX_MSGS
+  value: {{ .Values.distributor.config.jetStream.maxMsgs | quote }}
+{{- end }}
 {{- end }}
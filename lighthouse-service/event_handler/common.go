@@ -53,6 +53,8 @@ var ErrConfigService = errors.New("could not checkout the SLO")
 
 //go:generate moq -pkg event_handler_mock -skip-ensure -out ./fake/resource_handler_mock.go . ResourceHandler
 type ResourceHandler interface {
+	GetProjectResource(project string, resourceURI string) (*keptnapimodels.Resource, error)
+	GetStageResource(project string, stage string, resourceURI string) (*keptnapimodels.Resource, error)
 	GetServiceResource(project string, stage string, service string, resourceURI string) (*keptnapimodels.Resource, error)
 }
 
@@ -66,45 +68,183 @@ type EventStore interface {
 	GetEvents(filter *utils.EventFilter) ([]*keptnapimodels.KeptnContextExtendedCE, *keptnapimodels.Error)
 }
 
+// ServiceLevelObjectives is the content of the slo.yaml file. It corresponds to keptn.ServiceLevelObjectives,
+// but contains the lighthouse specific comparison settings
+type ServiceLevelObjectives struct {
+	SpecVersion string            `json:"spec_version" yaml:"spec_version"`
+	Filter      map[string]string `json:"filter" yaml:"filter"`
+	Comparison  *SLOComparison    `json:"comparison" yaml:"comparison"`
+	Objectives  []*keptn.SLO      `json:"objectives" yaml:"objectives"`
+	TotalScore  *keptn.SLOScore   `json:"total_score" yaml:"total_score"`
+	Segments    *SLOSegments      `json:"segments,omitempty" yaml:"segments,omitempty"`
+	// ComputedIndicators are calculated from the SLIs returned by the SLI provider and can be used in objectives like any other SLI
+	ComputedIndicators []*ComputedIndicator `json:"computed_indicators,omitempty" yaml:"computed_indicators,omitempty"`
+	// SLIProviders maps the names of SLI providers to the indicators retrieved from them.
+	// Indicators that are not listed are retrieved from the SLI provider configured for the project
+	SLIProviders map[string][]string `json:"sli_providers,omitempty" yaml:"sli_providers,omitempty"`
+	ErrorBudget  *SLOErrorBudget     `json:"error_budget,omitempty" yaml:"error_budget,omitempty"`
+}
+
+// SLOErrorBudget defines the error budget of the SLIs of a service over a rolling window of evaluations
+type SLOErrorBudget struct {
+	// Window is the rolling window the error budget is calculated for, e.g. "30d"
+	Window string `json:"window" yaml:"window"`
+	// Target is the percentage of evaluations an SLI has to pass, e.g. "99.5%"
+	Target string `json:"target" yaml:"target"`
+	// BurnRateWindow is the window the burn rate is calculated for. Defaults to 1h
+	BurnRateWindow string `json:"burn_rate_window,omitempty" yaml:"burn_rate_window,omitempty"`
+	// MinRemaining fails the evaluation if the remaining error budget of an SLI is below the given percentage, e.g. "10%"
+	MinRemaining string `json:"min_remaining,omitempty" yaml:"min_remaining,omitempty"`
+}
+
+// ComputedIndicator is an SLI that is calculated from other SLIs, e.g. error_rate = errors / requests * 100
+type ComputedIndicator struct {
+	SLI string `json:"sli" yaml:"sli"`
+	// Expression is an arithmetic expression (+, -, *, /, parentheses) over SLI names, including previously defined computed indicators
+	Expression string `json:"expression" yaml:"expression"`
+}
+
+type SLOComparison struct {
+	CompareWith               string `json:"compare_with" yaml:"compare_with"`                           // single_result|several_results|baseline
+	IncludeResultWithScore    string `json:"include_result_with_score" yaml:"include_result_with_score"` // all|pass|pass_or_warn
+	NumberOfComparisonResults int    `json:"number_of_comparison_results" yaml:"number_of_comparison_results"`
+	AggregateFunction         string `json:"aggregate_function" yaml:"aggregate_function"`
+	// Stage is the stage whose evaluations are used for the comparison. Defaults to the stage of the evaluation
+	Stage string `json:"stage,omitempty" yaml:"stage,omitempty"`
+}
+
+// SLOSegments splits the evaluation timeframe into intervals whose SLIs are retrieved and scored separately
+type SLOSegments struct {
+	// Intervals is the number of equally sized intervals the evaluation timeframe is split into
+	Intervals int `json:"intervals" yaml:"intervals"`
+	// MaxFailedIntervals is the percentage of intervals that may violate an objective without failing the evaluation, e.g. "20%"
+	MaxFailedIntervals string `json:"max_failed_intervals" yaml:"max_failed_intervals"`
+	// Aggregations maps SLIs to the function combining their values of the intervals into the value of the whole evaluation timeframe
+	// (avg, sum, min, max). The values of SLIs that are not listed are averaged
+	Aggregations map[string]string `json:"aggregations,omitempty" yaml:"aggregations,omitempty"`
+}
+
+const (
+	compareWithSingleResult   = "single_result"
+	compareWithSeveralResults = "several_results"
+	// compareWithBaseline compares with the evaluation that has been pinned as the baseline of the service
+	compareWithBaseline = "baseline"
+)
+
+const sloFileURI = "slo.yaml"
+
+// baselineResourceURI is the service resource containing the evaluation baseline of a service in a stage
+const baselineResourceURI = "lighthouse/baseline.yaml"
+
+// EvaluationBaseline references the evaluation new evaluations of a service are compared with, until the baseline is replaced or removed
+type EvaluationBaseline struct {
+	// KeptnContext is the context of the sequence that contains the baseline evaluation
+	KeptnContext string `json:"keptnContext" yaml:"keptnContext"`
+}
+
 type SLOFileRetriever struct {
 	ResourceHandler ResourceHandler
 	ServiceHandler  ServiceHandler
 }
 
-func (sr *SLOFileRetriever) GetSLOs(project, stage, service string) (*keptn.ServiceLevelObjectives, error) {
-	sloFile, err := sr.ResourceHandler.GetServiceResource(project, stage, service, "slo.yaml")
+// GetBaseline returns the evaluation baseline of the service in the given stage, or nil if no baseline has been set
+func (sr *SLOFileRetriever) GetBaseline(project, stage, service string) (*EvaluationBaseline, error) {
+	baselineFile, err := sr.ResourceHandler.GetServiceResource(project, stage, service, baselineResourceURI)
+	if err != nil {
+		logger.Debugf("No evaluation baseline available for service %s in stage %s: %s", service, stage, err.Error())
+		return nil, nil
+	}
+	if baselineFile == nil || baselineFile.ResourceContent == "" {
+		return nil, nil
+	}
+	baseline := &EvaluationBaseline{}
+	if err := yaml.Unmarshal([]byte(baselineFile.ResourceContent), baseline); err != nil {
+		return nil, errors.New("Could not parse evaluation baseline of service " + service + " in stage " + stage + " in project " + project)
+	}
+	if baseline.KeptnContext == "" {
+		return nil, nil
+	}
+	return baseline, nil
+}
+
+func (sr *SLOFileRetriever) GetSLOs(project, stage, service string) (*ServiceLevelObjectives, error) {
+	slo, _, err := sr.GetEffectiveSLOs(project, stage, service)
+	return slo, err
+}
+
+// GetEffectiveSLOs returns the SLOs of the service, which are merged from the slo.yaml files of the project, the stage and the service,
+// together with the content of the merged SLO file
+func (sr *SLOFileRetriever) GetEffectiveSLOs(project, stage, service string) (*ServiceLevelObjectives, []byte, error) {
+	serviceSLOFile, err := sr.getServiceSLOFile(project, stage, service)
+	if err != nil && err != ErrSLOFileNotFound {
+		return nil, nil, err
+	}
+
+	sloFiles := sr.getInheritedSLOFiles(project, stage)
+	if serviceSLOFile != "" {
+		sloFiles = append(sloFiles, serviceSLOFile)
+	}
+	if len(sloFiles) == 0 {
+		return nil, nil, ErrSLOFileNotFound
+	}
+
+	sloFileContent, err := mergeSLOFiles(sloFiles)
+	if err != nil {
+		return nil, nil, errors.New("Could not parse SLO file for service " + service + " in stage " + stage + " in project " + project)
+	}
+	slo, err := parseSLO(sloFileContent)
+	if err != nil {
+		return nil, nil, errors.New("Could not parse SLO file for service " + service + " in stage " + stage + " in project " + project)
+	}
+
+	return slo, sloFileContent, nil
+}
+
+func (sr *SLOFileRetriever) getServiceSLOFile(project, stage, service string) (string, error) {
+	sloFile, err := sr.ResourceHandler.GetServiceResource(project, stage, service, sloFileURI)
 	if err != nil {
 		_, err2 := sr.ServiceHandler.GetService(project, stage, service)
 		if err2 != nil {
 			if strings.Contains(strings.ToLower(err2.Error()), "project not found") {
-				return nil, ErrProjectNotFound
+				return "", ErrProjectNotFound
 			} else if strings.Contains(strings.ToLower(err2.Error()), "stage not found") {
-				return nil, ErrStageNotFound
+				return "", ErrStageNotFound
 			} else if strings.Contains(strings.ToLower(err2.Error()), "service not found") {
-				return nil, ErrServiceNotFound
+				return "", ErrServiceNotFound
 			}
 		} else {
 			if strings.Contains(strings.ToLower(err.Error()), "could not check out ") {
-				return nil, ErrConfigService
+				return "", ErrConfigService
 			}
-			return nil, ErrSLOFileNotFound
+			return "", ErrSLOFileNotFound
 		}
 	}
 	if sloFile == nil || sloFile.ResourceContent == "" {
-		return nil, ErrSLOFileNotFound
+		return "", ErrSLOFileNotFound
 	}
+	return sloFile.ResourceContent, nil
+}
 
-	slo, err := parseSLO([]byte(sloFile.ResourceContent))
-
+// getInheritedSLOFiles returns the slo.yaml files of the project and the stage, if available
+func (sr *SLOFileRetriever) getInheritedSLOFiles(project, stage string) []string {
+	sloFiles := []string{}
+	projectSLOFile, err := sr.ResourceHandler.GetProjectResource(project, sloFileURI)
 	if err != nil {
-		return nil, errors.New("Could not parse SLO file for service " + service + " in stage " + stage + " in project " + project)
+		logger.Debugf("No SLO file available for project %s: %s", project, err.Error())
+	} else if projectSLOFile != nil && projectSLOFile.ResourceContent != "" {
+		sloFiles = append(sloFiles, projectSLOFile.ResourceContent)
 	}
-
-	return slo, nil
+	stageSLOFile, err := sr.ResourceHandler.GetStageResource(project, stage, sloFileURI)
+	if err != nil {
+		logger.Debugf("No SLO file available for stage %s in project %s: %s", stage, project, err.Error())
+	} else if stageSLOFile != nil && stageSLOFile.ResourceContent != "" {
+		sloFiles = append(sloFiles, stageSLOFile.ResourceContent)
+	}
+	return sloFiles
 }
 
-func parseSLO(input []byte) (*keptn.ServiceLevelObjectives, error) {
-	slo := &keptn.ServiceLevelObjectives{}
+func parseSLO(input []byte) (*ServiceLevelObjectives, error) {
+	slo := &ServiceLevelObjectives{}
 	err := yaml.Unmarshal([]byte(input), &slo)
 
 	if err != nil {
@@ -112,7 +252,7 @@ func parseSLO(input []byte) (*keptn.ServiceLevelObjectives, error) {
 	}
 
 	if slo.Comparison == nil {
-		slo.Comparison = &keptn.SLOComparison{
+		slo.Comparison = &SLOComparison{
 			CompareWith:               "single_result",
 			IncludeResultWithScore:    "all",
 			NumberOfComparisonResults: 1,
@@ -144,6 +284,20 @@ func parseSLO(input []byte) (*keptn.ServiceLevelObjectives, error) {
 	}
 	slo.Objectives = objectives
 
+	if err := validateComputedIndicators(slo.ComputedIndicators); err != nil {
+		return nil, err
+	}
+
+	if err := validateSegmentAggregations(slo.Segments); err != nil {
+		return nil, err
+	}
+
+	if slo.ErrorBudget != nil {
+		if _, err := parseErrorBudget(slo.ErrorBudget); err != nil {
+			return nil, err
+		}
+	}
+
 	return slo, nil
 }
 
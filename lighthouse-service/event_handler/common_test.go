@@ -1,17 +1,20 @@
 package event_handler
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
+	keptnapimodels "github.com/keptn/go-utils/pkg/api/models"
 	keptn "github.com/keptn/go-utils/pkg/lib"
+	event_handler_mock "github.com/keptn/keptn/lighthouse-service/event_handler/fake"
 )
 
 type getSLOTestObject struct {
 	Name           string
 	SLOFileContent string
-	ExpectedSLO    *keptn.ServiceLevelObjectives
+	ExpectedSLO    *ServiceLevelObjectives
 	ExpectedError  error
 }
 
@@ -60,12 +63,12 @@ objectives:
 total_score:
   pass: "90%"
   warning: 75%`,
-			ExpectedSLO: &keptn.ServiceLevelObjectives{
+			ExpectedSLO: &ServiceLevelObjectives{
 				SpecVersion: "1.0",
 				Filter: map[string]string{
 					"id": "<prometheus_scrape_job_id>",
 				},
-				Comparison: &keptn.SLOComparison{
+				Comparison: &SLOComparison{
 					CompareWith:               "single_result",
 					IncludeResultWithScore:    "pass",
 					NumberOfComparisonResults: 3,
@@ -163,12 +166,12 @@ objectives:
 total_score:
   pass: "90%"
   warning: 75%`,
-			ExpectedSLO: &keptn.ServiceLevelObjectives{
+			ExpectedSLO: &ServiceLevelObjectives{
 				SpecVersion: "1.0",
 				Filter: map[string]string{
 					"id": "<prometheus_scrape_job_id>",
 				},
-				Comparison: &keptn.SLOComparison{
+				Comparison: &SLOComparison{
 					CompareWith:               "single_result",
 					IncludeResultWithScore:    "all",
 					NumberOfComparisonResults: 1,
@@ -250,10 +253,10 @@ objectives:
 total_score:
   pass: 90%
   warning: 75%`,
-			ExpectedSLO: &keptn.ServiceLevelObjectives{
+			ExpectedSLO: &ServiceLevelObjectives{
 				SpecVersion: "",
 				Filter:      map[string]string{},
-				Comparison: &keptn.SLOComparison{
+				Comparison: &SLOComparison{
 					CompareWith:               "single_result",
 					IncludeResultWithScore:    "pass",
 					NumberOfComparisonResults: 1,
@@ -314,12 +317,12 @@ objectives:
 total_score:
   pass: "90%"
   warning: 75%`,
-			ExpectedSLO: &keptn.ServiceLevelObjectives{
+			ExpectedSLO: &ServiceLevelObjectives{
 				SpecVersion: "1.0",
 				Filter: map[string]string{
 					"id": "<prometheus_scrape_job_id>",
 				},
-				Comparison: &keptn.SLOComparison{
+				Comparison: &SLOComparison{
 					CompareWith:               "single_result",
 					IncludeResultWithScore:    "all",
 					NumberOfComparisonResults: 3,
@@ -380,6 +383,98 @@ total_score:
 			},
 			ExpectedError: nil,
 		},
+		{
+			Name: "Segmented SLO file",
+			SLOFileContent: `---
+spec_version: '1.0'
+segments:
+  intervals: 6
+  max_failed_intervals: "20%"
+objectives:
+  - sli: responseTime95
+    pass:
+      - criteria:
+          - "<200"
+total_score:
+  pass: "90%"`,
+			ExpectedSLO: &ServiceLevelObjectives{
+				SpecVersion: "1.0",
+				Comparison: &SLOComparison{
+					CompareWith:               "single_result",
+					IncludeResultWithScore:    "all",
+					NumberOfComparisonResults: 1,
+					AggregateFunction:         "avg",
+				},
+				Objectives: []*keptn.SLO{
+					{
+						SLI: "responseTime95",
+						Pass: []*keptn.SLOCriteria{
+							{
+								Criteria: []string{"<200"},
+							},
+						},
+						Weight: 1,
+					},
+				},
+				TotalScore: &keptn.SLOScore{
+					Pass: "90%",
+				},
+				Segments: &SLOSegments{
+					Intervals:          6,
+					MaxFailedIntervals: "20%",
+				},
+			},
+			ExpectedError: nil,
+		},
+		{
+			Name: "SLO file with error budget",
+			SLOFileContent: `---
+spec_version: '1.0'
+error_budget:
+  window: "30d"
+  target: "99.5%"
+  min_remaining: "10%"
+objectives:
+  - sli: responseTime95
+total_score:
+  pass: "90%"`,
+			ExpectedSLO: &ServiceLevelObjectives{
+				SpecVersion: "1.0",
+				Comparison: &SLOComparison{
+					CompareWith:               "single_result",
+					IncludeResultWithScore:    "all",
+					NumberOfComparisonResults: 1,
+					AggregateFunction:         "avg",
+				},
+				Objectives: []*keptn.SLO{
+					{
+						SLI:    "responseTime95",
+						Weight: 1,
+					},
+				},
+				TotalScore: &keptn.SLOScore{
+					Pass: "90%",
+				},
+				ErrorBudget: &SLOErrorBudget{
+					Window:       "30d",
+					Target:       "99.5%",
+					MinRemaining: "10%",
+				},
+			},
+			ExpectedError: nil,
+		},
+		{
+			Name: "SLO file with invalid error budget",
+			SLOFileContent: `---
+spec_version: '1.0'
+error_budget:
+  window: "30d"
+  target: "100%"
+objectives:
+  - sli: responseTime95`,
+			ExpectedSLO:   nil,
+			ExpectedError: errors.New("invalid error budget target: must be a percentage between 0% and 100%"),
+		},
 	}
 
 	for _, test := range tests {
@@ -390,3 +485,129 @@ total_score:
 		})
 	}
 }
+
+func TestSLOFileRetriever_GetBaseline(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource *keptnapimodels.Resource
+		err      error
+		want     *EvaluationBaseline
+		wantErr  bool
+	}{
+		{
+			name:     "baseline set",
+			resource: &keptnapimodels.Resource{ResourceContent: "keptnContext: my-context"},
+			want:     &EvaluationBaseline{KeptnContext: "my-context"},
+		},
+		{
+			name: "no baseline resource",
+			err:  errors.New("resource not found"),
+		},
+		{
+			name:     "empty baseline",
+			resource: &keptnapimodels.Resource{ResourceContent: "keptnContext: ''"},
+		},
+		{
+			name:     "invalid baseline",
+			resource: &keptnapimodels.Resource{ResourceContent: "keptnContext: [a"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sr := &SLOFileRetriever{
+				ResourceHandler: &event_handler_mock.ResourceHandlerMock{
+					GetServiceResourceFunc: func(project string, stage string, service string, resourceURI string) (*keptnapimodels.Resource, error) {
+						assert.Equal(t, "lighthouse/baseline.yaml", resourceURI)
+						return tt.resource, tt.err
+					},
+				},
+			}
+			got, err := sr.GetBaseline("sockshop", "staging", "carts")
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSLOFileRetriever_GetEffectiveSLOs(t *testing.T) {
+	tests := []struct {
+		name           string
+		projectSLOFile string
+		stageSLOFile   string
+		serviceSLOFile string
+		wantSLIs       []string
+		wantContent    string
+		wantErr        error
+	}{
+		{
+			name:           "service level only",
+			serviceSLOFile: serviceSLOFile,
+			wantSLIs:       []string{"response_time_p95", "throughput"},
+			wantContent:    serviceSLOFile,
+		},
+		{
+			name:           "inherited from project and stage",
+			projectSLOFile: projectSLOFile,
+			stageSLOFile:   stageSLOFile,
+			serviceSLOFile: serviceSLOFile,
+			wantSLIs:       []string{"response_time_p95", "error_rate", "throughput"},
+		},
+		{
+			name:           "no SLO file on service level",
+			projectSLOFile: projectSLOFile,
+			wantSLIs:       []string{"response_time_p95", "error_rate"},
+			wantContent:    projectSLOFile,
+		},
+		{
+			name:    "no SLO file",
+			wantErr: ErrSLOFileNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getResource := func(content string) (*keptnapimodels.Resource, error) {
+				if content == "" {
+					return nil, errors.New("resource not found")
+				}
+				return &keptnapimodels.Resource{ResourceContent: content}, nil
+			}
+			sr := &SLOFileRetriever{
+				ResourceHandler: &event_handler_mock.ResourceHandlerMock{
+					GetProjectResourceFunc: func(project string, resourceURI string) (*keptnapimodels.Resource, error) {
+						return getResource(tt.projectSLOFile)
+					},
+					GetStageResourceFunc: func(project string, stage string, resourceURI string) (*keptnapimodels.Resource, error) {
+						return getResource(tt.stageSLOFile)
+					},
+					GetServiceResourceFunc: func(project string, stage string, service string, resourceURI string) (*keptnapimodels.Resource, error) {
+						return getResource(tt.serviceSLOFile)
+					},
+				},
+				ServiceHandler: &event_handler_mock.ServiceHandlerMock{
+					GetServiceFunc: func(project string, stage string, service string) (*keptnapimodels.Service, error) {
+						return &keptnapimodels.Service{}, nil
+					},
+				},
+			}
+
+			slo, content, err := sr.GetEffectiveSLOs("sockshop", "staging", "carts")
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr != nil {
+				return
+			}
+			slis := []string{}
+			for _, objective := range slo.Objectives {
+				slis = append(slis, objective.SLI)
+			}
+			assert.Equal(t, tt.wantSLIs, slis)
+			if tt.wantContent != "" {
+				assert.Equal(t, tt.wantContent, string(content))
+			}
+			// the content of the effective SLO file describes the same objectives
+			parsedContent, err := parseSLO(content)
+			assert.Nil(t, err)
+			assert.Equal(t, slo, parsedContent)
+		})
+	}
+}
@@ -0,0 +1,297 @@
+package event_handler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"unicode"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+// indicatorExpression is an arithmetic expression over SLI values, e.g. "errors / requests * 100"
+type indicatorExpression interface {
+	evaluate(values map[string]float64) (float64, error)
+	// variables returns the names of the SLIs referenced by the expression
+	variables() []string
+}
+
+type numberExpression struct {
+	value float64
+}
+
+func (n numberExpression) evaluate(map[string]float64) (float64, error) {
+	return n.value, nil
+}
+
+func (n numberExpression) variables() []string {
+	return nil
+}
+
+type variableExpression struct {
+	name string
+}
+
+func (v variableExpression) evaluate(values map[string]float64) (float64, error) {
+	value, ok := values[v.name]
+	if !ok {
+		return 0, fmt.Errorf("no value available for SLI %s", v.name)
+	}
+	return value, nil
+}
+
+func (v variableExpression) variables() []string {
+	return []string{v.name}
+}
+
+type negationExpression struct {
+	operand indicatorExpression
+}
+
+func (n negationExpression) evaluate(values map[string]float64) (float64, error) {
+	value, err := n.operand.evaluate(values)
+	if err != nil {
+		return 0, err
+	}
+	return -value, nil
+}
+
+func (n negationExpression) variables() []string {
+	return n.operand.variables()
+}
+
+type binaryExpression struct {
+	operator rune
+	left     indicatorExpression
+	right    indicatorExpression
+}
+
+func (b binaryExpression) evaluate(values map[string]float64) (float64, error) {
+	left, err := b.left.evaluate(values)
+	if err != nil {
+		return 0, err
+	}
+	right, err := b.right.evaluate(values)
+	if err != nil {
+		return 0, err
+	}
+	switch b.operator {
+	case '+':
+		return left + right, nil
+	case '-':
+		return left - right, nil
+	case '*':
+		return left * right, nil
+	default:
+		if right == 0 {
+			return 0, errors.New("division by zero")
+		}
+		return left / right, nil
+	}
+}
+
+func (b binaryExpression) variables() []string {
+	return append(b.left.variables(), b.right.variables()...)
+}
+
+// expressionParser is a recursive descent parser for arithmetic expressions supporting +, -, *, /, parentheses, numbers and SLI names
+type expressionParser struct {
+	input    []rune
+	position int
+}
+
+func parseIndicatorExpression(input string) (indicatorExpression, error) {
+	p := &expressionParser{input: []rune(input)}
+	expr, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	p.skipWhitespace()
+	if p.position < len(p.input) {
+		return nil, fmt.Errorf("unexpected character '%c' at position %d", p.input[p.position], p.position)
+	}
+	return expr, nil
+}
+
+func (p *expressionParser) skipWhitespace() {
+	for p.position < len(p.input) && unicode.IsSpace(p.input[p.position]) {
+		p.position++
+	}
+}
+
+// peek returns the next non-whitespace character, or 0 if the end of the input has been reached
+func (p *expressionParser) peek() rune {
+	p.skipWhitespace()
+	if p.position >= len(p.input) {
+		return 0
+	}
+	return p.input[p.position]
+}
+
+func (p *expressionParser) parseSum() (indicatorExpression, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for operator := p.peek(); operator == '+' || operator == '-'; operator = p.peek() {
+		p.position++
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryExpression{operator: operator, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *expressionParser) parseProduct() (indicatorExpression, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for operator := p.peek(); operator == '*' || operator == '/'; operator = p.peek() {
+		p.position++
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryExpression{operator: operator, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *expressionParser) parseFactor() (indicatorExpression, error) {
+	next := p.peek()
+	switch {
+	case next == 0:
+		return nil, errors.New("unexpected end of expression")
+	case next == '-':
+		p.position++
+		operand, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		return negationExpression{operand: operand}, nil
+	case next == '(':
+		p.position++
+		expr, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, fmt.Errorf("missing closing parenthesis at position %d", p.position)
+		}
+		p.position++
+		return expr, nil
+	case unicode.IsDigit(next) || next == '.':
+		start := p.position
+		for p.position < len(p.input) && (unicode.IsDigit(p.input[p.position]) || p.input[p.position] == '.') {
+			p.position++
+		}
+		value, err := strconv.ParseFloat(string(p.input[start:p.position]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number '%s'", string(p.input[start:p.position]))
+		}
+		return numberExpression{value: value}, nil
+	case unicode.IsLetter(next) || next == '_':
+		start := p.position
+		for p.position < len(p.input) && (unicode.IsLetter(p.input[p.position]) || unicode.IsDigit(p.input[p.position]) || p.input[p.position] == '_') {
+			p.position++
+		}
+		return variableExpression{name: string(p.input[start:p.position])}, nil
+	default:
+		return nil, fmt.Errorf("unexpected character '%c' at position %d", next, p.position)
+	}
+}
+
+func validateComputedIndicators(computedIndicators []*ComputedIndicator) error {
+	for _, computedIndicator := range computedIndicators {
+		if computedIndicator == nil || computedIndicator.SLI == "" {
+			return errors.New("computed indicator without sli name")
+		}
+		if _, err := parseIndicatorExpression(computedIndicator.Expression); err != nil {
+			return fmt.Errorf("invalid expression of computed indicator %s: %w", computedIndicator.SLI, err)
+		}
+	}
+	return nil
+}
+
+// getRequiredIndicators returns the SLIs that have to be retrieved from the SLI provider, i.e. the SLIs of the objectives
+// that are not computed, and the SLIs referenced by the computed indicators
+func getRequiredIndicators(sloConfig *ServiceLevelObjectives) []string {
+	computed := map[string]bool{}
+	for _, computedIndicator := range sloConfig.ComputedIndicators {
+		computed[computedIndicator.SLI] = true
+	}
+
+	indicators := []string{}
+	added := map[string]bool{}
+	addIndicator := func(sli string) {
+		if !computed[sli] && !added[sli] {
+			indicators = append(indicators, sli)
+			added[sli] = true
+		}
+	}
+	for _, objective := range sloConfig.Objectives {
+		addIndicator(objective.SLI)
+	}
+	for _, computedIndicator := range sloConfig.ComputedIndicators {
+		expr, err := parseIndicatorExpression(computedIndicator.Expression)
+		if err != nil {
+			continue
+		}
+		for _, variable := range expr.variables() {
+			addIndicator(variable)
+		}
+	}
+	return indicators
+}
+
+// computeIndicators appends the values of the computed indicators to the given SLI results. A computed indicator fails if
+// one of the SLIs referenced by its expression is not available. It returns the names of the SLIs referenced by the expressions
+func computeIndicators(results *[]*keptnv2.SLIResult, computedIndicators []*ComputedIndicator) map[string]bool {
+	referenced := map[string]bool{}
+	values := map[string]float64{}
+	failed := map[string]string{}
+	for _, result := range *results {
+		if result == nil {
+			continue
+		}
+		if result.Success {
+			values[result.Metric] = result.Value
+		} else {
+			failed[result.Metric] = result.Message
+		}
+	}
+
+	for _, computedIndicator := range computedIndicators {
+		computedResult := &keptnv2.SLIResult{Metric: computedIndicator.SLI}
+		expr, err := parseIndicatorExpression(computedIndicator.Expression)
+		if err != nil {
+			computedResult.Message = fmt.Sprintf("invalid expression: %s", err.Error())
+			*results = append(*results, computedResult)
+			continue
+		}
+		for _, variable := range expr.variables() {
+			referenced[variable] = true
+			if message, ok := failed[variable]; ok && computedResult.Message == "" {
+				computedResult.Message = fmt.Sprintf("SLI %s could not be retrieved: %s", variable, message)
+			}
+		}
+		if computedResult.Message == "" {
+			value, err := expr.evaluate(values)
+			if err != nil {
+				computedResult.Message = fmt.Sprintf("could not compute value: %s", err.Error())
+			} else {
+				computedResult.Value = value
+				computedResult.Success = true
+				values[computedIndicator.SLI] = value
+			}
+		}
+		if !computedResult.Success {
+			failed[computedIndicator.SLI] = computedResult.Message
+		}
+		*results = append(*results, computedResult)
+	}
+	return referenced
+}
@@ -0,0 +1,192 @@
+package event_handler
+
+import (
+	"testing"
+
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseIndicatorExpression(t *testing.T) {
+	values := map[string]float64{
+		"errors":       5,
+		"requests":     200,
+		"p95_new":      320,
+		"p95_baseline": 300,
+	}
+	tests := []struct {
+		name          string
+		expression    string
+		want          float64
+		wantVariables []string
+		wantParseErr  bool
+		wantEvalErr   bool
+	}{
+		{
+			name:          "error rate",
+			expression:    "errors / requests * 100",
+			want:          2.5,
+			wantVariables: []string{"errors", "requests"},
+		},
+		{
+			name:          "difference",
+			expression:    "p95_new - p95_baseline",
+			want:          20,
+			wantVariables: []string{"p95_new", "p95_baseline"},
+		},
+		{
+			name:       "operator precedence",
+			expression: "2 + 3 * 4 - 10 / 5",
+			want:       12,
+		},
+		{
+			name:          "parentheses and negation",
+			expression:    "-(p95_new - p95_baseline) * (1 + .5)",
+			want:          -30,
+			wantVariables: []string{"p95_new", "p95_baseline"},
+		},
+		{
+			name:          "missing value",
+			expression:    "errors / unknown",
+			wantVariables: []string{"errors", "unknown"},
+			wantEvalErr:   true,
+		},
+		{
+			name:          "division by zero",
+			expression:    "errors / (requests - 200)",
+			wantVariables: []string{"errors", "requests"},
+			wantEvalErr:   true,
+		},
+		{
+			name:         "missing operand",
+			expression:   "errors / ",
+			wantParseErr: true,
+		},
+		{
+			name:         "missing closing parenthesis",
+			expression:   "(errors / requests",
+			wantParseErr: true,
+		},
+		{
+			name:         "invalid character",
+			expression:   "errors % requests",
+			wantParseErr: true,
+		},
+		{
+			name:         "invalid number",
+			expression:   "1.2.3",
+			wantParseErr: true,
+		},
+		{
+			name:         "empty expression",
+			expression:   "",
+			wantParseErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := parseIndicatorExpression(tt.expression)
+			if tt.wantParseErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.wantVariables, expr.variables())
+
+			got, err := expr.evaluate(values)
+			if tt.wantEvalErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.InDelta(t, tt.want, got, 0.0001)
+		})
+	}
+}
+
+func Test_getRequiredIndicators(t *testing.T) {
+	sloConfig := &ServiceLevelObjectives{
+		Objectives: []*keptn.SLO{
+			{SLI: "error_rate"},
+			{SLI: "response_time_p95"},
+			{SLI: "errors"},
+		},
+		ComputedIndicators: []*ComputedIndicator{
+			{SLI: "error_rate", Expression: "errors / requests * 100"},
+		},
+	}
+
+	require.Equal(t, []string{"response_time_p95", "errors", "requests"}, getRequiredIndicators(sloConfig))
+}
+
+func Test_computeIndicators(t *testing.T) {
+	results := []*keptnv2.SLIResult{
+		{Metric: "errors", Value: 5, Success: true},
+		{Metric: "requests", Value: 200, Success: true},
+		{Metric: "throughput", Success: false, Message: "timeout"},
+	}
+	computedIndicators := []*ComputedIndicator{
+		{SLI: "error_rate", Expression: "errors / requests * 100"},
+		{SLI: "success_rate", Expression: "100 - error_rate"},
+		{SLI: "errors_per_throughput", Expression: "errors / throughput"},
+		{SLI: "unknown_rate", Expression: "errors / unknown"},
+	}
+
+	referenced := computeIndicators(&results, computedIndicators)
+
+	require.Equal(t, map[string]bool{"errors": true, "requests": true, "error_rate": true, "throughput": true, "unknown": true}, referenced)
+	require.Len(t, results, 7)
+	require.Equal(t, &keptnv2.SLIResult{Metric: "error_rate", Value: 2.5, Success: true}, results[3])
+	require.Equal(t, &keptnv2.SLIResult{Metric: "success_rate", Value: 97.5, Success: true}, results[4])
+	require.Equal(t, &keptnv2.SLIResult{Metric: "errors_per_throughput", Message: "SLI throughput could not be retrieved: timeout"}, results[5])
+	require.Equal(t, &keptnv2.SLIResult{Metric: "unknown_rate", Message: "could not compute value: no value available for SLI unknown"}, results[6])
+}
+
+func TestEvaluateObjectives_ComputedIndicators(t *testing.T) {
+	e := &keptnv2.GetSLIFinishedEventData{
+		EventData: keptnv2.EventData{Project: "sockshop", Stage: "staging", Service: "carts"},
+		GetSLI: keptnv2.GetSLIFinished{
+			IndicatorValues: []*keptnv2.SLIResult{
+				{Metric: "errors", Value: 5, Success: true},
+				{Metric: "requests", Value: 200, Success: true},
+			},
+		},
+	}
+	sloConfig := &ServiceLevelObjectives{
+		Comparison: &SLOComparison{CompareWith: "single_result", AggregateFunction: "avg"},
+		Objectives: []*keptn.SLO{
+			{
+				SLI:    "error_rate",
+				Weight: 1,
+				Pass:   []*keptn.SLOCriteria{{Criteria: []string{"<5"}}},
+			},
+		},
+		ComputedIndicators: []*ComputedIndicator{
+			{SLI: "error_rate", Expression: "errors / requests * 100"},
+		},
+	}
+
+	evaluationResult, maximumAchievableScore, keySLIFailed := evaluateObjectives(e, sloConfig, nil)
+
+	require.Equal(t, 1.0, maximumAchievableScore)
+	require.False(t, keySLIFailed)
+	require.Len(t, evaluationResult.Evaluation.IndicatorResults, 1)
+	require.Equal(t, "error_rate", evaluationResult.Evaluation.IndicatorResults[0].Value.Metric)
+	require.Equal(t, 2.5, evaluationResult.Evaluation.IndicatorResults[0].Value.Value)
+	require.Equal(t, "pass", evaluationResult.Evaluation.IndicatorResults[0].Status)
+	// the SLIs used by the computed indicator are not reported as additional SLIs
+	require.Empty(t, evaluationResult.Message)
+}
+
+func TestParseSLO_InvalidComputedIndicator(t *testing.T) {
+	_, err := parseSLO([]byte(`---
+spec_version: '1.0'
+computed_indicators:
+  - sli: error_rate
+    expression: "errors / (requests"
+objectives:
+  - sli: error_rate
+`))
+	require.NotNil(t, err)
+}
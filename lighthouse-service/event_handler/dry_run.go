@@ -0,0 +1,111 @@
+package event_handler
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+// DryRunEvaluationAPIPath is the path of the endpoint evaluating SLI values against an SLO without sending any events
+const DryRunEvaluationAPIPath = "/v1/evaluate"
+
+// DryRunEvaluationRequest contains the SLO and the SLI values of a dry-run evaluation
+type DryRunEvaluationRequest struct {
+	// SLO is the content of an slo.yaml file
+	SLO string `json:"slo" yaml:"slo"`
+	// SLIValues are the values of the SLIs by their name
+	SLIValues map[string]float64 `json:"sliValues" yaml:"sliValues"`
+	// PreviousResults are the SLI values of previous evaluations used by relative criteria, starting with the most recent one
+	PreviousResults []map[string]float64 `json:"previousResults,omitempty" yaml:"previousResults,omitempty"`
+	Start           string               `json:"start,omitempty" yaml:"start,omitempty"`
+	End             string               `json:"end,omitempty" yaml:"end,omitempty"`
+}
+
+// DryRunEvaluationResult is the result of a dry-run evaluation
+type DryRunEvaluationResult struct {
+	Evaluation EvaluationDetails `json:"evaluation"`
+	Message    string            `json:"message,omitempty"`
+}
+
+// EvaluateDryRun scores the given SLI values against the SLO. In contrast to an evaluation triggered by an event, no events are sent
+// and no previous evaluations are retrieved. Segments and error budgets are not evaluated, since they depend on data that is not part of the request
+func EvaluateDryRun(request DryRunEvaluationRequest) (*DryRunEvaluationResult, error) {
+	if request.SLO == "" {
+		return nil, errors.New("no SLO provided")
+	}
+	sloConfig, err := parseSLO([]byte(request.SLO))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse SLO: %w", err)
+	}
+
+	e := &keptnv2.GetSLIFinishedEventData{
+		GetSLI: keptnv2.GetSLIFinished{
+			Start:           request.Start,
+			End:             request.End,
+			IndicatorValues: toSLIResults(request.SLIValues),
+		},
+	}
+	previousEvaluations := []*EvaluationFinishedEventData{}
+	for _, previousResult := range request.PreviousResults {
+		previousEvaluation := &EvaluationFinishedEventData{}
+		for _, value := range toSLIResults(previousResult) {
+			previousEvaluation.Evaluation.IndicatorResults = append(previousEvaluation.Evaluation.IndicatorResults, &SLIEvaluationResult{SLIEvaluationResult: keptnv2.SLIEvaluationResult{Value: value}})
+		}
+		previousEvaluations = append(previousEvaluations, previousEvaluation)
+	}
+
+	evaluationResult, maximumAchievableScore, keySLIFailed := evaluateObjectives(e, sloConfig, previousEvaluations)
+	if err := calculateScore(maximumAchievableScore, evaluationResult, sloConfig, keySLIFailed); err != nil {
+		return nil, err
+	}
+	evaluationResult.Evaluation.SLOFileContent = base64.StdEncoding.EncodeToString([]byte(request.SLO))
+
+	return &DryRunEvaluationResult{
+		Evaluation: evaluationResult.Evaluation,
+		Message:    evaluationResult.Message,
+	}, nil
+}
+
+// toSLIResults converts the given values into SLI results, ordered by the name of the SLI
+func toSLIResults(values map[string]float64) []*keptnv2.SLIResult {
+	slis := []string{}
+	for sli := range values {
+		slis = append(slis, sli)
+	}
+	sort.Strings(slis)
+
+	results := []*keptnv2.SLIResult{}
+	for _, sli := range slis {
+		results = append(results, &keptnv2.SLIResult{Metric: sli, Value: values[sli], Success: true})
+	}
+	return results
+}
+
+// DryRunEvaluationAPIHandler serves dry-run evaluations
+type DryRunEvaluationAPIHandler struct{}
+
+// ServeHTTP handles POST /v1/evaluate with a DryRunEvaluationRequest as body
+func (h *DryRunEvaluationAPIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeAPIError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	request := DryRunEvaluationRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "could not parse request: "+err.Error())
+		return
+	}
+
+	result, err := EvaluateDryRun(request)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,137 @@
+package event_handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const dryRunSLO = `---
+spec_version: "1.0"
+comparison:
+  compare_with: "several_results"
+  number_of_comparison_results: 2
+  aggregate_function: "avg"
+objectives:
+  - sli: "response_time"
+    pass:
+      - criteria:
+          - "<=+10%"
+          - "<600"
+    warning:
+      - criteria:
+          - "<=800"
+  - sli: "error_rate"
+    pass:
+      - criteria:
+          - "<5"
+total_score:
+  pass: "90%"
+  warning: "50%"
+`
+
+func TestEvaluateDryRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     DryRunEvaluationRequest
+		wantResult  string
+		wantScore   float64
+		wantMessage string
+		wantErr     bool
+	}{
+		{
+			name: "all objectives met",
+			request: DryRunEvaluationRequest{
+				SLO:       dryRunSLO,
+				SLIValues: map[string]float64{"response_time": 500, "error_rate": 1},
+			},
+			wantResult: "pass",
+			wantScore:  100,
+		},
+		{
+			name: "objective failed",
+			request: DryRunEvaluationRequest{
+				SLO:       dryRunSLO,
+				SLIValues: map[string]float64{"response_time": 500, "error_rate": 10},
+			},
+			wantResult:  "warning",
+			wantScore:   50,
+			wantMessage: "Evaluation returned a warning",
+		},
+		{
+			name: "relative criteria based on previous results",
+			request: DryRunEvaluationRequest{
+				SLO:             dryRunSLO,
+				SLIValues:       map[string]float64{"response_time": 500, "error_rate": 1},
+				PreviousResults: []map[string]float64{{"response_time": 400}, {"response_time": 400}},
+			},
+			wantResult: "warning",
+			wantScore:  75,
+		},
+		{
+			name: "SLI without objective",
+			request: DryRunEvaluationRequest{
+				SLO:       dryRunSLO,
+				SLIValues: map[string]float64{"response_time": 500, "error_rate": 1, "throughput": 200},
+			},
+			wantResult:  "pass",
+			wantScore:   100,
+			wantMessage: "Lighthouse received additional SLIs, which are not specified as SLO: throughput . Please consider using them as an SLO.",
+		},
+		{
+			name:    "no SLO",
+			request: DryRunEvaluationRequest{SLIValues: map[string]float64{"response_time": 500}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid SLO",
+			request: DryRunEvaluationRequest{SLO: "objectives: some"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EvaluateDryRun(tt.request)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.wantResult, got.Evaluation.Result)
+			require.Equal(t, tt.wantScore, got.Evaluation.Score)
+			require.Len(t, got.Evaluation.IndicatorResults, 2)
+			require.NotEmpty(t, got.Evaluation.SLOFileContent)
+			if tt.wantMessage != "" {
+				require.Contains(t, got.Message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestDryRunEvaluationAPIHandler_ServeHTTP(t *testing.T) {
+	h := &DryRunEvaluationAPIHandler{}
+
+	body, _ := json.Marshal(DryRunEvaluationRequest{
+		SLO:       dryRunSLO,
+		SLIValues: map[string]float64{"response_time": 500, "error_rate": 1},
+	})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, DryRunEvaluationAPIPath, bytes.NewReader(body)))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	result := &DryRunEvaluationResult{}
+	require.Nil(t, json.Unmarshal(w.Body.Bytes(), result))
+	require.Equal(t, "pass", result.Evaluation.Result)
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, DryRunEvaluationAPIPath, bytes.NewReader([]byte("invalid"))))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, DryRunEvaluationAPIPath, nil))
+	require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
@@ -0,0 +1,251 @@
+package event_handler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	keptnapi "github.com/keptn/go-utils/pkg/api/utils"
+	"github.com/keptn/go-utils/pkg/common/timeutils"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	logger "github.com/sirupsen/logrus"
+)
+
+const defaultBurnRateWindow = time.Hour
+
+// ErrorBudget contains the error budget of an SLI over a rolling window of evaluations
+type ErrorBudget struct {
+	SLI string `json:"sli"`
+	// Target is the percentage of evaluations the SLI has to pass
+	Target            float64 `json:"target"`
+	Window            string  `json:"window"`
+	TotalEvaluations  int     `json:"totalEvaluations"`
+	FailedEvaluations int     `json:"failedEvaluations"`
+	// RemainingBudget is the percentage of the error budget that has not been consumed yet. It is negative if the budget has been exceeded
+	RemainingBudget float64 `json:"remainingBudget"`
+	// BurnRate is the rate the error budget has been consumed at within the burn rate window. At a burn rate of 1, the budget lasts exactly for the window
+	BurnRate float64 `json:"burnRate"`
+}
+
+type errorBudgetConfig struct {
+	window         time.Duration
+	windowString   string
+	target         float64
+	burnRateWindow time.Duration
+	minRemaining   *float64
+}
+
+// evaluationRecord is a previous evaluation together with the time it has been finished
+type evaluationRecord struct {
+	Time       time.Time
+	Evaluation *EvaluationFinishedEventData
+}
+
+func parseErrorBudget(budget *SLOErrorBudget) (*errorBudgetConfig, error) {
+	config := &errorBudgetConfig{
+		windowString:   budget.Window,
+		burnRateWindow: defaultBurnRateWindow,
+	}
+	var err error
+	if config.window, err = parseWindow(budget.Window); err != nil {
+		return nil, fmt.Errorf("invalid error budget window: %w", err)
+	}
+	if config.target, err = parsePercentage(budget.Target); err != nil || config.target <= 0 || config.target >= 100 {
+		return nil, errors.New("invalid error budget target: must be a percentage between 0% and 100%")
+	}
+	if budget.BurnRateWindow != "" {
+		if config.burnRateWindow, err = parseWindow(budget.BurnRateWindow); err != nil {
+			return nil, fmt.Errorf("invalid error budget burn rate window: %w", err)
+		}
+	}
+	if budget.MinRemaining != "" {
+		minRemaining, err := parsePercentage(budget.MinRemaining)
+		if err != nil {
+			return nil, errors.New("invalid minimum remaining error budget")
+		}
+		config.minRemaining = &minRemaining
+	}
+	return config, nil
+}
+
+// parseWindow parses durations like "30d", "12h" or "90m"
+func parseWindow(window string) (time.Duration, error) {
+	var duration time.Duration
+	if strings.HasSuffix(window, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(window, "d"))
+		if err != nil {
+			return 0, fmt.Errorf("could not parse window %s", window)
+		}
+		duration = time.Duration(days) * 24 * time.Hour
+	} else {
+		var err error
+		if duration, err = time.ParseDuration(window); err != nil {
+			return 0, fmt.Errorf("could not parse window %s", window)
+		}
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("window %s must be positive", window)
+	}
+	return duration, nil
+}
+
+func parsePercentage(percentage string) (float64, error) {
+	return strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(percentage, "%")), 64)
+}
+
+// getEvaluationRecords retrieves the evaluations of a service that have been finished after the given time, excluding invalidated ones
+func getEvaluationRecords(eventStore EventStore, project, stage, service string, from time.Time) ([]evaluationRecord, error) {
+	filter := keptnapi.EventFilter{
+		Project:  project,
+		Stage:    stage,
+		Service:  service,
+		PageSize: "100",
+		FromTime: timeutils.GetKeptnTimeStamp(from),
+	}
+
+	filter.EventType = keptnv2.GetInvalidatedEventType(keptnv2.EvaluationTaskName)
+	invalidatedEvents, errObj := eventStore.GetEvents(&filter)
+	if errObj != nil {
+		return nil, fmt.Errorf("could not retrieve invalidated evaluations: %s", getErrorMessage(errObj.Message))
+	}
+	invalidated := map[string]bool{}
+	for _, event := range invalidatedEvents {
+		invalidated[event.Triggeredid] = true
+	}
+
+	filter.EventType = keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName)
+	finishedEvents, errObj := eventStore.GetEvents(&filter)
+	if errObj != nil {
+		return nil, fmt.Errorf("could not retrieve evaluations: %s", getErrorMessage(errObj.Message))
+	}
+	records := []evaluationRecord{}
+	for _, event := range finishedEvents {
+		if invalidated[event.Triggeredid] {
+			continue
+		}
+		evaluation := &EvaluationFinishedEventData{}
+		if err := keptnv2.Decode(event.Data, evaluation); err != nil {
+			logger.Errorf("Could not decode evaluation.finished event %s: %s", event.ID, err.Error())
+			continue
+		}
+		records = append(records, evaluationRecord{Time: event.Time, Evaluation: evaluation})
+	}
+	return records, nil
+}
+
+func getErrorMessage(message *string) string {
+	if message == nil {
+		return "unknown error"
+	}
+	return *message
+}
+
+// calculateErrorBudgets calculates the error budgets of the given SLIs based on the evaluations within the window.
+// An SLI is counted as failed in an evaluation if its status is "fail"; evaluations without a status for the SLI are ignored
+func calculateErrorBudgets(config *errorBudgetConfig, slis []string, records []evaluationRecord, now time.Time) []*ErrorBudget {
+	windowStart := now.Add(-config.window)
+	burnRateWindowStart := now.Add(-config.burnRateWindow)
+	allowedFailureRatio := (100 - config.target) / 100
+
+	budgets := []*ErrorBudget{}
+	for _, sli := range slis {
+		budget := &ErrorBudget{
+			SLI:             sli,
+			Target:          config.target,
+			Window:          config.windowString,
+			RemainingBudget: 100,
+		}
+		burnRateEvaluations := 0
+		burnRateFailures := 0
+		for _, record := range records {
+			if record.Time.Before(windowStart) {
+				continue
+			}
+			status := getIndicatorStatus(record.Evaluation, sli)
+			if status == "" || status == "info" {
+				continue
+			}
+			failed := status == "fail"
+			budget.TotalEvaluations++
+			if failed {
+				budget.FailedEvaluations++
+			}
+			if !record.Time.Before(burnRateWindowStart) {
+				burnRateEvaluations++
+				if failed {
+					burnRateFailures++
+				}
+			}
+		}
+		if budget.TotalEvaluations > 0 {
+			allowedFailures := allowedFailureRatio * float64(budget.TotalEvaluations)
+			budget.RemainingBudget = 100 * (allowedFailures - float64(budget.FailedEvaluations)) / allowedFailures
+		}
+		if burnRateEvaluations > 0 {
+			budget.BurnRate = (float64(burnRateFailures) / float64(burnRateEvaluations)) / allowedFailureRatio
+		}
+		budgets = append(budgets, budget)
+	}
+	return budgets
+}
+
+func getIndicatorStatus(evaluation *EvaluationFinishedEventData, sli string) string {
+	for _, indicatorResult := range evaluation.Evaluation.IndicatorResults {
+		if indicatorResult != nil && indicatorResult.Value != nil && indicatorResult.Value.Metric == sli {
+			return indicatorResult.Status
+		}
+	}
+	return ""
+}
+
+// getErrorBudgetSLIs returns the SLIs error budgets are calculated for, i.e. the SLIs of objectives with pass criteria
+func getErrorBudgetSLIs(sloConfig *ServiceLevelObjectives) []string {
+	slis := []string{}
+	for _, objective := range sloConfig.Objectives {
+		if len(objective.Pass) > 0 {
+			slis = append(slis, objective.SLI)
+		}
+	}
+	return slis
+}
+
+// evaluateErrorBudgets adds the error budgets, including the current evaluation, to the evaluation result and fails the evaluation
+// if the remaining error budget of an SLI is below the configured minimum
+func (eh *EvaluateSLIHandler) evaluateErrorBudgets(evaluationResult *EvaluationFinishedEventData, sloConfig *ServiceLevelObjectives) error {
+	config, err := parseErrorBudget(sloConfig.ErrorBudget)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	records, err := getEvaluationRecords(eh.EventStore, evaluationResult.Project, evaluationResult.Stage, evaluationResult.Service, now.Add(-config.window))
+	if err != nil {
+		if config.minRemaining != nil {
+			return fmt.Errorf("could not calculate error budget: %w", err)
+		}
+		logger.Errorf("Could not calculate error budget: %s", err.Error())
+		return nil
+	}
+	records = append(records, evaluationRecord{Time: now, Evaluation: evaluationResult})
+
+	budgets := calculateErrorBudgets(config, getErrorBudgetSLIs(sloConfig), records, now)
+	evaluationResult.Evaluation.ErrorBudgets = budgets
+	if config.minRemaining == nil {
+		return nil
+	}
+
+	exhausted := []string{}
+	for _, budget := range budgets {
+		if budget.RemainingBudget < *config.minRemaining {
+			exhausted = append(exhausted, fmt.Sprintf("%s (%.2f%%)", budget.SLI, budget.RemainingBudget))
+		}
+	}
+	if len(exhausted) > 0 {
+		evaluationResult.Evaluation.Result = string(keptnv2.ResultFailed)
+		evaluationResult.Result = keptnv2.ResultFailed
+		evaluationResult.Status = keptnv2.StatusSucceeded
+		evaluationResult.Message = fmt.Sprintf("Evaluation failed since the remaining error budget of %s is below %v%%. ", strings.Join(exhausted, ", "), *config.minRemaining) + evaluationResult.Message
+	}
+	return nil
+}
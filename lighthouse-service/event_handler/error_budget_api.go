@@ -0,0 +1,118 @@
+package event_handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	keptnapi "github.com/keptn/go-utils/pkg/api/utils"
+	keptncommon "github.com/keptn/go-utils/pkg/lib/keptn"
+	logger "github.com/sirupsen/logrus"
+)
+
+// ErrorBudgetAPIPath is the path of the endpoint providing the error budgets of a service
+const ErrorBudgetAPIPath = "/v1/error-budget"
+
+// ErrorBudgetResponse is the response of the error budget endpoint
+type ErrorBudgetResponse struct {
+	Project      string         `json:"project"`
+	Stage        string         `json:"stage"`
+	Service      string         `json:"service"`
+	ErrorBudgets []*ErrorBudget `json:"errorBudgets"`
+}
+
+type apiError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// ErrorBudgetAPIHandler serves the error budgets of the SLIs of a service, calculated from the stored evaluation.finished events
+type ErrorBudgetAPIHandler struct {
+	SLOFileRetriever SLOFileRetriever
+	EventStore       EventStore
+}
+
+// NewErrorBudgetAPIHandler creates an ErrorBudgetAPIHandler using the configuration-service and the mongodb-datastore
+func NewErrorBudgetAPIHandler() (*ErrorBudgetAPIHandler, error) {
+	configurationServiceEndpoint, err := keptncommon.GetServiceEndpoint("CONFIGURATION_SERVICE")
+	if err != nil {
+		return nil, err
+	}
+	return &ErrorBudgetAPIHandler{
+		SLOFileRetriever: SLOFileRetriever{
+			ResourceHandler: keptnapi.NewResourceHandler(configurationServiceEndpoint.String()),
+			ServiceHandler:  keptnapi.NewServiceHandler(configurationServiceEndpoint.String()),
+		},
+		EventStore: keptnapi.NewEventHandler(getDatastoreURL()),
+	}, nil
+}
+
+// ServeHTTP handles GET /v1/error-budget?project=<project>&stage=<stage>&service=<service>. The window and target
+// configured in the SLO file can be overridden using the query parameters window and target
+func (h *ErrorBudgetAPIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	query := r.URL.Query()
+	project, stage, service := query.Get("project"), query.Get("stage"), query.Get("service")
+	if project == "" || stage == "" || service == "" {
+		writeAPIError(w, http.StatusBadRequest, "project, stage and service must be provided")
+		return
+	}
+
+	sloConfig, err := h.SLOFileRetriever.GetSLOs(project, stage, service)
+	if err != nil {
+		if errors.Is(err, ErrProjectNotFound) || errors.Is(err, ErrStageNotFound) || errors.Is(err, ErrServiceNotFound) || errors.Is(err, ErrSLOFileNotFound) {
+			writeAPIError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	budgetConfig := SLOErrorBudget{}
+	if sloConfig.ErrorBudget != nil {
+		budgetConfig = *sloConfig.ErrorBudget
+	}
+	if query.Get("window") != "" {
+		budgetConfig.Window = query.Get("window")
+	}
+	if query.Get("target") != "" {
+		budgetConfig.Target = query.Get("target")
+	}
+	if sloConfig.ErrorBudget == nil && (budgetConfig.Window == "" || budgetConfig.Target == "") {
+		writeAPIError(w, http.StatusNotFound, fmt.Sprintf("no error budget configured for service %s in stage %s", service, stage))
+		return
+	}
+	config, err := parseErrorBudget(&budgetConfig)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	now := time.Now().UTC()
+	records, err := getEvaluationRecords(h.EventStore, project, stage, service, now.Add(-config.window))
+	if err != nil {
+		logger.Errorf("Could not calculate error budget of service %s in stage %s: %s", service, stage, err.Error())
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response := ErrorBudgetResponse{
+		Project:      project,
+		Stage:        stage,
+		Service:      service,
+		ErrorBudgets: calculateErrorBudgets(config, getErrorBudgetSLIs(sloConfig), records, now),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func writeAPIError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(apiError{Code: code, Message: message})
+}
@@ -0,0 +1,345 @@
+package event_handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/api/models"
+	keptnapi "github.com/keptn/go-utils/pkg/api/utils"
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/require"
+
+	event_handler_mock "github.com/keptn/keptn/lighthouse-service/event_handler/fake"
+)
+
+func Test_parseErrorBudget(t *testing.T) {
+	minRemaining := 10.0
+	tests := []struct {
+		name    string
+		budget  *SLOErrorBudget
+		want    *errorBudgetConfig
+		wantErr bool
+	}{
+		{
+			name:   "window in days",
+			budget: &SLOErrorBudget{Window: "30d", Target: "99.5%"},
+			want:   &errorBudgetConfig{window: 30 * 24 * time.Hour, windowString: "30d", target: 99.5, burnRateWindow: time.Hour},
+		},
+		{
+			name:   "burn rate window and minimum remaining budget",
+			budget: &SLOErrorBudget{Window: "12h", Target: "99", BurnRateWindow: "30m", MinRemaining: "10%"},
+			want:   &errorBudgetConfig{window: 12 * time.Hour, windowString: "12h", target: 99, burnRateWindow: 30 * time.Minute, minRemaining: &minRemaining},
+		},
+		{
+			name:    "invalid window",
+			budget:  &SLOErrorBudget{Window: "a month", Target: "99.5%"},
+			wantErr: true,
+		},
+		{
+			name:    "negative window",
+			budget:  &SLOErrorBudget{Window: "-1d", Target: "99.5%"},
+			wantErr: true,
+		},
+		{
+			name:    "target of 100%",
+			budget:  &SLOErrorBudget{Window: "30d", Target: "100%"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid minimum remaining budget",
+			budget:  &SLOErrorBudget{Window: "30d", Target: "99.5%", MinRemaining: "some"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseErrorBudget(tt.budget)
+			require.Equal(t, tt.wantErr, err != nil)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func newEvaluationRecord(timestamp time.Time, statuses map[string]string) evaluationRecord {
+	evaluation := &EvaluationFinishedEventData{}
+	for sli, status := range statuses {
+		evaluation.Evaluation.IndicatorResults = append(evaluation.Evaluation.IndicatorResults, &SLIEvaluationResult{
+			SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+				Value:  &keptnv2.SLIResult{Metric: sli, Success: true},
+				Status: status,
+			},
+		})
+	}
+	return evaluationRecord{Time: timestamp, Evaluation: evaluation}
+}
+
+func Test_calculateErrorBudgets(t *testing.T) {
+	now := time.Date(2021, 1, 31, 12, 0, 0, 0, time.UTC)
+	config := &errorBudgetConfig{window: 30 * 24 * time.Hour, windowString: "30d", target: 80, burnRateWindow: time.Hour}
+
+	records := []evaluationRecord{
+		// outside of the window
+		newEvaluationRecord(now.Add(-31*24*time.Hour), map[string]string{"response_time": "fail"}),
+		// within the burn rate window
+		newEvaluationRecord(now.Add(-10*time.Minute), map[string]string{"response_time": "fail", "error_rate": "pass"}),
+		newEvaluationRecord(now.Add(-20*time.Minute), map[string]string{"response_time": "pass", "error_rate": "info"}),
+	}
+	for i := 0; i < 8; i++ {
+		records = append(records, newEvaluationRecord(now.Add(-time.Duration(i+1)*24*time.Hour), map[string]string{"response_time": "pass", "error_rate": "fail"}))
+	}
+
+	got := calculateErrorBudgets(config, []string{"response_time", "error_rate", "throughput"}, records, now)
+
+	require.Len(t, got, 3)
+
+	require.Equal(t, "response_time", got[0].SLI)
+	require.Equal(t, 10, got[0].TotalEvaluations)
+	require.Equal(t, 1, got[0].FailedEvaluations)
+	require.InDelta(t, 50.0, got[0].RemainingBudget, 0.0001)
+	require.InDelta(t, 2.5, got[0].BurnRate, 0.0001)
+
+	// the error budget of error_rate has been exceeded, but no failures occurred within the burn rate window
+	require.Equal(t, 9, got[1].TotalEvaluations)
+	require.Equal(t, 8, got[1].FailedEvaluations)
+	require.Less(t, got[1].RemainingBudget, 0.0)
+	require.Equal(t, 0.0, got[1].BurnRate)
+
+	// without evaluations, the full budget remains
+	require.Equal(t, &ErrorBudget{SLI: "throughput", Target: 80, Window: "30d", RemainingBudget: 100}, got[2])
+}
+
+func newEvaluationFinishedEvent(id, triggeredID string, timestamp time.Time, status string) *models.KeptnContextExtendedCE {
+	return &models.KeptnContextExtendedCE{
+		ID:          id,
+		Triggeredid: triggeredID,
+		Time:        timestamp,
+		Type:        stringp(keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName)),
+		Data: EvaluationFinishedEventData{
+			Evaluation: EvaluationDetails{
+				IndicatorResults: []*SLIEvaluationResult{
+					{SLIEvaluationResult: keptnv2.SLIEvaluationResult{Value: &keptnv2.SLIResult{Metric: "response_time", Success: true}, Status: status}},
+				},
+			},
+		},
+	}
+}
+
+func newErrorBudgetEventStore(now time.Time) *event_handler_mock.EventStoreMock {
+	return &event_handler_mock.EventStoreMock{GetEventsFunc: func(filter *keptnapi.EventFilter) ([]*models.KeptnContextExtendedCE, *models.Error) {
+		if filter.EventType == keptnv2.GetInvalidatedEventType(keptnv2.EvaluationTaskName) {
+			return []*models.KeptnContextExtendedCE{{ID: "invalidated", Triggeredid: "triggered-3"}}, nil
+		}
+		return []*models.KeptnContextExtendedCE{
+			newEvaluationFinishedEvent("finished-1", "triggered-1", now.Add(-2*time.Hour), "pass"),
+			newEvaluationFinishedEvent("finished-2", "triggered-2", now.Add(-3*time.Hour), "pass"),
+			newEvaluationFinishedEvent("finished-3", "triggered-3", now.Add(-4*time.Hour), "fail"),
+		}, nil
+	}}
+}
+
+func Test_getEvaluationRecords(t *testing.T) {
+	now := time.Now().UTC()
+	eventStore := newErrorBudgetEventStore(now)
+
+	records, err := getEvaluationRecords(eventStore, "sockshop", "staging", "carts", now.Add(-24*time.Hour))
+
+	require.Nil(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, now.Add(-2*time.Hour), records[0].Time)
+	require.Equal(t, "pass", records[0].Evaluation.Evaluation.IndicatorResults[0].Status)
+
+	calls := eventStore.GetEventsCalls()
+	require.Len(t, calls, 2)
+	require.Equal(t, "sockshop", calls[1].Filter.Project)
+	require.Equal(t, "staging", calls[1].Filter.Stage)
+	require.Equal(t, "carts", calls[1].Filter.Service)
+	require.Equal(t, keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName), calls[1].Filter.EventType)
+	require.NotEmpty(t, calls[1].Filter.FromTime)
+
+	eventStore.GetEventsFunc = func(filter *keptnapi.EventFilter) ([]*models.KeptnContextExtendedCE, *models.Error) {
+		return nil, &models.Error{Message: stringp("datastore not available")}
+	}
+	_, err = getEvaluationRecords(eventStore, "sockshop", "staging", "carts", now.Add(-24*time.Hour))
+	require.NotNil(t, err)
+}
+
+func TestEvaluateSLIHandler_evaluateErrorBudgets(t *testing.T) {
+	sloConfig := &ServiceLevelObjectives{
+		Objectives: []*keptn.SLO{
+			{SLI: "response_time", Pass: []*keptn.SLOCriteria{{Criteria: []string{"<200"}}}},
+			{SLI: "throughput"},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		minRemaining string
+		currentState string
+		eventStore   *event_handler_mock.EventStoreMock
+		wantResult   string
+		wantErr      bool
+	}{
+		{
+			name:         "budget remaining",
+			minRemaining: "10%",
+			currentState: "pass",
+			wantResult:   "pass",
+		},
+		{
+			name:         "budget exhausted by the current evaluation",
+			minRemaining: "10%",
+			currentState: "fail",
+			wantResult:   "fail",
+		},
+		{
+			name:         "budget exhausted without gating",
+			currentState: "fail",
+			wantResult:   "pass",
+		},
+		{
+			name:         "event store not available",
+			minRemaining: "10%",
+			currentState: "pass",
+			eventStore: &event_handler_mock.EventStoreMock{GetEventsFunc: func(filter *keptnapi.EventFilter) ([]*models.KeptnContextExtendedCE, *models.Error) {
+				return nil, &models.Error{Message: stringp("datastore not available")}
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventStore := tt.eventStore
+			if eventStore == nil {
+				eventStore = newErrorBudgetEventStore(time.Now().UTC())
+			}
+			eh := &EvaluateSLIHandler{EventStore: eventStore}
+			sloConfig.ErrorBudget = &SLOErrorBudget{Window: "30d", Target: "90%", MinRemaining: tt.minRemaining}
+			evaluationResult := &EvaluationFinishedEventData{
+				EventData: keptnv2.EventData{Project: "sockshop", Stage: "staging", Service: "carts", Result: keptnv2.ResultPass},
+				Evaluation: EvaluationDetails{
+					EvaluationDetails: keptnv2.EvaluationDetails{
+						Result: "pass",
+					},
+					IndicatorResults: []*SLIEvaluationResult{
+						{SLIEvaluationResult: keptnv2.SLIEvaluationResult{Value: &keptnv2.SLIResult{Metric: "response_time", Success: true}, Status: tt.currentState}},
+					},
+				},
+			}
+
+			err := eh.evaluateErrorBudgets(evaluationResult, sloConfig)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.wantResult, evaluationResult.Evaluation.Result)
+			require.Equal(t, tt.wantResult, string(evaluationResult.Result))
+
+			require.Len(t, evaluationResult.Evaluation.ErrorBudgets, 1)
+			budget := evaluationResult.Evaluation.ErrorBudgets[0]
+			require.Equal(t, "response_time", budget.SLI)
+			// the invalidated evaluation is not counted, but the current one is
+			require.Equal(t, 3, budget.TotalEvaluations)
+		})
+	}
+}
+
+func TestErrorBudgetAPIHandler_ServeHTTP(t *testing.T) {
+	sloFile := `---
+spec_version: "1.0"
+error_budget:
+  window: "30d"
+  target: "90%"
+objectives:
+  - sli: "response_time"
+    pass:
+      - criteria:
+          - "<200"
+total_score:
+  pass: "90%"
+  warning: "75%"
+`
+	tests := []struct {
+		name       string
+		query      string
+		sloFile    string
+		sloErr     error
+		wantStatus int
+		wantBudget *ErrorBudget
+	}{
+		{
+			name:       "error budget of a service",
+			query:      "project=sockshop&stage=staging&service=carts",
+			sloFile:    sloFile,
+			wantStatus: http.StatusOK,
+			wantBudget: &ErrorBudget{SLI: "response_time", Target: 90, Window: "30d", TotalEvaluations: 2, RemainingBudget: 100},
+		},
+		{
+			name:       "window and target overridden",
+			query:      "project=sockshop&stage=staging&service=carts&window=3h&target=80%25",
+			sloFile:    sloFile,
+			wantStatus: http.StatusOK,
+			wantBudget: &ErrorBudget{SLI: "response_time", Target: 80, Window: "3h", TotalEvaluations: 1, RemainingBudget: 100},
+		},
+		{
+			name:       "missing service",
+			query:      "project=sockshop&stage=staging",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "no error budget configured",
+			query:      "project=sockshop&stage=staging&service=carts",
+			sloFile:    "objectives:\n  - sli: response_time\n",
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "no SLO file",
+			query:      "project=sockshop&stage=staging&service=carts",
+			sloErr:     errors.New("resource not found"),
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &ErrorBudgetAPIHandler{
+				SLOFileRetriever: SLOFileRetriever{
+					ResourceHandler: &event_handler_mock.ResourceHandlerMock{
+						GetProjectResourceFunc: func(project string, resourceURI string) (*models.Resource, error) {
+							return nil, errors.New("resource not found")
+						},
+						GetStageResourceFunc: func(project string, stage string, resourceURI string) (*models.Resource, error) {
+							return nil, errors.New("resource not found")
+						},
+						GetServiceResourceFunc: func(project string, stage string, service string, resourceURI string) (*models.Resource, error) {
+							if tt.sloErr != nil {
+								return nil, tt.sloErr
+							}
+							return &models.Resource{ResourceContent: tt.sloFile}, nil
+						},
+					},
+					ServiceHandler: &event_handler_mock.ServiceHandlerMock{GetServiceFunc: func(project string, stage string, service string) (*models.Service, error) {
+						return &models.Service{}, nil
+					}},
+				},
+				EventStore: newErrorBudgetEventStore(time.Now().UTC()),
+			}
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, ErrorBudgetAPIPath+"?"+tt.query, nil))
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantBudget == nil {
+				return
+			}
+			response := &ErrorBudgetResponse{}
+			require.Nil(t, json.Unmarshal(w.Body.Bytes(), response))
+			require.Equal(t, "carts", response.Service)
+			require.Equal(t, []*ErrorBudget{tt.wantBudget}, response.ErrorBudgets)
+		})
+	}
+}
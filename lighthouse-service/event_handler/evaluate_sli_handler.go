@@ -17,7 +17,6 @@ import (
 	"sync"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
-	"gopkg.in/yaml.v3"
 
 	keptnapi "github.com/keptn/go-utils/pkg/api/utils"
 	keptn "github.com/keptn/go-utils/pkg/lib"
@@ -40,6 +39,56 @@ type criteriaObject struct {
 	CheckPercentage bool
 	IsComparison    bool
 	CheckIncrease   bool
+	// Statistic is set if the criteria compares the value with the distribution of the previous results (zscore, mad, increasing, decreasing)
+	Statistic string
+}
+
+const (
+	// zScoreStatistic checks the number of standard deviations between the value and the mean of the previous results
+	zScoreStatistic = "zscore"
+	// madStatistic checks the number of median absolute deviations between the value and the median of the previous results
+	madStatistic = "mad"
+	// increasingStatistic checks the number of consecutive results (including the current one) the value has been increasing
+	increasingStatistic = "increasing"
+	// decreasingStatistic checks the number of consecutive results (including the current one) the value has been decreasing
+	decreasingStatistic = "decreasing"
+)
+
+// madScaleFactor scales the median absolute deviation to be comparable with the standard deviation of normally distributed values
+const madScaleFactor = 1.4826
+
+var statisticalCriteriaRegex = regexp.MustCompile(`^(zscore|mad|increasing|decreasing)(<=|<)(\d*\.?\d+)$`)
+
+// EvaluationFinishedEventData is the data of the evaluation.finished event sent by the lighthouse-service.
+// It corresponds to keptnv2.EvaluationFinishedEventData, but contains the extended EvaluationDetails
+type EvaluationFinishedEventData struct {
+	keptnv2.EventData
+	Evaluation EvaluationDetails `json:"evaluation,omitempty"`
+}
+
+// EvaluationDetails extends keptnv2.EvaluationDetails with the segments and error budgets of the evaluation.
+// IndicatorResults shadows the field of keptnv2.EvaluationDetails to contain the bounds of the evaluated SLI targets
+type EvaluationDetails struct {
+	keptnv2.EvaluationDetails
+	IndicatorResults []*SLIEvaluationResult `json:"indicatorResults"`
+	// Segments contains the results of the intervals of a segmented evaluation
+	Segments []*EvaluationSegment `json:"segments,omitempty"`
+	// ErrorBudgets contains the error budgets of the SLIs if an error budget is configured in the SLO file
+	ErrorBudgets []*ErrorBudget `json:"errorBudgets,omitempty"`
+}
+
+// SLIEvaluationResult extends keptnv2.SLIEvaluationResult. PassTargets and WarningTargets shadow the fields of keptnv2.SLIEvaluationResult
+type SLIEvaluationResult struct {
+	keptnv2.SLIEvaluationResult
+	PassTargets    []*SLITarget `json:"passTargets"`
+	WarningTargets []*SLITarget `json:"warningTargets"`
+}
+
+// SLITarget extends keptnv2.SLITarget with the range the SLI value has to be in to satisfy a statistical criteria (e.g. zscore<=3)
+type SLITarget struct {
+	keptnv2.SLITarget
+	LowerBound *float64 `json:"lowerBound,omitempty"`
+	UpperBound *float64 `json:"upperBound,omitempty"`
 }
 
 type EvaluateSLIHandler struct {
@@ -101,18 +150,38 @@ func (eh *EvaluateSLIHandler) processGetSliFinishedEvent(ctx context.Context, sh
 		logger.Error(msg)
 		return sendErroredFinishedEventWithMessage(shkeptncontext, "", msg, "", eh.KeptnHandler, e)
 	}
-	triggeredID := triggeredEvents[0].ID
+
+	// compare the results based on the evaluation strategy
+	// the merged slo.yaml is used as a plain file to avoid confusion due to defaulted values (see https://github.com/keptn/keptn/issues/1495)
+	sloConfig, sloFileContent, err := eh.SLOFileRetriever.GetEffectiveSLOs(e.Project, e.Stage, e.Service)
+	numberOfSLIRequests := 1
+	if err == nil {
+		numberOfSLIRequests = getNumberOfSLIRequests(sloConfig)
+	}
+
+	// the service may have been evaluated several times in the same context (e.g. in case of a restarted sequence),
+	// therefore the evaluation is determined by the get-sli.triggered event the results belong to
+	var sliRequestID string
+	_ = eh.Event.ExtensionAs("triggeredid", &sliRequestID)
+	evaluationTriggeredEvent := getEvaluationOfSLIRequest(triggeredEvents, sliRequestID, numberOfSLIRequests)
+	if evaluationTriggeredEvent == nil {
+		logger.Warnf("Could not determine the evaluation of get-sli.triggered event %s, using the latest evaluation of context %s", sliRequestID, shkeptncontext)
+		evaluationTriggeredEvent = triggeredEvents[0]
+	}
+	triggeredID := evaluationTriggeredEvent.ID
 
 	logger.Debug("Start to evaluate SLIs")
 
-	evaluationDetails := keptnv2.EvaluationDetails{
+	evaluationDetails := EvaluationDetails{
+		EvaluationDetails: keptnv2.EvaluationDetails{
+			TimeStart: e.GetSLI.Start,
+			TimeEnd:   e.GetSLI.End,
+			Result:    string(keptnv2.ResultPass),
+		},
 		IndicatorResults: nil,
-		TimeStart:        e.GetSLI.Start,
-		TimeEnd:          e.GetSLI.End,
-		Result:           string(keptnv2.ResultPass),
 	}
 
-	evalResult := keptnv2.EvaluationFinishedEventData{
+	evalResult := EvaluationFinishedEventData{
 		Evaluation: evaluationDetails,
 		EventData: keptnv2.EventData{
 			Status:  keptnv2.StatusSucceeded,
@@ -123,14 +192,13 @@ func (eh *EvaluateSLIHandler) processGetSliFinishedEvent(ctx context.Context, sh
 		},
 	}
 	if e.Result == "fail" {
+		// the remaining SLI results of the evaluation must not trigger another evaluation
+		sliResults.abort(triggeredID)
 		evalResult.EventData.Result = keptnv2.ResultFailed
 		evalResult.Message = fmt.Sprintf("no evaluation performed by lighthouse because SLI failed with message %s", e.Message)
 		return sendEvent(shkeptncontext, triggeredID, keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName), eh.KeptnHandler, &evalResult)
 	}
 
-	// compare the results based on the evaluation strategy
-	sloConfig, err := eh.SLOFileRetriever.GetSLOs(e.Project, e.Stage, e.Service)
-
 	if err != nil {
 		if err == ErrSLOFileNotFound {
 			evalResult.EventData.Result = keptnv2.ResultPass
@@ -141,27 +209,47 @@ func (eh *EvaluateSLIHandler) processGetSliFinishedEvent(ctx context.Context, sh
 		return sendErroredFinishedEventWithMessage(shkeptncontext, triggeredID, err.Error(), "", eh.KeptnHandler, e)
 	}
 
-	// get the slo.yaml as a plain file to avoid confusion due to defaulted values (see https://github.com/keptn/keptn/issues/1495)
-	sloFileContent, err := eh.KeptnHandler.GetKeptnResource("slo.yaml")
-	if err != nil {
-		logger.Debug("Could not fetch slo.yaml from service repository: " + err.Error() + ". Will append internally used SLO object to evaluation.finished event.")
-		sloFileContent, _ = yaml.Marshal(sloConfig)
+	if numberOfSLIRequests == 1 {
+		return eh.evaluateSLIResults(shkeptncontext, triggeredID, e, nil, sloConfig, sloFileContent)
 	}
 
-	// get results of previous evaluations from data store (mongodb-datastore)
-	numberOfPreviousResults := 3
-	if sloConfig.Comparison.CompareWith == "single_result" {
-		numberOfPreviousResults = 1
-	} else if sloConfig.Comparison.CompareWith == "several_results" {
-		numberOfPreviousResults = sloConfig.Comparison.NumberOfComparisonResults
+	// the results of the remaining get-sli.triggered events are collected if the SLIs are retrieved from several providers or for several intervals.
+	// If not all of them are received within the timeout, the evaluation fails, since its result would be based on an incomplete set of SLIs
+	onTimeout := func(receivedIDs map[string]bool) {
+		missingProviders := []string{}
+		for _, provider := range getMissingSLIProviders(sloConfig, triggeredID, receivedIDs) {
+			if provider == "" {
+				provider = "SLI provider of the project"
+			}
+			missingProviders = append(missingProviders, provider)
+		}
+		message := fmt.Sprintf("no evaluation performed by lighthouse because the SLI results of the following SLI providers have not been received within %s: %s",
+			getSLIRetrievalTimeout().String(), strings.Join(missingProviders, ", "))
+		logger.Error(message)
+		if err := sendErroredFinishedEventWithMessage(shkeptncontext, triggeredID, message, string(sloFileContent), eh.KeptnHandler, e); err != nil {
+			logger.Errorf("Could not send evaluation.finished event of evaluation %s: %s", triggeredID, err.Error())
+		}
+	}
+	results := eh.collectSLIResults(shkeptncontext, triggeredID, e, numberOfSLIRequests, onTimeout)
+	if results == nil {
+		logger.Infof("Waiting for the remaining SLI results of evaluation %s", triggeredID)
+		return nil
 	}
+	merged, segments := mergeSLIResults(e, results, sloConfig.Segments)
+	return eh.evaluateSLIResults(shkeptncontext, triggeredID, merged, segments, sloConfig, sloFileContent)
+}
 
-	previousEvaluationEvents, comparisonEventIDs, err := eh.getPreviousEvaluations(e, numberOfPreviousResults, sloConfig.Comparison.IncludeResultWithScore)
+// evaluateSLIResults evaluates the SLI results of the whole evaluation timeframe, as well as the results of its intervals for segmented evaluations,
+// and sends the evaluation.finished event
+func (eh *EvaluateSLIHandler) evaluateSLIResults(shkeptncontext string, triggeredID string, e *keptnv2.GetSLIFinishedEventData, segments []*keptnv2.GetSLIFinishedEventData,
+	sloConfig *ServiceLevelObjectives, sloFileContent []byte) error {
+	// get results of previous evaluations from data store (mongodb-datastore)
+	previousEvaluationEvents, comparisonEventIDs, err := eh.getComparisonEvaluations(e, sloConfig.Comparison)
 	if err != nil {
 		return sendErroredFinishedEventWithMessage(shkeptncontext, triggeredID, err.Error(), string(sloFileContent), eh.KeptnHandler, e)
 	}
 
-	var filteredPreviousEvaluationEvents []*keptnv2.EvaluationFinishedEventData
+	var filteredPreviousEvaluationEvents []*EvaluationFinishedEventData
 
 	// verify that we have enough evaluations
 	for _, val := range previousEvaluationEvents {
@@ -177,35 +265,91 @@ func (eh *EvaluateSLIHandler) processGetSliFinishedEvent(ctx context.Context, sh
 	if err != nil {
 		return sendErroredFinishedEventWithMessage(shkeptncontext, triggeredID, err.Error(), string(sloFileContent), eh.KeptnHandler, e)
 	}
+
+	if segments != nil {
+		err = evaluateSegments(evaluationResult, segments, sloConfig, filteredPreviousEvaluationEvents)
+		if err != nil {
+			return sendErroredFinishedEventWithMessage(shkeptncontext, triggeredID, err.Error(), string(sloFileContent), eh.KeptnHandler, e)
+		}
+	}
+
+	if sloConfig.ErrorBudget != nil {
+		err = eh.evaluateErrorBudgets(evaluationResult, sloConfig)
+		if err != nil {
+			return sendErroredFinishedEventWithMessage(shkeptncontext, triggeredID, err.Error(), string(sloFileContent), eh.KeptnHandler, e)
+		}
+	}
 	logger.Debug("Evaluation result: " + string(evaluationResult.Result))
 
 	evaluationResult.Evaluation.SLOFileContent = base64.StdEncoding.EncodeToString(sloFileContent)
 
-	return sendEvent(shkeptncontext, triggeredEvents[0].ID, keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName), eh.KeptnHandler, evaluationResult)
+	return sendEvent(shkeptncontext, triggeredID, keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName), eh.KeptnHandler, evaluationResult)
 }
 
-func evaluateObjectives(e *keptnv2.GetSLIFinishedEventData, sloConfig *keptn.ServiceLevelObjectives, previousEvaluationEvents []*keptnv2.EvaluationFinishedEventData) (*keptnv2.EvaluationFinishedEventData, float64, bool) {
-	evaluationResult := &keptnv2.EvaluationFinishedEventData{
+// collectSLIResults adds the SLI results of the event to the results of the evaluation with the given evaluation.triggered event ID. The results
+// received so far are retrieved from the event store, so that the collection continues after a restart of the service. Once the results of all
+// get-sli.triggered events of the evaluation have been received, they are returned. Otherwise, nil is returned, and onTimeout is called with the
+// IDs of the get-sli.triggered events whose results have been received if the remaining results are not received within the timeout
+func (eh *EvaluateSLIHandler) collectSLIResults(shkeptncontext string, evaluationID string, e *keptnv2.GetSLIFinishedEventData, numberOfSLIRequests int, onTimeout func(receivedIDs map[string]bool)) []*keptnv2.GetSLIFinishedEventData {
+	storedEvents, errObj := eh.EventStore.GetEvents(&keptnapi.EventFilter{
+		Project:      e.Project,
+		Stage:        e.Stage,
+		Service:      e.Service,
+		EventType:    keptnv2.GetFinishedEventType(keptnv2.GetSLITaskName),
+		KeptnContext: shkeptncontext,
+	})
+	if errObj != nil && errObj.Message != nil {
+		// the results received by this instance may still be complete
+		logger.Errorf("Could not retrieve get-sli.finished events for context %s: %s", shkeptncontext, *errObj.Message)
+	}
+
+	var triggeredID string
+	_ = eh.Event.ExtensionAs("triggeredid", &triggeredID)
+	sliRequestIDs := getSLIRequestIDs(evaluationID, numberOfSLIRequests)
+	results := map[string]*keptnv2.GetSLIFinishedEventData{triggeredID: e}
+	for _, storedEvent := range storedEvents {
+		// the context may contain the results of other evaluations of the service
+		if !sliRequestIDs[storedEvent.Triggeredid] {
+			continue
+		}
+		storedResult := &keptnv2.GetSLIFinishedEventData{}
+		if err := keptnv2.Decode(storedEvent.Data, storedResult); err != nil {
+			logger.Errorf("Could not decode get-sli.finished event %s: %s", storedEvent.ID, err.Error())
+			continue
+		}
+		if storedResult.Result == keptnv2.ResultFailed {
+			continue
+		}
+		results[storedEvent.Triggeredid] = storedResult
+	}
+	return sliResults.add(evaluationID, numberOfSLIRequests, results, getSLIRetrievalTimeout(), onTimeout)
+}
+
+func evaluateObjectives(e *keptnv2.GetSLIFinishedEventData, sloConfig *ServiceLevelObjectives, previousEvaluationEvents []*EvaluationFinishedEventData) (*EvaluationFinishedEventData, float64, bool) {
+	evaluationResult := &EvaluationFinishedEventData{
 		EventData: keptnv2.EventData{
 			Status:  "",
 			Project: e.Project,
 			Service: e.Service,
 			Stage:   e.Stage,
 		},
-		Evaluation: keptnv2.EvaluationDetails{
-			TimeStart: e.GetSLI.Start,
-			TimeEnd:   e.GetSLI.End,
+		Evaluation: EvaluationDetails{
+			EvaluationDetails: keptnv2.EvaluationDetails{
+				TimeStart: e.GetSLI.Start,
+				TimeEnd:   e.GetSLI.End,
+			},
 		},
 	}
-	var sliEvaluationResults []*keptnv2.SLIEvaluationResult
+	var sliEvaluationResults []*SLIEvaluationResult
 	maximumAchievableScore := 0.0
 	keySLIFailed := false
+	computedIndicatorInputs := computeIndicators(&e.GetSLI.IndicatorValues, sloConfig.ComputedIndicators)
 	for _, objective := range sloConfig.Objectives {
 		// only consider the SLI for the total score if pass criteria have been included
 		if len(objective.Pass) > 0 {
 			maximumAchievableScore += float64(objective.Weight)
 		}
-		sliEvaluationResult := &keptnv2.SLIEvaluationResult{}
+		sliEvaluationResult := &SLIEvaluationResult{}
 		result := getSLIResult(&e.GetSLI.IndicatorValues, objective.SLI)
 
 		if result == nil {
@@ -222,7 +366,7 @@ func evaluateObjectives(e *keptnv2.GetSLIFinishedEventData, sloConfig *keptn.Ser
 		sliEvaluationResult.Value = (*keptnv2.SLIResult)(result)
 
 		// gather the previous results for the current SLI
-		var previousSLIResults []*keptnv2.SLIEvaluationResult
+		var previousSLIResults []*SLIEvaluationResult
 
 		if previousEvaluationEvents != nil && len(previousEvaluationEvents) > 0 {
 			for _, event := range previousEvaluationEvents {
@@ -234,8 +378,8 @@ func evaluateObjectives(e *keptnv2.GetSLIFinishedEventData, sloConfig *keptn.Ser
 			}
 		}
 
-		var passTargets []*keptnv2.SLITarget
-		var warningTargets []*keptnv2.SLITarget
+		var passTargets []*SLITarget
+		var warningTargets []*SLITarget
 		isPassed := true
 		isWarning := true
 		if objective.Pass != nil && len(objective.Pass) > 0 {
@@ -274,14 +418,20 @@ func evaluateObjectives(e *keptnv2.GetSLIFinishedEventData, sloConfig *keptn.Ser
 		sliEvaluationResults = append(sliEvaluationResults, sliEvaluationResult)
 	}
 
-	// now we check if any metric from the SLI has not been handled
-	checkLeftoverSLI(e.GetSLI.IndicatorValues, evaluationResult)
+	// now we check if any metric from the SLI has not been handled, ignoring the ones used by computed indicators
+	var leftoverSLIs []*keptnv2.SLIResult
+	for _, result := range e.GetSLI.IndicatorValues {
+		if !computedIndicatorInputs[result.Metric] {
+			leftoverSLIs = append(leftoverSLIs, result)
+		}
+	}
+	checkLeftoverSLI(leftoverSLIs, evaluationResult)
 	evaluationResult.Evaluation.IndicatorResults = sliEvaluationResults
 
 	return evaluationResult, maximumAchievableScore, keySLIFailed
 }
 
-func checkLeftoverSLI(results []*keptnv2.SLIResult, evaluationResult *keptnv2.EvaluationFinishedEventData) {
+func checkLeftoverSLI(results []*keptnv2.SLIResult, evaluationResult *EvaluationFinishedEventData) {
 	if len(results) > 0 {
 		//collect SLIs that did not have objectives defined
 		sliEvaluations := ""
@@ -300,7 +450,7 @@ func checkLeftoverSLI(results []*keptnv2.SLIResult, evaluationResult *keptnv2.Ev
 	}
 }
 
-func calculateScore(maximumAchievableScore float64, evaluationResult *keptnv2.EvaluationFinishedEventData, sloConfig *keptn.ServiceLevelObjectives, keySLIFailed bool) error {
+func calculateScore(maximumAchievableScore float64, evaluationResult *EvaluationFinishedEventData, sloConfig *ServiceLevelObjectives, keySLIFailed bool) error {
 	if maximumAchievableScore == 0 {
 		evaluationResult.Evaluation.Result = "pass"
 		evaluationResult.Result = keptnv2.ResultPass
@@ -367,10 +517,10 @@ func getSLIResult(results *[]*keptnv2.SLIResult, sli string) *keptnv2.SLIResult
 	return nil
 }
 
-func evaluateOrCombinedCriteria(result *keptnv2.SLIResult, sloCriteria []*keptn.SLOCriteria, previousResults []*keptnv2.SLIEvaluationResult, comparison *keptn.SLOComparison) (bool, []*keptnv2.SLITarget, error) {
+func evaluateOrCombinedCriteria(result *keptnv2.SLIResult, sloCriteria []*keptn.SLOCriteria, previousResults []*SLIEvaluationResult, comparison *SLOComparison) (bool, []*SLITarget, error) {
 	var satisfied bool
 	satisfied = false
-	var sliTargets []*keptnv2.SLITarget
+	var sliTargets []*SLITarget
 	for _, crit := range sloCriteria {
 		criteriaSatisfied, evaluatedTargets, _ := evaluateCriteriaSet(result, crit, previousResults, comparison)
 		if criteriaSatisfied {
@@ -386,12 +536,14 @@ func evaluateOrCombinedCriteria(result *keptnv2.SLIResult, sloCriteria []*keptn.
 }
 
 // evaluateCriteria evaluates a set of criteria strings. Per definition, all criteria clauses within a SLOCriteria object have to be fulfilled to satisfy the SLOCriteria
-func evaluateCriteriaSet(result *keptnv2.SLIResult, sloCriteria *keptn.SLOCriteria, previousResults []*keptnv2.SLIEvaluationResult, comparison *keptn.SLOComparison) (bool, []*keptnv2.SLITarget, error) {
+func evaluateCriteriaSet(result *keptnv2.SLIResult, sloCriteria *keptn.SLOCriteria, previousResults []*SLIEvaluationResult, comparison *SLOComparison) (bool, []*SLITarget, error) {
 	satisfied := true
-	var sliTargets []*keptnv2.SLITarget
+	var sliTargets []*SLITarget
 	for _, criteria := range sloCriteria.Criteria {
-		target := &keptnv2.SLITarget{
-			Criteria: criteria,
+		target := &SLITarget{
+			SLITarget: keptnv2.SLITarget{
+				Criteria: criteria,
+			},
 		}
 		criteriaSatisfied, _ := evaluateSingleCriteria(result, criteria, previousResults, comparison, target)
 		if !criteriaSatisfied {
@@ -406,7 +558,7 @@ func evaluateCriteriaSet(result *keptnv2.SLIResult, sloCriteria *keptn.SLOCriter
 	return satisfied, sliTargets, nil
 }
 
-func evaluateSingleCriteria(sliResult *keptnv2.SLIResult, criteria string, previousResults []*keptnv2.SLIEvaluationResult, comparison *keptn.SLOComparison, violation *keptnv2.SLITarget) (bool, error) {
+func evaluateSingleCriteria(sliResult *keptnv2.SLIResult, criteria string, previousResults []*SLIEvaluationResult, comparison *SLOComparison, violation *SLITarget) (bool, error) {
 	if !sliResult.Success {
 		return false, errors.New("cannot evaluate invalid SLI result")
 	}
@@ -417,6 +569,10 @@ func evaluateSingleCriteria(sliResult *keptnv2.SLIResult, criteria string, previ
 		return false, err
 	}
 
+	if co.Statistic != "" {
+		return evaluateStatisticalCriteria(sliResult, co, previousResults, violation)
+	}
+
 	if !co.IsComparison {
 		//compared value is used only if the criteria is a comparison without fixed threshold,
 		//anyway we calculate it here to allow Bridge to display it
@@ -429,7 +585,7 @@ func evaluateSingleCriteria(sliResult *keptnv2.SLIResult, criteria string, previ
 	return evaluateComparison(sliResult, co, previousResults, comparison, violation)
 }
 
-func evaluateComparison(sliResult *keptnv2.SLIResult, co *criteriaObject, previousResults []*keptnv2.SLIEvaluationResult, comparison *keptn.SLOComparison, violation *keptnv2.SLITarget) (bool, error) {
+func evaluateComparison(sliResult *keptnv2.SLIResult, co *criteriaObject, previousResults []*SLIEvaluationResult, comparison *SLOComparison, violation *SLITarget) (bool, error) {
 	// aggregate previous results
 	var aggregatedValue float64
 	var targetValue float64
@@ -457,19 +613,13 @@ func evaluateComparison(sliResult *keptnv2.SLIResult, co *criteriaObject, previo
 //aggregateValues combines the previous values into a single one, based on the aggregation function
 //it returns the aggregated value and a boolean telling if the rest of the evaluation should be skipped
 //(no previous results or no successful previous results)
-func aggregateValues(previousResults []*keptnv2.SLIEvaluationResult, comparison *keptn.SLOComparison) (float64, bool) {
+func aggregateValues(previousResults []*SLIEvaluationResult, comparison *SLOComparison) (float64, bool) {
 
 	if len(previousResults) == 0 {
 		// if no comparison values are available, the evaluation passes
 		return 0, true
 	}
-	var previousValues []float64
-	for _, val := range previousResults {
-		if val.Value.Success == true {
-			// always include
-			previousValues = append(previousValues, val.Value.Value)
-		}
-	}
+	previousValues := getSuccessfulValues(previousResults)
 
 	if len(previousValues) == 0 {
 		// if no comparison values are available, the evaluation passes
@@ -492,6 +642,120 @@ func aggregateValues(previousResults []*keptnv2.SLIEvaluationResult, comparison
 	return aggregatedValue, false
 }
 
+// getSuccessfulValues returns the values of the previous results that have been retrieved successfully, in the order of the results
+func getSuccessfulValues(previousResults []*SLIEvaluationResult) []float64 {
+	var values []float64
+	for _, val := range previousResults {
+		if val.Value.Success == true {
+			values = append(values, val.Value.Value)
+		}
+	}
+	return values
+}
+
+// evaluateStatisticalCriteria checks if the value fits into the distribution of the previous results. The range the value has to be in
+// is reported as the lower and upper bound of the target. If there are not enough previous results, the criteria is satisfied
+func evaluateStatisticalCriteria(sliResult *keptnv2.SLIResult, co *criteriaObject, previousResults []*SLIEvaluationResult, violation *SLITarget) (bool, error) {
+	previousValues := getSuccessfulValues(previousResults)
+
+	switch co.Statistic {
+	case zScoreStatistic, madStatistic:
+		// a distribution can not be derived from a single result
+		if len(previousValues) < 2 {
+			return true, nil
+		}
+		var center, deviation float64
+		if co.Statistic == zScoreStatistic {
+			center = calculateAverage(previousValues)
+			deviation = calculateStandardDeviation(previousValues, center)
+		} else {
+			center = calculateMedian(previousValues)
+			deviation = madScaleFactor * calculateMedianAbsoluteDeviation(previousValues, center)
+		}
+		sliResult.ComparedValue = center
+		lowerBound := center - co.Value*deviation
+		upperBound := center + co.Value*deviation
+		violation.LowerBound = &lowerBound
+		violation.UpperBound = &upperBound
+		violation.TargetValue = upperBound
+		if sliResult.Value < center {
+			violation.TargetValue = lowerBound
+		}
+		return evaluateValue(math.Abs(sliResult.Value-center), co.Value*deviation, co.Operator)
+	case increasingStatistic, decreasingStatistic:
+		if len(previousValues) == 0 {
+			return true, nil
+		}
+		// the previous results are ordered from the newest to the oldest one
+		lastValue := previousValues[0]
+		sliResult.ComparedValue = lastValue
+		trendLength := getTrendLength(previousValues, co.Statistic == increasingStatistic)
+		if ok, err := evaluateValue(float64(trendLength+1), co.Value, co.Operator); err != nil || ok {
+			// continuing the trend does not violate the criteria
+			return ok, err
+		}
+		violation.TargetValue = lastValue
+		if co.Statistic == increasingStatistic {
+			violation.UpperBound = &lastValue
+			return sliResult.Value <= lastValue, nil
+		}
+		violation.LowerBound = &lastValue
+		return sliResult.Value >= lastValue, nil
+	default:
+		return false, fmt.Errorf("unknown statistic %s", co.Statistic)
+	}
+}
+
+// getTrendLength returns the number of consecutive values, starting with the newest one, that have been strictly increasing (or decreasing)
+func getTrendLength(newestFirstValues []float64, increasing bool) int {
+	if len(newestFirstValues) == 0 {
+		return 0
+	}
+	length := 1
+	for i := 1; i < len(newestFirstValues); i++ {
+		newer, older := newestFirstValues[i-1], newestFirstValues[i]
+		if (increasing && newer <= older) || (!increasing && newer >= older) {
+			break
+		}
+		length++
+	}
+	return length
+}
+
+// calculateStandardDeviation returns the sample standard deviation of the values
+func calculateStandardDeviation(values []float64, mean float64) float64 {
+	if len(values) < 2 {
+		return 0.0
+	}
+	sum := 0.0
+	for _, value := range values {
+		sum += (value - mean) * (value - mean)
+	}
+	return math.Sqrt(sum / float64(len(values)-1))
+}
+
+func calculateMedian(values []float64) float64 {
+	if len(values) == 0 {
+		return 0.0
+	}
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+func calculateMedianAbsoluteDeviation(values []float64, median float64) float64 {
+	deviations := make([]float64, len(values))
+	for i, value := range values {
+		deviations[i] = math.Abs(value - median)
+	}
+	return calculateMedian(deviations)
+}
+
 func calculateAverage(values []float64) float64 {
 	sum := 0.0
 
@@ -532,7 +796,7 @@ func calculatePercentile(values sort.Float64Slice, perc float64) float64 {
 	return scores[0]
 }
 
-func evaluateFixedThreshold(sliResult *keptnv2.SLIResult, co *criteriaObject, violation *keptnv2.SLITarget) (bool, error) {
+func evaluateFixedThreshold(sliResult *keptnv2.SLIResult, co *criteriaObject, violation *SLITarget) (bool, error) {
 	violation.TargetValue = co.Value
 	return evaluateValue(sliResult.Value, co.Value, co.Operator)
 }
@@ -565,6 +829,20 @@ func parseCriteriaString(criteria string) (*criteriaObject, error) {
 	// remove whitespaces
 	criteria = strings.Replace(criteria, " ", "", -1)
 
+	// example values: zscore<=3, mad<3.5, increasing<5
+	if matches := statisticalCriteriaRegex.FindStringSubmatch(criteria); matches != nil {
+		floatValue, err := strconv.ParseFloat(matches[3], 64)
+		if err != nil {
+			return nil, errors.New("could not parse criteria target value")
+		}
+		return &criteriaObject{
+			Operator:     matches[2],
+			Value:        floatValue,
+			IsComparison: true,
+			Statistic:    matches[1],
+		}, nil
+	}
+
 	if !re.MatchString(criteria) {
 		return nil, errors.New("invalid criteria string")
 	}
@@ -607,18 +885,68 @@ func parseCriteriaString(criteria string) (*criteriaObject, error) {
 	return c, nil
 }
 
+// evaluationQuery selects the previous evaluation.finished events of a service
+type evaluationQuery struct {
+	Project string
+	Stage   string
+	Service string
+	// KeptnContext restricts the query to the evaluation of a single sequence, e.g. a baseline
+	KeptnContext            string
+	NumberOfPreviousResults int
+	IncludeResult           string
+}
+
+// getComparisonEvaluations returns the evaluations the new evaluation is compared with, based on the comparison settings of the SLO file
+func (eh *EvaluateSLIHandler) getComparisonEvaluations(e *keptnv2.GetSLIFinishedEventData, comparison *SLOComparison) ([]*EvaluationFinishedEventData, []string, error) {
+	query := evaluationQuery{
+		Project:                 e.Project,
+		Stage:                   e.Stage,
+		Service:                 e.Service,
+		NumberOfPreviousResults: 3,
+		IncludeResult:           comparison.IncludeResultWithScore,
+	}
+	if comparison.Stage != "" {
+		query.Stage = comparison.Stage
+	}
+
+	switch comparison.CompareWith {
+	case compareWithSingleResult:
+		query.NumberOfPreviousResults = 1
+	case compareWithSeveralResults:
+		query.NumberOfPreviousResults = comparison.NumberOfComparisonResults
+	case compareWithBaseline:
+		baseline, err := eh.SLOFileRetriever.GetBaseline(query.Project, query.Stage, query.Service)
+		if err != nil {
+			return nil, nil, err
+		}
+		if baseline == nil {
+			logger.Infof("No evaluation baseline set for service %s in stage %s of project %s", query.Service, query.Stage, query.Project)
+			return nil, nil, nil
+		}
+		// the baseline has been pinned explicitly, therefore its result is not taken into account
+		query.KeptnContext = baseline.KeptnContext
+		query.NumberOfPreviousResults = 1
+		query.IncludeResult = ""
+	}
+	return eh.getPreviousEvaluations(query)
+}
+
 // gets previous evaluation.finished events from mongodb-datastore
-func (eh *EvaluateSLIHandler) getPreviousEvaluations(e *keptnv2.GetSLIFinishedEventData, numberOfPreviousResults int, includeResult string) ([]*keptnv2.EvaluationFinishedEventData, []string, error) {
-	var evaluationDoneEvents []*keptnv2.EvaluationFinishedEventData
+func (eh *EvaluateSLIHandler) getPreviousEvaluations(query evaluationQuery) ([]*EvaluationFinishedEventData, []string, error) {
+	var evaluationDoneEvents []*EvaluationFinishedEventData
 	var eventIDs []string
+	numberOfPreviousResults := query.NumberOfPreviousResults
 
 	// previous results are fetched from mongodb datastore with source=lighthouse-service
 	queryString := fmt.Sprintf("source=%s&limit=%d&excludeInvalidated=true&",
 		"lighthouse-service", numberOfPreviousResults)
 
-	includeResult = strings.ToLower(includeResult)
+	includeResult := strings.ToLower(query.IncludeResult)
 
-	filter := "filter=data.project:" + e.Project + "%20AND%20data.stage:" + e.Stage + "%20AND%20data.service:" + e.Service
+	filter := "filter=data.project:" + query.Project + "%20AND%20data.stage:" + query.Stage + "%20AND%20data.service:" + query.Service
+	if query.KeptnContext != "" {
+		filter = filter + "%20AND%20shkeptncontext:" + query.KeptnContext
+	}
 	switch includeResult {
 	case "pass":
 		filter = filter + "%20AND%20data.result:pass"
@@ -656,7 +984,7 @@ func (eh *EvaluateSLIHandler) getPreviousEvaluations(e *keptnv2.GetSLIFinishedEv
 		if err != nil {
 			continue
 		}
-		var evaluationDoneEvent keptnv2.EvaluationFinishedEventData
+		var evaluationDoneEvent EvaluationFinishedEventData
 		err = json.Unmarshal(bytes, &evaluationDoneEvent)
 
 		if err != nil {
@@ -126,6 +126,33 @@ func TestParseCriteriaString(t *testing.T) {
 				CheckIncrease:   true,
 			},
 		},
+		{
+			Criteria: "zscore <= 3",
+			ExpectedCriteriaObject: &criteriaObject{
+				Operator:     "<=",
+				Value:        3,
+				IsComparison: true,
+				Statistic:    "zscore",
+			},
+		},
+		{
+			Criteria: "mad<3.5",
+			ExpectedCriteriaObject: &criteriaObject{
+				Operator:     "<",
+				Value:        3.5,
+				IsComparison: true,
+				Statistic:    "mad",
+			},
+		},
+		{
+			Criteria: "increasing<5",
+			ExpectedCriteriaObject: &criteriaObject{
+				Operator:     "<",
+				Value:        5,
+				IsComparison: true,
+				Statistic:    "increasing",
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -136,6 +163,16 @@ func TestParseCriteriaString(t *testing.T) {
 			assert.EqualValues(t, test.ExpectedCriteriaObject.CheckPercentage, co.CheckPercentage)
 			assert.EqualValues(t, test.ExpectedCriteriaObject.IsComparison, co.IsComparison)
 			assert.EqualValues(t, test.ExpectedCriteriaObject.CheckIncrease, co.CheckIncrease)
+			assert.EqualValues(t, test.ExpectedCriteriaObject.Statistic, co.Statistic)
+		})
+	}
+}
+
+func TestParseCriteriaString_InvalidStatisticalCriteria(t *testing.T) {
+	for _, criteria := range []string{"zscore>3", "median<=3", "zscore<=", "increasing=5"} {
+		t.Run(criteria, func(t *testing.T) {
+			_, err := parseCriteriaString(criteria)
+			assert.NotNil(t, err)
 		})
 	}
 }
@@ -214,7 +251,7 @@ type evaluateFixedThresholdTestObject struct {
 	Name             string
 	InSLIResult      *keptnv2.SLIResult
 	InCriteriaObject *criteriaObject
-	InTarget         *keptnv2.SLITarget
+	InTarget         *SLITarget
 	ExpectedResult   bool
 	ExpectedError    error
 }
@@ -236,8 +273,10 @@ func TestEvaluateFixedThreshold(t *testing.T) {
 				IsComparison:    false,
 				CheckIncrease:   false,
 			},
-			InTarget: &keptnv2.SLITarget{
-				Criteria: ">9.0",
+			InTarget: &SLITarget{
+				SLITarget: keptnv2.SLITarget{
+					Criteria: ">9.0",
+				},
 			},
 			ExpectedResult: true,
 			ExpectedError:  nil,
@@ -257,8 +296,10 @@ func TestEvaluateFixedThreshold(t *testing.T) {
 				IsComparison:    false,
 				CheckIncrease:   false,
 			},
-			InTarget: &keptnv2.SLITarget{
-				Criteria: "=9.0",
+			InTarget: &SLITarget{
+				SLITarget: keptnv2.SLITarget{
+					Criteria: "=9.0",
+				},
 			},
 			ExpectedResult: false,
 			ExpectedError:  nil,
@@ -278,8 +319,10 @@ func TestEvaluateFixedThreshold(t *testing.T) {
 				IsComparison:    false,
 				CheckIncrease:   false,
 			},
-			InTarget: &keptnv2.SLITarget{
-				Criteria: "?9.0",
+			InTarget: &SLITarget{
+				SLITarget: keptnv2.SLITarget{
+					Criteria: "?9.0",
+				},
 			},
 			ExpectedResult: false,
 			ExpectedError:  errors.New("no operator set"),
@@ -338,9 +381,9 @@ type evaluateComparisonTestObject struct {
 	Name              string
 	InSLIResult       *keptnv2.SLIResult
 	InCriteriaObject  *criteriaObject
-	InPreviousResults []*keptnv2.SLIEvaluationResult
-	InComparison      *keptnmodelsv2.SLOComparison
-	InTarget          *keptnv2.SLITarget
+	InPreviousResults []*SLIEvaluationResult
+	InComparison      *SLOComparison
+	InTarget          *SLITarget
 	ExpectedResult    bool
 	ExpectedError     error
 }
@@ -362,42 +405,48 @@ func TestEvaluateComparison(t *testing.T) {
 				IsComparison:    true,
 				CheckIncrease:   true,
 			},
-			InPreviousResults: []*keptnv2.SLIEvaluationResult{
+			InPreviousResults: []*SLIEvaluationResult{
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
 				AggregateFunction:         "avg",
 			},
-			InTarget: &keptnv2.SLITarget{
-				Criteria: "<=+10%",
+			InTarget: &SLITarget{
+				SLITarget: keptnv2.SLITarget{
+					Criteria: "<=+10%",
+				},
 			},
 			ExpectedResult: true,
 			ExpectedError:  nil,
@@ -417,42 +466,48 @@ func TestEvaluateComparison(t *testing.T) {
 				IsComparison:    true,
 				CheckIncrease:   true,
 			},
-			InPreviousResults: []*keptnv2.SLIEvaluationResult{
+			InPreviousResults: []*SLIEvaluationResult{
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
 				AggregateFunction:         "avg",
 			},
-			InTarget: &keptnv2.SLITarget{
-				Criteria: "<=+10%",
+			InTarget: &SLITarget{
+				SLITarget: keptnv2.SLITarget{
+					Criteria: "<=+10%",
+				},
 			},
 			ExpectedResult: false,
 			ExpectedError:  nil,
@@ -472,42 +527,48 @@ func TestEvaluateComparison(t *testing.T) {
 				IsComparison:    true,
 				CheckIncrease:   true,
 			},
-			InPreviousResults: []*keptnv2.SLIEvaluationResult{
+			InPreviousResults: []*SLIEvaluationResult{
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
 				AggregateFunction:         "avg",
 			},
-			InTarget: &keptnv2.SLITarget{
-				Criteria: "<=+10%",
+			InTarget: &SLITarget{
+				SLITarget: keptnv2.SLITarget{
+					Criteria: "<=+10%",
+				},
 			},
 			ExpectedResult: false,
 			ExpectedError:  nil,
@@ -527,42 +588,48 @@ func TestEvaluateComparison(t *testing.T) {
 				IsComparison:    true,
 				CheckIncrease:   true,
 			},
-			InPreviousResults: []*keptnv2.SLIEvaluationResult{
+			InPreviousResults: []*SLIEvaluationResult{
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
 				AggregateFunction:         "avg",
 			},
-			InTarget: &keptnv2.SLITarget{
-				Criteria: "<=+10%",
+			InTarget: &SLITarget{
+				SLITarget: keptnv2.SLITarget{
+					Criteria: "<=+10%",
+				},
 			},
 			ExpectedResult: false,
 			ExpectedError:  nil,
@@ -582,42 +649,48 @@ func TestEvaluateComparison(t *testing.T) {
 				IsComparison:    true,
 				CheckIncrease:   true,
 			},
-			InPreviousResults: []*keptnv2.SLIEvaluationResult{
+			InPreviousResults: []*SLIEvaluationResult{
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
 				AggregateFunction:         "avg",
 			},
-			InTarget: &keptnv2.SLITarget{
-				Criteria: "<=+10%",
+			InTarget: &SLITarget{
+				SLITarget: keptnv2.SLITarget{
+					Criteria: "<=+10%",
+				},
 			},
 			ExpectedResult: true,
 			ExpectedError:  nil,
@@ -637,42 +710,48 @@ func TestEvaluateComparison(t *testing.T) {
 				IsComparison:    true,
 				CheckIncrease:   false,
 			},
-			InPreviousResults: []*keptnv2.SLIEvaluationResult{
+			InPreviousResults: []*SLIEvaluationResult{
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
 				AggregateFunction:         "avg",
 			},
-			InTarget: &keptnv2.SLITarget{
-				Criteria: "<=+10%",
+			InTarget: &SLITarget{
+				SLITarget: keptnv2.SLITarget{
+					Criteria: "<=+10%",
+				},
 			},
 			ExpectedResult: true,
 			ExpectedError:  nil,
@@ -692,42 +771,48 @@ func TestEvaluateComparison(t *testing.T) {
 				IsComparison:    true,
 				CheckIncrease:   false,
 			},
-			InPreviousResults: []*keptnv2.SLIEvaluationResult{
+			InPreviousResults: []*SLIEvaluationResult{
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "fail",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "fail",
 				},
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
 				AggregateFunction:         "p50",
 			},
-			InTarget: &keptnv2.SLITarget{
-				Criteria: "<=+10%",
+			InTarget: &SLITarget{
+				SLITarget: keptnv2.SLITarget{
+					Criteria: "<=+10%",
+				},
 			},
 			ExpectedResult: true,
 			ExpectedError:  nil,
@@ -747,9 +832,9 @@ type evaluateSingleCriteriaTestObject struct {
 	Name              string
 	InSLIResult       *keptnv2.SLIResult
 	InCriteria        string
-	InPreviousResults []*keptnv2.SLIEvaluationResult
-	InComparison      *keptnmodelsv2.SLOComparison
-	InTarget          *keptnv2.SLITarget
+	InPreviousResults []*SLIEvaluationResult
+	InComparison      *SLOComparison
+	InTarget          *SLITarget
 	ExpectedResult    bool
 	ExpectedError     error
 }
@@ -765,42 +850,48 @@ func TestEvaluateSingleCriteria(t *testing.T) {
 				Message: "",
 			},
 			InCriteria: "<=+10%",
-			InPreviousResults: []*keptnv2.SLIEvaluationResult{
+			InPreviousResults: []*SLIEvaluationResult{
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
 				AggregateFunction:         "avg",
 			},
-			InTarget: &keptnv2.SLITarget{
-				Criteria: "<=+10%",
+			InTarget: &SLITarget{
+				SLITarget: keptnv2.SLITarget{
+					Criteria: "<=+10%",
+				},
 			},
 			ExpectedResult: true,
 			ExpectedError:  nil,
@@ -814,42 +905,48 @@ func TestEvaluateSingleCriteria(t *testing.T) {
 				Message: "",
 			},
 			InCriteria: "<=+10%",
-			InPreviousResults: []*keptnv2.SLIEvaluationResult{
+			InPreviousResults: []*SLIEvaluationResult{
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
 				AggregateFunction:         "avg",
 			},
-			InTarget: &keptnv2.SLITarget{
-				Criteria: "<=+10%",
+			InTarget: &SLITarget{
+				SLITarget: keptnv2.SLITarget{
+					Criteria: "<=+10%",
+				},
 			},
 			ExpectedResult: false,
 			ExpectedError:  nil,
@@ -863,42 +960,48 @@ func TestEvaluateSingleCriteria(t *testing.T) {
 				Message: "",
 			},
 			InCriteria: "<+0%",
-			InPreviousResults: []*keptnv2.SLIEvaluationResult{
+			InPreviousResults: []*SLIEvaluationResult{
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
 				AggregateFunction:         "avg",
 			},
-			InTarget: &keptnv2.SLITarget{
-				Criteria: "<=+10%",
+			InTarget: &SLITarget{
+				SLITarget: keptnv2.SLITarget{
+					Criteria: "<=+10%",
+				},
 			},
 			ExpectedResult: false,
 			ExpectedError:  nil,
@@ -912,42 +1015,48 @@ func TestEvaluateSingleCriteria(t *testing.T) {
 				Message: "",
 			},
 			InCriteria: ">+0%",
-			InPreviousResults: []*keptnv2.SLIEvaluationResult{
+			InPreviousResults: []*SLIEvaluationResult{
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
 				AggregateFunction:         "avg",
 			},
-			InTarget: &keptnv2.SLITarget{
-				Criteria: "<=+10%",
+			InTarget: &SLITarget{
+				SLITarget: keptnv2.SLITarget{
+					Criteria: "<=+10%",
+				},
 			},
 			ExpectedResult: false,
 			ExpectedError:  nil,
@@ -961,42 +1070,48 @@ func TestEvaluateSingleCriteria(t *testing.T) {
 				Message: "",
 			},
 			InCriteria: "=+1",
-			InPreviousResults: []*keptnv2.SLIEvaluationResult{
+			InPreviousResults: []*SLIEvaluationResult{
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
 				AggregateFunction:         "avg",
 			},
-			InTarget: &keptnv2.SLITarget{
-				Criteria: "<=+10%",
+			InTarget: &SLITarget{
+				SLITarget: keptnv2.SLITarget{
+					Criteria: "<=+10%",
+				},
 			},
 			ExpectedResult: true,
 			ExpectedError:  nil,
@@ -1010,42 +1125,48 @@ func TestEvaluateSingleCriteria(t *testing.T) {
 				Message: "",
 			},
 			InCriteria: "=-1",
-			InPreviousResults: []*keptnv2.SLIEvaluationResult{
+			InPreviousResults: []*SLIEvaluationResult{
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
 				AggregateFunction:         "avg",
 			},
-			InTarget: &keptnv2.SLITarget{
-				Criteria: "<=+10%",
+			InTarget: &SLITarget{
+				SLITarget: keptnv2.SLITarget{
+					Criteria: "<=+10%",
+				},
 			},
 			ExpectedResult: true,
 			ExpectedError:  nil,
@@ -1059,42 +1180,48 @@ func TestEvaluateSingleCriteria(t *testing.T) {
 				Message: "",
 			},
 			InCriteria: "<=+10%",
-			InPreviousResults: []*keptnv2.SLIEvaluationResult{
+			InPreviousResults: []*SLIEvaluationResult{
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
 				AggregateFunction:         "p50",
 			},
-			InTarget: &keptnv2.SLITarget{
-				Criteria: "<=+10%",
+			InTarget: &SLITarget{
+				SLITarget: keptnv2.SLITarget{
+					Criteria: "<=+10%",
+				},
 			},
 			ExpectedResult: true,
 			ExpectedError:  nil,
@@ -1108,8 +1235,10 @@ func TestEvaluateSingleCriteria(t *testing.T) {
 				Message: "",
 			},
 			InCriteria: "<=10",
-			InTarget: &keptnv2.SLITarget{
-				Criteria: "<=+10%",
+			InTarget: &SLITarget{
+				SLITarget: keptnv2.SLITarget{
+					Criteria: "<=+10%",
+				},
 			},
 			ExpectedResult: true,
 			ExpectedError:  nil,
@@ -1129,9 +1258,9 @@ type evaluateCriteriaSetTestObject struct {
 	Name              string
 	InSLIResult       *keptnv2.SLIResult
 	InCriteriaSet     *keptnmodelsv2.SLOCriteria
-	InPreviousResults []*keptnv2.SLIEvaluationResult
-	InComparison      *keptnmodelsv2.SLOComparison
-	ExpectedTargets   []*keptnv2.SLITarget
+	InPreviousResults []*SLIEvaluationResult
+	InComparison      *SLOComparison
+	ExpectedTargets   []*SLITarget
 	ExpectedResult    bool
 	ExpectedError     error
 }
@@ -1149,50 +1278,58 @@ func TestEvaluateCriteriaSet(t *testing.T) {
 			InCriteriaSet: &keptnmodelsv2.SLOCriteria{
 				Criteria: []string{"<=+10%", "<=10.0"},
 			},
-			InPreviousResults: []*keptnv2.SLIEvaluationResult{
+			InPreviousResults: []*SLIEvaluationResult{
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
 				AggregateFunction:         "avg",
 			},
-			ExpectedTargets: []*keptnv2.SLITarget{
+			ExpectedTargets: []*SLITarget{
 				{
-					Criteria:    "<=+10%",
-					TargetValue: 11,
-					Violated:    false,
+					SLITarget: keptnv2.SLITarget{
+						Criteria:    "<=+10%",
+						TargetValue: 11,
+						Violated:    false,
+					},
 				},
 				{
-					Criteria:    "<=10.0",
-					TargetValue: 10,
-					Violated:    false,
+					SLITarget: keptnv2.SLITarget{
+						Criteria:    "<=10.0",
+						TargetValue: 10,
+						Violated:    false,
+					},
 				},
 			},
 			ExpectedResult: true,
@@ -1209,50 +1346,58 @@ func TestEvaluateCriteriaSet(t *testing.T) {
 			InCriteriaSet: &keptnmodelsv2.SLOCriteria{
 				Criteria: []string{"<=+10%", "<=10.0"},
 			},
-			InPreviousResults: []*keptnv2.SLIEvaluationResult{
+			InPreviousResults: []*SLIEvaluationResult{
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
 				AggregateFunction:         "avg",
 			},
-			ExpectedTargets: []*keptnv2.SLITarget{
+			ExpectedTargets: []*SLITarget{
 				{
-					Criteria:    "<=+10%",
-					TargetValue: 11,
-					Violated:    false,
+					SLITarget: keptnv2.SLITarget{
+						Criteria:    "<=+10%",
+						TargetValue: 11,
+						Violated:    false,
+					},
 				},
 				{
-					Criteria:    "<=10.0",
-					TargetValue: 10.0,
-					Violated:    true,
+					SLITarget: keptnv2.SLITarget{
+						Criteria:    "<=10.0",
+						TargetValue: 10.0,
+						Violated:    true,
+					},
 				},
 			},
 			ExpectedResult: false,
@@ -1274,9 +1419,9 @@ type evaluateOrCombinedCriteriaTestObject struct {
 	Name              string
 	InSLIResult       *keptnv2.SLIResult
 	InCriteriaSets    []*keptnmodelsv2.SLOCriteria
-	InPreviousResults []*keptnv2.SLIEvaluationResult
-	InComparison      *keptnmodelsv2.SLOComparison
-	ExpectedTargets   []*keptnv2.SLITarget
+	InPreviousResults []*SLIEvaluationResult
+	InComparison      *SLOComparison
+	ExpectedTargets   []*SLITarget
 	ExpectedResult    bool
 	ExpectedError     error
 }
@@ -1299,50 +1444,58 @@ func TestEvaluateOrCombinedCriteria(t *testing.T) {
 					Criteria: []string{"<=+10%"},
 				},
 			},
-			InPreviousResults: []*keptnv2.SLIEvaluationResult{
+			InPreviousResults: []*SLIEvaluationResult{
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
 				AggregateFunction:         "avg",
 			},
-			ExpectedTargets: []*keptnv2.SLITarget{
+			ExpectedTargets: []*SLITarget{
 				{
-					Criteria:    "<=10.0",
-					TargetValue: 10.0,
-					Violated:    false,
+					SLITarget: keptnv2.SLITarget{
+						Criteria:    "<=10.0",
+						TargetValue: 10.0,
+						Violated:    false,
+					},
 				},
 				{
-					Criteria:    "<=+10%",
-					TargetValue: 11,
-					Violated:    false,
+					SLITarget: keptnv2.SLITarget{
+						Criteria:    "<=+10%",
+						TargetValue: 11,
+						Violated:    false,
+					},
 				},
 			},
 			ExpectedResult: true,
@@ -1364,50 +1517,58 @@ func TestEvaluateOrCombinedCriteria(t *testing.T) {
 					Criteria: []string{"<=+10%"},
 				},
 			},
-			InPreviousResults: []*keptnv2.SLIEvaluationResult{
+			InPreviousResults: []*SLIEvaluationResult{
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
 				AggregateFunction:         "avg",
 			},
-			ExpectedTargets: []*keptnv2.SLITarget{
+			ExpectedTargets: []*SLITarget{
 				{
-					Criteria:    "<=10.0",
-					TargetValue: 10.0,
-					Violated:    true,
+					SLITarget: keptnv2.SLITarget{
+						Criteria:    "<=10.0",
+						TargetValue: 10.0,
+						Violated:    true,
+					},
 				},
 				{
-					Criteria:    "<=+10%",
-					TargetValue: 11,
-					Violated:    false,
+					SLITarget: keptnv2.SLITarget{
+						Criteria:    "<=+10%",
+						TargetValue: 11,
+						Violated:    false,
+					},
 				},
 			},
 			ExpectedResult: true,
@@ -1429,50 +1590,58 @@ func TestEvaluateOrCombinedCriteria(t *testing.T) {
 					Criteria: []string{"<=+10%"},
 				},
 			},
-			InPreviousResults: []*keptnv2.SLIEvaluationResult{
+			InPreviousResults: []*SLIEvaluationResult{
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 				{
-					Score: 2,
-					Value: &keptnv2.SLIResult{
-						Metric:  "my-test-metric",
-						Value:   10.0,
-						Success: true,
-						Message: "",
+					SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+						Score: 2,
+						Value: &keptnv2.SLIResult{
+							Metric:  "my-test-metric",
+							Value:   10.0,
+							Success: true,
+							Message: "",
+						},
+						KeySLI: false,
+						Status: "pass",
 					},
 					PassTargets:    nil,
 					WarningTargets: nil,
-					KeySLI:         false,
-					Status:         "pass",
 				},
 			},
-			InComparison: &keptnmodelsv2.SLOComparison{
+			InComparison: &SLOComparison{
 				CompareWith:               "several_results",
 				IncludeResultWithScore:    "pass",
 				NumberOfComparisonResults: 2,
 				AggregateFunction:         "avg",
 			},
-			ExpectedTargets: []*keptnv2.SLITarget{
+			ExpectedTargets: []*SLITarget{
 				{
-					Criteria:    "<=10.0",
-					TargetValue: 10.0,
-					Violated:    true,
+					SLITarget: keptnv2.SLITarget{
+						Criteria:    "<=10.0",
+						TargetValue: 10.0,
+						Violated:    true,
+					},
 				},
 				{
-					Criteria:    "<=+10%",
-					TargetValue: 11.0,
-					Violated:    true,
+					SLITarget: keptnv2.SLITarget{
+						Criteria:    "<=+10%",
+						TargetValue: 11.0,
+						Violated:    true,
+					},
 				},
 			},
 			ExpectedResult: false,
@@ -1495,9 +1664,9 @@ func TestEvaluateOrCombinedCriteria(t *testing.T) {
 type evaluateObjectivesTestObject struct {
 	Name                       string
 	InGetSLIDoneEvent          *keptnv2.GetSLIFinishedEventData
-	InSLOConfig                *keptnmodelsv2.ServiceLevelObjectives
-	InPreviousEvaluationEvents []*keptnv2.EvaluationFinishedEventData
-	ExpectedEvaluationResult   *keptnv2.EvaluationFinishedEventData
+	InSLOConfig                *ServiceLevelObjectives
+	InPreviousEvaluationEvents []*EvaluationFinishedEventData
+	ExpectedEvaluationResult   *EvaluationFinishedEventData
 	ExpectedMaximumScore       float64
 	ExpectedKeySLIFailed       bool
 }
@@ -1525,10 +1694,10 @@ func TestEvaluateObjectives(t *testing.T) {
 					},
 				},
 			},
-			InSLOConfig: &keptnmodelsv2.ServiceLevelObjectives{
+			InSLOConfig: &ServiceLevelObjectives{
 				SpecVersion: "1.0",
 				Filter:      nil,
-				Comparison: &keptnmodelsv2.SLOComparison{
+				Comparison: &SLOComparison{
 					CompareWith:               "several_results",
 					IncludeResultWithScore:    "pass",
 					NumberOfComparisonResults: 2,
@@ -1562,26 +1731,30 @@ func TestEvaluateObjectives(t *testing.T) {
 					Warning: "75%",
 				},
 			},
-			InPreviousEvaluationEvents: []*keptnv2.EvaluationFinishedEventData{
+			InPreviousEvaluationEvents: []*EvaluationFinishedEventData{
 				{
-					Evaluation: keptnv2.EvaluationDetails{
-						TimeStart: "",
-						TimeEnd:   "",
-						Result:    "pass",
-						Score:     2,
-						IndicatorResults: []*keptnv2.SLIEvaluationResult{
+					Evaluation: EvaluationDetails{
+						EvaluationDetails: keptnv2.EvaluationDetails{
+							TimeStart: "",
+							TimeEnd:   "",
+							Result:    "pass",
+							Score:     2,
+						},
+						IndicatorResults: []*SLIEvaluationResult{
 							{
-								Score: 2,
-								Value: &keptnv2.SLIResult{
-									Metric:  "my-test-metric-1",
-									Value:   10.0,
-									Success: true,
-									Message: "",
+								SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+									Score: 2,
+									Value: &keptnv2.SLIResult{
+										Metric:  "my-test-metric-1",
+										Value:   10.0,
+										Success: true,
+										Message: "",
+									},
+									KeySLI: false,
+									Status: "pass",
 								},
 								PassTargets:    nil,
 								WarningTargets: nil,
-								KeySLI:         false,
-								Status:         "pass",
 							},
 						},
 					},
@@ -1593,48 +1766,60 @@ func TestEvaluateObjectives(t *testing.T) {
 					},
 				},
 			},
-			ExpectedEvaluationResult: &keptnv2.EvaluationFinishedEventData{
-				Evaluation: keptnv2.EvaluationDetails{
-					TimeStart: "2019-10-20T07:57:27.152330783Z",
-					TimeEnd:   "2019-10-22T08:57:27.152330783Z",
-					Result:    "", // not set by the tested function
-					Score:     0,  // not calculated by tested function
-					IndicatorResults: []*keptnv2.SLIEvaluationResult{
+			ExpectedEvaluationResult: &EvaluationFinishedEventData{
+				Evaluation: EvaluationDetails{
+					EvaluationDetails: keptnv2.EvaluationDetails{
+						TimeStart: "2019-10-20T07:57:27.152330783Z",
+						TimeEnd:   "2019-10-22T08:57:27.152330783Z",
+						Result:    "",
+						Score:     0,
+					},
+					IndicatorResults: []*SLIEvaluationResult{
 						{
-							Score: 1,
-							Value: &keptnv2.SLIResult{
-								Metric:        "my-test-metric-1",
-								Value:         10.0,
-								ComparedValue: 10.0,
-								Success:       true,
-								Message:       "",
+							SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+								Score: 1,
+								Value: &keptnv2.SLIResult{
+									Metric:        "my-test-metric-1",
+									Value:         10.0,
+									ComparedValue: 10.0,
+									Success:       true,
+									Message:       "",
+								},
+								KeySLI: false,
+								Status: "pass",
 							},
-							WarningTargets: []*keptnv2.SLITarget{
+							WarningTargets: []*SLITarget{
 								{
-									Criteria:    "<=20.0",
-									TargetValue: 20,
-									Violated:    false,
+									SLITarget: keptnv2.SLITarget{
+										Criteria:    "<=20.0",
+										TargetValue: 20,
+										Violated:    false,
+									},
 								},
 								{
-									Criteria:    "<=+15%",
-									TargetValue: 11.5,
-									Violated:    false,
+									SLITarget: keptnv2.SLITarget{
+										Criteria:    "<=+15%",
+										TargetValue: 11.5,
+										Violated:    false,
+									},
 								},
 							},
-							PassTargets: []*keptnv2.SLITarget{
+							PassTargets: []*SLITarget{
 								{
-									Criteria:    "<=15.0",
-									TargetValue: 15.0,
-									Violated:    false,
+									SLITarget: keptnv2.SLITarget{
+										Criteria:    "<=15.0",
+										TargetValue: 15.0,
+										Violated:    false,
+									},
 								},
 								{
-									Criteria:    "<=+10%",
-									TargetValue: 11,
-									Violated:    false,
+									SLITarget: keptnv2.SLITarget{
+										Criteria:    "<=+10%",
+										TargetValue: 11,
+										Violated:    false,
+									},
 								},
 							},
-							KeySLI: false,
-							Status: "pass",
 						},
 					},
 				},
@@ -1669,10 +1854,10 @@ func TestEvaluateObjectives(t *testing.T) {
 					},
 				},
 			},
-			InSLOConfig: &keptnmodelsv2.ServiceLevelObjectives{
+			InSLOConfig: &ServiceLevelObjectives{
 				SpecVersion: "1.0",
 				Filter:      nil,
-				Comparison: &keptnmodelsv2.SLOComparison{
+				Comparison: &SLOComparison{
 					CompareWith:               "several_results",
 					IncludeResultWithScore:    "pass",
 					NumberOfComparisonResults: 2,
@@ -1706,26 +1891,30 @@ func TestEvaluateObjectives(t *testing.T) {
 					Warning: "75%",
 				},
 			},
-			InPreviousEvaluationEvents: []*keptnv2.EvaluationFinishedEventData{
+			InPreviousEvaluationEvents: []*EvaluationFinishedEventData{
 				{
-					Evaluation: keptnv2.EvaluationDetails{
-						TimeStart: "",
-						TimeEnd:   "",
-						Result:    "pass",
-						Score:     2,
-						IndicatorResults: []*keptnv2.SLIEvaluationResult{
+					Evaluation: EvaluationDetails{
+						EvaluationDetails: keptnv2.EvaluationDetails{
+							TimeStart: "",
+							TimeEnd:   "",
+							Result:    "pass",
+							Score:     2,
+						},
+						IndicatorResults: []*SLIEvaluationResult{
 							{
-								Score: 2,
-								Value: &keptnv2.SLIResult{
-									Metric:  "my-test-metric-1",
-									Value:   10.0,
-									Success: true,
-									Message: "",
+								SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+									Score: 2,
+									Value: &keptnv2.SLIResult{
+										Metric:  "my-test-metric-1",
+										Value:   10.0,
+										Success: true,
+										Message: "",
+									},
+									KeySLI: false,
+									Status: "pass",
 								},
 								PassTargets:    nil,
 								WarningTargets: nil,
-								KeySLI:         false,
-								Status:         "pass",
 							},
 						},
 					},
@@ -1737,48 +1926,60 @@ func TestEvaluateObjectives(t *testing.T) {
 					},
 				},
 			},
-			ExpectedEvaluationResult: &keptnv2.EvaluationFinishedEventData{
-				Evaluation: keptnv2.EvaluationDetails{
-					TimeStart: "2019-10-20T07:57:27.152330783Z",
-					TimeEnd:   "2019-10-22T08:57:27.152330783Z",
-					Result:    "", // not set by the tested function
-					Score:     0,  // not calculated by tested function
-					IndicatorResults: []*keptnv2.SLIEvaluationResult{
+			ExpectedEvaluationResult: &EvaluationFinishedEventData{
+				Evaluation: EvaluationDetails{
+					EvaluationDetails: keptnv2.EvaluationDetails{
+						TimeStart: "2019-10-20T07:57:27.152330783Z",
+						TimeEnd:   "2019-10-22T08:57:27.152330783Z",
+						Result:    "",
+						Score:     0,
+					},
+					IndicatorResults: []*SLIEvaluationResult{
 						{
-							Score: 0.5,
-							Value: &keptnv2.SLIResult{
-								Metric:        "my-test-metric-1",
-								Value:         16.0,
-								ComparedValue: 10.0,
-								Success:       true,
-								Message:       "",
+							SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+								Score: 0.5,
+								Value: &keptnv2.SLIResult{
+									Metric:        "my-test-metric-1",
+									Value:         16.0,
+									ComparedValue: 10.0,
+									Success:       true,
+									Message:       "",
+								},
+								KeySLI: false,
+								Status: "warning",
 							},
-							WarningTargets: []*keptnv2.SLITarget{
+							WarningTargets: []*SLITarget{
 								{
-									Criteria:    "<=20.0",
-									TargetValue: 20,
-									Violated:    false,
+									SLITarget: keptnv2.SLITarget{
+										Criteria:    "<=20.0",
+										TargetValue: 20,
+										Violated:    false,
+									},
 								},
 								{
-									Criteria:    "<=+15%",
-									TargetValue: 11.5,
-									Violated:    true,
+									SLITarget: keptnv2.SLITarget{
+										Criteria:    "<=+15%",
+										TargetValue: 11.5,
+										Violated:    true,
+									},
 								},
 							},
-							PassTargets: []*keptnv2.SLITarget{
+							PassTargets: []*SLITarget{
 								{
-									Criteria:    "<=15.0",
-									TargetValue: 15.0,
-									Violated:    true,
+									SLITarget: keptnv2.SLITarget{
+										Criteria:    "<=15.0",
+										TargetValue: 15.0,
+										Violated:    true,
+									},
 								},
 								{
-									Criteria:    "<=+10%",
-									TargetValue: 11,
-									Violated:    true,
+									SLITarget: keptnv2.SLITarget{
+										Criteria:    "<=+10%",
+										TargetValue: 11,
+										Violated:    true,
+									},
 								},
 							},
-							KeySLI: false,
-							Status: "warning",
 						},
 					},
 				},
@@ -1819,10 +2020,10 @@ func TestEvaluateObjectives(t *testing.T) {
 					},
 				},
 			},
-			InSLOConfig: &keptnmodelsv2.ServiceLevelObjectives{
+			InSLOConfig: &ServiceLevelObjectives{
 				SpecVersion: "1.0",
 				Filter:      nil,
-				Comparison: &keptnmodelsv2.SLOComparison{
+				Comparison: &SLOComparison{
 					CompareWith:               "several_results",
 					IncludeResultWithScore:    "pass",
 					NumberOfComparisonResults: 2,
@@ -1861,26 +2062,30 @@ func TestEvaluateObjectives(t *testing.T) {
 					Warning: "75%",
 				},
 			},
-			InPreviousEvaluationEvents: []*keptnv2.EvaluationFinishedEventData{
+			InPreviousEvaluationEvents: []*EvaluationFinishedEventData{
 				{
-					Evaluation: keptnv2.EvaluationDetails{
-						TimeStart: "",
-						TimeEnd:   "",
-						Result:    "pass",
-						Score:     2,
-						IndicatorResults: []*keptnv2.SLIEvaluationResult{
+					Evaluation: EvaluationDetails{
+						EvaluationDetails: keptnv2.EvaluationDetails{
+							TimeStart: "",
+							TimeEnd:   "",
+							Result:    "pass",
+							Score:     2,
+						},
+						IndicatorResults: []*SLIEvaluationResult{
 							{
-								Score: 2,
-								Value: &keptnv2.SLIResult{
-									Metric:  "my-test-metric-1",
-									Value:   10.0,
-									Success: true,
-									Message: "",
+								SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+									Score: 2,
+									Value: &keptnv2.SLIResult{
+										Metric:  "my-test-metric-1",
+										Value:   10.0,
+										Success: true,
+										Message: "",
+									},
+									KeySLI: false,
+									Status: "pass",
 								},
 								PassTargets:    nil,
 								WarningTargets: nil,
-								KeySLI:         false,
-								Status:         "pass",
 							},
 						},
 					},
@@ -1892,58 +2097,72 @@ func TestEvaluateObjectives(t *testing.T) {
 					},
 				},
 			},
-			ExpectedEvaluationResult: &keptnv2.EvaluationFinishedEventData{
-				Evaluation: keptnv2.EvaluationDetails{
-					TimeStart: "2019-10-20T07:57:27.152330783Z",
-					TimeEnd:   "2019-10-22T08:57:27.152330783Z",
-					Result:    "", // not set by the tested function
-					Score:     0,  // not calculated by tested function
-					IndicatorResults: []*keptnv2.SLIEvaluationResult{
+			ExpectedEvaluationResult: &EvaluationFinishedEventData{
+				Evaluation: EvaluationDetails{
+					EvaluationDetails: keptnv2.EvaluationDetails{
+						TimeStart: "2019-10-20T07:57:27.152330783Z",
+						TimeEnd:   "2019-10-22T08:57:27.152330783Z",
+						Result:    "",
+						Score:     0,
+					},
+					IndicatorResults: []*SLIEvaluationResult{
 						{
-							Score: 1,
-							Value: &keptnv2.SLIResult{
-								Metric:        "my-test-metric-1",
-								Value:         10.0,
-								ComparedValue: 10.0,
-								Success:       true,
-								Message:       "",
+							SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+								Score: 1,
+								Value: &keptnv2.SLIResult{
+									Metric:        "my-test-metric-1",
+									Value:         10.0,
+									ComparedValue: 10.0,
+									Success:       true,
+									Message:       "",
+								},
+								KeySLI: false,
+								Status: "pass",
 							},
-							WarningTargets: []*keptnv2.SLITarget{
+							WarningTargets: []*SLITarget{
 								{
-									Criteria:    "<=20.0",
-									TargetValue: 20,
-									Violated:    false,
+									SLITarget: keptnv2.SLITarget{
+										Criteria:    "<=20.0",
+										TargetValue: 20,
+										Violated:    false,
+									},
 								},
 								{
-									Criteria:    "<=+15%",
-									TargetValue: 11.5,
-									Violated:    false,
+									SLITarget: keptnv2.SLITarget{
+										Criteria:    "<=+15%",
+										TargetValue: 11.5,
+										Violated:    false,
+									},
 								},
 							},
-							PassTargets: []*keptnv2.SLITarget{
+							PassTargets: []*SLITarget{
 								{
-									Criteria:    "<=15.0",
-									TargetValue: 15.0,
-									Violated:    false,
+									SLITarget: keptnv2.SLITarget{
+										Criteria:    "<=15.0",
+										TargetValue: 15.0,
+										Violated:    false,
+									},
 								},
 								{
-									Criteria:    "<=+10%",
-									TargetValue: 11,
-									Violated:    false,
+									SLITarget: keptnv2.SLITarget{
+										Criteria:    "<=+10%",
+										TargetValue: 11,
+										Violated:    false,
+									},
 								},
 							},
-							KeySLI: false,
-							Status: "pass",
 						},
 						{
-							Score: 0,
-							Value: &keptnv2.SLIResult{
-								Metric:  "my-log-metric",
-								Value:   30.0,
-								Success: true,
-								Message: "",
+							SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+								Score: 0,
+								Value: &keptnv2.SLIResult{
+									Metric:  "my-log-metric",
+									Value:   30.0,
+									Success: true,
+									Message: "",
+								},
+								Status: "info",
 							},
-							Status: "info",
 						},
 					},
 				},
@@ -1984,10 +2203,10 @@ func TestEvaluateObjectives(t *testing.T) {
 					},
 				},
 			},
-			InSLOConfig: &keptnmodelsv2.ServiceLevelObjectives{
+			InSLOConfig: &ServiceLevelObjectives{
 				SpecVersion: "1.0",
 				Filter:      nil,
-				Comparison: &keptnmodelsv2.SLOComparison{
+				Comparison: &SLOComparison{
 					CompareWith:               "several_results",
 					IncludeResultWithScore:    "pass",
 					NumberOfComparisonResults: 2,
@@ -2028,26 +2247,30 @@ func TestEvaluateObjectives(t *testing.T) {
 					Warning: "75%",
 				},
 			},
-			InPreviousEvaluationEvents: []*keptnv2.EvaluationFinishedEventData{
+			InPreviousEvaluationEvents: []*EvaluationFinishedEventData{
 				{
-					Evaluation: keptnv2.EvaluationDetails{
-						TimeStart: "",
-						TimeEnd:   "",
-						Result:    "pass",
-						Score:     2,
-						IndicatorResults: []*keptnv2.SLIEvaluationResult{
+					Evaluation: EvaluationDetails{
+						EvaluationDetails: keptnv2.EvaluationDetails{
+							TimeStart: "",
+							TimeEnd:   "",
+							Result:    "pass",
+							Score:     2,
+						},
+						IndicatorResults: []*SLIEvaluationResult{
 							{
-								Score: 2,
-								Value: &keptnv2.SLIResult{
-									Metric:  "my-test-metric-1",
-									Value:   10.0,
-									Success: true,
-									Message: "",
+								SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+									Score: 2,
+									Value: &keptnv2.SLIResult{
+										Metric:  "my-test-metric-1",
+										Value:   10.0,
+										Success: true,
+										Message: "",
+									},
+									KeySLI: false,
+									Status: "pass",
 								},
 								PassTargets:    nil,
 								WarningTargets: nil,
-								KeySLI:         false,
-								Status:         "pass",
 							},
 						},
 					},
@@ -2059,59 +2282,73 @@ func TestEvaluateObjectives(t *testing.T) {
 					},
 				},
 			},
-			ExpectedEvaluationResult: &keptnv2.EvaluationFinishedEventData{
-				Evaluation: keptnv2.EvaluationDetails{
-					TimeStart: "2019-10-20T07:57:27.152330783Z",
-					TimeEnd:   "2019-10-22T08:57:27.152330783Z",
-					Result:    "", // not set by the tested function
-					Score:     0,  // not calculated by tested function
-					IndicatorResults: []*keptnv2.SLIEvaluationResult{
+			ExpectedEvaluationResult: &EvaluationFinishedEventData{
+				Evaluation: EvaluationDetails{
+					EvaluationDetails: keptnv2.EvaluationDetails{
+						TimeStart: "2019-10-20T07:57:27.152330783Z",
+						TimeEnd:   "2019-10-22T08:57:27.152330783Z",
+						Result:    "",
+						Score:     0,
+					},
+					IndicatorResults: []*SLIEvaluationResult{
 						{
-							Score: 1,
-							Value: &keptnv2.SLIResult{
-								Metric:        "my-test-metric-1",
-								Value:         10.0,
-								ComparedValue: 10.0,
-								Success:       true,
-								Message:       "",
+							SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+								Score: 1,
+								Value: &keptnv2.SLIResult{
+									Metric:        "my-test-metric-1",
+									Value:         10.0,
+									ComparedValue: 10.0,
+									Success:       true,
+									Message:       "",
+								},
+								KeySLI: false,
+								Status: "pass",
 							},
-							WarningTargets: []*keptnv2.SLITarget{
+							WarningTargets: []*SLITarget{
 								{
-									Criteria:    "<=20.0",
-									TargetValue: 20,
-									Violated:    false,
+									SLITarget: keptnv2.SLITarget{
+										Criteria:    "<=20.0",
+										TargetValue: 20,
+										Violated:    false,
+									},
 								},
 								{
-									Criteria:    "<=+15%",
-									TargetValue: 11.5,
-									Violated:    false,
+									SLITarget: keptnv2.SLITarget{
+										Criteria:    "<=+15%",
+										TargetValue: 11.5,
+										Violated:    false,
+									},
 								},
 							},
-							PassTargets: []*keptnv2.SLITarget{
+							PassTargets: []*SLITarget{
 								{
-									Criteria:    "<=15.0",
-									TargetValue: 15.0,
-									Violated:    false,
+									SLITarget: keptnv2.SLITarget{
+										Criteria:    "<=15.0",
+										TargetValue: 15.0,
+										Violated:    false,
+									},
 								},
 								{
-									Criteria:    "<=+10%",
-									TargetValue: 11,
-									Violated:    false,
+									SLITarget: keptnv2.SLITarget{
+										Criteria:    "<=+10%",
+										TargetValue: 11,
+										Violated:    false,
+									},
 								},
 							},
-							KeySLI: false,
-							Status: "pass",
 						},
 						{
-							Score: 0,
-							Value: &keptnv2.SLIResult{
-								Metric:        "my-log-metric",
-								Value:         30.0,
-								ComparedValue: 0.0,
-								Success:       true,
-								Message:       "",
+							SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+								Score: 0,
+								Value: &keptnv2.SLIResult{
+									Metric:        "my-log-metric",
+									Value:         30.0,
+									ComparedValue: 0.0,
+									Success:       true,
+									Message:       "",
+								},
+								Status: "info",
 							},
-							Status: "info",
 						},
 					},
 				},
@@ -2146,10 +2383,10 @@ func TestEvaluateObjectives(t *testing.T) {
 					},
 				},
 			},
-			InSLOConfig: &keptnmodelsv2.ServiceLevelObjectives{
+			InSLOConfig: &ServiceLevelObjectives{
 				SpecVersion: "1.0",
 				Filter:      nil,
-				Comparison: &keptnmodelsv2.SLOComparison{
+				Comparison: &SLOComparison{
 					CompareWith:               "several_results",
 					IncludeResultWithScore:    "pass",
 					NumberOfComparisonResults: 2,
@@ -2173,36 +2410,44 @@ func TestEvaluateObjectives(t *testing.T) {
 				},
 			},
 			InPreviousEvaluationEvents: nil,
-			ExpectedEvaluationResult: &keptnv2.EvaluationFinishedEventData{
-				Evaluation: keptnv2.EvaluationDetails{
-					TimeStart: "2019-10-20T07:57:27.152330783Z",
-					TimeEnd:   "2019-10-22T08:57:27.152330783Z",
-					Result:    "", // not set by the tested function
-					Score:     0,  // not calculated by tested function
-					IndicatorResults: []*keptnv2.SLIEvaluationResult{
+			ExpectedEvaluationResult: &EvaluationFinishedEventData{
+				Evaluation: EvaluationDetails{
+					EvaluationDetails: keptnv2.EvaluationDetails{
+						TimeStart: "2019-10-20T07:57:27.152330783Z",
+						TimeEnd:   "2019-10-22T08:57:27.152330783Z",
+						Result:    "",
+						Score:     0,
+					},
+					IndicatorResults: []*SLIEvaluationResult{
 						{
-							Score: 0,
-							Value: &keptnv2.SLIResult{
-								Metric:  "response_time_p50",
-								Value:   1011.0745528937252,
-								Success: true,
-								Message: "",
+							SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+								Score: 0,
+								Value: &keptnv2.SLIResult{
+									Metric:  "response_time_p50",
+									Value:   1011.0745528937252,
+									Success: true,
+									Message: "",
+								},
+								KeySLI: false,
+								Status: "fail",
 							},
 							WarningTargets: nil,
-							PassTargets: []*keptnv2.SLITarget{
+							PassTargets: []*SLITarget{
 								{
-									Criteria:    "<=+20%",
-									TargetValue: 0,
-									Violated:    false,
+									SLITarget: keptnv2.SLITarget{
+										Criteria:    "<=+20%",
+										TargetValue: 0,
+										Violated:    false,
+									},
 								},
 								{
-									Criteria:    "<500",
-									TargetValue: 500,
-									Violated:    true,
+									SLITarget: keptnv2.SLITarget{
+										Criteria:    "<500",
+										TargetValue: 500,
+										Violated:    true,
+									},
 								},
 							},
-							KeySLI: false,
-							Status: "fail",
 						},
 					},
 				},
@@ -2237,10 +2482,10 @@ func TestEvaluateObjectives(t *testing.T) {
 					},
 				},
 			},
-			InSLOConfig: &keptnmodelsv2.ServiceLevelObjectives{
+			InSLOConfig: &ServiceLevelObjectives{
 				SpecVersion: "1.0",
 				Filter:      nil,
-				Comparison: &keptnmodelsv2.SLOComparison{
+				Comparison: &SLOComparison{
 					CompareWith:               "single_result",
 					IncludeResultWithScore:    "pass",
 					NumberOfComparisonResults: 1,
@@ -2263,26 +2508,30 @@ func TestEvaluateObjectives(t *testing.T) {
 					Warning: "75%",
 				},
 			},
-			InPreviousEvaluationEvents: []*keptnv2.EvaluationFinishedEventData{
+			InPreviousEvaluationEvents: []*EvaluationFinishedEventData{
 				{
-					Evaluation: keptnv2.EvaluationDetails{
-						TimeStart: "",
-						TimeEnd:   "",
-						Result:    "fail",
-						Score:     0,
-						IndicatorResults: []*keptnv2.SLIEvaluationResult{
+					Evaluation: EvaluationDetails{
+						EvaluationDetails: keptnv2.EvaluationDetails{
+							TimeStart: "",
+							TimeEnd:   "",
+							Result:    "fail",
+							Score:     0,
+						},
+						IndicatorResults: []*SLIEvaluationResult{
 							{
-								Score: 0,
-								Value: &keptnv2.SLIResult{
-									Metric:  "response_time_p50",
-									Value:   0.0,
-									Success: false,
-									Message: "",
+								SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+									Score: 0,
+									Value: &keptnv2.SLIResult{
+										Metric:  "response_time_p50",
+										Value:   0.0,
+										Success: false,
+										Message: "",
+									},
+									KeySLI: false,
+									Status: "fail",
 								},
 								PassTargets:    nil,
 								WarningTargets: nil,
-								KeySLI:         false,
-								Status:         "fail",
 							},
 						},
 					},
@@ -2294,31 +2543,37 @@ func TestEvaluateObjectives(t *testing.T) {
 					},
 				},
 			},
-			ExpectedEvaluationResult: &keptnv2.EvaluationFinishedEventData{
-				Evaluation: keptnv2.EvaluationDetails{
-					TimeStart: "2019-10-20T07:57:27.152330783Z",
-					TimeEnd:   "2019-10-22T08:57:27.152330783Z",
-					Result:    "", // not set by the tested function
-					Score:     0,  // not calculated by tested function
-					IndicatorResults: []*keptnv2.SLIEvaluationResult{
+			ExpectedEvaluationResult: &EvaluationFinishedEventData{
+				Evaluation: EvaluationDetails{
+					EvaluationDetails: keptnv2.EvaluationDetails{
+						TimeStart: "2019-10-20T07:57:27.152330783Z",
+						TimeEnd:   "2019-10-22T08:57:27.152330783Z",
+						Result:    "",
+						Score:     0,
+					},
+					IndicatorResults: []*SLIEvaluationResult{
 						{
-							Score: 1,
-							Value: &keptnv2.SLIResult{
-								Metric:  "response_time_p50",
-								Value:   100,
-								Success: true,
-								Message: "",
+							SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+								Score: 1,
+								Value: &keptnv2.SLIResult{
+									Metric:  "response_time_p50",
+									Value:   100,
+									Success: true,
+									Message: "",
+								},
+								KeySLI: false,
+								Status: "pass",
 							},
 							WarningTargets: nil,
-							PassTargets: []*keptnv2.SLITarget{
+							PassTargets: []*SLITarget{
 								{
-									Criteria:    "<=+20%",
-									TargetValue: 0,
-									Violated:    false,
+									SLITarget: keptnv2.SLITarget{
+										Criteria:    "<=+20%",
+										TargetValue: 0,
+										Violated:    false,
+									},
 								},
 							},
-							KeySLI: false,
-							Status: "pass",
 						},
 					},
 				},
@@ -2354,10 +2609,10 @@ func TestEvaluateObjectives(t *testing.T) {
 					},
 				},
 			},
-			InSLOConfig: &keptnmodelsv2.ServiceLevelObjectives{
+			InSLOConfig: &ServiceLevelObjectives{
 				SpecVersion: "1.0",
 				Filter:      nil,
-				Comparison: &keptnmodelsv2.SLOComparison{
+				Comparison: &SLOComparison{
 					CompareWith:               "several_results",
 					IncludeResultWithScore:    "pass",
 					NumberOfComparisonResults: 2,
@@ -2391,26 +2646,30 @@ func TestEvaluateObjectives(t *testing.T) {
 					Warning: "75%",
 				},
 			},
-			InPreviousEvaluationEvents: []*keptnv2.EvaluationFinishedEventData{
+			InPreviousEvaluationEvents: []*EvaluationFinishedEventData{
 				{
-					Evaluation: keptnv2.EvaluationDetails{
-						TimeStart: "",
-						TimeEnd:   "",
-						Result:    "pass",
-						Score:     2,
-						IndicatorResults: []*keptnv2.SLIEvaluationResult{
+					Evaluation: EvaluationDetails{
+						EvaluationDetails: keptnv2.EvaluationDetails{
+							TimeStart: "",
+							TimeEnd:   "",
+							Result:    "pass",
+							Score:     2,
+						},
+						IndicatorResults: []*SLIEvaluationResult{
 							{
-								Score: 2,
-								Value: &keptnv2.SLIResult{
-									Metric:  "my-test-metric-1",
-									Value:   10.0,
-									Success: true,
-									Message: "",
+								SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+									Score: 2,
+									Value: &keptnv2.SLIResult{
+										Metric:  "my-test-metric-1",
+										Value:   10.0,
+										Success: true,
+										Message: "",
+									},
+									KeySLI: false,
+									Status: "pass",
 								},
 								PassTargets:    nil,
 								WarningTargets: nil,
-								KeySLI:         false,
-								Status:         "pass",
 							},
 						},
 					},
@@ -2422,12 +2681,14 @@ func TestEvaluateObjectives(t *testing.T) {
 					},
 				},
 			},
-			ExpectedEvaluationResult: &keptnv2.EvaluationFinishedEventData{
-				Evaluation: keptnv2.EvaluationDetails{
-					TimeStart:        "2019-10-20T07:57:27.152330783Z",
-					TimeEnd:          "2019-10-22T08:57:27.152330783Z",
-					Result:           "", // not set by the tested function
-					Score:            0,  // not calculated by tested function
+			ExpectedEvaluationResult: &EvaluationFinishedEventData{
+				Evaluation: EvaluationDetails{
+					EvaluationDetails: keptnv2.EvaluationDetails{
+						TimeStart: "2019-10-20T07:57:27.152330783Z",
+						TimeEnd:   "2019-10-22T08:57:27.152330783Z",
+						Result:    "",
+						Score:     0,
+					},
 					IndicatorResults: nil,
 				},
 				EventData: keptnv2.EventData{
@@ -2456,10 +2717,10 @@ func TestEvaluateObjectives(t *testing.T) {
 type calculateScoreTestObject struct {
 	Name                     string
 	InMaximumScore           float64
-	InEvaluationResult       *keptnv2.EvaluationFinishedEventData
-	InSLOConfig              *keptnmodelsv2.ServiceLevelObjectives
+	InEvaluationResult       *EvaluationFinishedEventData
+	InSLOConfig              *ServiceLevelObjectives
 	InKeySLIFailed           bool
-	ExpectedEvaluationResult *keptnv2.EvaluationFinishedEventData
+	ExpectedEvaluationResult *EvaluationFinishedEventData
 	ExpectedError            error
 }
 
@@ -2468,25 +2729,29 @@ func TestCalculateScore(t *testing.T) {
 		{
 			Name:           "Simple comparison",
 			InMaximumScore: 1,
-			InEvaluationResult: &keptnv2.EvaluationFinishedEventData{
-				Evaluation: keptnv2.EvaluationDetails{
-					TimeStart: "2019-10-20T07:57:27.152330783Z",
-					TimeEnd:   "2019-10-22T08:57:27.152330783Z",
-					Result:    "", // to be calculated
-					Score:     0,  // to be calculated
-					IndicatorResults: []*keptnv2.SLIEvaluationResult{
+			InEvaluationResult: &EvaluationFinishedEventData{
+				Evaluation: EvaluationDetails{
+					EvaluationDetails: keptnv2.EvaluationDetails{
+						TimeStart: "2019-10-20T07:57:27.152330783Z",
+						TimeEnd:   "2019-10-22T08:57:27.152330783Z",
+						Result:    "",
+						Score:     0,
+					},
+					IndicatorResults: []*SLIEvaluationResult{
 						{
-							Score: 1,
-							Value: &keptnv2.SLIResult{
-								Metric:  "my-test-metric-1",
-								Value:   10.0,
-								Success: true,
-								Message: "",
+							SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+								Score: 1,
+								Value: &keptnv2.SLIResult{
+									Metric:  "my-test-metric-1",
+									Value:   10.0,
+									Success: true,
+									Message: "",
+								},
+								KeySLI: false,
+								Status: "pass",
 							},
 							PassTargets:    nil,
 							WarningTargets: nil,
-							KeySLI:         false,
-							Status:         "pass",
 						},
 					},
 				},
@@ -2497,10 +2762,10 @@ func TestCalculateScore(t *testing.T) {
 					Stage:   "dev",
 				},
 			},
-			InSLOConfig: &keptnmodelsv2.ServiceLevelObjectives{
+			InSLOConfig: &ServiceLevelObjectives{
 				SpecVersion: "1.0",
 				Filter:      nil,
-				Comparison: &keptnmodelsv2.SLOComparison{
+				Comparison: &SLOComparison{
 					CompareWith:               "several_results",
 					IncludeResultWithScore:    "pass",
 					NumberOfComparisonResults: 2,
@@ -2535,25 +2800,29 @@ func TestCalculateScore(t *testing.T) {
 				},
 			},
 			InKeySLIFailed: false,
-			ExpectedEvaluationResult: &keptnv2.EvaluationFinishedEventData{
-				Evaluation: keptnv2.EvaluationDetails{
-					TimeStart: "2019-10-20T07:57:27.152330783Z",
-					TimeEnd:   "2019-10-22T08:57:27.152330783Z",
-					Result:    "pass",
-					Score:     100.0,
-					IndicatorResults: []*keptnv2.SLIEvaluationResult{
+			ExpectedEvaluationResult: &EvaluationFinishedEventData{
+				Evaluation: EvaluationDetails{
+					EvaluationDetails: keptnv2.EvaluationDetails{
+						TimeStart: "2019-10-20T07:57:27.152330783Z",
+						TimeEnd:   "2019-10-22T08:57:27.152330783Z",
+						Result:    "pass",
+						Score:     100.0,
+					},
+					IndicatorResults: []*SLIEvaluationResult{
 						{
-							Score: 1,
-							Value: &keptnv2.SLIResult{
-								Metric:  "my-test-metric-1",
-								Value:   10.0,
-								Success: true,
-								Message: "",
+							SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+								Score: 1,
+								Value: &keptnv2.SLIResult{
+									Metric:  "my-test-metric-1",
+									Value:   10.0,
+									Success: true,
+									Message: "",
+								},
+								KeySLI: false,
+								Status: "pass",
 							},
 							PassTargets:    nil,
 							WarningTargets: nil,
-							KeySLI:         false,
-							Status:         "pass",
 						},
 					},
 				},
@@ -2570,38 +2839,44 @@ func TestCalculateScore(t *testing.T) {
 		{
 			Name:           "Key SLI failed",
 			InMaximumScore: 2,
-			InEvaluationResult: &keptnv2.EvaluationFinishedEventData{
-				Evaluation: keptnv2.EvaluationDetails{
-					TimeStart: "2019-10-20T07:57:27.152330783Z",
-					TimeEnd:   "2019-10-22T08:57:27.152330783Z",
-					Result:    "", // to be calculated
-					Score:     0,  // to be calculated
-					IndicatorResults: []*keptnv2.SLIEvaluationResult{
+			InEvaluationResult: &EvaluationFinishedEventData{
+				Evaluation: EvaluationDetails{
+					EvaluationDetails: keptnv2.EvaluationDetails{
+						TimeStart: "2019-10-20T07:57:27.152330783Z",
+						TimeEnd:   "2019-10-22T08:57:27.152330783Z",
+						Result:    "",
+						Score:     0,
+					},
+					IndicatorResults: []*SLIEvaluationResult{
 						{
-							Score: 1,
-							Value: &keptnv2.SLIResult{
-								Metric:  "my-test-metric-1",
-								Value:   10.0,
-								Success: true,
-								Message: "",
+							SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+								Score: 1,
+								Value: &keptnv2.SLIResult{
+									Metric:  "my-test-metric-1",
+									Value:   10.0,
+									Success: true,
+									Message: "",
+								},
+								KeySLI: false,
+								Status: "pass",
 							},
 							PassTargets:    nil,
 							WarningTargets: nil,
-							KeySLI:         false,
-							Status:         "pass",
 						},
 						{
-							Score: 0,
-							Value: &keptnv2.SLIResult{
-								Metric:  "my-key-metric",
-								Value:   10.0,
-								Success: true,
-								Message: "",
+							SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+								Score: 0,
+								Value: &keptnv2.SLIResult{
+									Metric:  "my-key-metric",
+									Value:   10.0,
+									Success: true,
+									Message: "",
+								},
+								KeySLI: false,
+								Status: "fail",
 							},
 							PassTargets:    nil,
 							WarningTargets: nil,
-							KeySLI:         false,
-							Status:         "fail",
 						},
 					},
 				},
@@ -2612,10 +2887,10 @@ func TestCalculateScore(t *testing.T) {
 					Stage:   "dev",
 				},
 			},
-			InSLOConfig: &keptnmodelsv2.ServiceLevelObjectives{
+			InSLOConfig: &ServiceLevelObjectives{
 				SpecVersion: "1.0",
 				Filter:      nil,
-				Comparison: &keptnmodelsv2.SLOComparison{
+				Comparison: &SLOComparison{
 					CompareWith:               "several_results",
 					IncludeResultWithScore:    "pass",
 					NumberOfComparisonResults: 2,
@@ -2671,38 +2946,44 @@ func TestCalculateScore(t *testing.T) {
 				},
 			},
 			InKeySLIFailed: true,
-			ExpectedEvaluationResult: &keptnv2.EvaluationFinishedEventData{
-				Evaluation: keptnv2.EvaluationDetails{
-					TimeStart: "2019-10-20T07:57:27.152330783Z",
-					TimeEnd:   "2019-10-22T08:57:27.152330783Z",
-					Result:    "fail",
-					Score:     50.0,
-					IndicatorResults: []*keptnv2.SLIEvaluationResult{
+			ExpectedEvaluationResult: &EvaluationFinishedEventData{
+				Evaluation: EvaluationDetails{
+					EvaluationDetails: keptnv2.EvaluationDetails{
+						TimeStart: "2019-10-20T07:57:27.152330783Z",
+						TimeEnd:   "2019-10-22T08:57:27.152330783Z",
+						Result:    "fail",
+						Score:     50.0,
+					},
+					IndicatorResults: []*SLIEvaluationResult{
 						{
-							Score: 1,
-							Value: &keptnv2.SLIResult{
-								Metric:  "my-test-metric-1",
-								Value:   10.0,
-								Success: true,
-								Message: "",
+							SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+								Score: 1,
+								Value: &keptnv2.SLIResult{
+									Metric:  "my-test-metric-1",
+									Value:   10.0,
+									Success: true,
+									Message: "",
+								},
+								KeySLI: false,
+								Status: "pass",
 							},
 							PassTargets:    nil,
 							WarningTargets: nil,
-							KeySLI:         false,
-							Status:         "pass",
 						},
 						{
-							Score: 0,
-							Value: &keptnv2.SLIResult{
-								Metric:  "my-key-metric",
-								Value:   10.0,
-								Success: true,
-								Message: "",
+							SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+								Score: 0,
+								Value: &keptnv2.SLIResult{
+									Metric:  "my-key-metric",
+									Value:   10.0,
+									Success: true,
+									Message: "",
+								},
+								KeySLI: false,
+								Status: "fail",
 							},
 							PassTargets:    nil,
 							WarningTargets: nil,
-							KeySLI:         false,
-							Status:         "fail",
 						},
 					},
 				},
@@ -2720,38 +3001,44 @@ func TestCalculateScore(t *testing.T) {
 		}, {
 			Name:           "Non-Key SLI warning",
 			InMaximumScore: 2,
-			InEvaluationResult: &keptnv2.EvaluationFinishedEventData{
-				Evaluation: keptnv2.EvaluationDetails{
-					TimeStart: "2019-10-20T07:57:27.152330783Z",
-					TimeEnd:   "2019-10-22T08:57:27.152330783Z",
-					Result:    "", // to be calculated
-					Score:     0,  // to be calculated
-					IndicatorResults: []*keptnv2.SLIEvaluationResult{
+			InEvaluationResult: &EvaluationFinishedEventData{
+				Evaluation: EvaluationDetails{
+					EvaluationDetails: keptnv2.EvaluationDetails{
+						TimeStart: "2019-10-20T07:57:27.152330783Z",
+						TimeEnd:   "2019-10-22T08:57:27.152330783Z",
+						Result:    "",
+						Score:     0,
+					},
+					IndicatorResults: []*SLIEvaluationResult{
 						{
-							Score: 1,
-							Value: &keptnv2.SLIResult{
-								Metric:  "my-test-metric-1",
-								Value:   10.0,
-								Success: true,
-								Message: "",
+							SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+								Score: 1,
+								Value: &keptnv2.SLIResult{
+									Metric:  "my-test-metric-1",
+									Value:   10.0,
+									Success: true,
+									Message: "",
+								},
+								KeySLI: false,
+								Status: "pass",
 							},
 							PassTargets:    nil,
 							WarningTargets: nil,
-							KeySLI:         false,
-							Status:         "pass",
 						},
 						{
-							Score: 0.506,
-							Value: &keptnv2.SLIResult{
-								Metric:  "my-metric",
-								Value:   10.0,
-								Success: true,
-								Message: "",
+							SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+								Score: 0.506,
+								Value: &keptnv2.SLIResult{
+									Metric:  "my-metric",
+									Value:   10.0,
+									Success: true,
+									Message: "",
+								},
+								KeySLI: false,
+								Status: "pass",
 							},
 							PassTargets:    nil,
 							WarningTargets: nil,
-							KeySLI:         false,
-							Status:         "pass",
 						},
 					},
 				},
@@ -2762,10 +3049,10 @@ func TestCalculateScore(t *testing.T) {
 					Stage:   "dev",
 				},
 			},
-			InSLOConfig: &keptnmodelsv2.ServiceLevelObjectives{
+			InSLOConfig: &ServiceLevelObjectives{
 				SpecVersion: "1.0",
 				Filter:      nil,
-				Comparison: &keptnmodelsv2.SLOComparison{
+				Comparison: &SLOComparison{
 					CompareWith:               "several_results",
 					IncludeResultWithScore:    "pass",
 					NumberOfComparisonResults: 2,
@@ -2821,38 +3108,44 @@ func TestCalculateScore(t *testing.T) {
 				},
 			},
 			InKeySLIFailed: false,
-			ExpectedEvaluationResult: &keptnv2.EvaluationFinishedEventData{
-				Evaluation: keptnv2.EvaluationDetails{
-					TimeStart: "2019-10-20T07:57:27.152330783Z",
-					TimeEnd:   "2019-10-22T08:57:27.152330783Z",
-					Result:    "warning",
-					Score:     75.3,
-					IndicatorResults: []*keptnv2.SLIEvaluationResult{
+			ExpectedEvaluationResult: &EvaluationFinishedEventData{
+				Evaluation: EvaluationDetails{
+					EvaluationDetails: keptnv2.EvaluationDetails{
+						TimeStart: "2019-10-20T07:57:27.152330783Z",
+						TimeEnd:   "2019-10-22T08:57:27.152330783Z",
+						Result:    "warning",
+						Score:     75.3,
+					},
+					IndicatorResults: []*SLIEvaluationResult{
 						{
-							Score: 1,
-							Value: &keptnv2.SLIResult{
-								Metric:  "my-test-metric-1",
-								Value:   10.0,
-								Success: true,
-								Message: "",
+							SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+								Score: 1,
+								Value: &keptnv2.SLIResult{
+									Metric:  "my-test-metric-1",
+									Value:   10.0,
+									Success: true,
+									Message: "",
+								},
+								KeySLI: false,
+								Status: "pass",
 							},
 							PassTargets:    nil,
 							WarningTargets: nil,
-							KeySLI:         false,
-							Status:         "pass",
 						},
 						{
-							Score: 0.506,
-							Value: &keptnv2.SLIResult{
-								Metric:  "my-metric",
-								Value:   10.0,
-								Success: true,
-								Message: "",
+							SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+								Score: 0.506,
+								Value: &keptnv2.SLIResult{
+									Metric:  "my-metric",
+									Value:   10.0,
+									Success: true,
+									Message: "",
+								},
+								KeySLI: false,
+								Status: "pass",
 							},
 							PassTargets:    nil,
 							WarningTargets: nil,
-							KeySLI:         false,
-							Status:         "pass",
 						},
 					},
 				},
@@ -2870,38 +3163,44 @@ func TestCalculateScore(t *testing.T) {
 		}, {
 			Name:           "Non-Key SLI fail",
 			InMaximumScore: 2,
-			InEvaluationResult: &keptnv2.EvaluationFinishedEventData{
-				Evaluation: keptnv2.EvaluationDetails{
-					TimeStart: "2019-10-20T07:57:27.152330783Z",
-					TimeEnd:   "2019-10-22T08:57:27.152330783Z",
-					Result:    "", // to be calculated
-					Score:     0,  // to be calculated
-					IndicatorResults: []*keptnv2.SLIEvaluationResult{
+			InEvaluationResult: &EvaluationFinishedEventData{
+				Evaluation: EvaluationDetails{
+					EvaluationDetails: keptnv2.EvaluationDetails{
+						TimeStart: "2019-10-20T07:57:27.152330783Z",
+						TimeEnd:   "2019-10-22T08:57:27.152330783Z",
+						Result:    "",
+						Score:     0,
+					},
+					IndicatorResults: []*SLIEvaluationResult{
 						{
-							Score: 1,
-							Value: &keptnv2.SLIResult{
-								Metric:  "my-test-metric-1",
-								Value:   10.0,
-								Success: true,
-								Message: "",
+							SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+								Score: 1,
+								Value: &keptnv2.SLIResult{
+									Metric:  "my-test-metric-1",
+									Value:   10.0,
+									Success: true,
+									Message: "",
+								},
+								KeySLI: false,
+								Status: "pass",
 							},
 							PassTargets:    nil,
 							WarningTargets: nil,
-							KeySLI:         false,
-							Status:         "pass",
 						},
 						{
-							Score: 0.48,
-							Value: &keptnv2.SLIResult{
-								Metric:  "my-metric",
-								Value:   10.0,
-								Success: false,
-								Message: "",
+							SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+								Score: 0.48,
+								Value: &keptnv2.SLIResult{
+									Metric:  "my-metric",
+									Value:   10.0,
+									Success: false,
+									Message: "",
+								},
+								KeySLI: false,
+								Status: "fail",
 							},
 							PassTargets:    nil,
 							WarningTargets: nil,
-							KeySLI:         false,
-							Status:         "fail",
 						},
 					},
 				},
@@ -2912,10 +3211,10 @@ func TestCalculateScore(t *testing.T) {
 					Stage:   "dev",
 				},
 			},
-			InSLOConfig: &keptnmodelsv2.ServiceLevelObjectives{
+			InSLOConfig: &ServiceLevelObjectives{
 				SpecVersion: "1.0",
 				Filter:      nil,
-				Comparison: &keptnmodelsv2.SLOComparison{
+				Comparison: &SLOComparison{
 					CompareWith:               "several_results",
 					IncludeResultWithScore:    "pass",
 					NumberOfComparisonResults: 2,
@@ -2971,38 +3270,44 @@ func TestCalculateScore(t *testing.T) {
 				},
 			},
 			InKeySLIFailed: false,
-			ExpectedEvaluationResult: &keptnv2.EvaluationFinishedEventData{
-				Evaluation: keptnv2.EvaluationDetails{
-					TimeStart: "2019-10-20T07:57:27.152330783Z",
-					TimeEnd:   "2019-10-22T08:57:27.152330783Z",
-					Result:    "fail",
-					Score:     74,
-					IndicatorResults: []*keptnv2.SLIEvaluationResult{
+			ExpectedEvaluationResult: &EvaluationFinishedEventData{
+				Evaluation: EvaluationDetails{
+					EvaluationDetails: keptnv2.EvaluationDetails{
+						TimeStart: "2019-10-20T07:57:27.152330783Z",
+						TimeEnd:   "2019-10-22T08:57:27.152330783Z",
+						Result:    "fail",
+						Score:     74,
+					},
+					IndicatorResults: []*SLIEvaluationResult{
 						{
-							Score: 1,
-							Value: &keptnv2.SLIResult{
-								Metric:  "my-test-metric-1",
-								Value:   10.0,
-								Success: true,
-								Message: "",
+							SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+								Score: 1,
+								Value: &keptnv2.SLIResult{
+									Metric:  "my-test-metric-1",
+									Value:   10.0,
+									Success: true,
+									Message: "",
+								},
+								KeySLI: false,
+								Status: "pass",
 							},
 							PassTargets:    nil,
 							WarningTargets: nil,
-							KeySLI:         false,
-							Status:         "pass",
 						},
 						{
-							Score: 0.48,
-							Value: &keptnv2.SLIResult{
-								Metric:  "my-metric",
-								Value:   10.0,
-								Success: false,
-								Message: "",
+							SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+								Score: 0.48,
+								Value: &keptnv2.SLIResult{
+									Metric:  "my-metric",
+									Value:   10.0,
+									Success: false,
+									Message: "",
+								},
+								KeySLI: false,
+								Status: "fail",
 							},
 							PassTargets:    nil,
 							WarningTargets: nil,
-							KeySLI:         false,
-							Status:         "fail",
 						},
 					},
 				},
@@ -3021,38 +3326,44 @@ func TestCalculateScore(t *testing.T) {
 		{
 			Name:           "Only Info SLIs",
 			InMaximumScore: 0,
-			InEvaluationResult: &keptnv2.EvaluationFinishedEventData{
-				Evaluation: keptnv2.EvaluationDetails{
-					TimeStart: "2019-10-20T07:57:27.152330783Z",
-					TimeEnd:   "2019-10-22T08:57:27.152330783Z",
-					Result:    "", // to be calculated
-					Score:     0,  // to be calculated
-					IndicatorResults: []*keptnv2.SLIEvaluationResult{
+			InEvaluationResult: &EvaluationFinishedEventData{
+				Evaluation: EvaluationDetails{
+					EvaluationDetails: keptnv2.EvaluationDetails{
+						TimeStart: "2019-10-20T07:57:27.152330783Z",
+						TimeEnd:   "2019-10-22T08:57:27.152330783Z",
+						Result:    "",
+						Score:     0,
+					},
+					IndicatorResults: []*SLIEvaluationResult{
 						{
-							Score: 1,
-							Value: &keptnv2.SLIResult{
-								Metric:  "my-test-metric-1",
-								Value:   10.0,
-								Success: true,
-								Message: "",
+							SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+								Score: 1,
+								Value: &keptnv2.SLIResult{
+									Metric:  "my-test-metric-1",
+									Value:   10.0,
+									Success: true,
+									Message: "",
+								},
+								KeySLI: false,
+								Status: "pass",
 							},
 							PassTargets:    nil,
 							WarningTargets: nil,
-							KeySLI:         false,
-							Status:         "pass",
 						},
 						{
-							Score: 1,
-							Value: &keptnv2.SLIResult{
-								Metric:  "my-key-metric",
-								Value:   10.0,
-								Success: true,
-								Message: "",
+							SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+								Score: 1,
+								Value: &keptnv2.SLIResult{
+									Metric:  "my-key-metric",
+									Value:   10.0,
+									Success: true,
+									Message: "",
+								},
+								KeySLI: false,
+								Status: "pass",
 							},
 							PassTargets:    nil,
 							WarningTargets: nil,
-							KeySLI:         false,
-							Status:         "pass",
 						},
 					},
 				},
@@ -3063,10 +3374,10 @@ func TestCalculateScore(t *testing.T) {
 					Stage:   "dev",
 				},
 			},
-			InSLOConfig: &keptnmodelsv2.ServiceLevelObjectives{
+			InSLOConfig: &ServiceLevelObjectives{
 				SpecVersion: "1.0",
 				Filter:      nil,
-				Comparison: &keptnmodelsv2.SLOComparison{
+				Comparison: &SLOComparison{
 					CompareWith:               "several_results",
 					IncludeResultWithScore:    "pass",
 					NumberOfComparisonResults: 2,
@@ -3090,40 +3401,46 @@ func TestCalculateScore(t *testing.T) {
 				},
 			},
 			InKeySLIFailed: true,
-			ExpectedEvaluationResult: &keptnv2.EvaluationFinishedEventData{
-				Evaluation: keptnv2.EvaluationDetails{
-					TimeStart: "2019-10-20T07:57:27.152330783Z",
-					TimeEnd:   "2019-10-22T08:57:27.152330783Z",
-					Result:    "pass",
-					Score:     100.0,
-					IndicatorResults: []*keptnv2.SLIEvaluationResult{
+			ExpectedEvaluationResult: &EvaluationFinishedEventData{
+				Evaluation: EvaluationDetails{
+					EvaluationDetails: keptnv2.EvaluationDetails{
+						TimeStart: "2019-10-20T07:57:27.152330783Z",
+						TimeEnd:   "2019-10-22T08:57:27.152330783Z",
+						Result:    "pass",
+						Score:     100.0,
+					},
+					IndicatorResults: []*SLIEvaluationResult{
 						{
-							Score: 1,
-							Value: &keptnv2.SLIResult{
-								Metric:        "my-test-metric-1",
-								Value:         10.0,
-								ComparedValue: 0.0,
-								Success:       true,
-								Message:       "",
+							SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+								Score: 1,
+								Value: &keptnv2.SLIResult{
+									Metric:        "my-test-metric-1",
+									Value:         10.0,
+									ComparedValue: 0.0,
+									Success:       true,
+									Message:       "",
+								},
+								KeySLI: false,
+								Status: "pass",
 							},
 							PassTargets:    nil,
 							WarningTargets: nil,
-							KeySLI:         false,
-							Status:         "pass",
 						},
 						{
-							Score: 1,
-							Value: &keptnv2.SLIResult{
-								Metric:        "my-key-metric",
-								Value:         10.0,
-								ComparedValue: 0.0,
-								Success:       true,
-								Message:       "",
+							SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+								Score: 1,
+								Value: &keptnv2.SLIResult{
+									Metric:        "my-key-metric",
+									Value:         10.0,
+									ComparedValue: 0.0,
+									Success:       true,
+									Message:       "",
+								},
+								KeySLI: false,
+								Status: "pass",
 							},
 							PassTargets:    nil,
 							WarningTargets: nil,
-							KeySLI:         false,
-							Status:         "pass",
 						},
 					},
 				},
@@ -3181,7 +3498,7 @@ func TestEvaluateSLIHandler_getPreviousEvaluations(t *testing.T) {
 		fields              fields
 		args                args
 		resultFromDatastore datastoreResult
-		want                []*keptnv2.EvaluationFinishedEventData
+		want                []*EvaluationFinishedEventData
 		want2               []string
 		wantErr             bool
 	}{
@@ -3211,7 +3528,7 @@ func TestEvaluateSLIHandler_getPreviousEvaluations(t *testing.T) {
 					ID   string      `json:"id"`
 				}{
 					{
-						Data: &keptnv2.EvaluationFinishedEventData{
+						Data: &EvaluationFinishedEventData{
 							EventData: keptnv2.EventData{
 								Project: "sockshop",
 								Service: "carts",
@@ -3224,7 +3541,7 @@ func TestEvaluateSLIHandler_getPreviousEvaluations(t *testing.T) {
 					},
 				},
 			},
-			want: []*keptnv2.EvaluationFinishedEventData{
+			want: []*EvaluationFinishedEventData{
 				{
 					EventData: keptnv2.EventData{
 						Project: "sockshop",
@@ -3249,7 +3566,13 @@ func TestEvaluateSLIHandler_getPreviousEvaluations(t *testing.T) {
 				Event:        tt.fields.Event,
 				HTTPClient:   tt.fields.HTTPClient,
 			}
-			got, got2, err := eh.getPreviousEvaluations(tt.args.e, tt.args.numberOfPreviousResults, "all")
+			got, got2, err := eh.getPreviousEvaluations(evaluationQuery{
+				Project:                 tt.args.e.Project,
+				Stage:                   tt.args.e.Stage,
+				Service:                 tt.args.e.Service,
+				NumberOfPreviousResults: tt.args.numberOfPreviousResults,
+				IncludeResult:           "all",
+			})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("getPreviousEvaluations() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -3286,7 +3609,7 @@ func TestEvaluateSLIHandler_HandleEvent(t *testing.T) {
 		name       string
 		fields     fields
 		wantErr    bool
-		wantEvents []keptnv2.EvaluationFinishedEventData
+		wantEvents []EvaluationFinishedEventData
 	}{
 		{
 			name: "no SLO file available",
@@ -3310,20 +3633,28 @@ func TestEvaluateSLIHandler_HandleEvent(t *testing.T) {
 				},
 				KeptnHandler: keptn,
 				SLOFileRetriever: SLOFileRetriever{
-					ResourceHandler: &event_handler_mock.ResourceHandlerMock{GetServiceResourceFunc: func(project string, stage string, service string, resourceURI string) (*models.Resource, error) {
-						return nil, nil
-					}},
+					ResourceHandler: &event_handler_mock.ResourceHandlerMock{
+						GetProjectResourceFunc: func(project string, resourceURI string) (*models.Resource, error) {
+							return nil, errors.New("resource not found")
+						},
+						GetStageResourceFunc: func(project string, stage string, resourceURI string) (*models.Resource, error) {
+							return nil, errors.New("resource not found")
+						},
+						GetServiceResourceFunc: func(project string, stage string, service string, resourceURI string) (*models.Resource, error) {
+							return nil, nil
+						},
+					},
 				},
 			},
 			wantErr: false,
-			wantEvents: []keptnv2.EvaluationFinishedEventData{
+			wantEvents: []EvaluationFinishedEventData{
 				{
 					EventData: keptnv2.EventData{
 						Status:  keptnv2.StatusSucceeded,
 						Result:  keptnv2.ResultPass,
 						Message: "no evaluation performed by lighthouse because no SLO file configured for project ",
 					},
-					Evaluation: keptnv2.EvaluationDetails{},
+					Evaluation: EvaluationDetails{},
 				},
 			},
 		},
@@ -3349,23 +3680,31 @@ func TestEvaluateSLIHandler_HandleEvent(t *testing.T) {
 				},
 				KeptnHandler: keptn,
 				SLOFileRetriever: SLOFileRetriever{
-					ResourceHandler: &event_handler_mock.ResourceHandlerMock{GetServiceResourceFunc: func(project string, stage string, service string, resourceURI string) (*models.Resource, error) {
-						return nil, errors.New("Could not check out branch containing stage config")
-					}},
+					ResourceHandler: &event_handler_mock.ResourceHandlerMock{
+						GetProjectResourceFunc: func(project string, resourceURI string) (*models.Resource, error) {
+							return nil, errors.New("resource not found")
+						},
+						GetStageResourceFunc: func(project string, stage string, resourceURI string) (*models.Resource, error) {
+							return nil, errors.New("resource not found")
+						},
+						GetServiceResourceFunc: func(project string, stage string, service string, resourceURI string) (*models.Resource, error) {
+							return nil, errors.New("Could not check out branch containing stage config")
+						},
+					},
 					ServiceHandler: &event_handler_mock.ServiceHandlerMock{GetServiceFunc: func(project string, stage string, service string) (*models.Service, error) {
 						return &models.Service{}, nil
 					}},
 				},
 			},
 			wantErr: false,
-			wantEvents: []keptnv2.EvaluationFinishedEventData{
+			wantEvents: []EvaluationFinishedEventData{
 				{
 					EventData: keptnv2.EventData{
 						Status:  keptnv2.StatusErrored,
 						Result:  keptnv2.ResultFailed,
 						Message: "could not checkout the SLO",
 					},
-					Evaluation: keptnv2.EvaluationDetails{},
+					Evaluation: EvaluationDetails{},
 				},
 			},
 		},
@@ -3400,7 +3739,7 @@ func TestEvaluateSLIHandler_HandleEvent(t *testing.T) {
 
 			// evaluate which events have been sent
 			for index, event := range sender.SentEvents {
-				evaluationFinishedEvent := &keptnv2.EvaluationFinishedEventData{}
+				evaluationFinishedEvent := &EvaluationFinishedEventData{}
 				if err := event.DataAs(evaluationFinishedEvent); err != nil {
 					t.Errorf("could not decode event: %s", err.Error())
 				}
@@ -3413,8 +3752,8 @@ func TestEvaluateSLIHandler_HandleEvent(t *testing.T) {
 
 func Test_aggregateValues(t *testing.T) {
 	type fields struct {
-		InPreviousResults []*keptnv2.SLIEvaluationResult
-		InComparison      *keptnmodelsv2.SLOComparison
+		InPreviousResults []*SLIEvaluationResult
+		InComparison      *SLOComparison
 	}
 	tests := []struct {
 		name        string
@@ -3425,35 +3764,39 @@ func Test_aggregateValues(t *testing.T) {
 
 		{name: "Aggregate 2 values with AVG",
 			fields: fields{
-				InPreviousResults: []*keptnv2.SLIEvaluationResult{
+				InPreviousResults: []*SLIEvaluationResult{
 					{
-						Score: 2,
-						Value: &keptnv2.SLIResult{
-							Metric:  "my-test-metric",
-							Value:   5.0,
-							Success: true,
-							Message: "",
+						SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+							Score: 2,
+							Value: &keptnv2.SLIResult{
+								Metric:  "my-test-metric",
+								Value:   5.0,
+								Success: true,
+								Message: "",
+							},
+							KeySLI: false,
+							Status: "pass",
 						},
 						PassTargets:    nil,
 						WarningTargets: nil,
-						KeySLI:         false,
-						Status:         "pass",
 					},
 					{
-						Score: 2,
-						Value: &keptnv2.SLIResult{
-							Metric:  "my-test-metric",
-							Value:   15.0,
-							Success: true,
-							Message: "",
+						SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+							Score: 2,
+							Value: &keptnv2.SLIResult{
+								Metric:  "my-test-metric",
+								Value:   15.0,
+								Success: true,
+								Message: "",
+							},
+							KeySLI: false,
+							Status: "pass",
 						},
 						PassTargets:    nil,
 						WarningTargets: nil,
-						KeySLI:         false,
-						Status:         "pass",
 					},
 				},
-				InComparison: &keptnmodelsv2.SLOComparison{
+				InComparison: &SLOComparison{
 					CompareWith:               "several_results",
 					IncludeResultWithScore:    "pass",
 					NumberOfComparisonResults: 2,
@@ -3465,7 +3808,7 @@ func Test_aggregateValues(t *testing.T) {
 		},
 		{name: "Skip because of no previous results",
 			fields: fields{
-				InComparison: &keptnmodelsv2.SLOComparison{
+				InComparison: &SLOComparison{
 					CompareWith:               "several_results",
 					IncludeResultWithScore:    "pass",
 					NumberOfComparisonResults: 2,
@@ -3477,35 +3820,39 @@ func Test_aggregateValues(t *testing.T) {
 		},
 		{name: "Skip because of no previous success",
 			fields: fields{
-				InPreviousResults: []*keptnv2.SLIEvaluationResult{
+				InPreviousResults: []*SLIEvaluationResult{
 					{
-						Score: 2,
-						Value: &keptnv2.SLIResult{
-							Metric:  "my-test-metric",
-							Value:   5.0,
-							Success: false,
-							Message: "",
+						SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+							Score: 2,
+							Value: &keptnv2.SLIResult{
+								Metric:  "my-test-metric",
+								Value:   5.0,
+								Success: false,
+								Message: "",
+							},
+							KeySLI: false,
+							Status: "pass",
 						},
 						PassTargets:    nil,
 						WarningTargets: nil,
-						KeySLI:         false,
-						Status:         "pass",
 					},
 					{
-						Score: 2,
-						Value: &keptnv2.SLIResult{
-							Metric:  "my-test-metric",
-							Value:   15.0,
-							Success: false,
-							Message: "",
+						SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+							Score: 2,
+							Value: &keptnv2.SLIResult{
+								Metric:  "my-test-metric",
+								Value:   15.0,
+								Success: false,
+								Message: "",
+							},
+							KeySLI: false,
+							Status: "pass",
 						},
 						PassTargets:    nil,
 						WarningTargets: nil,
-						KeySLI:         false,
-						Status:         "pass",
 					},
 				},
-				InComparison: &keptnmodelsv2.SLOComparison{
+				InComparison: &SLOComparison{
 					CompareWith:               "several_results",
 					IncludeResultWithScore:    "pass",
 					NumberOfComparisonResults: 2,
@@ -3657,3 +4004,426 @@ func Test_getSLIResult(t *testing.T) {
 		})
 	}
 }
+
+func newPreviousResults(values ...float64) []*SLIEvaluationResult {
+	results := []*SLIEvaluationResult{}
+	for _, value := range values {
+		results = append(results, &SLIEvaluationResult{
+			SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+				Value: &keptnv2.SLIResult{
+					Metric:  "my-test-metric",
+					Value:   value,
+					Success: true,
+				},
+				Status: "pass",
+			},
+		})
+	}
+	return results
+}
+
+func floatp(f float64) *float64 {
+	return &f
+}
+
+func TestEvaluateStatisticalCriteria(t *testing.T) {
+	tests := []struct {
+		name              string
+		criteria          string
+		value             float64
+		previousResults   []*SLIEvaluationResult
+		want              bool
+		wantTarget        *SLITarget
+		wantComparedValue float64
+	}{
+		{
+			name:              "value within 2 standard deviations",
+			criteria:          "zscore<=2",
+			value:             12,
+			previousResults:   newPreviousResults(8, 10, 12),
+			want:              true,
+			wantTarget:        &SLITarget{SLITarget: keptnv2.SLITarget{Criteria: "zscore<=2", TargetValue: 14}, LowerBound: floatp(6), UpperBound: floatp(14)},
+			wantComparedValue: 10,
+		},
+		{
+			name:              "value outside of 2 standard deviations",
+			criteria:          "zscore<=2",
+			value:             5,
+			previousResults:   newPreviousResults(8, 10, 12),
+			want:              false,
+			wantTarget:        &SLITarget{SLITarget: keptnv2.SLITarget{Criteria: "zscore<=2", TargetValue: 6}, LowerBound: floatp(6), UpperBound: floatp(14)},
+			wantComparedValue: 10,
+		},
+		{
+			name:            "failed previous results are not taken into account",
+			criteria:        "zscore<=2",
+			value:           50,
+			previousResults: append(newPreviousResults(10), &SLIEvaluationResult{SLIEvaluationResult: keptnv2.SLIEvaluationResult{Value: &keptnv2.SLIResult{Value: 50, Success: false}}}),
+			want:            true,
+			wantTarget:      &SLITarget{SLITarget: keptnv2.SLITarget{Criteria: "zscore<=2"}},
+		},
+		{
+			name:              "value within median absolute deviations",
+			criteria:          "mad<=3",
+			value:             13,
+			previousResults:   newPreviousResults(9, 10, 11, 10, 100),
+			want:              true,
+			wantTarget:        &SLITarget{SLITarget: keptnv2.SLITarget{Criteria: "mad<=3", TargetValue: 10 + 3*madScaleFactor}, LowerBound: floatp(10 - 3*madScaleFactor), UpperBound: floatp(10 + 3*madScaleFactor)},
+			wantComparedValue: 10,
+		},
+		{
+			name:              "outlier is detected by median absolute deviation",
+			criteria:          "mad<=3",
+			value:             15,
+			previousResults:   newPreviousResults(9, 10, 11, 10, 100),
+			want:              false,
+			wantTarget:        &SLITarget{SLITarget: keptnv2.SLITarget{Criteria: "mad<=3", TargetValue: 10 + 3*madScaleFactor}, LowerBound: floatp(10 - 3*madScaleFactor), UpperBound: floatp(10 + 3*madScaleFactor)},
+			wantComparedValue: 10,
+		},
+		{
+			name:              "value grows monotonically over 5 results",
+			criteria:          "increasing<5",
+			value:             15,
+			previousResults:   newPreviousResults(14, 13, 12, 11, 20),
+			want:              false,
+			wantTarget:        &SLITarget{SLITarget: keptnv2.SLITarget{Criteria: "increasing<5", TargetValue: 14}, UpperBound: floatp(14)},
+			wantComparedValue: 14,
+		},
+		{
+			name:              "value stops growing trend",
+			criteria:          "increasing<5",
+			value:             13,
+			previousResults:   newPreviousResults(14, 13, 12, 11, 20),
+			want:              true,
+			wantTarget:        &SLITarget{SLITarget: keptnv2.SLITarget{Criteria: "increasing<5", TargetValue: 14}, UpperBound: floatp(14)},
+			wantComparedValue: 14,
+		},
+		{
+			name:              "value grows monotonically over 4 results",
+			criteria:          "increasing<5",
+			value:             15,
+			previousResults:   newPreviousResults(14, 13, 12, 20),
+			want:              true,
+			wantTarget:        &SLITarget{SLITarget: keptnv2.SLITarget{Criteria: "increasing<5"}},
+			wantComparedValue: 14,
+		},
+		{
+			name:              "value decreases monotonically over 3 results",
+			criteria:          "decreasing<=2",
+			value:             8,
+			previousResults:   newPreviousResults(9, 10),
+			want:              false,
+			wantTarget:        &SLITarget{SLITarget: keptnv2.SLITarget{Criteria: "decreasing<=2", TargetValue: 9}, LowerBound: floatp(9)},
+			wantComparedValue: 9,
+		},
+		{
+			name:       "no previous results",
+			criteria:   "zscore<=3",
+			value:      10,
+			want:       true,
+			wantTarget: &SLITarget{SLITarget: keptnv2.SLITarget{Criteria: "zscore<=3"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sliResult := &keptnv2.SLIResult{Metric: "my-test-metric", Value: tt.value, Success: true}
+			target := &SLITarget{SLITarget: keptnv2.SLITarget{Criteria: tt.criteria}}
+			got, err := evaluateSingleCriteria(sliResult, tt.criteria, tt.previousResults, &SLOComparison{AggregateFunction: "avg"}, target)
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+			require.InDelta(t, tt.wantTarget.TargetValue, target.TargetValue, 0.0001)
+			requireBound(t, tt.wantTarget.LowerBound, target.LowerBound)
+			requireBound(t, tt.wantTarget.UpperBound, target.UpperBound)
+			require.InDelta(t, tt.wantComparedValue, sliResult.ComparedValue, 0.0001)
+		})
+	}
+}
+
+func TestSLIEvaluationResult_JSON(t *testing.T) {
+	sliEvaluationResult := &SLIEvaluationResult{
+		SLIEvaluationResult: keptnv2.SLIEvaluationResult{
+			Score:  1,
+			Value:  &keptnv2.SLIResult{Metric: "response_time", Value: 12, Success: true},
+			Status: "pass",
+		},
+		PassTargets: []*SLITarget{
+			{SLITarget: keptnv2.SLITarget{Criteria: "zscore<=2", TargetValue: 14}, LowerBound: floatp(6), UpperBound: floatp(14)},
+		},
+	}
+	marshalled, err := json.Marshal(sliEvaluationResult)
+	require.Nil(t, err)
+	require.JSONEq(t, `{"score":1,"value":{"metric":"response_time","value":12,"comparedValue":0,"success":true},"displayName":"","keySli":false,"status":"pass",
+		"passTargets":[{"criteria":"zscore<=2","targetValue":14,"violated":false,"lowerBound":6,"upperBound":14}],"warningTargets":null}`, string(marshalled))
+
+	// the result can still be read by consumers of the keptnv2 types
+	keptnSLIEvaluationResult := &keptnv2.SLIEvaluationResult{}
+	require.Nil(t, json.Unmarshal(marshalled, keptnSLIEvaluationResult))
+	require.Equal(t, "zscore<=2", keptnSLIEvaluationResult.PassTargets[0].Criteria)
+	require.Equal(t, "response_time", keptnSLIEvaluationResult.Value.Metric)
+}
+
+func requireBound(t *testing.T, want, got *float64) {
+	if want == nil {
+		require.Nil(t, got)
+		return
+	}
+	require.NotNil(t, got)
+	require.InDelta(t, *want, *got, 0.0001)
+}
+
+func TestCalculateMedian(t *testing.T) {
+	require.Equal(t, 0.0, calculateMedian(nil))
+	require.Equal(t, 2.0, calculateMedian([]float64{3, 1, 2}))
+	require.Equal(t, 2.5, calculateMedian([]float64{4, 1, 3, 2}))
+	require.Equal(t, 1.0, calculateMedianAbsoluteDeviation([]float64{9, 10, 11, 10, 100}, 10))
+}
+
+func TestEvaluateSLIHandler_getComparisonEvaluations(t *testing.T) {
+	var receivedQueries []string
+	ts := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			receivedQueries = append(receivedQueries, r.URL.RawQuery)
+			w.Header().Add("Content-Type", "application/json")
+			w.WriteHeader(200)
+			w.Write([]byte(`{"events":[{"id":"my-id","data":{"project":"sockshop","stage":"production","service":"carts"}}]}`))
+		}),
+	)
+	defer ts.Close()
+
+	_ = os.Setenv("MONGODB_DATASTORE", strings.TrimPrefix(ts.URL, "http://"))
+
+	tests := []struct {
+		name            string
+		comparison      *SLOComparison
+		baselineContent string
+		wantQuery       string
+		wantIDs         []string
+	}{
+		{
+			name:       "compare with the last result of the same stage",
+			comparison: &SLOComparison{CompareWith: "single_result", IncludeResultWithScore: "pass"},
+			wantQuery:  "source=lighthouse-service&limit=1&excludeInvalidated=true&filter=data.project:sockshop%20AND%20data.stage:staging%20AND%20data.service:carts%20AND%20data.result:pass",
+			wantIDs:    []string{"my-id"},
+		},
+		{
+			name:       "compare with the results of a different stage",
+			comparison: &SLOComparison{CompareWith: "several_results", NumberOfComparisonResults: 5, IncludeResultWithScore: "all", Stage: "production"},
+			wantQuery:  "source=lighthouse-service&limit=5&excludeInvalidated=true&filter=data.project:sockshop%20AND%20data.stage:production%20AND%20data.service:carts",
+			wantIDs:    []string{"my-id"},
+		},
+		{
+			name:            "compare with the baseline",
+			comparison:      &SLOComparison{CompareWith: "baseline", IncludeResultWithScore: "pass"},
+			baselineContent: "keptnContext: my-baseline-context",
+			wantQuery:       "source=lighthouse-service&limit=1&excludeInvalidated=true&filter=data.project:sockshop%20AND%20data.stage:staging%20AND%20data.service:carts%20AND%20shkeptncontext:my-baseline-context",
+			wantIDs:         []string{"my-id"},
+		},
+		{
+			name:       "no baseline set",
+			comparison: &SLOComparison{CompareWith: "baseline"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receivedQueries = nil
+			resourceHandler := &event_handler_mock.ResourceHandlerMock{GetServiceResourceFunc: func(project string, stage string, service string, resourceURI string) (*models.Resource, error) {
+				if tt.baselineContent == "" {
+					return nil, errors.New("resource not found")
+				}
+				return &models.Resource{ResourceURI: stringp(resourceURI), ResourceContent: tt.baselineContent}, nil
+			}}
+			eh := &EvaluateSLIHandler{
+				HTTPClient:       &http.Client{},
+				SLOFileRetriever: SLOFileRetriever{ResourceHandler: resourceHandler},
+			}
+			_, ids, err := eh.getComparisonEvaluations(&keptnv2.GetSLIFinishedEventData{
+				EventData: keptnv2.EventData{Project: "sockshop", Stage: "staging", Service: "carts"},
+			}, tt.comparison)
+			require.Nil(t, err)
+			require.Equal(t, tt.wantIDs, ids)
+			if tt.wantQuery == "" {
+				require.Empty(t, receivedQueries)
+				return
+			}
+			require.Equal(t, []string{tt.wantQuery}, receivedQueries)
+			if tt.comparison.CompareWith == "baseline" {
+				require.Equal(t, baselineResourceURI, resourceHandler.GetServiceResourceCalls()[0].ResourceURI)
+			}
+		})
+	}
+}
+
+func TestEvaluateSLIHandler_CollectsSLIResultsOfEvaluation(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(200)
+		w.Write([]byte(`{"events":[]}`))
+	}))
+	defer ts.Close()
+	_ = os.Setenv("MONGODB_DATASTORE", strings.TrimPrefix(ts.URL, "http://"))
+	defer os.Unsetenv("MONGODB_DATASTORE")
+
+	newGetSLIFinishedEvent := func(triggeredID string, values ...*keptnv2.SLIResult) *models.KeptnContextExtendedCE {
+		return &models.KeptnContextExtendedCE{
+			ID:          triggeredID + "-finished",
+			Triggeredid: triggeredID,
+			Type:        stringp(keptnv2.GetFinishedEventType(keptnv2.GetSLITaskName)),
+			Data: keptnv2.GetSLIFinishedEventData{
+				EventData: keptnv2.EventData{Project: "sockshop", Stage: "staging", Service: "carts", Result: keptnv2.ResultPass},
+				GetSLI:    keptnv2.GetSLIFinished{Start: "2021-01-01T10:00:00.000Z", End: "2021-01-01T10:10:00.000Z", IndicatorValues: values},
+			},
+		}
+	}
+	// the service has been evaluated twice in the same context, the results of both evaluations are stored
+	eventStore := &event_handler_mock.EventStoreMock{GetEventsFunc: func(filter *keptnapi.EventFilter) ([]*models.KeptnContextExtendedCE, *models.Error) {
+		if filter.EventType == keptnv2.GetTriggeredEventType(keptnv2.EvaluationTaskName) {
+			return []*models.KeptnContextExtendedCE{{ID: "evaluation-2"}, {ID: "evaluation-1"}}, nil
+		}
+		return []*models.KeptnContextExtendedCE{
+			newGetSLIFinishedEvent(getSLIRequestID("evaluation-1", 0), &keptnv2.SLIResult{Metric: "response_time", Value: 500, Success: true}),
+			newGetSLIFinishedEvent(getSLIRequestID("evaluation-1", 1), &keptnv2.SLIResult{Metric: "conversion_rate", Value: 1, Success: true}),
+			newGetSLIFinishedEvent(getSLIRequestID("evaluation-2", 1), &keptnv2.SLIResult{Metric: "conversion_rate", Value: 5, Success: true}),
+		}, nil
+	}}
+	resourceHandler := &event_handler_mock.ResourceHandlerMock{
+		GetProjectResourceFunc: func(project string, resourceURI string) (*models.Resource, error) {
+			return nil, errors.New("resource not found")
+		},
+		GetStageResourceFunc: func(project string, stage string, resourceURI string) (*models.Resource, error) {
+			return nil, errors.New("resource not found")
+		},
+		GetServiceResourceFunc: func(project string, stage string, service string, resourceURI string) (*models.Resource, error) {
+			return &models.Resource{ResourceURI: stringp(resourceURI), ResourceContent: `---
+spec_version: '1.0'
+sli_providers:
+  business-kpis:
+    - conversion_rate
+objectives:
+  - sli: response_time
+    pass:
+      - criteria:
+          - "<200"
+  - sli: conversion_rate
+    pass:
+      - criteria:
+          - ">2"
+total_score:
+  pass: "90%"`}, nil
+		},
+	}
+
+	incomingEvent := cloudevents.NewEvent()
+	incomingEvent.SetID("get-sli-finished-id")
+	incomingEvent.SetSource("my-sli-provider")
+	incomingEvent.SetExtension("shkeptncontext", "my-context")
+	incomingEvent.SetExtension("triggeredid", getSLIRequestID("evaluation-2", 0))
+	eventSender := &keptnfake.EventSender{}
+	keptnHandler, _ := keptnv2.NewKeptn(&incomingEvent, keptncommon.KeptnOpts{EventSender: eventSender})
+	eh := &EvaluateSLIHandler{
+		Event:            incomingEvent,
+		HTTPClient:       &http.Client{},
+		KeptnHandler:     keptnHandler,
+		SLOFileRetriever: SLOFileRetriever{ResourceHandler: resourceHandler},
+		EventStore:       eventStore,
+	}
+
+	err := eh.processGetSliFinishedEvent(context.Background(), "my-context", &keptnv2.GetSLIFinishedEventData{
+		EventData: keptnv2.EventData{Project: "sockshop", Stage: "staging", Service: "carts", Result: keptnv2.ResultPass},
+		GetSLI: keptnv2.GetSLIFinished{
+			Start:           "2021-01-01T10:00:00.000Z",
+			End:             "2021-01-01T10:10:00.000Z",
+			IndicatorValues: []*keptnv2.SLIResult{{Metric: "response_time", Value: 100, Success: true}},
+		},
+	})
+	require.Nil(t, err)
+
+	// the evaluation is conducted with the results of its own get-sli.triggered events only
+	require.Len(t, eventSender.SentEvents, 1)
+	finishedEvent := eventSender.SentEvents[0]
+	require.Equal(t, keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName), finishedEvent.Type())
+	require.Equal(t, "evaluation-2", finishedEvent.Extensions()["triggeredid"])
+	evaluationFinishedData := &EvaluationFinishedEventData{}
+	require.Nil(t, finishedEvent.DataAs(evaluationFinishedData))
+	require.Equal(t, keptnv2.ResultPass, evaluationFinishedData.Result)
+	require.Len(t, evaluationFinishedData.Evaluation.IndicatorResults, 2)
+	for _, indicatorResult := range evaluationFinishedData.Evaluation.IndicatorResults {
+		if indicatorResult.Value.Metric == "conversion_rate" {
+			require.Equal(t, 5.0, indicatorResult.Value.Value)
+		}
+	}
+}
+
+func TestEvaluateSLIHandler_FailsEvaluationIfSLIResultsAreMissing(t *testing.T) {
+	_ = os.Setenv(sliRetrievalTimeoutEnvVar, "50ms")
+	defer os.Unsetenv(sliRetrievalTimeoutEnvVar)
+
+	eventStore := &event_handler_mock.EventStoreMock{GetEventsFunc: func(filter *keptnapi.EventFilter) ([]*models.KeptnContextExtendedCE, *models.Error) {
+		if filter.EventType == keptnv2.GetTriggeredEventType(keptnv2.EvaluationTaskName) {
+			return []*models.KeptnContextExtendedCE{{ID: "evaluation-missing-results"}}, nil
+		}
+		return []*models.KeptnContextExtendedCE{}, nil
+	}}
+	resourceHandler := &event_handler_mock.ResourceHandlerMock{
+		GetProjectResourceFunc: func(project string, resourceURI string) (*models.Resource, error) {
+			return nil, errors.New("resource not found")
+		},
+		GetStageResourceFunc: func(project string, stage string, resourceURI string) (*models.Resource, error) {
+			return nil, errors.New("resource not found")
+		},
+		GetServiceResourceFunc: func(project string, stage string, service string, resourceURI string) (*models.Resource, error) {
+			return &models.Resource{ResourceURI: stringp(resourceURI), ResourceContent: `---
+spec_version: '1.0'
+sli_providers:
+  business-kpis:
+    - conversion_rate
+  prometheus:
+    - throughput
+objectives:
+  - sli: response_time
+  - sli: conversion_rate
+  - sli: throughput
+total_score:
+  pass: "90%"`}, nil
+		},
+	}
+
+	incomingEvent := cloudevents.NewEvent()
+	incomingEvent.SetID("get-sli-finished-id")
+	incomingEvent.SetSource("business-kpis")
+	incomingEvent.SetExtension("shkeptncontext", "my-context")
+	incomingEvent.SetExtension("triggeredid", getSLIRequestID("evaluation-missing-results", 1))
+	eventSender := &keptnfake.EventSender{}
+	keptnHandler, _ := keptnv2.NewKeptn(&incomingEvent, keptncommon.KeptnOpts{EventSender: eventSender})
+	eh := &EvaluateSLIHandler{
+		Event:            incomingEvent,
+		HTTPClient:       &http.Client{},
+		KeptnHandler:     keptnHandler,
+		SLOFileRetriever: SLOFileRetriever{ResourceHandler: resourceHandler},
+		EventStore:       eventStore,
+	}
+
+	err := eh.processGetSliFinishedEvent(context.Background(), "my-context", &keptnv2.GetSLIFinishedEventData{
+		EventData: keptnv2.EventData{Project: "sockshop", Stage: "staging", Service: "carts", Result: keptnv2.ResultPass},
+		GetSLI: keptnv2.GetSLIFinished{
+			Start:           "2021-01-01T10:00:00.000Z",
+			End:             "2021-01-01T10:10:00.000Z",
+			IndicatorValues: []*keptnv2.SLIResult{{Metric: "conversion_rate", Value: 1, Success: true}},
+		},
+	})
+	require.Nil(t, err)
+	require.Empty(t, eventSender.SentEvents)
+
+	// the results of the SLI provider of the project and of prometheus are not received within the timeout
+	require.Eventually(t, func() bool {
+		return len(eventSender.SentEvents) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	finishedEvent := eventSender.SentEvents[0]
+	require.Equal(t, keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName), finishedEvent.Type())
+	require.Equal(t, "evaluation-missing-results", finishedEvent.Extensions()["triggeredid"])
+	evaluationFinishedData := &keptnv2.EvaluationFinishedEventData{}
+	require.Nil(t, finishedEvent.DataAs(evaluationFinishedData))
+	require.Equal(t, keptnv2.ResultFailed, evaluationFinishedData.Result)
+	require.Equal(t, keptnv2.StatusErrored, evaluationFinishedData.Status)
+	require.Equal(t, "no evaluation performed by lighthouse because the SLI results of the following SLI providers have not been received within 50ms: "+
+		"SLI provider of the project, prometheus", evaluationFinishedData.Message)
+}
@@ -14,9 +14,15 @@ import (
 //
 // 		// make and configure a mocked event_handler.ResourceHandler
 // 		mockedResourceHandler := &ResourceHandlerMock{
+// 			GetProjectResourceFunc: func(project string, resourceURI string) (*keptnapimodels.Resource, error) {
+// 				panic("mock out the GetProjectResource method")
+// 			},
 // 			GetServiceResourceFunc: func(project string, stage string, service string, resourceURI string) (*keptnapimodels.Resource, error) {
 // 				panic("mock out the GetServiceResource method")
 // 			},
+// 			GetStageResourceFunc: func(project string, stage string, resourceURI string) (*keptnapimodels.Resource, error) {
+// 				panic("mock out the GetStageResource method")
+// 			},
 // 		}
 //
 // 		// use mockedResourceHandler in code that requires event_handler.ResourceHandler
@@ -24,11 +30,24 @@ import (
 //
 // 	}
 type ResourceHandlerMock struct {
+	// GetProjectResourceFunc mocks the GetProjectResource method.
+	GetProjectResourceFunc func(project string, resourceURI string) (*keptnapimodels.Resource, error)
+
 	// GetServiceResourceFunc mocks the GetServiceResource method.
 	GetServiceResourceFunc func(project string, stage string, service string, resourceURI string) (*keptnapimodels.Resource, error)
 
+	// GetStageResourceFunc mocks the GetStageResource method.
+	GetStageResourceFunc func(project string, stage string, resourceURI string) (*keptnapimodels.Resource, error)
+
 	// calls tracks calls to the methods.
 	calls struct {
+		// GetProjectResource holds details about calls to the GetProjectResource method.
+		GetProjectResource []struct {
+			// Project is the project argument value.
+			Project string
+			// ResourceURI is the resourceURI argument value.
+			ResourceURI string
+		}
 		// GetServiceResource holds details about calls to the GetServiceResource method.
 		GetServiceResource []struct {
 			// Project is the project argument value.
@@ -40,8 +59,55 @@ type ResourceHandlerMock struct {
 			// ResourceURI is the resourceURI argument value.
 			ResourceURI string
 		}
+		// GetStageResource holds details about calls to the GetStageResource method.
+		GetStageResource []struct {
+			// Project is the project argument value.
+			Project string
+			// Stage is the stage argument value.
+			Stage string
+			// ResourceURI is the resourceURI argument value.
+			ResourceURI string
+		}
 	}
+	lockGetProjectResource sync.RWMutex
 	lockGetServiceResource sync.RWMutex
+	lockGetStageResource   sync.RWMutex
+}
+
+// GetProjectResource calls GetProjectResourceFunc.
+func (mock *ResourceHandlerMock) GetProjectResource(project string, resourceURI string) (*keptnapimodels.Resource, error) {
+	if mock.GetProjectResourceFunc == nil {
+		panic("ResourceHandlerMock.GetProjectResourceFunc: method is nil but ResourceHandler.GetProjectResource was just called")
+	}
+	callInfo := struct {
+		Project     string
+		ResourceURI string
+	}{
+		Project:     project,
+		ResourceURI: resourceURI,
+	}
+	mock.lockGetProjectResource.Lock()
+	mock.calls.GetProjectResource = append(mock.calls.GetProjectResource, callInfo)
+	mock.lockGetProjectResource.Unlock()
+	return mock.GetProjectResourceFunc(project, resourceURI)
+}
+
+// GetProjectResourceCalls gets all the calls that were made to GetProjectResource.
+// Check the length with:
+//
+//     len(mockedResourceHandler.GetProjectResourceCalls())
+func (mock *ResourceHandlerMock) GetProjectResourceCalls() []struct {
+	Project     string
+	ResourceURI string
+} {
+	var calls []struct {
+		Project     string
+		ResourceURI string
+	}
+	mock.lockGetProjectResource.RLock()
+	calls = mock.calls.GetProjectResource
+	mock.lockGetProjectResource.RUnlock()
+	return calls
 }
 
 // GetServiceResource calls GetServiceResourceFunc.
@@ -68,6 +134,7 @@ func (mock *ResourceHandlerMock) GetServiceResource(project string, stage string
 
 // GetServiceResourceCalls gets all the calls that were made to GetServiceResource.
 // Check the length with:
+//
 //     len(mockedResourceHandler.GetServiceResourceCalls())
 func (mock *ResourceHandlerMock) GetServiceResourceCalls() []struct {
 	Project     string
@@ -86,3 +153,43 @@ func (mock *ResourceHandlerMock) GetServiceResourceCalls() []struct {
 	mock.lockGetServiceResource.RUnlock()
 	return calls
 }
+
+// GetStageResource calls GetStageResourceFunc.
+func (mock *ResourceHandlerMock) GetStageResource(project string, stage string, resourceURI string) (*keptnapimodels.Resource, error) {
+	if mock.GetStageResourceFunc == nil {
+		panic("ResourceHandlerMock.GetStageResourceFunc: method is nil but ResourceHandler.GetStageResource was just called")
+	}
+	callInfo := struct {
+		Project     string
+		Stage       string
+		ResourceURI string
+	}{
+		Project:     project,
+		Stage:       stage,
+		ResourceURI: resourceURI,
+	}
+	mock.lockGetStageResource.Lock()
+	mock.calls.GetStageResource = append(mock.calls.GetStageResource, callInfo)
+	mock.lockGetStageResource.Unlock()
+	return mock.GetStageResourceFunc(project, stage, resourceURI)
+}
+
+// GetStageResourceCalls gets all the calls that were made to GetStageResource.
+// Check the length with:
+//
+//     len(mockedResourceHandler.GetStageResourceCalls())
+func (mock *ResourceHandlerMock) GetStageResourceCalls() []struct {
+	Project     string
+	Stage       string
+	ResourceURI string
+} {
+	var calls []struct {
+		Project     string
+		Stage       string
+		ResourceURI string
+	}
+	mock.lockGetStageResource.RLock()
+	calls = mock.calls.GetStageResource
+	mock.lockGetStageResource.RUnlock()
+	return calls
+}
@@ -0,0 +1,224 @@
+package event_handler
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/common/timeutils"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+// EvaluationSegment contains the result of a single interval of a segmented evaluation
+type EvaluationSegment struct {
+	TimeStart        string                 `json:"timeStart"`
+	TimeEnd          string                 `json:"timeEnd"`
+	Result           string                 `json:"result"`
+	Score            float64                `json:"score"`
+	IndicatorResults []*SLIEvaluationResult `json:"indicatorResults"`
+	// Violated is set if at least one objective failed in this interval
+	Violated bool `json:"violated"`
+}
+
+type evaluationTimeframe struct {
+	Start string
+	End   string
+}
+
+// isSegmented returns true if the evaluation timeframe has to be split into several intervals
+func (s *SLOSegments) isSegmented() bool {
+	return s != nil && s.Intervals > 1
+}
+
+// getEvaluationSegments splits the timeframe [start,end] into the given number of equally sized intervals
+func getEvaluationSegments(start, end string, intervals int) ([]evaluationTimeframe, error) {
+	startTime, err := timeutils.ParseTimestamp(start)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse start of evaluation timeframe: %w", err)
+	}
+	endTime, err := timeutils.ParseTimestamp(end)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse end of evaluation timeframe: %w", err)
+	}
+	duration := endTime.Sub(*startTime)
+	if duration <= 0 {
+		return nil, errors.New("end of evaluation timeframe must be after its start")
+	}
+	intervalDuration := duration / time.Duration(intervals)
+	if intervalDuration < time.Second {
+		return nil, fmt.Errorf("evaluation timeframe of %s is too short to be split into %d intervals", duration.String(), intervals)
+	}
+
+	segments := []evaluationTimeframe{}
+	for i := 0; i < intervals; i++ {
+		segmentStart := startTime.Add(time.Duration(i) * intervalDuration)
+		segmentEnd := startTime.Add(time.Duration(i+1) * intervalDuration)
+		if i == intervals-1 {
+			segmentEnd = *endTime
+		}
+		segments = append(segments, evaluationTimeframe{
+			Start: timeutils.GetKeptnTimeStamp(segmentStart),
+			End:   timeutils.GetKeptnTimeStamp(segmentEnd),
+		})
+	}
+	return segments, nil
+}
+
+func isBefore(timestamp1, timestamp2 string) bool {
+	time1, err1 := timeutils.ParseTimestamp(timestamp1)
+	time2, err2 := timeutils.ParseTimestamp(timestamp2)
+	if err1 != nil || err2 != nil {
+		return timestamp1 < timestamp2
+	}
+	return time1.Before(*time2)
+}
+
+const (
+	avgSegmentAggregation = "avg"
+	sumSegmentAggregation = "sum"
+	minSegmentAggregation = "min"
+	maxSegmentAggregation = "max"
+)
+
+// validateSegmentAggregations checks that the aggregations of the SLIs of a segmented evaluation are supported
+func validateSegmentAggregations(segments *SLOSegments) error {
+	if segments == nil {
+		return nil
+	}
+	for sli, aggregation := range segments.Aggregations {
+		switch aggregation {
+		case avgSegmentAggregation, sumSegmentAggregation, minSegmentAggregation, maxSegmentAggregation:
+		default:
+			return fmt.Errorf("unsupported aggregation %s of SLI %s in segments", aggregation, sli)
+		}
+	}
+	return nil
+}
+
+// mergeSegmentResults combines the SLI results of all intervals into the results of the whole evaluation timeframe.
+// The values of an SLI are combined using the aggregation configured for the SLI, or averaged if no aggregation is configured
+func mergeSegmentResults(e *keptnv2.GetSLIFinishedEventData, segments []*keptnv2.GetSLIFinishedEventData, sloSegments *SLOSegments) *keptnv2.GetSLIFinishedEventData {
+	merged := &keptnv2.GetSLIFinishedEventData{
+		EventData: e.EventData,
+		GetSLI: keptnv2.GetSLIFinished{
+			Start: segments[0].GetSLI.Start,
+			End:   segments[len(segments)-1].GetSLI.End,
+		},
+	}
+
+	metrics := []string{}
+	values := map[string][]*keptnv2.SLIResult{}
+	for _, segment := range segments {
+		for _, value := range segment.GetSLI.IndicatorValues {
+			if value == nil {
+				continue
+			}
+			if _, ok := values[value.Metric]; !ok {
+				metrics = append(metrics, value.Metric)
+			}
+			values[value.Metric] = append(values[value.Metric], value)
+		}
+	}
+
+	for _, metric := range metrics {
+		aggregation := sloSegments.Aggregations[metric]
+		if aggregation == "" {
+			aggregation = avgSegmentAggregation
+		}
+		merged.GetSLI.IndicatorValues = append(merged.GetSLI.IndicatorValues, aggregateSegmentValues(metric, values[metric], len(segments), aggregation))
+	}
+	return merged
+}
+
+// aggregateSegmentValues combines the values of an SLI retrieved for the intervals of an evaluation. Sums are only calculated if
+// the values of all intervals have been retrieved, since a partial sum would underestimate the value of the whole timeframe
+func aggregateSegmentValues(metric string, values []*keptnv2.SLIResult, intervals int, aggregation string) *keptnv2.SLIResult {
+	aggregatedValue := &keptnv2.SLIResult{Metric: metric}
+	successfulValues := []float64{}
+	for _, value := range values {
+		if !value.Success {
+			aggregatedValue.Message = value.Message
+			continue
+		}
+		successfulValues = append(successfulValues, value.Value)
+	}
+	if len(successfulValues) == 0 {
+		return aggregatedValue
+	}
+	if aggregation == sumSegmentAggregation && len(successfulValues) < intervals {
+		aggregatedValue.Message = fmt.Sprintf("could not sum up the values of SLI %s since only %d of %d intervals returned a value", metric, len(successfulValues), intervals)
+		return aggregatedValue
+	}
+
+	aggregatedValue.Value = successfulValues[0]
+	for _, value := range successfulValues[1:] {
+		switch aggregation {
+		case minSegmentAggregation:
+			aggregatedValue.Value = math.Min(aggregatedValue.Value, value)
+		case maxSegmentAggregation:
+			aggregatedValue.Value = math.Max(aggregatedValue.Value, value)
+		default:
+			aggregatedValue.Value += value
+		}
+	}
+	if aggregation == avgSegmentAggregation {
+		aggregatedValue.Value = aggregatedValue.Value / float64(len(successfulValues))
+	}
+	aggregatedValue.Success = true
+	aggregatedValue.Message = ""
+	return aggregatedValue
+}
+
+// evaluateSegments scores each interval separately and fails the evaluation if more than the allowed percentage of intervals violate an objective
+func evaluateSegments(evaluationResult *EvaluationFinishedEventData, segments []*keptnv2.GetSLIFinishedEventData, sloConfig *ServiceLevelObjectives, previousEvaluationEvents []*EvaluationFinishedEventData) error {
+	maxFailedPercentage := 0.0
+	if sloConfig.Segments.MaxFailedIntervals != "" {
+		var err error
+		maxFailedPercentage, err = strconv.ParseFloat(strings.TrimSuffix(sloConfig.Segments.MaxFailedIntervals, "%"), 64)
+		if err != nil {
+			return errors.New("could not parse percentage of max failed intervals")
+		}
+	}
+
+	failedIntervals := 0
+	for _, segment := range segments {
+		// evaluateObjectives removes the evaluated values, therefore a copy of the values is evaluated
+		segmentData := *segment
+		segmentData.GetSLI.IndicatorValues = append([]*keptnv2.SLIResult{}, segment.GetSLI.IndicatorValues...)
+
+		segmentResult, maximumAchievableScore, keySLIFailed := evaluateObjectives(&segmentData, sloConfig, previousEvaluationEvents)
+		if err := calculateScore(maximumAchievableScore, segmentResult, sloConfig, keySLIFailed); err != nil {
+			return err
+		}
+
+		evaluationSegment := &EvaluationSegment{
+			TimeStart:        segment.GetSLI.Start,
+			TimeEnd:          segment.GetSLI.End,
+			Result:           segmentResult.Evaluation.Result,
+			Score:            segmentResult.Evaluation.Score,
+			IndicatorResults: segmentResult.Evaluation.IndicatorResults,
+		}
+		for _, indicatorResult := range segmentResult.Evaluation.IndicatorResults {
+			if indicatorResult.Status == "fail" {
+				evaluationSegment.Violated = true
+				break
+			}
+		}
+		if evaluationSegment.Violated {
+			failedIntervals++
+		}
+		evaluationResult.Evaluation.Segments = append(evaluationResult.Evaluation.Segments, evaluationSegment)
+	}
+
+	failedPercentage := 100.0 * float64(failedIntervals) / float64(len(segments))
+	if failedPercentage > maxFailedPercentage {
+		evaluationResult.Evaluation.Result = string(keptnv2.ResultFailed)
+		evaluationResult.Result = keptnv2.ResultFailed
+		evaluationResult.Status = keptnv2.StatusSucceeded
+		evaluationResult.Message = fmt.Sprintf("Evaluation failed since %d of %d intervals (%v%%) violate an objective, which is more than the allowed %v%%", failedIntervals, len(segments), failedPercentage, maxFailedPercentage)
+	}
+	return nil
+}
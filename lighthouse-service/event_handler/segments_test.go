@@ -0,0 +1,223 @@
+package event_handler
+
+import (
+	"testing"
+
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_getEvaluationSegments(t *testing.T) {
+	tests := []struct {
+		name      string
+		start     string
+		end       string
+		intervals int
+		want      []evaluationTimeframe
+		wantErr   bool
+	}{
+		{
+			name:      "split into three intervals",
+			start:     "2021-01-01T10:00:00.000Z",
+			end:       "2021-01-01T10:30:00.000Z",
+			intervals: 3,
+			want: []evaluationTimeframe{
+				{Start: "2021-01-01T10:00:00.000Z", End: "2021-01-01T10:10:00.000Z"},
+				{Start: "2021-01-01T10:10:00.000Z", End: "2021-01-01T10:20:00.000Z"},
+				{Start: "2021-01-01T10:20:00.000Z", End: "2021-01-01T10:30:00.000Z"},
+			},
+		},
+		{
+			name:      "end before start",
+			start:     "2021-01-01T10:30:00.000Z",
+			end:       "2021-01-01T10:00:00.000Z",
+			intervals: 3,
+			wantErr:   true,
+		},
+		{
+			name:      "intervals too short",
+			start:     "2021-01-01T10:00:00.000Z",
+			end:       "2021-01-01T10:00:02.000Z",
+			intervals: 3,
+			wantErr:   true,
+		},
+		{
+			name:      "invalid timestamp",
+			start:     "yesterday",
+			end:       "2021-01-01T10:00:00.000Z",
+			intervals: 3,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getEvaluationSegments(tt.start, tt.end, tt.intervals)
+			require.Equal(t, tt.wantErr, err != nil)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func newSegmentResult(start, end string, values ...*keptnv2.SLIResult) *keptnv2.GetSLIFinishedEventData {
+	return &keptnv2.GetSLIFinishedEventData{
+		EventData: keptnv2.EventData{Project: "sockshop", Stage: "staging", Service: "carts"},
+		GetSLI: keptnv2.GetSLIFinished{
+			Start:           start,
+			End:             end,
+			IndicatorValues: values,
+		},
+	}
+}
+
+func Test_mergeSegmentResults(t *testing.T) {
+	e := newSegmentResult("2021-01-01T10:20:00.000Z", "2021-01-01T10:30:00.000Z")
+	segments := []*keptnv2.GetSLIFinishedEventData{
+		newSegmentResult("2021-01-01T10:00:00.000Z", "2021-01-01T10:10:00.000Z",
+			&keptnv2.SLIResult{Metric: "response_time", Value: 100, Success: true},
+			&keptnv2.SLIResult{Metric: "error_rate", Success: false, Message: "timeout"},
+		),
+		newSegmentResult("2021-01-01T10:10:00.000Z", "2021-01-01T10:20:00.000Z",
+			&keptnv2.SLIResult{Metric: "response_time", Value: 300, Success: true},
+			&keptnv2.SLIResult{Metric: "error_rate", Success: false, Message: "timeout"},
+		),
+		e,
+	}
+	e.GetSLI.IndicatorValues = []*keptnv2.SLIResult{
+		{Metric: "response_time", Success: false, Message: "no data"},
+		{Metric: "error_rate", Success: false, Message: "timeout"},
+	}
+
+	got := mergeSegmentResults(e, segments, &SLOSegments{Intervals: 3})
+
+	require.Equal(t, "2021-01-01T10:00:00.000Z", got.GetSLI.Start)
+	require.Equal(t, "2021-01-01T10:30:00.000Z", got.GetSLI.End)
+	require.Equal(t, e.EventData, got.EventData)
+	require.Equal(t, []*keptnv2.SLIResult{
+		{Metric: "response_time", Value: 200, Success: true},
+		{Metric: "error_rate", Success: false, Message: "timeout"},
+	}, got.GetSLI.IndicatorValues)
+}
+
+func Test_mergeSegmentResults_Aggregations(t *testing.T) {
+	e := newSegmentResult("2021-01-01T10:20:00.000Z", "2021-01-01T10:30:00.000Z")
+	segments := []*keptnv2.GetSLIFinishedEventData{
+		newSegmentResult("2021-01-01T10:00:00.000Z", "2021-01-01T10:10:00.000Z",
+			&keptnv2.SLIResult{Metric: "error_count", Value: 3, Success: true},
+			&keptnv2.SLIResult{Metric: "response_time_p95", Value: 300, Success: true},
+			&keptnv2.SLIResult{Metric: "throughput", Value: 20, Success: true},
+			&keptnv2.SLIResult{Metric: "request_count", Value: 100, Success: true},
+		),
+		newSegmentResult("2021-01-01T10:10:00.000Z", "2021-01-01T10:20:00.000Z",
+			&keptnv2.SLIResult{Metric: "error_count", Value: 5, Success: true},
+			&keptnv2.SLIResult{Metric: "response_time_p95", Value: 500, Success: true},
+			&keptnv2.SLIResult{Metric: "throughput", Value: 10, Success: true},
+			&keptnv2.SLIResult{Metric: "request_count", Success: false, Message: "no data"},
+		),
+	}
+	sloSegments := &SLOSegments{
+		Intervals: 2,
+		Aggregations: map[string]string{
+			"error_count":       "sum",
+			"response_time_p95": "max",
+			"throughput":        "min",
+			"request_count":     "sum",
+		},
+	}
+
+	got := mergeSegmentResults(e, segments, sloSegments)
+
+	require.Equal(t, []*keptnv2.SLIResult{
+		{Metric: "error_count", Value: 8, Success: true},
+		{Metric: "response_time_p95", Value: 500, Success: true},
+		{Metric: "throughput", Value: 10, Success: true},
+		// a sum is not calculated if the value of an interval is missing
+		{Metric: "request_count", Success: false, Message: "could not sum up the values of SLI request_count since only 1 of 2 intervals returned a value"},
+	}, got.GetSLI.IndicatorValues)
+}
+
+func Test_validateSegmentAggregations(t *testing.T) {
+	require.Nil(t, validateSegmentAggregations(nil))
+	require.Nil(t, validateSegmentAggregations(&SLOSegments{Intervals: 2, Aggregations: map[string]string{"error_count": "sum", "response_time": "avg"}}))
+	require.NotNil(t, validateSegmentAggregations(&SLOSegments{Intervals: 2, Aggregations: map[string]string{"response_time": "p95"}}))
+}
+
+func Test_evaluateSegments(t *testing.T) {
+	sloConfig := &ServiceLevelObjectives{
+		Objectives: []*keptn.SLO{
+			{
+				SLI:    "response_time",
+				Weight: 1,
+				Pass:   []*keptn.SLOCriteria{{Criteria: []string{"<200"}}},
+			},
+		},
+		TotalScore: &keptn.SLOScore{Pass: "90%"},
+		Comparison: &SLOComparison{CompareWith: "single_result", AggregateFunction: "avg"},
+	}
+	segments := []*keptnv2.GetSLIFinishedEventData{
+		newSegmentResult("2021-01-01T10:00:00.000Z", "2021-01-01T10:10:00.000Z", &keptnv2.SLIResult{Metric: "response_time", Value: 100, Success: true}),
+		newSegmentResult("2021-01-01T10:10:00.000Z", "2021-01-01T10:20:00.000Z", &keptnv2.SLIResult{Metric: "response_time", Value: 400, Success: true}),
+		newSegmentResult("2021-01-01T10:20:00.000Z", "2021-01-01T10:30:00.000Z", &keptnv2.SLIResult{Metric: "response_time", Value: 150, Success: true}),
+		newSegmentResult("2021-01-01T10:30:00.000Z", "2021-01-01T10:40:00.000Z", &keptnv2.SLIResult{Metric: "response_time", Value: 120, Success: true}),
+	}
+
+	tests := []struct {
+		name               string
+		maxFailedIntervals string
+		wantResult         string
+		wantErr            bool
+	}{
+		{
+			name:               "short spike within allowed failed intervals",
+			maxFailedIntervals: "25%",
+			wantResult:         "pass",
+		},
+		{
+			name:               "more failed intervals than allowed",
+			maxFailedIntervals: "20%",
+			wantResult:         "fail",
+		},
+		{
+			name:       "no failed intervals allowed by default",
+			wantResult: "fail",
+		},
+		{
+			name:               "invalid percentage",
+			maxFailedIntervals: "some",
+			wantErr:            true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sloConfig.Segments = &SLOSegments{Intervals: 4, MaxFailedIntervals: tt.maxFailedIntervals}
+			evaluationResult := &EvaluationFinishedEventData{
+				EventData:  keptnv2.EventData{Result: keptnv2.ResultPass},
+				Evaluation: EvaluationDetails{EvaluationDetails: keptnv2.EvaluationDetails{Result: "pass"}},
+			}
+
+			err := evaluateSegments(evaluationResult, segments, sloConfig, nil)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.wantResult, evaluationResult.Evaluation.Result)
+			require.Equal(t, tt.wantResult, string(evaluationResult.Result))
+
+			require.Len(t, evaluationResult.Evaluation.Segments, 4)
+			for i, segment := range evaluationResult.Evaluation.Segments {
+				require.Equal(t, segments[i].GetSLI.Start, segment.TimeStart)
+				require.Equal(t, segments[i].GetSLI.End, segment.TimeEnd)
+				require.Len(t, segment.IndicatorResults, 1)
+			}
+			require.True(t, evaluationResult.Evaluation.Segments[1].Violated)
+			require.Equal(t, "fail", evaluationResult.Evaluation.Segments[1].Result)
+			require.Equal(t, 0.0, evaluationResult.Evaluation.Segments[1].Score)
+			require.False(t, evaluationResult.Evaluation.Segments[0].Violated)
+			require.Equal(t, 100.0, evaluationResult.Evaluation.Segments[0].Score)
+
+			// the SLI values of the intervals are not modified by the evaluation
+			require.Len(t, segments[1].GetSLI.IndicatorValues, 1)
+		})
+	}
+}
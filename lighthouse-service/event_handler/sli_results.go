@@ -0,0 +1,287 @@
+package event_handler
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/keptn/go-utils/pkg/api/models"
+	logger "github.com/sirupsen/logrus"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+const sliRetrievalTimeoutEnvVar = "SLI_RETRIEVAL_TIMEOUT"
+
+const defaultSLIRetrievalTimeout = 10 * time.Minute
+
+// completedSLIResultsRetention is the duration for which completed evaluations are remembered to ignore late SLI results
+const completedSLIResultsRetention = time.Hour
+
+// getSLIRetrievalTimeout returns the duration lighthouse waits for the results of all get-sli.triggered events of an evaluation
+func getSLIRetrievalTimeout() time.Duration {
+	if os.Getenv(sliRetrievalTimeoutEnvVar) != "" {
+		timeout, err := time.ParseDuration(os.Getenv(sliRetrievalTimeoutEnvVar))
+		if err == nil && timeout > 0 {
+			return timeout
+		}
+		logger.Errorf("Invalid value of %s: %s", sliRetrievalTimeoutEnvVar, os.Getenv(sliRetrievalTimeoutEnvVar))
+	}
+	return defaultSLIRetrievalTimeout
+}
+
+// sliProviderIndicators are the indicators retrieved from a single SLI provider.
+// An empty provider refers to the SLI provider configured for the project
+type sliProviderIndicators struct {
+	Provider   string
+	Indicators []string
+}
+
+// getSLIProviderIndicators assigns the given indicators to the SLI providers configured in the SLO file.
+// Indicators that are not assigned to a provider are retrieved from the SLI provider of the project
+func getSLIProviderIndicators(sloConfig *ServiceLevelObjectives, indicators []string) []sliProviderIndicators {
+	if sloConfig == nil || len(sloConfig.SLIProviders) == 0 {
+		return []sliProviderIndicators{{Indicators: indicators}}
+	}
+
+	providers := []string{}
+	for provider := range sloConfig.SLIProviders {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+
+	assignedProviders := map[string]string{}
+	for _, provider := range providers {
+		for _, indicator := range sloConfig.SLIProviders[provider] {
+			if _, ok := assignedProviders[indicator]; !ok {
+				assignedProviders[indicator] = provider
+			}
+		}
+	}
+
+	defaultIndicators := []string{}
+	providerIndicators := map[string][]string{}
+	for _, indicator := range indicators {
+		if provider, ok := assignedProviders[indicator]; ok {
+			providerIndicators[provider] = append(providerIndicators[provider], indicator)
+		} else {
+			defaultIndicators = append(defaultIndicators, indicator)
+		}
+	}
+
+	result := []sliProviderIndicators{}
+	if len(defaultIndicators) > 0 || len(providerIndicators) == 0 {
+		result = append(result, sliProviderIndicators{Indicators: defaultIndicators})
+	}
+	for _, provider := range providers {
+		if len(providerIndicators[provider]) > 0 {
+			result = append(result, sliProviderIndicators{Provider: provider, Indicators: providerIndicators[provider]})
+		}
+	}
+	return result
+}
+
+// getNumberOfSLIRequests returns the number of get-sli.triggered events sent for an evaluation using the given SLO file
+func getNumberOfSLIRequests(sloConfig *ServiceLevelObjectives) int {
+	intervals := 1
+	if sloConfig.Segments.isSegmented() {
+		intervals = sloConfig.Segments.Intervals
+	}
+	return intervals * len(getSLIProviderIndicators(sloConfig, getRequiredIndicators(sloConfig)))
+}
+
+// getMissingSLIProviders returns the SLI providers of the evaluation with the given evaluation.triggered event ID whose results have not been received
+// for at least one interval. The SLI provider configured for the project is referred to by an empty string
+func getMissingSLIProviders(sloConfig *ServiceLevelObjectives, evaluationTriggeredID string, receivedIDs map[string]bool) []string {
+	intervals := 1
+	if sloConfig.Segments.isSegmented() {
+		intervals = sloConfig.Segments.Intervals
+	}
+	// the get-sli.triggered events are sent for each interval of one provider after another
+	missingProviders := []string{}
+	for providerIndex, providerIndicators := range getSLIProviderIndicators(sloConfig, getRequiredIndicators(sloConfig)) {
+		for interval := 0; interval < intervals; interval++ {
+			if !receivedIDs[getSLIRequestID(evaluationTriggeredID, providerIndex*intervals+interval)] {
+				missingProviders = append(missingProviders, providerIndicators.Provider)
+				break
+			}
+		}
+	}
+	return missingProviders
+}
+
+// getSLIRequestID returns the ID of the get-sli.triggered event with the given index that is sent for the evaluation with the given evaluation.triggered event ID.
+// Since the ID is derived from the evaluation, the get-sli.finished events can be assigned to their evaluation without keeping track of the sent events
+func getSLIRequestID(evaluationTriggeredID string, index int) string {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(fmt.Sprintf("%s/get-sli/%d", evaluationTriggeredID, index))).String()
+}
+
+// getSLIRequestIDs returns the IDs of all get-sli.triggered events sent for the evaluation with the given evaluation.triggered event ID
+func getSLIRequestIDs(evaluationTriggeredID string, numberOfSLIRequests int) map[string]bool {
+	ids := map[string]bool{}
+	for index := 0; index < numberOfSLIRequests; index++ {
+		ids[getSLIRequestID(evaluationTriggeredID, index)] = true
+	}
+	return ids
+}
+
+// getEvaluationOfSLIRequest returns the evaluation.triggered event the get-sli.triggered event with the given ID has been sent for, or nil if the
+// get-sli.triggered event does not belong to any of the given evaluations
+func getEvaluationOfSLIRequest(evaluationTriggeredEvents []*models.KeptnContextExtendedCE, sliRequestID string, numberOfSLIRequests int) *models.KeptnContextExtendedCE {
+	for _, evaluationTriggeredEvent := range evaluationTriggeredEvents {
+		if getSLIRequestIDs(evaluationTriggeredEvent.ID, numberOfSLIRequests)[sliRequestID] {
+			return evaluationTriggeredEvent
+		}
+	}
+	return nil
+}
+
+// sliResultCollector collects the get-sli.finished events of evaluations that retrieve their SLIs using several get-sli.triggered events,
+// i.e. segmented evaluations or evaluations using several SLI providers. The evaluations are identified by the ID of their evaluation.triggered event
+type sliResultCollector struct {
+	mutex     sync.Mutex
+	pending   map[string]*pendingSLIResults
+	completed map[string]time.Time
+}
+
+type pendingSLIResults struct {
+	expected int
+	// results contains the received results by the ID of the respective get-sli.triggered event
+	results map[string]*keptnv2.GetSLIFinishedEventData
+	// timer fails the evaluation if not all results are received within the timeout
+	timer *time.Timer
+}
+
+var sliResults = newSLIResultCollector()
+
+func newSLIResultCollector() *sliResultCollector {
+	return &sliResultCollector{
+		pending:   map[string]*pendingSLIResults{},
+		completed: map[string]time.Time{},
+	}
+}
+
+// add stores the results of the evaluation with the given evaluation.triggered event ID. Once the results of all get-sli.triggered events have been received,
+// they are returned ordered by the start of their timeframe. This happens only once per evaluation, i.e. subsequent calls return nil.
+// If not all results are received within the timeout, onTimeout is called with the IDs of the get-sli.triggered events whose results have been received instead
+func (c *sliResultCollector) add(evaluationID string, expected int, results map[string]*keptnv2.GetSLIFinishedEventData, timeout time.Duration, onTimeout func(receivedIDs map[string]bool)) []*keptnv2.GetSLIFinishedEventData {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.pruneCompleted()
+	if _, ok := c.completed[evaluationID]; ok {
+		return nil
+	}
+	pending, ok := c.pending[evaluationID]
+	if !ok {
+		pending = &pendingSLIResults{
+			expected: expected,
+			results:  map[string]*keptnv2.GetSLIFinishedEventData{},
+		}
+		pending.timer = time.AfterFunc(timeout, func() {
+			if receivedIDs := c.expire(evaluationID, timeout); receivedIDs != nil {
+				onTimeout(receivedIDs)
+			}
+		})
+		c.pending[evaluationID] = pending
+	}
+	for triggeredID, result := range results {
+		pending.results[triggeredID] = result
+	}
+	if len(pending.results) < pending.expected {
+		return nil
+	}
+	pending.timer.Stop()
+	return c.complete(evaluationID)
+}
+
+// expire marks the evaluation with the given evaluation.triggered event ID as finished and returns the IDs of the get-sli.triggered events
+// whose results have been received so far. It returns nil if the evaluation has already been completed
+func (c *sliResultCollector) expire(evaluationID string, timeout time.Duration) map[string]bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	pending, ok := c.pending[evaluationID]
+	if !ok {
+		return nil
+	}
+	logger.Warnf("Received %d of %d SLI results for evaluation %s within %s", len(pending.results), pending.expected, evaluationID, timeout.String())
+	receivedIDs := map[string]bool{}
+	for triggeredID := range pending.results {
+		receivedIDs[triggeredID] = true
+	}
+	delete(c.pending, evaluationID)
+	c.completed[evaluationID] = time.Now()
+	return receivedIDs
+}
+
+// complete marks the evaluation as finished and returns its results ordered by the start of their timeframe. It must be called while holding the lock
+func (c *sliResultCollector) complete(evaluationID string) []*keptnv2.GetSLIFinishedEventData {
+	pending := c.pending[evaluationID]
+	delete(c.pending, evaluationID)
+	c.completed[evaluationID] = time.Now()
+
+	triggeredIDs := []string{}
+	for triggeredID := range pending.results {
+		triggeredIDs = append(triggeredIDs, triggeredID)
+	}
+	sort.Strings(triggeredIDs)
+	collected := []*keptnv2.GetSLIFinishedEventData{}
+	for _, triggeredID := range triggeredIDs {
+		collected = append(collected, pending.results[triggeredID])
+	}
+	sort.SliceStable(collected, func(i, j int) bool {
+		return isBefore(collected[i].GetSLI.Start, collected[j].GetSLI.Start)
+	})
+	return collected
+}
+
+// abort marks the evaluation with the given evaluation.triggered event ID as finished, e.g. because the retrieval of some SLIs failed.
+// Results received afterwards are ignored
+func (c *sliResultCollector) abort(evaluationID string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if pending, ok := c.pending[evaluationID]; ok {
+		pending.timer.Stop()
+		delete(c.pending, evaluationID)
+	}
+	c.completed[evaluationID] = time.Now()
+}
+
+func (c *sliResultCollector) pruneCompleted() {
+	for evaluationID, completedAt := range c.completed {
+		if time.Since(completedAt) > completedSLIResultsRetention {
+			delete(c.completed, evaluationID)
+		}
+	}
+}
+
+// mergeSLIResults combines the results of several SLI providers for the same timeframe. For segmented evaluations,
+// the results of the intervals are returned as well
+func mergeSLIResults(e *keptnv2.GetSLIFinishedEventData, results []*keptnv2.GetSLIFinishedEventData, segments *SLOSegments) (*keptnv2.GetSLIFinishedEventData, []*keptnv2.GetSLIFinishedEventData) {
+	timeframes := []*keptnv2.GetSLIFinishedEventData{}
+	for _, result := range results {
+		if len(timeframes) > 0 && timeframes[len(timeframes)-1].GetSLI.Start == result.GetSLI.Start {
+			timeframe := timeframes[len(timeframes)-1]
+			timeframe.GetSLI.IndicatorValues = append(timeframe.GetSLI.IndicatorValues, result.GetSLI.IndicatorValues...)
+			continue
+		}
+		timeframes = append(timeframes, &keptnv2.GetSLIFinishedEventData{
+			EventData: e.EventData,
+			GetSLI: keptnv2.GetSLIFinished{
+				Start:           result.GetSLI.Start,
+				End:             result.GetSLI.End,
+				IndicatorValues: append([]*keptnv2.SLIResult{}, result.GetSLI.IndicatorValues...),
+			},
+		})
+	}
+
+	if segments.isSegmented() {
+		return mergeSegmentResults(e, timeframes, segments), timeframes
+	}
+	return timeframes[0], nil
+}
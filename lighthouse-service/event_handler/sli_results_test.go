@@ -0,0 +1,229 @@
+package event_handler
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/keptn/go-utils/pkg/api/models"
+	keptn "github.com/keptn/go-utils/pkg/lib"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_getSLIProviderIndicators(t *testing.T) {
+	tests := []struct {
+		name       string
+		sloConfig  *ServiceLevelObjectives
+		indicators []string
+		want       []sliProviderIndicators
+	}{
+		{
+			name:       "no SLO file",
+			indicators: []string{},
+			want:       []sliProviderIndicators{{Indicators: []string{}}},
+		},
+		{
+			name:       "no SLI providers configured",
+			sloConfig:  &ServiceLevelObjectives{},
+			indicators: []string{"response_time", "conversion_rate"},
+			want:       []sliProviderIndicators{{Indicators: []string{"response_time", "conversion_rate"}}},
+		},
+		{
+			name: "indicators from several providers",
+			sloConfig: &ServiceLevelObjectives{
+				SLIProviders: map[string][]string{
+					"prometheus":    {"response_time", "throughput"},
+					"business-kpis": {"conversion_rate", "unused"},
+				},
+			},
+			indicators: []string{"response_time", "conversion_rate", "error_rate"},
+			want: []sliProviderIndicators{
+				{Indicators: []string{"error_rate"}},
+				{Provider: "business-kpis", Indicators: []string{"conversion_rate"}},
+				{Provider: "prometheus", Indicators: []string{"response_time"}},
+			},
+		},
+		{
+			name: "all indicators assigned to providers",
+			sloConfig: &ServiceLevelObjectives{
+				SLIProviders: map[string][]string{
+					"prometheus":    {"response_time"},
+					"business-kpis": {"conversion_rate", "response_time"},
+				},
+			},
+			indicators: []string{"response_time", "conversion_rate"},
+			want: []sliProviderIndicators{
+				{Provider: "business-kpis", Indicators: []string{"response_time", "conversion_rate"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, getSLIProviderIndicators(tt.sloConfig, tt.indicators))
+		})
+	}
+}
+
+func Test_getNumberOfSLIRequests(t *testing.T) {
+	sloConfig := &ServiceLevelObjectives{
+		Objectives: []*keptn.SLO{{SLI: "response_time"}, {SLI: "conversion_rate"}},
+	}
+	require.Equal(t, 1, getNumberOfSLIRequests(sloConfig))
+
+	sloConfig.SLIProviders = map[string][]string{"business-kpis": {"conversion_rate"}}
+	require.Equal(t, 2, getNumberOfSLIRequests(sloConfig))
+
+	sloConfig.Segments = &SLOSegments{Intervals: 3}
+	require.Equal(t, 6, getNumberOfSLIRequests(sloConfig))
+}
+
+func Test_getMissingSLIProviders(t *testing.T) {
+	sloConfig := &ServiceLevelObjectives{
+		Objectives:   []*keptn.SLO{{SLI: "response_time"}, {SLI: "conversion_rate"}, {SLI: "throughput"}},
+		SLIProviders: map[string][]string{"business-kpis": {"conversion_rate"}, "prometheus": {"throughput"}},
+		Segments:     &SLOSegments{Intervals: 2},
+	}
+	// the requests are sent for both intervals of the SLI provider of the project, business-kpis and prometheus
+	received := func(indexes ...int) map[string]bool {
+		receivedIDs := map[string]bool{}
+		for _, index := range indexes {
+			receivedIDs[getSLIRequestID("evaluation-1", index)] = true
+		}
+		return receivedIDs
+	}
+
+	require.Equal(t, []string{}, getMissingSLIProviders(sloConfig, "evaluation-1", received(0, 1, 2, 3, 4, 5)))
+	require.Equal(t, []string{"", "prometheus"}, getMissingSLIProviders(sloConfig, "evaluation-1", received(0, 2, 3)))
+	require.Equal(t, []string{"", "business-kpis", "prometheus"}, getMissingSLIProviders(sloConfig, "evaluation-2", received(0, 1, 2, 3, 4, 5)))
+}
+
+func Test_getSLIRetrievalTimeout(t *testing.T) {
+	defer os.Unsetenv(sliRetrievalTimeoutEnvVar)
+
+	require.Equal(t, defaultSLIRetrievalTimeout, getSLIRetrievalTimeout())
+
+	_ = os.Setenv(sliRetrievalTimeoutEnvVar, "2m")
+	require.Equal(t, 2*time.Minute, getSLIRetrievalTimeout())
+
+	_ = os.Setenv(sliRetrievalTimeoutEnvVar, "invalid")
+	require.Equal(t, defaultSLIRetrievalTimeout, getSLIRetrievalTimeout())
+}
+
+func Test_sliResultCollector(t *testing.T) {
+	first := newSegmentResult("2021-01-01T10:00:00.000Z", "2021-01-01T10:10:00.000Z")
+	second := newSegmentResult("2021-01-01T10:10:00.000Z", "2021-01-01T10:20:00.000Z")
+	third := newSegmentResult("2021-01-01T10:00:00.000Z", "2021-01-01T10:10:00.000Z")
+	noTimeout := func(receivedIDs map[string]bool) {
+		t.Error("unexpected timeout")
+	}
+
+	t.Run("all results received", func(t *testing.T) {
+		c := newSLIResultCollector()
+		require.Nil(t, c.add("evaluation-1", 3, map[string]*keptnv2.GetSLIFinishedEventData{"id-2": second}, time.Minute, noTimeout))
+
+		// results retrieved from the event store may contain results that have already been received
+		require.Nil(t, c.add("evaluation-1", 3, map[string]*keptnv2.GetSLIFinishedEventData{"id-2": second, "id-3": third}, time.Minute, noTimeout))
+		collected := c.add("evaluation-1", 3, map[string]*keptnv2.GetSLIFinishedEventData{"id-1": first}, time.Minute, noTimeout)
+		require.Equal(t, []*keptnv2.GetSLIFinishedEventData{first, third, second}, collected)
+
+		// results received after the evaluation are ignored
+		require.Nil(t, c.add("evaluation-1", 3, map[string]*keptnv2.GetSLIFinishedEventData{"id-4": first}, time.Minute, noTimeout))
+	})
+
+	t.Run("evaluations in the same context are collected separately", func(t *testing.T) {
+		c := newSLIResultCollector()
+		require.Nil(t, c.add("evaluation-1", 2, map[string]*keptnv2.GetSLIFinishedEventData{"id-1": first}, time.Minute, noTimeout))
+		require.Nil(t, c.add("evaluation-2", 2, map[string]*keptnv2.GetSLIFinishedEventData{"id-3": third}, time.Minute, noTimeout))
+
+		require.Equal(t, []*keptnv2.GetSLIFinishedEventData{first, second},
+			c.add("evaluation-1", 2, map[string]*keptnv2.GetSLIFinishedEventData{"id-2": second}, time.Minute, noTimeout))
+		require.Equal(t, []*keptnv2.GetSLIFinishedEventData{third, second},
+			c.add("evaluation-2", 2, map[string]*keptnv2.GetSLIFinishedEventData{"id-4": second}, time.Minute, noTimeout))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		c := newSLIResultCollector()
+		timedOut := make(chan map[string]bool, 1)
+		onTimeout := func(receivedIDs map[string]bool) {
+			timedOut <- receivedIDs
+		}
+		// the caller is not blocked until the timeout
+		require.Nil(t, c.add("evaluation-1", 3, map[string]*keptnv2.GetSLIFinishedEventData{"id-1": first}, 10*time.Millisecond, onTimeout))
+
+		select {
+		case receivedIDs := <-timedOut:
+			require.Equal(t, map[string]bool{"id-1": true}, receivedIDs)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for the timeout of the evaluation")
+		}
+		require.Nil(t, c.add("evaluation-1", 3, map[string]*keptnv2.GetSLIFinishedEventData{"id-2": second}, 10*time.Millisecond, onTimeout))
+	})
+
+	t.Run("aborted", func(t *testing.T) {
+		c := newSLIResultCollector()
+		require.Nil(t, c.add("evaluation-1", 2, map[string]*keptnv2.GetSLIFinishedEventData{"id-1": first}, 10*time.Millisecond, noTimeout))
+		c.abort("evaluation-1")
+
+		require.Nil(t, c.add("evaluation-1", 2, map[string]*keptnv2.GetSLIFinishedEventData{"id-2": second}, 10*time.Millisecond, noTimeout))
+		// the evaluation does not time out after it has been aborted
+		time.Sleep(50 * time.Millisecond)
+	})
+}
+
+func Test_getEvaluationOfSLIRequest(t *testing.T) {
+	evaluations := []*models.KeptnContextExtendedCE{{ID: "evaluation-2"}, {ID: "evaluation-1"}}
+
+	require.Equal(t, "evaluation-1", getEvaluationOfSLIRequest(evaluations, getSLIRequestID("evaluation-1", 0), 2).ID)
+	require.Equal(t, "evaluation-1", getEvaluationOfSLIRequest(evaluations, getSLIRequestID("evaluation-1", 1), 2).ID)
+	require.Equal(t, "evaluation-2", getEvaluationOfSLIRequest(evaluations, getSLIRequestID("evaluation-2", 1), 2).ID)
+	require.Nil(t, getEvaluationOfSLIRequest(evaluations, getSLIRequestID("evaluation-1", 2), 2))
+	require.Nil(t, getEvaluationOfSLIRequest(evaluations, "unknown", 2))
+
+	// the IDs are valid UUIDs, which are unique per request and evaluation
+	_, err := uuid.Parse(getSLIRequestID("evaluation-1", 0))
+	require.Nil(t, err)
+	require.Len(t, getSLIRequestIDs("evaluation-1", 3), 3)
+	require.NotEqual(t, getSLIRequestID("evaluation-1", 0), getSLIRequestID("evaluation-2", 0))
+}
+
+func Test_mergeSLIResults(t *testing.T) {
+	e := newSegmentResult("2021-01-01T10:00:00.000Z", "2021-01-01T10:10:00.000Z")
+	e.Labels = map[string]string{"buildId": "1"}
+	results := []*keptnv2.GetSLIFinishedEventData{
+		newSegmentResult("2021-01-01T10:00:00.000Z", "2021-01-01T10:10:00.000Z", &keptnv2.SLIResult{Metric: "response_time", Value: 100, Success: true}),
+		newSegmentResult("2021-01-01T10:00:00.000Z", "2021-01-01T10:10:00.000Z", &keptnv2.SLIResult{Metric: "conversion_rate", Value: 5, Success: true}),
+		newSegmentResult("2021-01-01T10:10:00.000Z", "2021-01-01T10:20:00.000Z", &keptnv2.SLIResult{Metric: "response_time", Value: 300, Success: true}),
+		newSegmentResult("2021-01-01T10:10:00.000Z", "2021-01-01T10:20:00.000Z", &keptnv2.SLIResult{Metric: "conversion_rate", Value: 3, Success: true}),
+	}
+
+	t.Run("several providers", func(t *testing.T) {
+		merged, segments := mergeSLIResults(e, results[:2], nil)
+
+		require.Nil(t, segments)
+		require.Equal(t, e.EventData, merged.EventData)
+		require.Equal(t, "2021-01-01T10:00:00.000Z", merged.GetSLI.Start)
+		require.Equal(t, "2021-01-01T10:10:00.000Z", merged.GetSLI.End)
+		require.Equal(t, []*keptnv2.SLIResult{
+			{Metric: "response_time", Value: 100, Success: true},
+			{Metric: "conversion_rate", Value: 5, Success: true},
+		}, merged.GetSLI.IndicatorValues)
+	})
+
+	t.Run("several providers and intervals", func(t *testing.T) {
+		merged, segments := mergeSLIResults(e, results, &SLOSegments{Intervals: 2})
+
+		require.Len(t, segments, 2)
+		require.Len(t, segments[0].GetSLI.IndicatorValues, 2)
+		require.Len(t, segments[1].GetSLI.IndicatorValues, 2)
+		require.Equal(t, "2021-01-01T10:00:00.000Z", merged.GetSLI.Start)
+		require.Equal(t, "2021-01-01T10:20:00.000Z", merged.GetSLI.End)
+		require.Equal(t, []*keptnv2.SLIResult{
+			{Metric: "response_time", Value: 200, Success: true},
+			{Metric: "conversion_rate", Value: 4, Success: true},
+		}, merged.GetSLI.IndicatorValues)
+		// the results of the providers are not modified
+		require.Len(t, results[0].GetSLI.IndicatorValues, 1)
+	})
+}
@@ -0,0 +1,94 @@
+package event_handler
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// sloListKeys are the lists of an SLO file whose entries are merged by the name of their SLI
+var sloListKeys = map[string]bool{
+	"objectives":          true,
+	"computed_indicators": true,
+}
+
+// mergeSLOFiles deep-merges the given SLO files, ordered from the project level to the service level. Values of lower levels override
+// the ones of higher levels, and objectives as well as computed indicators are merged by the name of their SLI.
+// A single SLO file is returned unchanged
+func mergeSLOFiles(sloFiles []string) ([]byte, error) {
+	if len(sloFiles) == 1 {
+		return []byte(sloFiles[0]), nil
+	}
+
+	merged := map[string]interface{}{}
+	for _, sloFile := range sloFiles {
+		content := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(sloFile), &content); err != nil {
+			return nil, err
+		}
+		merged = mergeSLOMaps(merged, content)
+	}
+	return yaml.Marshal(merged)
+}
+
+func mergeSLOMaps(base, override map[string]interface{}) map[string]interface{} {
+	merged := map[string]interface{}{}
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range override {
+		merged[key] = mergeSLOValues(key, merged[key], value)
+	}
+	return merged
+}
+
+func mergeSLOValues(key string, base, override interface{}) interface{} {
+	switch overrideValue := override.(type) {
+	case map[string]interface{}:
+		if baseValue, ok := base.(map[string]interface{}); ok {
+			return mergeSLOMaps(baseValue, overrideValue)
+		}
+	case []interface{}:
+		if baseValue, ok := base.([]interface{}); ok && sloListKeys[key] {
+			return mergeSLOLists(baseValue, overrideValue)
+		}
+	}
+	return override
+}
+
+// mergeSLOLists merges entries with the same SLI and appends the entries of SLIs that are not contained in the base list
+func mergeSLOLists(base, override []interface{}) []interface{} {
+	merged := append([]interface{}{}, base...)
+	indexes := map[string]int{}
+	for i, entry := range merged {
+		if sli := getSLIName(entry); sli != "" {
+			indexes[sli] = i
+		}
+	}
+	for _, entry := range override {
+		sli := getSLIName(entry)
+		i, ok := indexes[sli]
+		if sli == "" || !ok {
+			merged = append(merged, entry)
+			if sli != "" {
+				indexes[sli] = len(merged) - 1
+			}
+			continue
+		}
+		baseEntry, baseIsMap := merged[i].(map[string]interface{})
+		overrideEntry, overrideIsMap := entry.(map[string]interface{})
+		if baseIsMap && overrideIsMap {
+			merged[i] = mergeSLOMaps(baseEntry, overrideEntry)
+		} else {
+			merged[i] = entry
+		}
+	}
+	return merged
+}
+
+func getSLIName(entry interface{}) string {
+	if values, ok := entry.(map[string]interface{}); ok && values["sli"] != nil {
+		return fmt.Sprintf("%v", values["sli"])
+	}
+	return ""
+}
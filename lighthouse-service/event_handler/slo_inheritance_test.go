@@ -0,0 +1,107 @@
+package event_handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const projectSLOFile = `---
+spec_version: "1.0"
+comparison:
+  compare_with: "several_results"
+  number_of_comparison_results: 3
+objectives:
+  - sli: "response_time_p95"
+    key_sli: true
+    pass:
+      - criteria:
+          - "<=+10%"
+    weight: 2
+  - sli: "error_rate"
+    pass:
+      - criteria:
+          - "<5"
+total_score:
+  pass: "90%"
+  warning: "75%"
+`
+
+const stageSLOFile = `---
+comparison:
+  number_of_comparison_results: 5
+objectives:
+  - sli: "error_rate"
+    pass:
+      - criteria:
+          - "<1"
+`
+
+const serviceSLOFile = `---
+objectives:
+  - sli: "response_time_p95"
+    pass:
+      - criteria:
+          - "<600"
+  - sli: "throughput"
+total_score:
+  warning: "50%"
+`
+
+func Test_mergeSLOFiles(t *testing.T) {
+	t.Run("single SLO file is not modified", func(t *testing.T) {
+		got, err := mergeSLOFiles([]string{serviceSLOFile})
+		require.Nil(t, err)
+		require.Equal(t, serviceSLOFile, string(got))
+	})
+
+	t.Run("project, stage and service level", func(t *testing.T) {
+		got, err := mergeSLOFiles([]string{projectSLOFile, stageSLOFile, serviceSLOFile})
+		require.Nil(t, err)
+
+		slo, err := parseSLO(got)
+		require.Nil(t, err)
+		require.Equal(t, "1.0", slo.SpecVersion)
+		require.Equal(t, &SLOComparison{
+			CompareWith:               "several_results",
+			NumberOfComparisonResults: 5,
+			IncludeResultWithScore:    "all",
+			AggregateFunction:         "avg",
+		}, slo.Comparison)
+		require.Equal(t, "90%", slo.TotalScore.Pass)
+		require.Equal(t, "50%", slo.TotalScore.Warning)
+
+		require.Len(t, slo.Objectives, 3)
+		// the criteria are overridden, all other properties are inherited
+		require.Equal(t, "response_time_p95", slo.Objectives[0].SLI)
+		require.Equal(t, []string{"<600"}, slo.Objectives[0].Pass[0].Criteria)
+		require.True(t, slo.Objectives[0].KeySLI)
+		require.Equal(t, 2, slo.Objectives[0].Weight)
+		require.Equal(t, "error_rate", slo.Objectives[1].SLI)
+		require.Equal(t, []string{"<1"}, slo.Objectives[1].Pass[0].Criteria)
+		require.Equal(t, "throughput", slo.Objectives[2].SLI)
+	})
+
+	t.Run("computed indicators are merged by SLI", func(t *testing.T) {
+		got, err := mergeSLOFiles([]string{
+			"computed_indicators:\n  - sli: error_rate\n    expression: errors / requests\n  - sli: success_rate\n    expression: 1 - errors / requests\n",
+			"computed_indicators:\n  - sli: error_rate\n    expression: errors / requests * 100\n",
+		})
+		require.Nil(t, err)
+
+		content := struct {
+			ComputedIndicators []*ComputedIndicator `yaml:"computed_indicators"`
+		}{}
+		require.Nil(t, yaml.Unmarshal(got, &content))
+		require.Equal(t, []*ComputedIndicator{
+			{SLI: "error_rate", Expression: "errors / requests * 100"},
+			{SLI: "success_rate", Expression: "1 - errors / requests"},
+		}, content.ComputedIndicators)
+	})
+
+	t.Run("invalid SLO file", func(t *testing.T) {
+		_, err := mergeSLOFiles([]string{projectSLOFile, "objectives: ["})
+		require.NotNil(t, err)
+	})
+}
@@ -81,9 +81,7 @@ func (eh *StartEvaluationHandler) sendGetSliCloudEvent(ctx context.Context, kept
 	objectives, err := eh.SLOFileRetriever.GetSLOs(e.Project, e.Stage, e.Service)
 	if err == nil && objectives != nil {
 		logger.Info("SLO file found")
-		for _, objective := range objectives.Objectives {
-			indicators = append(indicators, objective.SLI)
-		}
+		indicators = getRequiredIndicators(objectives)
 
 		if objectives.Filter != nil {
 			for key, value := range objectives.Filter {
@@ -111,41 +109,70 @@ func (eh *StartEvaluationHandler) sendGetSliCloudEvent(ctx context.Context, kept
 		logger.Error("no SLO file found")
 	}
 
+	sliProviders := getSLIProviderIndicators(objectives, indicators)
+
 	// get the SLI provider that has been configured for the project (e.g. 'dynatrace' or 'prometheus') from the respective configmap
 	var sliProvider string
-	sliProvider, err = eh.SLIProviderConfig.GetSLIProvider(e.Project)
-	if err != nil {
-		// no provider found - fallback to default SLI provider
-		sliProvider, err = eh.SLIProviderConfig.GetDefaultSLIProvider()
+	if sliProviders[0].Provider == "" {
+		sliProvider, err = eh.SLIProviderConfig.GetSLIProvider(e.Project)
 		if err != nil {
-			// no default SLI provider configured
-			logger.Error("no SLI-provider configured for project " + e.Project + ", no evaluation conducted")
-			evaluationDetails := keptnv2.EvaluationDetails{
-				IndicatorResults: nil,
-				TimeStart:        evaluationStartTimestamp,
-				TimeEnd:          evaluationEndTimestamp,
-				Result:           string(keptnv2.ResultPass),
-			}
+			// no provider found - fallback to default SLI provider
+			sliProvider, err = eh.SLIProviderConfig.GetDefaultSLIProvider()
+			if err != nil {
+				// no default SLI provider configured
+				logger.Error("no SLI-provider configured for project " + e.Project + ", no evaluation conducted")
+				evaluationDetails := keptnv2.EvaluationDetails{
+					IndicatorResults: nil,
+					TimeStart:        evaluationStartTimestamp,
+					TimeEnd:          evaluationEndTimestamp,
+					Result:           string(keptnv2.ResultPass),
+				}
+
+				evaluationFinishedData := keptnv2.EvaluationFinishedEventData{
+					EventData: keptnv2.EventData{
+						Project: e.Project,
+						Stage:   e.Stage,
+						Service: e.Service,
+						Labels:  e.Labels,
+						Status:  keptnv2.StatusSucceeded,
+						Result:  keptnv2.ResultPass,
+						Message: fmt.Sprintf("no evaluation performed by lighthouse because no SLI-provider configured for project %s", e.Project),
+					},
+					Evaluation: evaluationDetails,
+				}
 
-			evaluationFinishedData := keptnv2.EvaluationFinishedEventData{
-				EventData: keptnv2.EventData{
-					Project: e.Project,
-					Stage:   e.Stage,
-					Service: e.Service,
-					Labels:  e.Labels,
-					Status:  keptnv2.StatusSucceeded,
-					Result:  keptnv2.ResultPass,
-					Message: fmt.Sprintf("no evaluation performed by lighthouse because no SLI-provider configured for project %s", e.Project),
-				},
-				Evaluation: evaluationDetails,
+				return sendEvent(keptnContext, eh.Event.ID(), keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName), eh.KeptnHandler, &evaluationFinishedData)
 			}
+		}
+	}
 
-			return sendEvent(keptnContext, eh.Event.ID(), keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName), eh.KeptnHandler, &evaluationFinishedData)
+	// send new events to trigger the SLI retrieval, one for each SLI provider and interval
+	timeframes := []evaluationTimeframe{{Start: evaluationStartTimestamp, End: evaluationEndTimestamp}}
+	if objectives != nil && objectives.Segments.isSegmented() {
+		timeframes, err = getEvaluationSegments(evaluationStartTimestamp, evaluationEndTimestamp, objectives.Segments.Intervals)
+		if err != nil {
+			return eh.sendEvaluationFinishedWithErrorEvent(evaluationStartTimestamp, evaluationEndTimestamp, e, fmt.Sprintf("could not split evaluation timeframe: %s", err.Error()))
+		}
+	}
+	requestIndex := 0
+	for _, providerIndicators := range sliProviders {
+		provider := providerIndicators.Provider
+		if provider == "" {
+			provider = sliProvider
+		}
+		logger.Debugf("SLI provider for indicators %v of project %s is: %s", providerIndicators.Indicators, e.Project, provider)
+		for _, timeframe := range timeframes {
+			sliRequestID := getSLIRequestID(eh.Event.ID(), requestIndex)
+			requestIndex++
+			if err := eh.sendInternalGetSLIEvent(keptnContext, sliRequestID, e, provider, providerIndicators.Indicators, timeframe.Start, timeframe.End, filters); err != nil {
+				// the evaluation cannot be completed without the SLIs of the request, and the results of the requests that have already been sent must not trigger an evaluation
+				sliResults.abort(eh.Event.ID())
+				message := fmt.Sprintf("could not send get-sli.triggered event for SLI provider %s: %s", provider, err.Error())
+				logger.Error(message)
+				return eh.sendEvaluationFinishedWithErrorEvent(evaluationStartTimestamp, evaluationEndTimestamp, e, message)
+			}
 		}
 	}
-	// send a new event to trigger the SLI retrieval
-	logger.Debug("SLI provider for project " + e.Project + " is: " + sliProvider)
-	err = eh.sendInternalGetSLIEvent(keptnContext, e, sliProvider, indicators, evaluationStartTimestamp, evaluationEndTimestamp, filters)
 	return nil
 }
 
@@ -192,7 +219,7 @@ func getEvaluationTimestamps(e *keptnv2.EvaluationTriggeredEventData) (string, s
 	return "", "", errors.New("evaluation.triggered event does not contain evaluation timeframe")
 }
 
-func (eh *StartEvaluationHandler) sendInternalGetSLIEvent(shkeptncontext string, e *keptnv2.EvaluationTriggeredEventData, sliProvider string, indicators []string, start string, end string, filters []*keptnv2.SLIFilter) error {
+func (eh *StartEvaluationHandler) sendInternalGetSLIEvent(shkeptncontext string, eventID string, e *keptnv2.EvaluationTriggeredEventData, sliProvider string, indicators []string, start string, end string, filters []*keptnv2.SLIFilter) error {
 	source, _ := url.Parse("lighthouse-service")
 
 	getSLITriggeredEventData := keptnv2.GetSLITriggeredEventData{
@@ -216,6 +243,7 @@ func (eh *StartEvaluationHandler) sendInternalGetSLIEvent(shkeptncontext string,
 	}
 
 	event := cloudevents.NewEvent()
+	event.SetID(eventID)
 	event.SetType(keptnv2.GetTriggeredEventType(keptnv2.GetSLITaskName))
 	event.SetSource(source.String())
 	event.SetDataContentType(cloudevents.ApplicationJSON)
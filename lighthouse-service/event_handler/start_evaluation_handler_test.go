@@ -27,6 +27,7 @@ import (
 	keptnapi "github.com/keptn/go-utils/pkg/api/models"
 	keptncommon "github.com/keptn/go-utils/pkg/lib/keptn"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	keptnfake "github.com/keptn/go-utils/pkg/lib/v0_2_0/fake"
 )
 
 const TEST_PORT = 8370
@@ -141,9 +142,17 @@ func TestStartEvaluationHandler_HandleEvent(t *testing.T) {
 			fields: fields{
 				Event: getStartEvaluationEvent(),
 				SLOFileRetriever: SLOFileRetriever{
-					ResourceHandler: &event_handler_mock.ResourceHandlerMock{GetServiceResourceFunc: func(project string, stage string, service string, resourceURI string) (*keptnapi.Resource, error) {
-						return nil, nil
-					}},
+					ResourceHandler: &event_handler_mock.ResourceHandlerMock{
+						GetProjectResourceFunc: func(project string, resourceURI string) (*keptnapi.Resource, error) {
+							return nil, errors.New("resource not found")
+						},
+						GetStageResourceFunc: func(project string, stage string, resourceURI string) (*keptnapi.Resource, error) {
+							return nil, errors.New("resource not found")
+						},
+						GetServiceResourceFunc: func(project string, stage string, service string, resourceURI string) (*keptnapi.Resource, error) {
+							return nil, nil
+						},
+					},
 				},
 			},
 			sloAvailable:  false,
@@ -448,3 +457,66 @@ func Test_getEvaluationTimestamps(t *testing.T) {
 		})
 	}
 }
+
+func TestStartEvaluationHandler_FailsEvaluationIfSLIRequestCannotBeSent(t *testing.T) {
+	event := getStartEvaluationEvent()
+	event.SetID("my-evaluation-id")
+	eventSender := &keptnfake.EventSender{}
+	sentRequests := 0
+	eventSender.AddReactor(keptnv2.GetTriggeredEventType(keptnv2.GetSLITaskName), func(event cloudevents.Event) error {
+		sentRequests++
+		if sentRequests == 2 {
+			return errors.New("event broker not available")
+		}
+		return nil
+	})
+	keptnHandler, _ := keptnv2.NewKeptn(&event, keptncommon.KeptnOpts{EventSender: eventSender})
+	resourceHandler := &event_handler_mock.ResourceHandlerMock{
+		GetServiceResourceFunc: func(project string, stage string, service string, resourceURI string) (*keptnapi.Resource, error) {
+			return &keptnapi.Resource{ResourceURI: stringp(resourceURI), ResourceContent: `---
+spec_version: '1.0'
+segments:
+  intervals: 3
+objectives:
+  - sli: response_time`}, nil
+		},
+		GetProjectResourceFunc: func(project string, resourceURI string) (*keptnapi.Resource, error) {
+			return nil, errors.New("resource not found")
+		},
+		GetStageResourceFunc: func(project string, stage string, resourceURI string) (*keptnapi.Resource, error) {
+			return nil, errors.New("resource not found")
+		},
+	}
+	eh := &StartEvaluationHandler{
+		Event:        event,
+		KeptnHandler: keptnHandler,
+		SLIProviderConfig: &MockSLIProviderConfig{
+			ProjectSLIProvider: struct {
+				val string
+				err error
+			}{val: "my-sli-provider"},
+		},
+		SLOFileRetriever: SLOFileRetriever{ResourceHandler: resourceHandler},
+	}
+
+	require.Nil(t, eh.sendGetSliCloudEvent(context.Background(), "my-context", &keptnv2.EvaluationTriggeredEventData{
+		EventData: keptnv2.EventData{Project: "sockshop", Stage: "staging", Service: "carts"},
+	}, "2021-01-01T10:00:00.000Z", "2021-01-01T10:30:00.000Z"))
+
+	// no further requests are sent, and the evaluation fails
+	require.Nil(t, eventSender.AssertSentEventTypes([]string{
+		keptnv2.GetTriggeredEventType(keptnv2.GetSLITaskName),
+		keptnv2.GetFinishedEventType(keptnv2.EvaluationTaskName),
+	}))
+	finishedEventData := &keptnv2.EvaluationFinishedEventData{}
+	require.Nil(t, eventSender.SentEvents[1].DataAs(finishedEventData))
+	require.Equal(t, keptnv2.ResultFailed, finishedEventData.Result)
+	require.Equal(t, keptnv2.StatusErrored, finishedEventData.Status)
+	require.Contains(t, finishedEventData.Message, "event broker not available")
+
+	// the result of the request that has been sent does not trigger an evaluation
+	require.Nil(t, sliResults.add("my-evaluation-id", 3, map[string]*keptnv2.GetSLIFinishedEventData{getSLIRequestID("my-evaluation-id", 0): {}}, time.Minute, nil))
+
+	// the ID of the get-sli.triggered event is derived from the evaluation
+	require.Equal(t, getSLIRequestID("my-evaluation-id", 0), eventSender.SentEvents[0].ID())
+}
@@ -5,6 +5,7 @@ go 1.16
 require (
 	github.com/cloudevents/sdk-go/v2 v2.6.1
 	github.com/go-test/deep v1.0.8
+	github.com/google/uuid v1.3.0
 	github.com/kelseyhightower/envconfig v1.4.0
 	github.com/keptn/go-utils v0.11.1-0.20211215105940-5626bf92b8c6
 	github.com/nats-io/nats-server/v2 v2.6.6
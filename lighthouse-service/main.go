@@ -3,8 +3,10 @@ package main
 import (
 	"context"
 	keptnapi "github.com/keptn/go-utils/pkg/api/utils"
+	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"sync"
 	"syscall"
 
@@ -43,7 +45,16 @@ func main() {
 func _main(args []string, env envConfig) int {
 	ctx := getGracefulContext()
 
-	p, err := cloudevents.NewHTTP(cloudevents.WithPath(env.Path), cloudevents.WithPort(env.Port), cloudevents.WithGetHandlerFunc(keptnapi.HealthEndpointHandler))
+	errorBudgetHandler, err := event_handler.NewErrorBudgetAPIHandler()
+	if err != nil {
+		logger.Fatalf("failed to create error budget handler, %v", err)
+	}
+	apiHandlers := map[string]http.Handler{
+		event_handler.ErrorBudgetAPIPath:      errorBudgetHandler,
+		event_handler.DryRunEvaluationAPIPath: &event_handler.DryRunEvaluationAPIHandler{},
+	}
+
+	p, err := cloudevents.NewHTTP(cloudevents.WithPath(env.Path), cloudevents.WithPort(env.Port), cloudevents.WithGetHandlerFunc(keptnapi.HealthEndpointHandler), cloudevents.WithMiddleware(apiMiddleware(apiHandlers)))
 	if err != nil {
 		logger.Fatalf("failed to create client, %v", err)
 	}
@@ -62,6 +73,19 @@ func _main(args []string, env envConfig) int {
 	return 0
 }
 
+// apiMiddleware serves the APIs of lighthouse and passes all other requests to the cloudevents receiver
+func apiMiddleware(handlers map[string]http.Handler) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if handler, ok := handlers[strings.TrimSuffix(r.URL.Path, "/")]; ok {
+				handler.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 func gotEvent(ctx context.Context, event cloudevents.Event) error {
 	var shkeptncontext string
 	_ = event.Context.ExtensionAs("shkeptncontext", &shkeptncontext)
@@ -0,0 +1,426 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Condition is a boolean expression that can be evaluated against the data of events.
+// Supported are comparisons of properties and literals using the operators ==, !=, <, <=, > and >=,
+// as well as combinations of expressions using &&, || and !. Properties are referenced by their path within the event data,
+// e.g. 'evaluation.result == "warning" && labels.team == "a"'
+type Condition struct {
+	expression string
+	root       conditionNode
+}
+
+// ParseCondition parses the given expression. An error is returned if the expression is not syntactically valid
+func ParseCondition(expression string) (*Condition, error) {
+	tokens, err := tokenizeCondition(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid condition '%s': %w", expression, err)
+	}
+	p := &conditionParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid condition '%s': %w", expression, err)
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("invalid condition '%s': unexpected token '%s'", expression, p.peek().value)
+	}
+	return &Condition{expression: expression, root: root}, nil
+}
+
+// EvaluateCondition parses the given expression and evaluates it against the given data
+func EvaluateCondition(expression string, data interface{}) (bool, error) {
+	condition, err := ParseCondition(expression)
+	if err != nil {
+		return false, err
+	}
+	return condition.Evaluate(data)
+}
+
+// Evaluate evaluates the condition against the given data
+func (c *Condition) Evaluate(data interface{}) (bool, error) {
+	value, err := c.root.evaluate(data)
+	if err != nil {
+		return false, fmt.Errorf("could not evaluate condition '%s': %w", c.expression, err)
+	}
+	return isTruthy(value), nil
+}
+
+// String returns the expression of the condition
+func (c *Condition) String() string {
+	return c.expression
+}
+
+type conditionTokenType int
+
+const (
+	tokenOperator conditionTokenType = iota
+	tokenString
+	tokenNumber
+	tokenIdentifier
+	tokenParenOpen
+	tokenParenClose
+)
+
+type conditionToken struct {
+	tokenType conditionTokenType
+	value     string
+}
+
+var conditionOperators = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!"}
+
+func tokenizeCondition(expression string) ([]conditionToken, error) {
+	tokens := []conditionToken{}
+	runes := []rune(expression)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, conditionToken{tokenType: tokenParenOpen, value: "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, conditionToken{tokenType: tokenParenClose, value: ")"})
+			i++
+		case r == '"' || r == '\'':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("unterminated string literal at position %d", i)
+			}
+			tokens = append(tokens, conditionToken{tokenType: tokenString, value: string(runes[i+1 : end])})
+			i = end + 1
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			end := i + 1
+			for end < len(runes) && (unicode.IsDigit(runes[end]) || runes[end] == '.') {
+				end++
+			}
+			tokens = append(tokens, conditionToken{tokenType: tokenNumber, value: string(runes[i:end])})
+			i = end
+		case unicode.IsLetter(r) || r == '_' || r == '$':
+			end := i + 1
+			for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end]) || strings.ContainsRune("_.-/$", runes[end])) {
+				end++
+			}
+			tokens = append(tokens, conditionToken{tokenType: tokenIdentifier, value: string(runes[i:end])})
+			i = end
+		default:
+			operator := ""
+			for _, op := range conditionOperators {
+				if strings.HasPrefix(string(runes[i:]), op) {
+					operator = op
+					break
+				}
+			}
+			if operator == "" {
+				return nil, fmt.Errorf("unexpected character '%c' at position %d", r, i)
+			}
+			tokens = append(tokens, conditionToken{tokenType: tokenOperator, value: operator})
+			i += len(operator)
+		}
+	}
+	return tokens, nil
+}
+
+type conditionParser struct {
+	tokens []conditionToken
+	pos    int
+}
+
+func (p *conditionParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *conditionParser) peek() conditionToken {
+	if p.done() {
+		return conditionToken{}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *conditionParser) isOperator(values ...string) bool {
+	token := p.peek()
+	if token.tokenType != tokenOperator && token.tokenType != tokenIdentifier {
+		return false
+	}
+	for _, value := range values {
+		if token.value == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *conditionParser) parseOr() (conditionNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for !p.done() && p.isOperator("||") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{operator: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *conditionParser) parseAnd() (conditionNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for !p.done() && p.isOperator("&&") {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalNode{operator: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *conditionParser) parseUnary() (conditionNode, error) {
+	if p.peek().tokenType == tokenOperator && p.peek().value == "!" {
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *conditionParser) parseComparison() (conditionNode, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() && p.isOperator(comparisonOperators...) {
+		operator := p.peek().value
+		p.pos++
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		return &comparisonNode{operator: operator, left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *conditionParser) parsePrimary() (conditionNode, error) {
+	if p.done() {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	token := p.peek()
+	p.pos++
+	switch token.tokenType {
+	case tokenParenOpen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().tokenType != tokenParenClose {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return node, nil
+	case tokenString:
+		return &literalNode{value: token.value}, nil
+	case tokenNumber:
+		number, err := strconv.ParseFloat(token.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number '%s'", token.value)
+		}
+		return &literalNode{value: number}, nil
+	case tokenIdentifier:
+		switch token.value {
+		case "true":
+			return &literalNode{value: true}, nil
+		case "false":
+			return &literalNode{value: false}, nil
+		case "null", "nil":
+			return &literalNode{value: nil}, nil
+		}
+		return &propertyNode{path: strings.Split(strings.TrimPrefix(token.value, "$."), ".")}, nil
+	}
+	return nil, fmt.Errorf("unexpected token '%s'", token.value)
+}
+
+type conditionNode interface {
+	evaluate(data interface{}) (interface{}, error)
+}
+
+type literalNode struct {
+	value interface{}
+}
+
+func (n *literalNode) evaluate(interface{}) (interface{}, error) {
+	return n.value, nil
+}
+
+type propertyNode struct {
+	path []string
+}
+
+func (n *propertyNode) evaluate(data interface{}) (interface{}, error) {
+	return GetValueByPath(data, n.path), nil
+}
+
+type notNode struct {
+	operand conditionNode
+}
+
+func (n *notNode) evaluate(data interface{}) (interface{}, error) {
+	value, err := n.operand.evaluate(data)
+	if err != nil {
+		return nil, err
+	}
+	return !isTruthy(value), nil
+}
+
+type logicalNode struct {
+	operator string
+	left     conditionNode
+	right    conditionNode
+}
+
+func (n *logicalNode) evaluate(data interface{}) (interface{}, error) {
+	left, err := n.left.evaluate(data)
+	if err != nil {
+		return nil, err
+	}
+	if n.operator == "&&" && !isTruthy(left) {
+		return false, nil
+	}
+	if n.operator == "||" && isTruthy(left) {
+		return true, nil
+	}
+	right, err := n.right.evaluate(data)
+	if err != nil {
+		return nil, err
+	}
+	return isTruthy(right), nil
+}
+
+var comparisonOperators = []string{"==", "!=", "<", "<=", ">", ">="}
+
+type comparisonNode struct {
+	operator string
+	left     conditionNode
+	right    conditionNode
+}
+
+func (n *comparisonNode) evaluate(data interface{}) (interface{}, error) {
+	left, err := n.left.evaluate(data)
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.right.evaluate(data)
+	if err != nil {
+		return nil, err
+	}
+	return compareValues(n.operator, left, right)
+}
+
+func compareValues(operator string, left, right interface{}) (bool, error) {
+	switch operator {
+	case "==":
+		return valuesEqual(left, right), nil
+	case "!=":
+		return !valuesEqual(left, right), nil
+	}
+	if left == nil || right == nil {
+		// properties that are not available cannot be ordered
+		return false, nil
+	}
+	leftNumber, leftIsNumber := toNumber(left)
+	rightNumber, rightIsNumber := toNumber(right)
+	if leftIsNumber && rightIsNumber {
+		switch operator {
+		case "<":
+			return leftNumber < rightNumber, nil
+		case "<=":
+			return leftNumber <= rightNumber, nil
+		case ">":
+			return leftNumber > rightNumber, nil
+		case ">=":
+			return leftNumber >= rightNumber, nil
+		}
+	}
+	return false, fmt.Errorf("operator %s can only be applied to numbers, but got %v and %v", operator, left, right)
+}
+
+func valuesEqual(left, right interface{}) bool {
+	if left == nil || right == nil {
+		return left == nil && right == nil
+	}
+	leftNumber, leftIsNumber := toNumber(left)
+	rightNumber, rightIsNumber := toNumber(right)
+	if leftIsNumber && rightIsNumber {
+		return leftNumber == rightNumber
+	}
+	return fmt.Sprintf("%v", left) == fmt.Sprintf("%v", right)
+}
+
+func toNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case string:
+		number, err := strconv.ParseFloat(v, 64)
+		return number, err == nil
+	}
+	return 0, false
+}
+
+func isTruthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != "" && v != "false"
+	case float64:
+		return v != 0
+	}
+	return true
+}
+
+// GetValueByPath returns the value of the property with the given path within the data.
+// If the property does not exist, nil is returned
+func GetValueByPath(data interface{}, path []string) interface{} {
+	current := data
+	for _, key := range path {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current, ok = object[key]
+		if !ok {
+			return nil
+		}
+	}
+	return current
+}
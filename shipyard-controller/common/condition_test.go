@@ -0,0 +1,63 @@
+package common
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestEvaluateCondition(t *testing.T) {
+	data := map[string]interface{}{
+		"result": "pass",
+		"evaluation": map[string]interface{}{
+			"result": "warning",
+			"score":  float64(75),
+		},
+		"labels": map[string]interface{}{
+			"team":       "a",
+			"my-label":   "my-value",
+			"prioritize": "true",
+		},
+		"deployment": map[string]interface{}{
+			"deploymentstrategy": "blue_green_service",
+		},
+	}
+
+	tests := []struct {
+		name       string
+		expression string
+		want       bool
+		wantErr    bool
+	}{
+		{name: "string equality", expression: `evaluation.result == "warning"`, want: true},
+		{name: "string equality with single quotes", expression: `evaluation.result == 'warning'`, want: true},
+		{name: "string inequality", expression: `evaluation.result != "warning"`, want: false},
+		{name: "numeric comparison", expression: `evaluation.score >= 75`, want: true},
+		{name: "numeric comparison - less than", expression: `evaluation.score < 50`, want: false},
+		{name: "logical and", expression: `result == "pass" && labels.team == "a"`, want: true},
+		{name: "logical or", expression: `result == "fail" || labels.team == "a"`, want: true},
+		{name: "negation", expression: `!(result == "fail")`, want: true},
+		{name: "label key with dash", expression: `labels.my-label == "my-value"`, want: true},
+		{name: "property is truthy", expression: `labels.prioritize`, want: true},
+		{name: "missing property is not truthy", expression: `labels.unknown`, want: false},
+		{name: "missing property equals null", expression: `labels.unknown == null`, want: true},
+		{name: "missing property cannot be ordered", expression: `unknown.score > 10`, want: false},
+		{name: "nested parentheses", expression: `(result == "fail" || (evaluation.result == "warning" && evaluation.score > 70))`, want: true},
+		{name: "ordering of strings is not supported", expression: `result > 10`, wantErr: true},
+		{name: "unterminated string", expression: `result == "pass`, wantErr: true},
+		{name: "missing closing parenthesis", expression: `(result == "pass"`, wantErr: true},
+		{name: "missing operand", expression: `result ==`, wantErr: true},
+		{name: "unexpected token", expression: `result == "pass" "fail"`, wantErr: true},
+		{name: "invalid character", expression: `result = "pass"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EvaluateCondition(tt.expression, data)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule is a parsed cron expression consisting of the five fields 'minute hour day-of-month month day-of-week'.
+// Each field supports '*', single values, ranges (e.g. '1-5'), steps (e.g. '*/15' or '0-30/10') and comma separated lists.
+// Months and days of the week can also be given by their three letter names (e.g. 'jan' or 'mon').
+// Additionally, the descriptors '@yearly', '@annually', '@monthly', '@weekly', '@daily', '@midnight' and '@hourly' are supported.
+// All times are evaluated in UTC
+type CronSchedule struct {
+	minutes     uint64
+	hours       uint64
+	daysOfMonth uint64
+	months      uint64
+	daysOfWeek  uint64
+	// if both, day of month and day of week are restricted, a day matches if either of them matches
+	dayOfMonthRestricted bool
+	dayOfWeekRestricted  bool
+}
+
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6, "jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}},
+	{name: "day of week", min: 0, max: 6, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}},
+}
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseCronSchedule parses the given cron expression. An error is returned if the expression is not valid
+func ParseCronSchedule(expression string) (*CronSchedule, error) {
+	expression = strings.TrimSpace(expression)
+	if descriptor, ok := cronDescriptors[strings.ToLower(expression)]; ok {
+		expression = descriptor
+	}
+
+	fields := strings.Fields(expression)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("invalid cron expression '%s': expected %d fields, but got %d", expression, len(cronFields), len(fields))
+	}
+
+	values := make([]uint64, len(cronFields))
+	for i, field := range fields {
+		value, err := parseCronField(strings.ToLower(field), cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression '%s': %w", expression, err)
+		}
+		values[i] = value
+	}
+
+	return &CronSchedule{
+		minutes:              values[0],
+		hours:                values[1],
+		daysOfMonth:          values[2],
+		months:               values[3],
+		daysOfWeek:           values[4],
+		dayOfMonthRestricted: fields[2] != "*",
+		dayOfWeekRestricted:  fields[4] != "*",
+	}, nil
+}
+
+// Next returns the first point in time after the given time that matches the schedule.
+// A zero time is returned if there is no such point in time within the next five years (e.g. for '0 0 30 2 *')
+func (c *CronSchedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if !hasBit(c.months, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !c.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !hasBit(c.hours, t.Hour()) {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if !hasBit(c.minutes, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (c *CronSchedule) matchesDay(t time.Time) bool {
+	dayOfMonth := hasBit(c.daysOfMonth, t.Day())
+	dayOfWeek := hasBit(c.daysOfWeek, int(t.Weekday()))
+	if c.dayOfMonthRestricted && c.dayOfWeekRestricted {
+		return dayOfMonth || dayOfWeek
+	}
+	return dayOfMonth && dayOfWeek
+}
+
+func parseCronField(field string, definition cronField) (uint64, error) {
+	var result uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rangePart = part[:i]
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step '%s' in %s field", part[i+1:], definition.name)
+			}
+		}
+
+		start, end := definition.min, definition.max
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if start, err = parseCronValue(bounds[0], definition); err != nil {
+				return 0, err
+			}
+			end = start
+			if len(bounds) == 2 {
+				if end, err = parseCronValue(bounds[1], definition); err != nil {
+					return 0, err
+				}
+			} else if step > 1 {
+				// 'n/step' means 'n-max/step'
+				end = definition.max
+			}
+			if start > end {
+				return 0, fmt.Errorf("invalid range '%s' in %s field", rangePart, definition.name)
+			}
+		}
+
+		for value := start; value <= end; value += step {
+			result |= 1 << uint(value)
+		}
+	}
+	if definition.name == "day of week" && hasBit(result, 7) {
+		result |= 1
+	}
+	return result, nil
+}
+
+func parseCronValue(value string, definition cronField) (int, error) {
+	if number, ok := definition.names[value]; ok {
+		return number, nil
+	}
+	number, err := strconv.Atoi(value)
+	max := definition.max
+	if definition.name == "day of week" {
+		// '7' is an alias for sunday
+		max = 7
+	}
+	if err != nil || number < definition.min || number > max {
+		return 0, fmt.Errorf("invalid value '%s' in %s field: must be between %d and %d", value, definition.name, definition.min, definition.max)
+	}
+	return number, nil
+}
+
+func hasBit(bits uint64, position int) bool {
+	return bits&(1<<uint(position)) != 0
+}
@@ -0,0 +1,61 @@
+package common
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestCronSchedule_Next(t *testing.T) {
+	// 2021-12-15 is a wednesday
+	from := time.Date(2021, 12, 15, 10, 32, 20, 0, time.UTC)
+
+	tests := []struct {
+		expression string
+		want       time.Time
+	}{
+		{expression: "* * * * *", want: time.Date(2021, 12, 15, 10, 33, 0, 0, time.UTC)},
+		{expression: "*/15 * * * *", want: time.Date(2021, 12, 15, 10, 45, 0, 0, time.UTC)},
+		{expression: "0 2 * * *", want: time.Date(2021, 12, 16, 2, 0, 0, 0, time.UTC)},
+		{expression: "30 8-18/2 * * *", want: time.Date(2021, 12, 15, 12, 30, 0, 0, time.UTC)},
+		{expression: "0 9 * * mon-fri", want: time.Date(2021, 12, 16, 9, 0, 0, 0, time.UTC)},
+		{expression: "0 9 * * 6,7", want: time.Date(2021, 12, 18, 9, 0, 0, 0, time.UTC)},
+		{expression: "0 0 1 jan *", want: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{expression: "0 0 29 2 *", want: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// day of month and day of week are combined with OR if both are restricted
+		{expression: "0 0 20 * 5", want: time.Date(2021, 12, 17, 0, 0, 0, 0, time.UTC)},
+		{expression: "@hourly", want: time.Date(2021, 12, 15, 11, 0, 0, 0, time.UTC)},
+		{expression: "@weekly", want: time.Date(2021, 12, 19, 0, 0, 0, 0, time.UTC)},
+		{expression: "0 0 30 2 *", want: time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			schedule, err := ParseCronSchedule(tt.expression)
+			require.Nil(t, err)
+			require.Equal(t, tt.want, schedule.Next(from))
+		})
+	}
+}
+
+func TestParseCronSchedule_Invalid(t *testing.T) {
+	tests := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"10-5 * * * *",
+		"foo * * * *",
+		"@every 5m",
+	}
+	for _, expression := range tests {
+		t.Run(expression, func(t *testing.T) {
+			_, err := ParseCronSchedule(expression)
+			require.NotNil(t, err)
+		})
+	}
+}
@@ -5,55 +5,81 @@ import (
 	"sync"
 )
 
-var mutex = &sync.Mutex{}
+// Locker provides mutual exclusion for arbitrary keys
+type Locker interface {
+	// Lock blocks until the lock for the given key has been acquired, or returns an error if it could not be acquired
+	Lock(key string) error
+	Unlock(key string)
+}
+
+// LocalLocker is a Locker that is backed by a map of sync.Mutex objects and therefore only provides
+// mutual exclusion within the current process
+type LocalLocker struct {
+	mutex sync.Mutex
+	locks map[string]*sync.Mutex
+}
 
-var projectLocks = map[string]*sync.Mutex{}
+// NewLocalLocker creates a new LocalLocker
+func NewLocalLocker() *LocalLocker {
+	return &LocalLocker{
+		locks: map[string]*sync.Mutex{},
+	}
+}
 
-// Lock locks the mutex
-func Lock() {
-	mutex.Lock()
+// Lock locks the mutex for the given key
+func (l *LocalLocker) Lock(key string) error {
+	l.getMutex(key).Lock()
+	return nil
 }
 
-// Unlock unlocks the mutex
-func Unlock() {
-	mutex.Unlock()
+// Unlock unlocks the mutex for the given key
+func (l *LocalLocker) Unlock(key string) {
+	l.getMutex(key).Unlock()
 }
 
-// LockProject
-func LockProject(project string) {
-	if projectLocks[project] == nil {
-		Lock()
-		projectLocks[project] = &sync.Mutex{}
-		Unlock()
+func (l *LocalLocker) getMutex(key string) *sync.Mutex {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.locks[key] == nil {
+		l.locks[key] = &sync.Mutex{}
 	}
-	projectLocks[project].Lock()
+	return l.locks[key]
+}
+
+var lockerMutex = &sync.RWMutex{}
+
+var locker Locker = NewLocalLocker()
+
+// SetLocker sets the Locker that is used by the LockProject and LockServiceInStageOfProject functions.
+// By default, a LocalLocker is used
+func SetLocker(l Locker) {
+	lockerMutex.Lock()
+	defer lockerMutex.Unlock()
+	locker = l
+}
+
+func getLocker() Locker {
+	lockerMutex.RLock()
+	defer lockerMutex.RUnlock()
+	return locker
+}
+
+// LockProject locks the given project
+func LockProject(project string) error {
+	return getLocker().Lock(project)
 }
 
+// UnlockProject unlocks the given project
 func UnlockProject(project string) {
-	if projectLocks[project] == nil {
-		Lock()
-		projectLocks[project] = &sync.Mutex{}
-		Unlock()
-	}
-	projectLocks[project].Unlock()
+	getLocker().Unlock(project)
 }
 
-func LockServiceInStageOfProject(project, stage, service string) {
-	lockKey := fmt.Sprintf("%s.%s.%s", project, stage, service)
-	if projectLocks[lockKey] == nil {
-		Lock()
-		projectLocks[lockKey] = &sync.Mutex{}
-		Unlock()
-	}
-	projectLocks[lockKey].Lock()
+// LockServiceInStageOfProject locks the given service in the stage of a project
+func LockServiceInStageOfProject(project, stage, service string) error {
+	return getLocker().Lock(fmt.Sprintf("%s.%s.%s", project, stage, service))
 }
 
+// UnlockServiceInStageOfProject unlocks the given service in the stage of a project
 func UnlockServiceInStageOfProject(project, stage, service string) {
-	lockKey := fmt.Sprintf("%s.%s.%s", project, stage, service)
-	if projectLocks[lockKey] == nil {
-		Lock()
-		projectLocks[lockKey] = &sync.Mutex{}
-		Unlock()
-	}
-	projectLocks[lockKey].Unlock()
+	getLocker().Unlock(fmt.Sprintf("%s.%s.%s", project, stage, service))
 }
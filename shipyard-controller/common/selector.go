@@ -0,0 +1,175 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+// SelectorMatcher matches a single property of an event against the value of a 'triggeredOn' selector.
+// The value of a selector may start with one of the following operators:
+//
+//   - no operator or '==': the property must be equal to the given value, e.g. 'pass' or '== pass'
+//   - '!=': the property must not be equal to the given value
+//   - '=~' and '!~': the property must (not) match the given regular expression, e.g. '=~ ^carts-.*'
+//   - '<', '<=', '>' and '>=': the property must be a number that compares to the given number, e.g. '>= 90'
+//   - 'in(...)' and 'notin(...)': the property must (not) be one of the given comma separated values, e.g. 'in(carts, orders)'
+type SelectorMatcher struct {
+	operator string
+	values   []string
+	regex    *regexp.Regexp
+}
+
+var selectorOperators = []string{"==", "!=", "=~", "!~", "<=", ">=", "<", ">"}
+
+// ParseSelectorMatcher parses the value of a selector. An error is returned if the value is not valid
+func ParseSelectorMatcher(selector string) (*SelectorMatcher, error) {
+	selector = strings.TrimSpace(selector)
+
+	for _, operator := range []string{"in", "notin"} {
+		if strings.HasPrefix(selector, operator+"(") && strings.HasSuffix(selector, ")") {
+			values := []string{}
+			for _, value := range strings.Split(strings.TrimSuffix(strings.TrimPrefix(selector, operator+"("), ")"), ",") {
+				value = unquoteSelectorValue(value)
+				if value == "" {
+					return nil, fmt.Errorf("invalid selector '%s': empty value in list", selector)
+				}
+				values = append(values, value)
+			}
+			return &SelectorMatcher{operator: operator, values: values}, nil
+		}
+	}
+
+	matcher := &SelectorMatcher{operator: "=="}
+	for _, operator := range selectorOperators {
+		if strings.HasPrefix(selector, operator) {
+			matcher.operator = operator
+			selector = strings.TrimPrefix(selector, operator)
+			break
+		}
+	}
+	value := unquoteSelectorValue(selector)
+	matcher.values = []string{value}
+
+	switch matcher.operator {
+	case "=~", "!~":
+		regex, err := regexp.Compile(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression '%s': %w", value, err)
+		}
+		matcher.regex = regex
+	case "<", "<=", ">", ">=":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return nil, fmt.Errorf("operator %s requires a number, but got '%s'", matcher.operator, value)
+		}
+	}
+	return matcher, nil
+}
+
+// Matches returns true if the given property value matches the selector
+func (m *SelectorMatcher) Matches(value interface{}) bool {
+	switch m.operator {
+	case "in", "notin":
+		found := false
+		for _, candidate := range m.values {
+			if valuesEqual(value, candidate) {
+				found = true
+				break
+			}
+		}
+		return found == (m.operator == "in")
+	case "=~", "!~":
+		if value == nil {
+			return m.operator == "!~"
+		}
+		return m.regex.MatchString(fmt.Sprintf("%v", value)) == (m.operator == "=~")
+	case "==", "!=":
+		// an empty value in the selector matches properties that are not set
+		if value == nil {
+			return (m.values[0] == "") == (m.operator == "==")
+		}
+	}
+	matches, err := compareValues(m.operator, value, m.values[0])
+	if err != nil {
+		return false
+	}
+	return matches
+}
+
+func unquoteSelectorValue(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// MatchSelector returns true if all properties of the selector match the given data.
+// The properties of the selector are paths within the data, e.g. 'labels.team' or 'evaluation.score'
+func MatchSelector(selector map[string]string, data interface{}) (bool, error) {
+	for property, value := range selector {
+		matcher, err := ParseSelectorMatcher(value)
+		if err != nil {
+			return false, err
+		}
+		if !matcher.Matches(GetValueByPath(data, strings.Split(property, "."))) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// MatchTriggerSelector returns true if the selector of a 'triggeredOn' definition matches the completed sequence.
+// The legacy properties 'result' and '<previousTask>.result' keep their original semantics: their values are compared literally with the
+// result of the sequence, and it is sufficient that one of them matches. All other properties are matched against the data using MatchSelector
+func MatchTriggerSelector(selector map[string]string, result, previousTask string, data interface{}) (bool, error) {
+	legacyProperties := []string{"result"}
+	if previousTask != "" {
+		legacyProperties = append(legacyProperties, previousTask+".result")
+	}
+
+	propertySelector := map[string]string{}
+	for property, value := range selector {
+		propertySelector[property] = value
+	}
+	hasLegacyProperty := false
+	legacyPropertyMatches := false
+	for _, property := range legacyProperties {
+		value, ok := selector[property]
+		if !ok {
+			continue
+		}
+		delete(propertySelector, property)
+		hasLegacyProperty = true
+		if value == result {
+			legacyPropertyMatches = true
+		}
+	}
+	if hasLegacyProperty && !legacyPropertyMatches {
+		return false, nil
+	}
+	return MatchSelector(propertySelector, data)
+}
+
+// ValidateTriggerSelectors checks if the selectors of all 'triggeredOn' definitions of the shipyard are valid
+func ValidateTriggerSelectors(shipyard *keptnv2.Shipyard) error {
+	for _, stage := range shipyard.Spec.Stages {
+		for _, sequence := range stage.Sequences {
+			for _, trigger := range sequence.TriggeredOn {
+				for property, value := range trigger.Selector.Match {
+					if property == "" {
+						return errors.New("selector properties of sequence " + stage.Name + "." + sequence.Name + " must not be empty")
+					}
+					if _, err := ParseSelectorMatcher(value); err != nil {
+						return fmt.Errorf("invalid selector for property '%s' of sequence %s.%s: %w", property, stage.Name, sequence.Name, err)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package common
+
+import (
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestMatchSelector(t *testing.T) {
+	data := map[string]interface{}{
+		"result":  "pass",
+		"service": "carts",
+		"labels": map[string]interface{}{
+			"team": "a",
+		},
+		"deployment": map[string]interface{}{
+			"deploymentstrategy": "blue_green_service",
+		},
+		"evaluation": map[string]interface{}{
+			"score": float64(85),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		selector map[string]string
+		want     bool
+		wantErr  bool
+	}{
+		{name: "equality", selector: map[string]string{"result": "pass"}, want: true},
+		{name: "equality with operator", selector: map[string]string{"result": "== pass"}, want: true},
+		{name: "inequality", selector: map[string]string{"result": "!= fail"}, want: true},
+		{name: "inequality - not matching", selector: map[string]string{"result": "!=pass"}, want: false},
+		{name: "in", selector: map[string]string{"service": "in(carts, orders)"}, want: true},
+		{name: "in with quoted values", selector: map[string]string{"service": `in("orders", 'carts')`}, want: true},
+		{name: "in - not matching", selector: map[string]string{"service": "in(orders,payment)"}, want: false},
+		{name: "notin", selector: map[string]string{"service": "notin(orders,payment)"}, want: true},
+		{name: "regex", selector: map[string]string{"deployment.deploymentstrategy": "=~ ^blue_green"}, want: true},
+		{name: "negated regex", selector: map[string]string{"deployment.deploymentstrategy": "!~ ^direct"}, want: true},
+		{name: "numeric comparison", selector: map[string]string{"evaluation.score": "< 90"}, want: true},
+		{name: "numeric comparison - not matching", selector: map[string]string{"evaluation.score": ">= 90"}, want: false},
+		{name: "numeric comparison on missing property", selector: map[string]string{"evaluation.missing": "> 10"}, want: false},
+		{name: "numeric comparison on non-numeric property", selector: map[string]string{"service": "> 10"}, want: false},
+		{name: "all properties must match", selector: map[string]string{"result": "pass", "labels.team": "b"}, want: false},
+		{name: "multiple properties", selector: map[string]string{"result": "pass", "labels.team": "in(a,b)"}, want: true},
+		{name: "missing property", selector: map[string]string{"labels.owner": "a"}, want: false},
+		{name: "invalid regex", selector: map[string]string{"service": "=~ ^carts("}, wantErr: true},
+		{name: "numeric operator without number", selector: map[string]string{"evaluation.score": "> high"}, wantErr: true},
+		{name: "empty value in list", selector: map[string]string{"service": "in(carts,,orders)"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MatchSelector(tt.selector, data)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMatchTriggerSelector(t *testing.T) {
+	data := map[string]interface{}{
+		"result":  "warning",
+		"service": "carts",
+		"evaluation": map[string]interface{}{
+			"score": float64(85),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		selector map[string]string
+		want     bool
+	}{
+		{name: "result", selector: map[string]string{"result": "warning"}, want: true},
+		{name: "result - not matching", selector: map[string]string{"result": "pass"}, want: false},
+		{name: "previous task result", selector: map[string]string{"evaluation.result": "warning"}, want: true},
+		{name: "either result or previous task result", selector: map[string]string{"result": "pass", "evaluation.result": "warning"}, want: true},
+		{name: "neither result nor previous task result", selector: map[string]string{"result": "pass", "evaluation.result": "fail"}, want: false},
+		{name: "legacy properties are compared literally", selector: map[string]string{"result": "!= fail"}, want: false},
+		{name: "legacy and new properties", selector: map[string]string{"result": "warning", "evaluation.score": "< 90"}, want: true},
+		{name: "legacy and new properties - not matching", selector: map[string]string{"result": "warning", "service": "notin(carts)"}, want: false},
+		{name: "new properties only", selector: map[string]string{"service": "in(carts, orders)"}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MatchTriggerSelector(tt.selector, "warning", "evaluation", data)
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateTriggerSelectors(t *testing.T) {
+	newShipyard := func(match map[string]string) *keptnv2.Shipyard {
+		return &keptnv2.Shipyard{
+			Spec: keptnv2.ShipyardSpec{
+				Stages: []keptnv2.Stage{
+					{
+						Name: "production",
+						Sequences: []keptnv2.Sequence{
+							{
+								Name: "delivery",
+								TriggeredOn: []keptnv2.Trigger{
+									{
+										Event:    "dev.delivery.finished",
+										Selector: keptnv2.Selector{Match: match},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	require.Nil(t, ValidateTriggerSelectors(newShipyard(nil)))
+	require.Nil(t, ValidateTriggerSelectors(newShipyard(map[string]string{"result": "pass", "service": "in(carts,orders)", "evaluation.score": ">= 90"})))
+	require.NotNil(t, ValidateTriggerSelectors(newShipyard(map[string]string{"evaluation.score": ">= ninety"})))
+	require.NotNil(t, ValidateTriggerSelectors(newShipyard(map[string]string{"service": "=~ [carts"})))
+	require.NotNil(t, ValidateTriggerSelectors(newShipyard(map[string]string{"": "pass"})))
+}
@@ -0,0 +1,20 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/shipyard-controller/handler"
+)
+
+type ScheduleController struct {
+	ScheduleHandler handler.IScheduleHandler
+}
+
+func NewScheduleController(scheduleHandler handler.IScheduleHandler) Controller {
+	return &ScheduleController{ScheduleHandler: scheduleHandler}
+}
+
+func (controller ScheduleController) Inject(apiGroup *gin.RouterGroup) {
+	apiGroup.GET("/schedule/:project", controller.ScheduleHandler.GetSchedules)
+	apiGroup.POST("/schedule/:project/:scheduleId/control", controller.ScheduleHandler.ControlSchedule)
+	apiGroup.POST("/schedule/:project/:scheduleId/fire", controller.ScheduleHandler.FireSchedule)
+}
@@ -0,0 +1,154 @@
+package db
+
+import (
+	"fmt"
+	"sync"
+)
+
+// StorageBackend is the type of database the repositories of the shipyard-controller are stored in
+type StorageBackend string
+
+const (
+	// MongoDBBackend stores the repositories in a MongoDB. This is the default backend
+	MongoDBBackend StorageBackend = "mongodb"
+	// EmbeddedBackend stores the repositories in an embedded, file based database. Since the database file can only be opened by one process,
+	// this backend only supports a single replica of the shipyard-controller
+	EmbeddedBackend StorageBackend = "embedded"
+)
+
+// RepositoryFactory creates the repositories of a storage backend
+type RepositoryFactory interface {
+	CreateEventRepo() EventRepo
+	CreateTaskSequenceRepo() TaskSequenceRepo
+	CreateEventQueueRepo() EventQueueRepo
+	CreateSequenceQueueRepo() SequenceQueueRepo
+	CreateUniformRepo() UniformRepo
+	CreateLogRepo() LogRepo
+	CreateProjectRepo() ProjectRepo
+	CreateSequenceStateRepo() SequenceStateRepo
+	CreateLeaseRepo() LeaseRepo
+	CreateScheduleRepo() ScheduleRepo
+}
+
+// NewRepositoryFactory returns the RepositoryFactory of the given backend. The embeddedDBPath is only used by the embedded backend
+func NewRepositoryFactory(backend StorageBackend, embeddedDBPath string) (RepositoryFactory, error) {
+	switch backend {
+	case MongoDBBackend, "":
+		return &MongoDBRepositoryFactory{}, nil
+	case EmbeddedBackend:
+		embeddedDB, err := NewEmbeddedDB(embeddedDBPath)
+		if err != nil {
+			return nil, err
+		}
+		return NewEmbeddedRepositoryFactory(embeddedDB), nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %s", backend)
+	}
+}
+
+// MongoDBRepositoryFactory creates repositories that use the shared MongoDB connection
+type MongoDBRepositoryFactory struct{}
+
+func (f *MongoDBRepositoryFactory) CreateEventRepo() EventRepo {
+	return NewMongoDBEventsRepo(GetMongoDBConnectionInstance())
+}
+
+func (f *MongoDBRepositoryFactory) CreateTaskSequenceRepo() TaskSequenceRepo {
+	return NewTaskSequenceMongoDBRepo(GetMongoDBConnectionInstance())
+}
+
+func (f *MongoDBRepositoryFactory) CreateEventQueueRepo() EventQueueRepo {
+	return NewMongoDBEventQueueRepo(GetMongoDBConnectionInstance())
+}
+
+func (f *MongoDBRepositoryFactory) CreateSequenceQueueRepo() SequenceQueueRepo {
+	return NewMongoDBSequenceQueueRepo(GetMongoDBConnectionInstance())
+}
+
+func (f *MongoDBRepositoryFactory) CreateUniformRepo() UniformRepo {
+	return NewMongoDBUniformRepo(GetMongoDBConnectionInstance())
+}
+
+func (f *MongoDBRepositoryFactory) CreateLogRepo() LogRepo {
+	return NewMongoDBLogRepo(GetMongoDBConnectionInstance())
+}
+
+func (f *MongoDBRepositoryFactory) CreateProjectRepo() ProjectRepo {
+	return NewMongoDBKeyEncodingProjectsRepo(GetMongoDBConnectionInstance())
+}
+
+func (f *MongoDBRepositoryFactory) CreateSequenceStateRepo() SequenceStateRepo {
+	return NewMongoDBStateRepo(GetMongoDBConnectionInstance())
+}
+
+func (f *MongoDBRepositoryFactory) CreateLeaseRepo() LeaseRepo {
+	return NewMongoDBLeaseRepo(GetMongoDBConnectionInstance())
+}
+
+func (f *MongoDBRepositoryFactory) CreateScheduleRepo() ScheduleRepo {
+	return NewMongoDBScheduleRepo(GetMongoDBConnectionInstance())
+}
+
+// EmbeddedRepositoryFactory creates repositories that are stored in an embedded database
+type EmbeddedRepositoryFactory struct {
+	DB *EmbeddedDB
+	// the TTL of uniform integrations and log entries is kept by the repositories, therefore the same instances are returned every time
+	uniformRepo *EmbeddedUniformRepo
+	logRepo     *EmbeddedLogRepo
+	mutex       sync.Mutex
+}
+
+// NewEmbeddedRepositoryFactory creates a new EmbeddedRepositoryFactory
+func NewEmbeddedRepositoryFactory(db *EmbeddedDB) *EmbeddedRepositoryFactory {
+	return &EmbeddedRepositoryFactory{DB: db}
+}
+
+func (f *EmbeddedRepositoryFactory) CreateEventRepo() EventRepo {
+	return NewEmbeddedEventsRepo(f.DB)
+}
+
+func (f *EmbeddedRepositoryFactory) CreateTaskSequenceRepo() TaskSequenceRepo {
+	return NewEmbeddedTaskSequenceRepo(f.DB)
+}
+
+func (f *EmbeddedRepositoryFactory) CreateEventQueueRepo() EventQueueRepo {
+	return NewEmbeddedEventQueueRepo(f.DB)
+}
+
+func (f *EmbeddedRepositoryFactory) CreateSequenceQueueRepo() SequenceQueueRepo {
+	return NewEmbeddedSequenceQueueRepo(f.DB)
+}
+
+func (f *EmbeddedRepositoryFactory) CreateUniformRepo() UniformRepo {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.uniformRepo == nil {
+		f.uniformRepo = NewEmbeddedUniformRepo(f.DB)
+	}
+	return f.uniformRepo
+}
+
+func (f *EmbeddedRepositoryFactory) CreateLogRepo() LogRepo {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.logRepo == nil {
+		f.logRepo = NewEmbeddedLogRepo(f.DB)
+	}
+	return f.logRepo
+}
+
+func (f *EmbeddedRepositoryFactory) CreateProjectRepo() ProjectRepo {
+	return NewEmbeddedProjectsRepo(f.DB)
+}
+
+func (f *EmbeddedRepositoryFactory) CreateSequenceStateRepo() SequenceStateRepo {
+	return NewEmbeddedStateRepo(f.DB)
+}
+
+func (f *EmbeddedRepositoryFactory) CreateLeaseRepo() LeaseRepo {
+	return NewEmbeddedLeaseRepo(f.DB)
+}
+
+func (f *EmbeddedRepositoryFactory) CreateScheduleRepo() ScheduleRepo {
+	return NewEmbeddedScheduleRepo(f.DB)
+}
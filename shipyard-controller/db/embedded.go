@@ -0,0 +1,193 @@
+package db
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"go.etcd.io/bbolt"
+	"time"
+)
+
+// EmbeddedDB is a file based key/value store which allows running the shipyard-controller without a MongoDB.
+// Every collection of the MongoDB backend is mapped to a bucket, and the documents of a bucket are stored as JSON.
+// Since the database file is locked by the process that opened it, only one instance of the shipyard-controller can use it
+type EmbeddedDB struct {
+	db *bbolt.DB
+}
+
+// NewEmbeddedDB opens (or creates) the embedded database at the given path
+func NewEmbeddedDB(path string) (*EmbeddedDB, error) {
+	db, err := bbolt.Open(path, 0600, &bbolt.Options{Timeout: 10 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("could not open embedded database %s: %w", path, err)
+	}
+	return &EmbeddedDB{db: db}, nil
+}
+
+// Close closes the embedded database
+func (e *EmbeddedDB) Close() error {
+	return e.db.Close()
+}
+
+// insert stores the given document under a new key. Keys are increasing, which means that the documents of a bucket are iterated in insertion order
+func (e *EmbeddedDB) insert(bucket string, document interface{}) error {
+	return e.db.Update(func(tx *bbolt.Tx) error {
+		return insertDocument(tx, bucket, document)
+	})
+}
+
+// put stores the given document under the given key and replaces a document that is already stored under this key
+func (e *EmbeddedDB) put(bucket, key string, document interface{}) error {
+	return e.db.Update(func(tx *bbolt.Tx) error {
+		return putDocument(tx, bucket, key, document)
+	})
+}
+
+// get decodes the document with the given key into document. If no such document exists, false is returned
+func (e *EmbeddedDB) get(bucket, key string, document interface{}) (bool, error) {
+	found := false
+	err := e.db.View(func(tx *bbolt.Tx) error {
+		var err error
+		found, err = getDocument(tx, bucket, key, document)
+		return err
+	})
+	return found, err
+}
+
+// forEach calls fn for every document of the bucket
+func (e *EmbeddedDB) forEach(bucket string, fn func(key string, value []byte) error) error {
+	return e.db.View(func(tx *bbolt.Tx) error {
+		return forEachDocument(tx, bucket, fn)
+	})
+}
+
+// deleteWhere deletes all documents of the bucket that are matched by the given function
+func (e *EmbeddedDB) deleteWhere(bucket string, matches func(value []byte) (bool, error)) error {
+	return e.db.Update(func(tx *bbolt.Tx) error {
+		return deleteDocumentsWhere(tx, bucket, matches)
+	})
+}
+
+// deleteKey deletes the document with the given key
+func (e *EmbeddedDB) deleteKey(bucket, key string) error {
+	return e.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return nil
+		}
+		return b.Delete([]byte(key))
+	})
+}
+
+// dropBucket deletes the bucket including all of its documents
+func (e *EmbeddedDB) dropBucket(bucket string) error {
+	return e.db.Update(func(tx *bbolt.Tx) error {
+		if err := tx.DeleteBucket([]byte(bucket)); err != nil && err != bbolt.ErrBucketNotFound {
+			return fmt.Errorf("could not delete bucket %s: %w", bucket, err)
+		}
+		return nil
+	})
+}
+
+// update executes fn within a read-write transaction. Since only one read-write transaction can be active at a time,
+// fn can safely implement compare-and-set operations
+func (e *EmbeddedDB) update(fn func(tx *bbolt.Tx) error) error {
+	return e.db.Update(fn)
+}
+
+func insertDocument(tx *bbolt.Tx, bucket string, document interface{}) error {
+	b, err := tx.CreateBucketIfNotExists([]byte(bucket))
+	if err != nil {
+		return fmt.Errorf("could not create bucket %s: %w", bucket, err)
+	}
+	sequence, err := b.NextSequence()
+	if err != nil {
+		return err
+	}
+	value, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, sequence)
+	return b.Put(key, value)
+}
+
+func putDocument(tx *bbolt.Tx, bucket, key string, document interface{}) error {
+	b, err := tx.CreateBucketIfNotExists([]byte(bucket))
+	if err != nil {
+		return fmt.Errorf("could not create bucket %s: %w", bucket, err)
+	}
+	value, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+	return b.Put([]byte(key), value)
+}
+
+func getDocument(tx *bbolt.Tx, bucket, key string, document interface{}) (bool, error) {
+	b := tx.Bucket([]byte(bucket))
+	if b == nil {
+		return false, nil
+	}
+	value := b.Get([]byte(key))
+	if value == nil {
+		return false, nil
+	}
+	return true, json.Unmarshal(value, document)
+}
+
+func forEachDocument(tx *bbolt.Tx, bucket string, fn func(key string, value []byte) error) error {
+	b := tx.Bucket([]byte(bucket))
+	if b == nil {
+		return nil
+	}
+	return b.ForEach(func(k, v []byte) error {
+		return fn(string(k), v)
+	})
+}
+
+func deleteDocumentsWhere(tx *bbolt.Tx, bucket string, matches func(value []byte) (bool, error)) error {
+	b := tx.Bucket([]byte(bucket))
+	if b == nil {
+		return nil
+	}
+	// keys must not be deleted while iterating over the bucket
+	keysToDelete := [][]byte{}
+	err := b.ForEach(func(k, v []byte) error {
+		match, err := matches(v)
+		if err != nil {
+			return err
+		}
+		if match {
+			keysToDelete = append(keysToDelete, append([]byte{}, k...))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, key := range keysToDelete {
+		if err := b.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// paginate returns the page of items defined by nextPageKey and pageSize, as well as the key of the next page.
+// The semantics are the same as for the paginated queries of the MongoDB backend
+func paginate(totalCount, nextPageKey, pageSize int64) (start, end, newNextPageKey int64) {
+	start = nextPageKey
+	if start > totalCount {
+		start = totalCount
+	}
+	end = totalCount
+	if pageSize > 0 && start+pageSize < totalCount {
+		end = start + pageSize
+	}
+	if pageSize > 0 && pageSize+nextPageKey < totalCount {
+		newNextPageKey = pageSize + nextPageKey
+	}
+	return start, end, newNextPageKey
+}
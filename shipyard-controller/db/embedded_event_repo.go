@@ -0,0 +1,231 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/keptn/go-utils/pkg/common/timeutils"
+	"github.com/keptn/keptn/shipyard-controller/common"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	log "github.com/sirupsen/logrus"
+	"go.etcd.io/bbolt"
+	"sort"
+	"time"
+)
+
+// EmbeddedEventsRepo retrieves and stores events in the buckets of an embedded database
+type EmbeddedEventsRepo struct {
+	DB *EmbeddedDB
+}
+
+func NewEmbeddedEventsRepo(db *EmbeddedDB) *EmbeddedEventsRepo {
+	return &EmbeddedEventsRepo{DB: db}
+}
+
+// GetEvents gets all events of a project, based on the provided filter
+func (e *EmbeddedEventsRepo) GetEvents(project string, filter common.EventFilter, status ...common.EventStatus) ([]models.Event, error) {
+	events := []models.Event{}
+	err := e.DB.forEach(getEventsBucketName(project, status...), func(_ string, value []byte) error {
+		event := models.Event{}
+		if err := json.Unmarshal(value, &event); err != nil {
+			log.WithError(err).Error("could not decode event")
+			return nil
+		}
+		if eventMatchesFilter(event, filter) {
+			events = append(events, event)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, ErrNoEventFound
+	}
+	sortEventsByTimeDescending(events)
+	return events, nil
+}
+
+func (e *EmbeddedEventsRepo) GetRootEvents(getRootParams models.GetRootEventParams) (*models.GetEventsResult, error) {
+	events := []models.Event{}
+	err := e.DB.forEach(getEventsBucketName(getRootParams.Project, common.RootEvent), func(_ string, value []byte) error {
+		event := models.Event{}
+		if err := json.Unmarshal(value, &event); err != nil {
+			log.WithError(err).Error("could not decode event")
+			return nil
+		}
+		events = append(events, event)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortEventsByTimeDescending(events)
+
+	totalCount := int64(len(events))
+	start, end, nextPageKey := paginate(totalCount, getRootParams.NextPageKey, getRootParams.PageSize)
+
+	return &models.GetEventsResult{
+		Events:      events[start:end],
+		NextPageKey: nextPageKey,
+		PageSize:    0,
+		TotalCount:  totalCount,
+	}, nil
+}
+
+// InsertEvent inserts an event into the bucket of the specified project.
+// Since there is no data store that keeps the history of all events when using the embedded database, the event is also stored in the
+// bucket of the project that contains all events of the project
+func (e *EmbeddedEventsRepo) InsertEvent(project string, event models.Event, status common.EventStatus) error {
+	event.Time = timeutils.GetKeptnTimeStamp(time.Now().UTC())
+
+	return e.DB.update(func(tx *bbolt.Tx) error {
+		bucket := getEventsBucketName(project, status)
+		if bucket != project {
+			// events that are inserted again (e.g. when a sequence is restarted) keep their original entry in the history
+			inHistory, err := containsEvent(tx, project, event.ID)
+			if err != nil {
+				return err
+			}
+			if !inHistory {
+				if err := insertDocument(tx, project, event); err != nil {
+					return err
+				}
+			}
+		}
+		exists, err := containsEvent(tx, bucket, event.ID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return errors.New("event with ID " + event.ID + " already exists in collection")
+		}
+		return insertDocument(tx, bucket, event)
+	})
+}
+
+func containsEvent(tx *bbolt.Tx, bucket, eventID string) (bool, error) {
+	exists := false
+	err := forEachDocument(tx, bucket, func(_ string, value []byte) error {
+		existingEvent := models.Event{}
+		if err := json.Unmarshal(value, &existingEvent); err == nil && existingEvent.ID == eventID {
+			exists = true
+		}
+		return nil
+	})
+	return exists, err
+}
+
+// DeleteEvent deletes an event from the bucket
+func (e *EmbeddedEventsRepo) DeleteEvent(project, eventID string, status common.EventStatus) error {
+	err := e.DB.deleteWhere(getEventsBucketName(project, status), func(value []byte) (bool, error) {
+		event := models.Event{}
+		if err := json.Unmarshal(value, &event); err != nil {
+			return false, nil
+		}
+		return event.ID == eventID, nil
+	})
+	if err != nil {
+		log.Errorf("Could not delete event %s : %s\n", eventID, err.Error())
+		return err
+	}
+	log.Infof("Deleted event %s", eventID)
+	return nil
+}
+
+// DeleteEventCollections deletes the event buckets of the project
+func (e *EmbeddedEventsRepo) DeleteEventCollections(project string) error {
+	suffixes := []string{
+		"",
+		triggeredEventsCollectionNameSuffix,
+		startedEventsCollectionNameSuffix,
+		finishedEventsCollectionNameSuffix,
+		remediationCollectionNameSuffix,
+		taskSequenceStateCollectionSuffix,
+	}
+	for _, suffix := range suffixes {
+		if err := e.DB.dropBucket(project + suffix); err != nil {
+			// log the error but continue
+			log.Error(err.Error())
+		}
+	}
+	return nil
+}
+
+func (e *EmbeddedEventsRepo) GetStartedEventsForTriggeredID(eventScope models.EventScope) ([]models.Event, error) {
+	return getStartedEventsForTriggeredID(e, eventScope)
+}
+
+func (e *EmbeddedEventsRepo) GetEventsWithRetry(project string, filter common.EventFilter, status common.EventStatus, nrRetries int) ([]models.Event, error) {
+	return getEventsWithRetry(e, project, filter, status, nrRetries)
+}
+
+func (e *EmbeddedEventsRepo) GetTaskSequenceTriggeredEvent(eventScope models.EventScope, taskSequenceName string) (*models.Event, error) {
+	return getTaskSequenceTriggeredEvent(e, eventScope, taskSequenceName)
+}
+
+func (e *EmbeddedEventsRepo) DeleteAllFinishedEvents(eventScope models.EventScope) error {
+	return deleteAllFinishedEvents(e, eventScope)
+}
+
+func (e *EmbeddedEventsRepo) GetFinishedEvents(eventScope models.EventScope) ([]models.Event, error) {
+	return getFinishedEvents(e, eventScope)
+}
+
+func getEventsBucketName(project string, status ...common.EventStatus) string {
+	if len(status) == 0 {
+		return project
+	}
+	switch status[0] {
+	case common.TriggeredEvent:
+		return project + triggeredEventsCollectionNameSuffix
+	case common.StartedEvent:
+		return project + startedEventsCollectionNameSuffix
+	case common.FinishedEvent:
+		return project + finishedEventsCollectionNameSuffix
+	case common.RootEvent:
+		return project + rootEventCollectionSuffix
+	default:
+		return project
+	}
+}
+
+func eventMatchesFilter(event models.Event, filter common.EventFilter) bool {
+	if filter.Type != "" && (event.Type == nil || *event.Type != filter.Type) {
+		return false
+	}
+	if filter.Stage != nil && *filter.Stage != "" && getEventDataProperty(event, "stage") != *filter.Stage {
+		return false
+	}
+	if filter.Service != nil && *filter.Service != "" && getEventDataProperty(event, "service") != *filter.Service {
+		return false
+	}
+	if filter.ID != nil && *filter.ID != "" && event.ID != *filter.ID {
+		return false
+	}
+	if filter.TriggeredID != nil && *filter.TriggeredID != "" && event.Triggeredid != *filter.TriggeredID {
+		return false
+	}
+	if filter.Source != nil && *filter.Source != "" && (event.Source == nil || *event.Source != *filter.Source) {
+		return false
+	}
+	if filter.KeptnContext != nil && *filter.KeptnContext != "" && event.Shkeptncontext != *filter.KeptnContext {
+		return false
+	}
+	return true
+}
+
+func getEventDataProperty(event models.Event, property string) string {
+	data, ok := event.Data.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	value, _ := data[property].(string)
+	return value
+}
+
+func sortEventsByTimeDescending(events []models.Event) {
+	// the keptn timestamp format can be sorted lexicographically
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time > events[j].Time
+	})
+}
@@ -0,0 +1,212 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	log "github.com/sirupsen/logrus"
+	"go.etcd.io/bbolt"
+	"time"
+)
+
+// EmbeddedEventQueueRepo stores queued events and the states of queued sequences in the buckets of an embedded database
+type EmbeddedEventQueueRepo struct {
+	DB *EmbeddedDB
+}
+
+func NewEmbeddedEventQueueRepo(db *EmbeddedDB) *EmbeddedEventQueueRepo {
+	return &EmbeddedEventQueueRepo{DB: db}
+}
+
+// GetQueuedEvents gets all queued events that should be sent next
+func (e *EmbeddedEventQueueRepo) GetQueuedEvents(timestamp time.Time) ([]models.QueueItem, error) {
+	return getQueueItemsFromBucket(e.DB, eventQueueCollectionName, func(item models.QueueItem) bool {
+		return !item.Timestamp.After(timestamp)
+	})
+}
+
+func (e *EmbeddedEventQueueRepo) QueueEvent(item models.QueueItem) error {
+	return insertQueueItemIntoBucket(e.DB, eventQueueCollectionName, item)
+}
+
+// DeleteQueuedEvent deletes a queue item from the bucket
+func (e *EmbeddedEventQueueRepo) DeleteQueuedEvent(eventID string) error {
+	err := deleteQueueItemsFromBucket(e.DB, eventQueueCollectionName, func(item models.QueueItem) bool {
+		return item.EventID == eventID
+	})
+	if err != nil {
+		log.Errorf("Could not delete event %s : %s\n", eventID, err.Error())
+		return err
+	}
+	log.Infof("Deleted event %s", eventID)
+	return nil
+}
+
+func (e *EmbeddedEventQueueRepo) IsEventInQueue(eventID string) (bool, error) {
+	queueItems, err := getQueueItemsFromBucket(e.DB, eventQueueCollectionName, func(item models.QueueItem) bool {
+		return item.EventID == eventID
+	})
+	if err != nil {
+		if err == ErrNoEventFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return len(queueItems) > 0, nil
+}
+
+func (e *EmbeddedEventQueueRepo) IsSequenceOfEventPaused(eventScope models.EventScope) bool {
+	return isSequenceOfEventPaused(e, eventScope)
+}
+
+// DeleteQueuedEvents deletes all matching queue items from the bucket
+func (e *EmbeddedEventQueueRepo) DeleteQueuedEvents(scope models.EventScope) error {
+	err := deleteQueueItemsFromBucket(e.DB, eventQueueCollectionName, func(item models.QueueItem) bool {
+		return queueItemMatchesScope(item, scope)
+	})
+	if err != nil {
+		log.Errorf("Could not delete queue items : %s\n", err.Error())
+		return err
+	}
+	return nil
+}
+
+func (e *EmbeddedEventQueueRepo) CreateOrUpdateEventQueueState(state models.EventQueueSequenceState) error {
+	return e.DB.update(func(tx *bbolt.Tx) error {
+		// same as for the upsert of the MongoDB backend, the first matching state is replaced
+		existingKey := ""
+		err := forEachDocument(tx, eventQueueSequenceStateCollectionName, func(key string, value []byte) error {
+			if existingKey != "" {
+				return nil
+			}
+			existingState := models.EventQueueSequenceState{}
+			if err := json.Unmarshal(value, &existingState); err != nil {
+				return err
+			}
+			if existingState.Scope.KeptnContext == state.Scope.KeptnContext && (state.Scope.Stage == "" || existingState.Scope.Stage == state.Scope.Stage) {
+				existingKey = key
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if existingKey != "" {
+			return putDocument(tx, eventQueueSequenceStateCollectionName, existingKey, state)
+		}
+		return insertDocument(tx, eventQueueSequenceStateCollectionName, state)
+	})
+}
+
+func (e *EmbeddedEventQueueRepo) GetEventQueueSequenceStates(filter models.EventQueueSequenceState) ([]models.EventQueueSequenceState, error) {
+	stateItems := []models.EventQueueSequenceState{}
+	err := e.DB.forEach(eventQueueSequenceStateCollectionName, func(_ string, value []byte) error {
+		stateItem := models.EventQueueSequenceState{}
+		if err := json.Unmarshal(value, &stateItem); err != nil {
+			return err
+		}
+		if eventQueueStateMatchesFilter(stateItem, filter) {
+			stateItems = append(stateItems, stateItem)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(stateItems) == 0 {
+		return nil, ErrNoEventFound
+	}
+	return stateItems, nil
+}
+
+func (e *EmbeddedEventQueueRepo) DeleteEventQueueStates(filter models.EventQueueSequenceState) error {
+	err := e.DB.deleteWhere(eventQueueSequenceStateCollectionName, func(value []byte) (bool, error) {
+		stateItem := models.EventQueueSequenceState{}
+		if err := json.Unmarshal(value, &stateItem); err != nil {
+			return false, err
+		}
+		return eventQueueStateMatchesFilter(stateItem, filter), nil
+	})
+	if err != nil {
+		log.Errorf("Could not delete queue items : %s\n", err.Error())
+		return err
+	}
+	return nil
+}
+
+func eventQueueStateMatchesFilter(state models.EventQueueSequenceState, filter models.EventQueueSequenceState) bool {
+	if filter.Scope.KeptnContext != "" && state.Scope.KeptnContext != filter.Scope.KeptnContext {
+		return false
+	}
+	if filter.Scope.Stage != "" && state.Scope.Stage != filter.Scope.Stage {
+		return false
+	}
+	return true
+}
+
+func queueItemMatchesScope(item models.QueueItem, scope models.EventScope) bool {
+	if scope.KeptnContext != "" && item.Scope.KeptnContext != scope.KeptnContext {
+		return false
+	}
+	if scope.Project != "" && item.Scope.Project != scope.Project {
+		return false
+	}
+	if scope.Stage != "" && item.Scope.Stage != scope.Stage {
+		return false
+	}
+	if scope.Service != "" && item.Scope.Service != scope.Service {
+		return false
+	}
+	return true
+}
+
+func insertQueueItemIntoBucket(db *EmbeddedDB, bucket string, item models.QueueItem) error {
+	return db.update(func(tx *bbolt.Tx) error {
+		exists := false
+		err := forEachDocument(tx, bucket, func(_ string, value []byte) error {
+			existingItem := models.QueueItem{}
+			if err := json.Unmarshal(value, &existingItem); err == nil && existingItem.EventID == item.EventID {
+				exists = true
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if exists {
+			return errors.New("queue item with ID " + item.EventID + " already exists in collection")
+		}
+		return insertDocument(tx, bucket, item)
+	})
+}
+
+func getQueueItemsFromBucket(db *EmbeddedDB, bucket string, matches func(item models.QueueItem) bool) ([]models.QueueItem, error) {
+	queuedItems := []models.QueueItem{}
+	err := db.forEach(bucket, func(_ string, value []byte) error {
+		queueItem := models.QueueItem{}
+		if err := json.Unmarshal(value, &queueItem); err != nil {
+			return err
+		}
+		if matches(queueItem) {
+			queuedItems = append(queuedItems, queueItem)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(queuedItems) == 0 {
+		return nil, ErrNoEventFound
+	}
+	return queuedItems, nil
+}
+
+func deleteQueueItemsFromBucket(db *EmbeddedDB, bucket string, matches func(item models.QueueItem) bool) error {
+	return db.deleteWhere(bucket, func(value []byte) (bool, error) {
+		queueItem := models.QueueItem{}
+		if err := json.Unmarshal(value, &queueItem); err != nil {
+			return false, err
+		}
+		return matches(queueItem), nil
+	})
+}
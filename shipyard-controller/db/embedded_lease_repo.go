@@ -0,0 +1,74 @@
+package db
+
+import (
+	"fmt"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"go.etcd.io/bbolt"
+	"time"
+)
+
+// EmbeddedLeaseRepo stores leases in a bucket of an embedded database. Since the database can only be opened by one process,
+// the leases are only shared between the components of a single shipyard-controller instance
+type EmbeddedLeaseRepo struct {
+	DB *EmbeddedDB
+}
+
+// NewEmbeddedLeaseRepo creates a new EmbeddedLeaseRepo
+func NewEmbeddedLeaseRepo(db *EmbeddedDB) *EmbeddedLeaseRepo {
+	return &EmbeddedLeaseRepo{DB: db}
+}
+
+// AcquireLease tries to acquire (or renew) the lease with the given name. If the lease is currently held by another holder and has not expired yet,
+// false is returned
+func (lr *EmbeddedLeaseRepo) AcquireLease(name, holder string, duration time.Duration) (bool, error) {
+	acquired := false
+	err := lr.DB.update(func(tx *bbolt.Tx) error {
+		now := time.Now().UTC()
+
+		lease := &models.Lease{}
+		found, err := getDocument(tx, leaseCollectionName, name, lease)
+		if err != nil {
+			return err
+		}
+		if found && lease.Holder != holder && !lease.ExpiresAt.Before(now) {
+			// the lease exists, but is held by someone else
+			return nil
+		}
+
+		acquired = true
+		return putDocument(tx, leaseCollectionName, name, models.Lease{Name: name, Holder: holder, ExpiresAt: now.Add(duration)})
+	})
+	if err != nil {
+		return false, fmt.Errorf("could not acquire lease %s: %w", name, err)
+	}
+	return acquired, nil
+}
+
+// ReleaseLease releases the lease with the given name if it is held by the given holder
+func (lr *EmbeddedLeaseRepo) ReleaseLease(name, holder string) error {
+	err := lr.DB.update(func(tx *bbolt.Tx) error {
+		lease := &models.Lease{}
+		found, err := getDocument(tx, leaseCollectionName, name, lease)
+		if err != nil || !found || lease.Holder != holder {
+			return err
+		}
+		return tx.Bucket([]byte(leaseCollectionName)).Delete([]byte(name))
+	})
+	if err != nil {
+		return fmt.Errorf("could not release lease %s: %w", name, err)
+	}
+	return nil
+}
+
+// GetLease returns the lease with the given name
+func (lr *EmbeddedLeaseRepo) GetLease(name string) (*models.Lease, error) {
+	lease := &models.Lease{}
+	found, err := lr.DB.get(leaseCollectionName, name, lease)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode lease %s: %w", name, err)
+	}
+	if !found {
+		return nil, ErrLeaseNotFound
+	}
+	return lease, nil
+}
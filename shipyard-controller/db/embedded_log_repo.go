@@ -0,0 +1,156 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/benbjohnson/clock"
+	"github.com/keptn/go-utils/pkg/common/timeutils"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"go.etcd.io/bbolt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// EmbeddedLogRepo stores the error logs of uniform integrations in a bucket of an embedded database
+type EmbeddedLogRepo struct {
+	DB       *EmbeddedDB
+	TheClock clock.Clock
+	// ttl is the duration after which log entries are removed. Since the embedded database has no TTL indexes,
+	// expired log entries are removed whenever the log entries are read
+	ttl   time.Duration
+	mutex sync.Mutex
+}
+
+func NewEmbeddedLogRepo(db *EmbeddedDB) *EmbeddedLogRepo {
+	return &EmbeddedLogRepo{DB: db, TheClock: clock.New()}
+}
+
+// SetupTTLIndex sets the duration after which log entries are removed
+func (e *EmbeddedLogRepo) SetupTTLIndex(duration time.Duration) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.ttl = duration
+	return nil
+}
+
+func (e *EmbeddedLogRepo) CreateLogEntries(entries []models.LogEntry) error {
+	return e.DB.update(func(tx *bbolt.Tx) error {
+		for index := range entries {
+			if entries[index].Time.IsZero() {
+				entries[index].Time = e.TheClock.Now().UTC()
+			}
+			if err := insertDocument(tx, logCollectionName, entries[index]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (e *EmbeddedLogRepo) GetLogEntries(params models.GetLogParams) (*models.GetLogsResponse, error) {
+	if err := e.deleteExpiredLogEntries(); err != nil {
+		return nil, err
+	}
+
+	matches, err := getLogFilterFunc(params.LogFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := []models.LogEntry{}
+	err = e.DB.forEach(logCollectionName, func(_ string, value []byte) error {
+		logEntry := models.LogEntry{}
+		if err := json.Unmarshal(value, &logEntry); err != nil {
+			return fmt.Errorf("could not decode log entry: %w", err)
+		}
+		if matches(logEntry) {
+			logs = append(logs, logEntry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(logs, func(i, j int) bool {
+		return logs[i].Time.After(logs[j].Time)
+	})
+
+	totalCount := int64(len(logs))
+	start, end, nextPageKey := paginate(totalCount, params.NextPageKey, params.PageSize)
+
+	return &models.GetLogsResponse{
+		Logs:        logs[start:end],
+		NextPageKey: nextPageKey,
+		PageSize:    0,
+		TotalCount:  totalCount,
+	}, nil
+}
+
+func (e *EmbeddedLogRepo) DeleteLogEntries(params models.DeleteLogParams) error {
+	matches, err := getLogFilterFunc(params.LogFilter)
+	if err != nil {
+		return err
+	}
+
+	err = e.DB.deleteWhere(logCollectionName, func(value []byte) (bool, error) {
+		logEntry := models.LogEntry{}
+		if err := json.Unmarshal(value, &logEntry); err != nil {
+			return false, err
+		}
+		return matches(logEntry), nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not delete log entries: %s", err)
+	}
+	return nil
+}
+
+func (e *EmbeddedLogRepo) deleteExpiredLogEntries() error {
+	e.mutex.Lock()
+	ttl := e.ttl
+	e.mutex.Unlock()
+	if ttl <= 0 {
+		return nil
+	}
+
+	expiry := e.TheClock.Now().UTC().Add(-ttl)
+	return e.DB.deleteWhere(logCollectionName, func(value []byte) (bool, error) {
+		logEntry := models.LogEntry{}
+		if err := json.Unmarshal(value, &logEntry); err != nil {
+			return false, err
+		}
+		return logEntry.Time.Before(expiry), nil
+	})
+}
+
+func getLogFilterFunc(filter models.LogFilter) (func(entry models.LogEntry) bool, error) {
+	var fromTime, beforeTime time.Time
+	var err error
+	if filter.FromTime != "" {
+		fromTime, err = time.Parse(timeutils.KeptnTimeFormatISO8601, filter.FromTime)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse provided fromTime %s: %s", filter.FromTime, err.Error())
+		}
+	}
+	if filter.BeforeTime != "" {
+		beforeTime, err = time.Parse(timeutils.KeptnTimeFormatISO8601, filter.BeforeTime)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse provided beforeTime %s: %s", filter.BeforeTime, err.Error())
+		}
+	}
+
+	return func(entry models.LogEntry) bool {
+		if filter.IntegrationID != "" && entry.IntegrationID != filter.IntegrationID {
+			return false
+		}
+		if filter.FromTime != "" && entry.Time.Before(fromTime) {
+			return false
+		}
+		if filter.BeforeTime != "" && entry.Time.After(beforeTime) {
+			return false
+		}
+		return true
+	}, nil
+}
@@ -0,0 +1,91 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	log "github.com/sirupsen/logrus"
+	"go.etcd.io/bbolt"
+)
+
+// EmbeddedProjectsRepo stores projects in a bucket of an embedded database, using the project name as the key.
+// Since the documents are stored as JSON, the keys of the project do not need to be encoded
+type EmbeddedProjectsRepo struct {
+	DB *EmbeddedDB
+}
+
+func NewEmbeddedProjectsRepo(db *EmbeddedDB) *EmbeddedProjectsRepo {
+	return &EmbeddedProjectsRepo{DB: db}
+}
+
+func (e *EmbeddedProjectsRepo) GetProjects() ([]*models.ExpandedProject, error) {
+	result := []*models.ExpandedProject{}
+	err := e.DB.forEach(projectsCollectionName, func(_ string, value []byte) error {
+		project := &models.ExpandedProject{}
+		if err := json.Unmarshal(value, project); err != nil {
+			return fmt.Errorf("could not decode project: %w", err)
+		}
+		result = append(result, project)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (e *EmbeddedProjectsRepo) GetProject(projectName string) (*models.ExpandedProject, error) {
+	project := &models.ExpandedProject{}
+	found, err := e.DB.get(projectsCollectionName, projectName, project)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+	return project, nil
+}
+
+func (e *EmbeddedProjectsRepo) CreateProject(project *models.ExpandedProject) error {
+	err := e.DB.update(func(tx *bbolt.Tx) error {
+		found, err := getDocument(tx, projectsCollectionName, project.ProjectName, &models.ExpandedProject{})
+		if err != nil {
+			return err
+		}
+		if found {
+			return fmt.Errorf("project %s already exists", project.ProjectName)
+		}
+		return putDocument(tx, projectsCollectionName, project.ProjectName, project)
+	})
+	if err != nil {
+		log.Errorf("Could not create project %s: %v", project.ProjectName, err)
+	}
+	return nil
+}
+
+func (e *EmbeddedProjectsRepo) UpdateProject(project *models.ExpandedProject) error {
+	err := e.DB.update(func(tx *bbolt.Tx) error {
+		found, err := getDocument(tx, projectsCollectionName, project.ProjectName, &models.ExpandedProject{})
+		if err != nil || !found {
+			return err
+		}
+		return putDocument(tx, projectsCollectionName, project.ProjectName, project)
+	})
+	if err != nil {
+		log.Errorf("Could not update project %s: %v", project.ProjectName, err)
+		return err
+	}
+	return nil
+}
+
+func (e *EmbeddedProjectsRepo) UpdateProjectUpstream(projectName string, uri string, user string) error {
+	return updateProjectUpstream(e, projectName, uri, user)
+}
+
+func (e *EmbeddedProjectsRepo) DeleteProject(projectName string) error {
+	if err := e.DB.deleteKey(projectsCollectionName, projectName); err != nil {
+		log.Errorf("Could not delete project %s: %v", projectName, err)
+		return err
+	}
+	return nil
+}
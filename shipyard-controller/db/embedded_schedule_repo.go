@@ -0,0 +1,162 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"go.etcd.io/bbolt"
+	"time"
+)
+
+// EmbeddedScheduleRepo stores the schedules that periodically trigger sequences in a bucket of an embedded database,
+// using the schedule ID as the key
+type EmbeddedScheduleRepo struct {
+	DB *EmbeddedDB
+}
+
+// NewEmbeddedScheduleRepo creates a new EmbeddedScheduleRepo
+func NewEmbeddedScheduleRepo(db *EmbeddedDB) *EmbeddedScheduleRepo {
+	return &EmbeddedScheduleRepo{DB: db}
+}
+
+// GetSchedules returns all schedules of the given project, or of all projects if the project is empty.
+// Since the keys of a bucket are sorted, the schedules are returned in the order of their IDs
+func (sr *EmbeddedScheduleRepo) GetSchedules(project string) ([]models.Schedule, error) {
+	schedules := []models.Schedule{}
+	err := sr.DB.forEach(scheduleCollectionName, func(_ string, value []byte) error {
+		schedule := models.Schedule{}
+		if err := json.Unmarshal(value, &schedule); err != nil {
+			return fmt.Errorf("could not decode schedules: %w", err)
+		}
+		if project == "" || schedule.Project == project {
+			schedules = append(schedules, schedule)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// GetSchedule returns the schedule with the given ID
+func (sr *EmbeddedScheduleRepo) GetSchedule(id string) (*models.Schedule, error) {
+	schedule := &models.Schedule{}
+	found, err := sr.DB.get(scheduleCollectionName, id, schedule)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode schedule %s: %w", id, err)
+	}
+	if !found {
+		return nil, ErrScheduleNotFound
+	}
+	return schedule, nil
+}
+
+// UpsertSchedule creates or updates the given schedule without modifying the paused state and the last run of an existing schedule.
+// The next fire time of an existing schedule is only updated if its cron expression has changed
+func (sr *EmbeddedScheduleRepo) UpsertSchedule(schedule models.Schedule) error {
+	err := sr.DB.update(func(tx *bbolt.Tx) error {
+		existingSchedule := &models.Schedule{}
+		found, err := getDocument(tx, scheduleCollectionName, schedule.ID, existingSchedule)
+		if err != nil {
+			return err
+		}
+		if found {
+			schedule.Paused = existingSchedule.Paused
+			schedule.LastRun = existingSchedule.LastRun
+			if existingSchedule.Cron == schedule.Cron {
+				schedule.NextFireTime = existingSchedule.NextFireTime
+			}
+		} else {
+			schedule.LastRun = nil
+		}
+		return putDocument(tx, scheduleCollectionName, schedule.ID, schedule)
+	})
+	if err != nil {
+		return fmt.Errorf("could not store schedule %s: %w", schedule.ID, err)
+	}
+	return nil
+}
+
+// ClaimScheduledRun moves the next fire time of the schedule forward. Only one caller can successfully claim a run,
+// since read-write transactions of the embedded database are serialized
+func (sr *EmbeddedScheduleRepo) ClaimScheduledRun(id string, expectedNextFireTime, nextFireTime time.Time, run models.ScheduleRun) (bool, error) {
+	claimed := false
+	err := sr.DB.update(func(tx *bbolt.Tx) error {
+		schedule := &models.Schedule{}
+		found, err := getDocument(tx, scheduleCollectionName, id, schedule)
+		if err != nil {
+			return err
+		}
+		if !found || schedule.Paused || !schedule.NextFireTime.Equal(expectedNextFireTime) {
+			return nil
+		}
+		schedule.NextFireTime = nextFireTime
+		schedule.LastRun = &run
+		claimed = true
+		return putDocument(tx, scheduleCollectionName, id, schedule)
+	})
+	if err != nil {
+		return false, fmt.Errorf("could not claim run of schedule %s: %w", id, err)
+	}
+	return claimed, nil
+}
+
+// ReleaseScheduledRun restores the next fire time and the last run of the schedule, unless the claimed run has been changed in the meantime
+func (sr *EmbeddedScheduleRepo) ReleaseScheduledRun(id string, claimedNextFireTime, nextFireTime time.Time, lastRun *models.ScheduleRun) error {
+	err := sr.DB.update(func(tx *bbolt.Tx) error {
+		schedule := &models.Schedule{}
+		found, err := getDocument(tx, scheduleCollectionName, id, schedule)
+		if err != nil {
+			return err
+		}
+		if !found || !schedule.NextFireTime.Equal(claimedNextFireTime) {
+			return nil
+		}
+		schedule.NextFireTime = nextFireTime
+		schedule.LastRun = lastRun
+		return putDocument(tx, scheduleCollectionName, id, schedule)
+	})
+	if err != nil {
+		return fmt.Errorf("could not release run of schedule %s: %w", id, err)
+	}
+	return nil
+}
+
+// SetLastScheduleRun stores the last run of the schedule
+func (sr *EmbeddedScheduleRepo) SetLastScheduleRun(id string, run models.ScheduleRun) error {
+	return sr.updateSchedule(id, func(schedule *models.Schedule) {
+		schedule.LastRun = &run
+	})
+}
+
+// SetSchedulePaused pauses or resumes the schedule
+func (sr *EmbeddedScheduleRepo) SetSchedulePaused(id string, paused bool, nextFireTime time.Time) error {
+	return sr.updateSchedule(id, func(schedule *models.Schedule) {
+		schedule.Paused = paused
+		schedule.NextFireTime = nextFireTime
+	})
+}
+
+// DeleteSchedule deletes the schedule with the given ID
+func (sr *EmbeddedScheduleRepo) DeleteSchedule(id string) error {
+	if err := sr.DB.deleteKey(scheduleCollectionName, id); err != nil {
+		return fmt.Errorf("could not delete schedule %s: %w", id, err)
+	}
+	return nil
+}
+
+func (sr *EmbeddedScheduleRepo) updateSchedule(id string, update func(schedule *models.Schedule)) error {
+	return sr.DB.update(func(tx *bbolt.Tx) error {
+		schedule := &models.Schedule{}
+		found, err := getDocument(tx, scheduleCollectionName, id, schedule)
+		if err != nil {
+			return fmt.Errorf("could not update schedule %s: %w", id, err)
+		}
+		if !found {
+			return ErrScheduleNotFound
+		}
+		update(schedule)
+		return putDocument(tx, scheduleCollectionName, id, schedule)
+	})
+}
@@ -0,0 +1,45 @@
+package db
+
+import (
+	"fmt"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"sort"
+)
+
+// EmbeddedSequenceQueueRepo stores queued sequences in a bucket of an embedded database
+type EmbeddedSequenceQueueRepo struct {
+	DB *EmbeddedDB
+}
+
+func NewEmbeddedSequenceQueueRepo(db *EmbeddedDB) *EmbeddedSequenceQueueRepo {
+	return &EmbeddedSequenceQueueRepo{DB: db}
+}
+
+func (sq *EmbeddedSequenceQueueRepo) QueueSequence(item models.QueueItem) error {
+	return insertQueueItemIntoBucket(sq.DB, sequenceQueueCollectionName, item)
+}
+
+func (sq *EmbeddedSequenceQueueRepo) GetQueuedSequences() ([]models.QueueItem, error) {
+	queuedItems, err := getQueueItemsFromBucket(sq.DB, sequenceQueueCollectionName, func(item models.QueueItem) bool {
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// ascending order -> oldest to newest
+	sort.SliceStable(queuedItems, func(i, j int) bool {
+		return queuedItems[i].Timestamp.Before(queuedItems[j].Timestamp)
+	})
+	return queuedItems, nil
+}
+
+func (sq *EmbeddedSequenceQueueRepo) DeleteQueuedSequences(itemFilter models.QueueItem) error {
+	err := deleteQueueItemsFromBucket(sq.DB, sequenceQueueCollectionName, func(item models.QueueItem) bool {
+		return (itemFilter.EventID == "" || item.EventID == itemFilter.EventID) && queueItemMatchesScope(item, itemFilter.Scope)
+	})
+	if err != nil {
+		return fmt.Errorf("could not delete queued sequences that match filter %v: %s", itemFilter, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	log "github.com/sirupsen/logrus"
+	"go.etcd.io/bbolt"
+	"sort"
+	"strings"
+)
+
+// EmbeddedStateRepo stores the sequence states of a project in a bucket of an embedded database, using the keptn context as the key
+type EmbeddedStateRepo struct {
+	DB *EmbeddedDB
+}
+
+func NewEmbeddedStateRepo(db *EmbeddedDB) *EmbeddedStateRepo {
+	return &EmbeddedStateRepo{DB: db}
+}
+
+func (e *EmbeddedStateRepo) CreateSequenceState(state models.SequenceState) error {
+	if err := validateSequenceState(state); err != nil {
+		return err
+	}
+	return e.DB.update(func(tx *bbolt.Tx) error {
+		bucket := state.Project + taskSequenceStateCollectionSuffix
+		found, err := getDocument(tx, bucket, state.Shkeptncontext, &models.SequenceState{})
+		if err != nil {
+			return err
+		}
+		if found {
+			return ErrStateAlreadyExists
+		}
+		return putDocument(tx, bucket, state.Shkeptncontext, state)
+	})
+}
+
+func (e *EmbeddedStateRepo) FindSequenceStates(filter models.StateFilter) (*models.SequenceStates, error) {
+	if filter.Project == "" {
+		return nil, errors.New("project must be set")
+	}
+
+	states := []models.SequenceState{}
+	err := e.DB.forEach(filter.Project+taskSequenceStateCollectionSuffix, func(_ string, value []byte) error {
+		sequenceState := models.SequenceState{}
+		if err := json.Unmarshal(value, &sequenceState); err != nil {
+			log.WithError(err).Error("could not decode sequence state")
+			return nil
+		}
+		if sequenceStateMatchesFilter(sequenceState, filter) {
+			states = append(states, sequenceState)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(states, func(i, j int) bool {
+		return states[i].Time > states[j].Time
+	})
+
+	totalCount := int64(len(states))
+	start, end, nextPageKey := paginate(totalCount, filter.NextPageKey, filter.PageSize)
+
+	return &models.SequenceStates{
+		States:      states[start:end],
+		NextPageKey: nextPageKey,
+		PageSize:    0,
+		TotalCount:  totalCount,
+	}, nil
+}
+
+func (e *EmbeddedStateRepo) UpdateSequenceState(state models.SequenceState) error {
+	if err := validateSequenceState(state); err != nil {
+		return err
+	}
+	return e.DB.update(func(tx *bbolt.Tx) error {
+		bucket := state.Project + taskSequenceStateCollectionSuffix
+		found, err := getDocument(tx, bucket, state.Shkeptncontext, &models.SequenceState{})
+		if err != nil || !found {
+			return err
+		}
+		return putDocument(tx, bucket, state.Shkeptncontext, state)
+	})
+}
+
+func (e *EmbeddedStateRepo) DeleteSequenceStates(filter models.StateFilter) error {
+	if filter.Project == "" {
+		return errors.New("project must be set")
+	}
+	if filter.KeptnContext == "" {
+		return errors.New("keptnContext must be set")
+	}
+	return e.DB.deleteWhere(filter.Project+taskSequenceStateCollectionSuffix, func(value []byte) (bool, error) {
+		sequenceState := models.SequenceState{}
+		if err := json.Unmarshal(value, &sequenceState); err != nil {
+			return false, err
+		}
+		return sequenceStateMatchesFilter(sequenceState, filter), nil
+	})
+}
+
+func sequenceStateMatchesFilter(state models.SequenceState, filter models.StateFilter) bool {
+	if state.Project != filter.Project {
+		return false
+	}
+	if filter.KeptnContext != "" {
+		splitContexts := strings.Split(strings.ReplaceAll(filter.KeptnContext, " ", ""), ",")
+		if !containsString(splitContexts, state.Shkeptncontext) {
+			return false
+		}
+	}
+	if filter.Name != "" && state.Name != filter.Name {
+		return false
+	}
+	if filter.State != "" && state.State != filter.State {
+		return false
+	}
+	// the timestamps are compared as strings, same as in the MongoDB backend
+	if filter.FromTime != "" && state.Time <= filter.FromTime {
+		return false
+	}
+	if filter.BeforeTime != "" && state.Time >= filter.BeforeTime {
+		return false
+	}
+	return true
+}
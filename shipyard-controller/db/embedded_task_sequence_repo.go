@@ -0,0 +1,89 @@
+package db
+
+import (
+	"encoding/json"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// EmbeddedTaskSequenceRepo stores the task executions of a project in a bucket of an embedded database
+type EmbeddedTaskSequenceRepo struct {
+	DB *EmbeddedDB
+}
+
+func NewEmbeddedTaskSequenceRepo(db *EmbeddedDB) *EmbeddedTaskSequenceRepo {
+	return &EmbeddedTaskSequenceRepo{DB: db}
+}
+
+// GetTaskExecutions returns all task executions of the project that match the filter
+func (e *EmbeddedTaskSequenceRepo) GetTaskExecutions(project string, filter models.TaskExecution) ([]models.TaskExecution, error) {
+	result := []models.TaskExecution{}
+	err := e.DB.forEach(project+taskSequenceCollectionNameSuffix, func(_ string, value []byte) error {
+		taskExecution := models.TaskExecution{}
+		if err := json.Unmarshal(value, &taskExecution); err != nil {
+			log.WithError(err).Errorf("could not decode task sequence mapping")
+			return nil
+		}
+		if taskExecutionMatchesFilter(taskExecution, filter) {
+			result = append(result, taskExecution)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateTaskExecution stores the given task execution
+func (e *EmbeddedTaskSequenceRepo) CreateTaskExecution(project string, taskExecution models.TaskExecution) error {
+	if err := e.DB.insert(project+taskSequenceCollectionNameSuffix, taskExecution); err != nil {
+		log.Errorf("Could not store task execution %s -> %s: %s", taskExecution.TriggeredEventID, taskExecution.TaskSequenceName, err.Error())
+		return err
+	}
+	return nil
+}
+
+// DeleteTaskExecution deletes the task executions of the given task sequence
+func (e *EmbeddedTaskSequenceRepo) DeleteTaskExecution(keptnContext, project, stage, taskSequenceName string) error {
+	err := e.DB.deleteWhere(project+taskSequenceCollectionNameSuffix, func(value []byte) (bool, error) {
+		taskExecution := models.TaskExecution{}
+		if err := json.Unmarshal(value, &taskExecution); err != nil {
+			return false, nil
+		}
+		return taskExecution.KeptnContext == keptnContext && taskExecution.Stage == stage && taskExecution.TaskSequenceName == taskSequenceName, nil
+	})
+	if err != nil {
+		log.Errorf("Could not delete entries for task %s with context %s in stage %s: %s", taskSequenceName, keptnContext, stage, err.Error())
+		return err
+	}
+	return nil
+}
+
+// DeleteRepo deletes all task executions of the project
+func (e *EmbeddedTaskSequenceRepo) DeleteRepo(project string) error {
+	if err := e.DB.dropBucket(project + taskSequenceCollectionNameSuffix); err != nil {
+		log.Error(err.Error())
+		return err
+	}
+	return nil
+}
+
+func taskExecutionMatchesFilter(taskExecution models.TaskExecution, filter models.TaskExecution) bool {
+	if filter.TriggeredEventID != "" && taskExecution.TriggeredEventID != filter.TriggeredEventID {
+		return false
+	}
+	if filter.KeptnContext != "" && taskExecution.KeptnContext != filter.KeptnContext {
+		return false
+	}
+	if filter.TaskSequenceName != "" && taskExecution.TaskSequenceName != filter.TaskSequenceName {
+		return false
+	}
+	if filter.Stage != "" && taskExecution.Stage != filter.Stage {
+		return false
+	}
+	if filter.Service != "" && taskExecution.Service != filter.Service {
+		return false
+	}
+	return true
+}
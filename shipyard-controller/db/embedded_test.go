@@ -0,0 +1,88 @@
+package db_test
+
+import (
+	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestEmbeddedDB(t *testing.T) *db.EmbeddedDB {
+	embeddedDB, err := db.NewEmbeddedDB(filepath.Join(t.TempDir(), "shipyard-controller.db"))
+	require.Nil(t, err)
+	t.Cleanup(func() {
+		_ = embeddedDB.Close()
+	})
+	return embeddedDB
+}
+
+func TestEmbeddedRepositories_Conformance(t *testing.T) {
+	runRepositoryConformanceTests(t, db.NewEmbeddedRepositoryFactory(newTestEmbeddedDB(t)))
+}
+
+func TestNewRepositoryFactory(t *testing.T) {
+	factory, err := db.NewRepositoryFactory(db.MongoDBBackend, "")
+	require.Nil(t, err)
+	require.IsType(t, &db.MongoDBRepositoryFactory{}, factory)
+
+	factory, err = db.NewRepositoryFactory(db.EmbeddedBackend, filepath.Join(t.TempDir(), "shipyard-controller.db"))
+	require.Nil(t, err)
+	require.IsType(t, &db.EmbeddedRepositoryFactory{}, factory)
+	require.Nil(t, factory.(*db.EmbeddedRepositoryFactory).DB.Close())
+
+	_, err = db.NewRepositoryFactory("unknown", "")
+	require.NotNil(t, err)
+}
+
+func TestEmbeddedDB_PersistsDataAfterReopening(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "shipyard-controller.db")
+
+	embeddedDB, err := db.NewEmbeddedDB(path)
+	require.Nil(t, err)
+	err = db.NewEmbeddedProjectsRepo(embeddedDB).CreateProject(&models.ExpandedProject{ProjectName: "my-project"})
+	require.Nil(t, err)
+	require.Nil(t, embeddedDB.Close())
+
+	embeddedDB, err = db.NewEmbeddedDB(path)
+	require.Nil(t, err)
+	defer embeddedDB.Close()
+
+	project, err := db.NewEmbeddedProjectsRepo(embeddedDB).GetProject("my-project")
+	require.Nil(t, err)
+	require.NotNil(t, project)
+}
+
+func TestEmbeddedUniformRepo_RemovesExpiredIntegrations(t *testing.T) {
+	repo := db.NewEmbeddedUniformRepo(newTestEmbeddedDB(t))
+	require.Nil(t, repo.SetupTTLIndex(time.Minute))
+
+	expired := models.Integration{ID: "expired"}
+	expired.MetaData.LastSeen = time.Now().UTC().Add(-2 * time.Minute)
+	active := models.Integration{ID: "active"}
+	active.MetaData.LastSeen = time.Now().UTC()
+	require.Nil(t, repo.CreateUniformIntegration(expired))
+	require.Nil(t, repo.CreateUniformIntegration(active))
+
+	integrations, err := repo.GetUniformIntegrations(models.GetUniformIntegrationsParams{})
+	require.Nil(t, err)
+	require.Len(t, integrations, 1)
+	require.Equal(t, "active", integrations[0].ID)
+}
+
+func TestEmbeddedLogRepo_RemovesExpiredLogEntries(t *testing.T) {
+	repo := db.NewEmbeddedLogRepo(newTestEmbeddedDB(t))
+	require.Nil(t, repo.SetupTTLIndex(time.Hour))
+
+	err := repo.CreateLogEntries([]models.LogEntry{
+		{IntegrationID: "my-integration", Message: "expired", Time: time.Now().UTC().Add(-2 * time.Hour)},
+		{IntegrationID: "my-integration", Message: "active"},
+	})
+	require.Nil(t, err)
+
+	logs, err := repo.GetLogEntries(models.GetLogParams{})
+	require.Nil(t, err)
+	require.Equal(t, int64(1), logs.TotalCount)
+	require.Equal(t, "active", logs.Logs[0].Message)
+}
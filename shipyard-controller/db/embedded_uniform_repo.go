@@ -0,0 +1,239 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"go.etcd.io/bbolt"
+	"sync"
+	"time"
+)
+
+// EmbeddedUniformRepo stores uniform integrations in a bucket of an embedded database, using the integration ID as the key
+type EmbeddedUniformRepo struct {
+	DB *EmbeddedDB
+	// ttl is the duration after which integrations that have not been seen anymore are removed. Since the embedded database
+	// has no TTL indexes, expired integrations are removed whenever the integrations are read
+	ttl   time.Duration
+	mutex sync.Mutex
+}
+
+func NewEmbeddedUniformRepo(db *EmbeddedDB) *EmbeddedUniformRepo {
+	return &EmbeddedUniformRepo{DB: db}
+}
+
+func (e *EmbeddedUniformRepo) GetUniformIntegrations(params models.GetUniformIntegrationsParams) ([]models.Integration, error) {
+	if err := e.deleteExpiredIntegrations(); err != nil {
+		return nil, err
+	}
+
+	result := []models.Integration{}
+	err := e.DB.forEach(uniformCollectionName, func(_ string, value []byte) error {
+		integration := models.Integration{}
+		if err := json.Unmarshal(value, &integration); err != nil {
+			return fmt.Errorf("could not decode integration: %w", err)
+		}
+		if integrationMatchesFilter(integration, params) {
+			result = append(result, integration)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (e *EmbeddedUniformRepo) DeleteUniformIntegration(id string) error {
+	return e.DB.deleteKey(uniformCollectionName, id)
+}
+
+func (e *EmbeddedUniformRepo) CreateUniformIntegration(integration models.Integration) error {
+	return e.DB.update(func(tx *bbolt.Tx) error {
+		found, err := getDocument(tx, uniformCollectionName, integration.ID, &models.Integration{})
+		if err != nil {
+			return err
+		}
+		if found {
+			return ErrUniformRegistrationAlreadyExists
+		}
+		return putDocument(tx, uniformCollectionName, integration.ID, integration)
+	})
+}
+
+func (e *EmbeddedUniformRepo) CreateOrUpdateUniformIntegration(integration models.Integration) error {
+	return e.DB.put(uniformCollectionName, integration.ID, integration)
+}
+
+func (e *EmbeddedUniformRepo) CreateOrUpdateSubscription(integrationID string, subscription models.Subscription) error {
+	return e.updateIntegration(integrationID, func(integration *models.Integration) {
+		replaceSubscription(integration, subscription)
+	})
+}
+
+func (e *EmbeddedUniformRepo) DeleteSubscription(integrationID, subscriptionID string) error {
+	return e.updateIntegration(integrationID, func(integration *models.Integration) {
+		removeSubscription(integration, subscriptionID)
+	})
+}
+
+func (e *EmbeddedUniformRepo) GetSubscription(integrationID, subscriptionID string) (*models.Subscription, error) {
+	integration, err := e.getIntegration(integrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range integration.Subscriptions {
+		if s.ID == subscriptionID {
+			returnSubscription := models.Subscription(s)
+			return &returnSubscription, nil
+		}
+	}
+	return nil, ErrUniformSubscriptionNotFound
+}
+
+func (e *EmbeddedUniformRepo) GetSubscriptions(integrationID string) ([]models.Subscription, error) {
+	integration, err := e.getIntegration(integrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var subscriptions []models.Subscription
+	for _, s := range integration.Subscriptions {
+		subscriptions = append(subscriptions, models.Subscription(s))
+	}
+	return subscriptions, nil
+}
+
+func (e *EmbeddedUniformRepo) DeleteServiceFromSubscriptions(subscriptionName string) error {
+	return e.DB.update(func(tx *bbolt.Tx) error {
+		integrations := map[string]models.Integration{}
+		err := forEachDocument(tx, uniformCollectionName, func(key string, value []byte) error {
+			integration := models.Integration{}
+			if err := json.Unmarshal(value, &integration); err != nil {
+				return fmt.Errorf("could not decode integration: %w", err)
+			}
+			if integrationContainsService(integration, subscriptionName) {
+				integrations[key] = integration
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for key, integration := range integrations {
+			removeServiceFromIntegration(&integration, subscriptionName)
+			if err := putDocument(tx, uniformCollectionName, key, integration); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (e *EmbeddedUniformRepo) UpdateLastSeen(integrationID string) (*models.Integration, error) {
+	var updatedIntegration *models.Integration
+	err := e.updateIntegration(integrationID, func(integration *models.Integration) {
+		integration.MetaData.LastSeen = time.Now().UTC()
+		updatedIntegration = integration
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updatedIntegration, nil
+}
+
+// SetupTTLIndex sets the duration after which integrations that have not been seen anymore are removed
+func (e *EmbeddedUniformRepo) SetupTTLIndex(duration time.Duration) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.ttl = duration
+	return nil
+}
+
+func (e *EmbeddedUniformRepo) getIntegration(integrationID string) (*models.Integration, error) {
+	integration := &models.Integration{}
+	found, err := e.DB.get(uniformCollectionName, integrationID, integration)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrUniformRegistrationNotFound
+	}
+	return integration, nil
+}
+
+func (e *EmbeddedUniformRepo) updateIntegration(integrationID string, update func(integration *models.Integration)) error {
+	return e.DB.update(func(tx *bbolt.Tx) error {
+		integration := &models.Integration{}
+		found, err := getDocument(tx, uniformCollectionName, integrationID, integration)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return ErrUniformRegistrationNotFound
+		}
+		update(integration)
+		return putDocument(tx, uniformCollectionName, integrationID, integration)
+	})
+}
+
+func (e *EmbeddedUniformRepo) deleteExpiredIntegrations() error {
+	e.mutex.Lock()
+	ttl := e.ttl
+	e.mutex.Unlock()
+	if ttl <= 0 {
+		return nil
+	}
+
+	expiry := time.Now().UTC().Add(-ttl)
+	return e.DB.deleteWhere(uniformCollectionName, func(value []byte) (bool, error) {
+		integration := models.Integration{}
+		if err := json.Unmarshal(value, &integration); err != nil {
+			return false, err
+		}
+		return integration.MetaData.LastSeen.Before(expiry), nil
+	})
+}
+
+func integrationMatchesFilter(integration models.Integration, params models.GetUniformIntegrationsParams) bool {
+	if params.ID != "" && integration.ID != params.ID {
+		return false
+	}
+	if params.Name != "" && integration.Name != params.Name {
+		return false
+	}
+	if params.Project == "" && params.Stage == "" && params.Service == "" {
+		return true
+	}
+
+	// like in the MongoDB backend, each of the filter properties can be matched by a different subscription
+	projectMatched, stageMatched, serviceMatched := params.Project == "", params.Stage == "", params.Service == ""
+	for _, subscription := range integration.Subscriptions {
+		projectMatched = projectMatched || containsString(subscription.Filter.Projects, params.Project)
+		stageMatched = stageMatched || containsString(subscription.Filter.Stages, params.Stage)
+		serviceMatched = serviceMatched || containsString(subscription.Filter.Services, params.Service)
+	}
+	return projectMatched && stageMatched && serviceMatched
+}
+
+func integrationContainsService(integration models.Integration, serviceName string) bool {
+	if integration.Subscription.Filter.Service == serviceName {
+		return true
+	}
+	for _, subscription := range integration.Subscriptions {
+		if containsString(subscription.Filter.Services, serviceName) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -6,6 +6,7 @@ package db_mock
 import (
 	"github.com/keptn/keptn/shipyard-controller/models"
 	"sync"
+	"time"
 )
 
 // UniformRepoMock is a mock implementation of db.UniformRepo.
@@ -41,6 +42,9 @@ import (
 // 			GetUniformIntegrationsFunc: func(filter models.GetUniformIntegrationsParams) ([]models.Integration, error) {
 // 				panic("mock out the GetUniformIntegrations method")
 // 			},
+// 			SetupTTLIndexFunc: func(duration time.Duration) error {
+// 				panic("mock out the SetupTTLIndex method")
+// 			},
 // 			UpdateLastSeenFunc: func(integrationID string) (*models.Integration, error) {
 // 				panic("mock out the UpdateLastSeen method")
 // 			},
@@ -78,6 +82,9 @@ type UniformRepoMock struct {
 	// GetUniformIntegrationsFunc mocks the GetUniformIntegrations method.
 	GetUniformIntegrationsFunc func(filter models.GetUniformIntegrationsParams) ([]models.Integration, error)
 
+	// SetupTTLIndexFunc mocks the SetupTTLIndex method.
+	SetupTTLIndexFunc func(duration time.Duration) error
+
 	// UpdateLastSeenFunc mocks the UpdateLastSeen method.
 	UpdateLastSeenFunc func(integrationID string) (*models.Integration, error)
 
@@ -134,6 +141,11 @@ type UniformRepoMock struct {
 			// Filter is the filter argument value.
 			Filter models.GetUniformIntegrationsParams
 		}
+		// SetupTTLIndex holds details about calls to the SetupTTLIndex method.
+		SetupTTLIndex []struct {
+			// Duration is the duration argument value.
+			Duration time.Duration
+		}
 		// UpdateLastSeen holds details about calls to the UpdateLastSeen method.
 		UpdateLastSeen []struct {
 			// IntegrationID is the integrationID argument value.
@@ -149,6 +161,7 @@ type UniformRepoMock struct {
 	lockGetSubscription                  sync.RWMutex
 	lockGetSubscriptions                 sync.RWMutex
 	lockGetUniformIntegrations           sync.RWMutex
+	lockSetupTTLIndex                    sync.RWMutex
 	lockUpdateLastSeen                   sync.RWMutex
 }
 
@@ -443,6 +456,37 @@ func (mock *UniformRepoMock) GetUniformIntegrationsCalls() []struct {
 	return calls
 }
 
+// SetupTTLIndex calls SetupTTLIndexFunc.
+func (mock *UniformRepoMock) SetupTTLIndex(duration time.Duration) error {
+	if mock.SetupTTLIndexFunc == nil {
+		panic("UniformRepoMock.SetupTTLIndexFunc: method is nil but UniformRepo.SetupTTLIndex was just called")
+	}
+	callInfo := struct {
+		Duration time.Duration
+	}{
+		Duration: duration,
+	}
+	mock.lockSetupTTLIndex.Lock()
+	mock.calls.SetupTTLIndex = append(mock.calls.SetupTTLIndex, callInfo)
+	mock.lockSetupTTLIndex.Unlock()
+	return mock.SetupTTLIndexFunc(duration)
+}
+
+// SetupTTLIndexCalls gets all the calls that were made to SetupTTLIndex.
+// Check the length with:
+//     len(mockedUniformRepo.SetupTTLIndexCalls())
+func (mock *UniformRepoMock) SetupTTLIndexCalls() []struct {
+	Duration time.Duration
+} {
+	var calls []struct {
+		Duration time.Duration
+	}
+	mock.lockSetupTTLIndex.RLock()
+	calls = mock.calls.SetupTTLIndex
+	mock.lockSetupTTLIndex.RUnlock()
+	return calls
+}
+
 // UpdateLastSeen calls UpdateLastSeenFunc.
 func (mock *UniformRepoMock) UpdateLastSeen(integrationID string) (*models.Integration, error) {
 	if mock.UpdateLastSeenFunc == nil {
@@ -0,0 +1,112 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+const leaseCollectionName = "shipyard-controller-leases"
+
+var ErrLeaseNotFound = errors.New("lease not found")
+
+// MongoDBLeaseRepo stores leases in a MongoDB collection. Since every lease is stored in a document with the lease name as its _id,
+// the unique index on _id guarantees that only one holder can own a lease at a time
+type MongoDBLeaseRepo struct {
+	DBConnection *MongoDBConnection
+}
+
+// NewMongoDBLeaseRepo creates a new MongoDBLeaseRepo
+func NewMongoDBLeaseRepo(dbConnection *MongoDBConnection) *MongoDBLeaseRepo {
+	return &MongoDBLeaseRepo{DBConnection: dbConnection}
+}
+
+// AcquireLease tries to acquire (or renew) the lease with the given name. If the lease is currently held by another holder and has not expired yet,
+// the upsert operation will fail with a duplicate key error, and false is returned
+func (lr *MongoDBLeaseRepo) AcquireLease(name, holder string, duration time.Duration) (bool, error) {
+	collection, ctx, cancel, err := lr.getCollectionAndContext()
+	if err != nil {
+		return false, err
+	}
+	defer cancel()
+
+	now := time.Now().UTC()
+
+	filter := bson.M{
+		"_id": name,
+		"$or": []bson.M{
+			{"holder": holder},
+			{"expiresAt": bson.M{"$lt": now}},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"holder":    holder,
+			"expiresAt": now.Add(duration),
+		},
+	}
+
+	_, err = collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			// the lease exists, but is held by someone else
+			return false, nil
+		}
+		return false, fmt.Errorf("could not acquire lease %s: %w", name, err)
+	}
+	return true, nil
+}
+
+// ReleaseLease releases the lease with the given name if it is held by the given holder
+func (lr *MongoDBLeaseRepo) ReleaseLease(name, holder string) error {
+	collection, ctx, cancel, err := lr.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	_, err = collection.DeleteOne(ctx, bson.M{"_id": name, "holder": holder})
+	if err != nil {
+		return fmt.Errorf("could not release lease %s: %w", name, err)
+	}
+	return nil
+}
+
+// GetLease returns the lease with the given name
+func (lr *MongoDBLeaseRepo) GetLease(name string) (*models.Lease, error) {
+	collection, ctx, cancel, err := lr.getCollectionAndContext()
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	result := collection.FindOne(ctx, bson.M{"_id": name})
+	if result.Err() != nil {
+		if result.Err() == mongo.ErrNoDocuments {
+			return nil, ErrLeaseNotFound
+		}
+		return nil, result.Err()
+	}
+
+	lease := &models.Lease{}
+	if err := result.Decode(lease); err != nil {
+		return nil, fmt.Errorf("could not decode lease %s: %w", name, err)
+	}
+	return lease, nil
+}
+
+func (lr *MongoDBLeaseRepo) getCollectionAndContext() (*mongo.Collection, context.Context, context.CancelFunc, error) {
+	err := lr.DBConnection.EnsureDBConnection()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	collection := lr.DBConnection.Client.Database(getDatabaseName()).Collection(leaseCollectionName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	return collection, ctx, cancel, nil
+}
@@ -0,0 +1,74 @@
+package db
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestMongoDBLeaseRepo_AcquireAndRelease(t *testing.T) {
+	repo := NewMongoDBLeaseRepo(GetMongoDBConnectionInstance())
+
+	const leaseName = "test-lease-acquire"
+
+	acquired, err := repo.AcquireLease(leaseName, "instance-1", time.Minute)
+	require.Nil(t, err)
+	require.True(t, acquired)
+
+	// the lease is held by instance-1, so instance-2 must not be able to get it
+	acquired, err = repo.AcquireLease(leaseName, "instance-2", time.Minute)
+	require.Nil(t, err)
+	require.False(t, acquired)
+
+	// instance-1 can renew its own lease
+	acquired, err = repo.AcquireLease(leaseName, "instance-1", time.Minute)
+	require.Nil(t, err)
+	require.True(t, acquired)
+
+	lease, err := repo.GetLease(leaseName)
+	require.Nil(t, err)
+	require.Equal(t, "instance-1", lease.Holder)
+
+	// releasing a lease held by another instance does not have any effect
+	err = repo.ReleaseLease(leaseName, "instance-2")
+	require.Nil(t, err)
+
+	lease, err = repo.GetLease(leaseName)
+	require.Nil(t, err)
+	require.Equal(t, "instance-1", lease.Holder)
+
+	err = repo.ReleaseLease(leaseName, "instance-1")
+	require.Nil(t, err)
+
+	_, err = repo.GetLease(leaseName)
+	require.ErrorIs(t, err, ErrLeaseNotFound)
+
+	acquired, err = repo.AcquireLease(leaseName, "instance-2", time.Minute)
+	require.Nil(t, err)
+	require.True(t, acquired)
+}
+
+func TestMongoDBLeaseRepo_AcquireExpiredLease(t *testing.T) {
+	repo := NewMongoDBLeaseRepo(GetMongoDBConnectionInstance())
+
+	const leaseName = "test-lease-expired"
+
+	acquired, err := repo.AcquireLease(leaseName, "instance-1", 100*time.Millisecond)
+	require.Nil(t, err)
+	require.True(t, acquired)
+
+	acquired, err = repo.AcquireLease(leaseName, "instance-2", time.Minute)
+	require.Nil(t, err)
+	require.False(t, acquired)
+
+	<-time.After(200 * time.Millisecond)
+
+	// the lease of instance-1 has expired, so instance-2 can take it over
+	acquired, err = repo.AcquireLease(leaseName, "instance-2", time.Minute)
+	require.Nil(t, err)
+	require.True(t, acquired)
+
+	lease, err := repo.GetLease(leaseName)
+	require.Nil(t, err)
+	require.Equal(t, "instance-2", lease.Holder)
+}
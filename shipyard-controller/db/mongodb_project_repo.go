@@ -116,22 +116,7 @@ func (m *MongoDBProjectsRepo) UpdateProject(project *models.ExpandedProject) err
 }
 
 func (m *MongoDBProjectsRepo) UpdateProjectUpstream(projectName string, uri string, user string) error {
-	existingProject, err := m.GetProject(projectName)
-	if err != nil {
-		return err
-	}
-	if existingProject == nil {
-		return nil
-	}
-	if existingProject.GitRemoteURI != uri || existingProject.GitUser != user {
-		existingProject.GitRemoteURI = uri
-		existingProject.GitUser = user
-		if err := m.UpdateProject(existingProject); err != nil {
-			log.Errorf("could not update upstream credentials of project %s: %s", projectName, err.Error())
-			return err
-		}
-	}
-	return nil
+	return updateProjectUpstream(m, projectName, uri, user)
 }
 
 func (m *MongoDBProjectsRepo) DeleteProject(projectName string) error {
@@ -0,0 +1,221 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+const scheduleCollectionName = "shipyard-controller-schedules"
+
+var ErrScheduleNotFound = errors.New("schedule not found")
+
+// MongoDBScheduleRepo stores the schedules that periodically trigger sequences. The next fire time of a schedule is persisted,
+// which allows the schedules to be continued after a restart of the shipyard-controller
+type MongoDBScheduleRepo struct {
+	DBConnection *MongoDBConnection
+}
+
+// NewMongoDBScheduleRepo creates a new MongoDBScheduleRepo
+func NewMongoDBScheduleRepo(dbConnection *MongoDBConnection) *MongoDBScheduleRepo {
+	return &MongoDBScheduleRepo{DBConnection: dbConnection}
+}
+
+// GetSchedules returns all schedules of the given project, or of all projects if the project is empty
+func (sr *MongoDBScheduleRepo) GetSchedules(project string) ([]models.Schedule, error) {
+	collection, ctx, cancel, err := sr.getCollectionAndContext()
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	filter := bson.M{}
+	if project != "" {
+		filter["project"] = project
+	}
+
+	cur, err := collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve schedules: %w", err)
+	}
+	defer cur.Close(ctx)
+
+	schedules := []models.Schedule{}
+	if err := cur.All(ctx, &schedules); err != nil {
+		return nil, fmt.Errorf("could not decode schedules: %w", err)
+	}
+	return schedules, nil
+}
+
+// GetSchedule returns the schedule with the given ID
+func (sr *MongoDBScheduleRepo) GetSchedule(id string) (*models.Schedule, error) {
+	collection, ctx, cancel, err := sr.getCollectionAndContext()
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	result := collection.FindOne(ctx, bson.M{"_id": id})
+	if result.Err() != nil {
+		if result.Err() == mongo.ErrNoDocuments {
+			return nil, ErrScheduleNotFound
+		}
+		return nil, result.Err()
+	}
+
+	schedule := &models.Schedule{}
+	if err := result.Decode(schedule); err != nil {
+		return nil, fmt.Errorf("could not decode schedule %s: %w", id, err)
+	}
+	return schedule, nil
+}
+
+// UpsertSchedule creates or updates the given schedule without modifying the paused state and the last run of an existing schedule.
+// The next fire time of an existing schedule is only updated if its cron expression has changed
+func (sr *MongoDBScheduleRepo) UpsertSchedule(schedule models.Schedule) error {
+	collection, ctx, cancel, err := sr.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	definition := bson.M{
+		"project":    schedule.Project,
+		"stage":      schedule.Stage,
+		"service":    schedule.Service,
+		"sequence":   schedule.Sequence,
+		"cron":       schedule.Cron,
+		"properties": schedule.Properties,
+	}
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": schedule.ID, "cron": schedule.Cron}, bson.M{"$set": definition})
+	if err != nil {
+		return fmt.Errorf("could not store schedule %s: %w", schedule.ID, err)
+	}
+	if result.MatchedCount == 1 {
+		return nil
+	}
+
+	// the schedule is new, or its cron expression has changed
+	definition["nextFireTime"] = schedule.NextFireTime
+	update := bson.M{
+		"$set": definition,
+		"$setOnInsert": bson.M{
+			"paused": schedule.Paused,
+		},
+	}
+	_, err = collection.UpdateOne(ctx, bson.M{"_id": schedule.ID}, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("could not store schedule %s: %w", schedule.ID, err)
+	}
+	return nil
+}
+
+// ClaimScheduledRun atomically moves the next fire time of the schedule forward. Only one caller can successfully claim a run,
+// since the update only matches if the stored next fire time has not been changed in the meantime
+func (sr *MongoDBScheduleRepo) ClaimScheduledRun(id string, expectedNextFireTime, nextFireTime time.Time, run models.ScheduleRun) (bool, error) {
+	collection, ctx, cancel, err := sr.getCollectionAndContext()
+	if err != nil {
+		return false, err
+	}
+	defer cancel()
+
+	filter := bson.M{
+		"_id":          id,
+		"paused":       false,
+		"nextFireTime": expectedNextFireTime,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"nextFireTime": nextFireTime,
+			"lastRun":      run,
+		},
+	}
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, fmt.Errorf("could not claim run of schedule %s: %w", id, err)
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// ReleaseScheduledRun restores the next fire time and the last run of the schedule, unless the claimed run has been changed in the meantime
+func (sr *MongoDBScheduleRepo) ReleaseScheduledRun(id string, claimedNextFireTime, nextFireTime time.Time, lastRun *models.ScheduleRun) error {
+	collection, ctx, cancel, err := sr.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	filter := bson.M{
+		"_id":          id,
+		"nextFireTime": claimedNextFireTime,
+	}
+	update := bson.M{
+		"$set": bson.M{"nextFireTime": nextFireTime},
+	}
+	if lastRun != nil {
+		update["$set"].(bson.M)["lastRun"] = lastRun
+	} else {
+		update["$unset"] = bson.M{"lastRun": ""}
+	}
+	if _, err := collection.UpdateOne(ctx, filter, update); err != nil {
+		return fmt.Errorf("could not release run of schedule %s: %w", id, err)
+	}
+	return nil
+}
+
+// SetLastScheduleRun stores the last run of the schedule
+func (sr *MongoDBScheduleRepo) SetLastScheduleRun(id string, run models.ScheduleRun) error {
+	return sr.updateSchedule(id, bson.M{"lastRun": run})
+}
+
+// SetSchedulePaused pauses or resumes the schedule
+func (sr *MongoDBScheduleRepo) SetSchedulePaused(id string, paused bool, nextFireTime time.Time) error {
+	return sr.updateSchedule(id, bson.M{"paused": paused, "nextFireTime": nextFireTime})
+}
+
+// DeleteSchedule deletes the schedule with the given ID
+func (sr *MongoDBScheduleRepo) DeleteSchedule(id string) error {
+	collection, ctx, cancel, err := sr.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	if _, err := collection.DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+		return fmt.Errorf("could not delete schedule %s: %w", id, err)
+	}
+	return nil
+}
+
+func (sr *MongoDBScheduleRepo) updateSchedule(id string, fields bson.M) error {
+	collection, ctx, cancel, err := sr.getCollectionAndContext()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": fields})
+	if err != nil {
+		return fmt.Errorf("could not update schedule %s: %w", id, err)
+	}
+	if result.MatchedCount == 0 {
+		return ErrScheduleNotFound
+	}
+	return nil
+}
+
+func (sr *MongoDBScheduleRepo) getCollectionAndContext() (*mongo.Collection, context.Context, context.CancelFunc, error) {
+	err := sr.DBConnection.EnsureDBConnection()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	collection := sr.DBConnection.Client.Database(getDatabaseName()).Collection(scheduleCollectionName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	return collection, ctx, cancel, nil
+}
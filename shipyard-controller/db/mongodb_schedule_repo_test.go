@@ -0,0 +1,99 @@
+package db
+
+import (
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestMongoDBScheduleRepo_UpsertAndGet(t *testing.T) {
+	repo := NewMongoDBScheduleRepo(GetMongoDBConnectionInstance())
+
+	nextFireTime := time.Date(2021, 12, 16, 2, 0, 0, 0, time.UTC)
+	schedule := models.Schedule{
+		ID:           models.GetScheduleID("schedule-project", "dev", "carts", "evaluation"),
+		Project:      "schedule-project",
+		Stage:        "dev",
+		Service:      "carts",
+		Sequence:     "evaluation",
+		Cron:         "0 2 * * *",
+		NextFireTime: nextFireTime,
+	}
+
+	err := repo.UpsertSchedule(schedule)
+	require.Nil(t, err)
+
+	err = repo.SetSchedulePaused(schedule.ID, true, nextFireTime)
+	require.Nil(t, err)
+
+	// updating the definition must not resume the schedule
+	schedule.Cron = "0 3 * * *"
+	schedule.NextFireTime = nextFireTime.Add(time.Hour)
+	err = repo.UpsertSchedule(schedule)
+	require.Nil(t, err)
+
+	storedSchedule, err := repo.GetSchedule(schedule.ID)
+	require.Nil(t, err)
+	require.Equal(t, "0 3 * * *", storedSchedule.Cron)
+	require.True(t, storedSchedule.Paused)
+	require.Equal(t, schedule.NextFireTime, storedSchedule.NextFireTime.UTC())
+
+	schedules, err := repo.GetSchedules("schedule-project")
+	require.Nil(t, err)
+	require.Len(t, schedules, 1)
+
+	schedules, err = repo.GetSchedules("other-project")
+	require.Nil(t, err)
+	require.Empty(t, schedules)
+
+	err = repo.DeleteSchedule(schedule.ID)
+	require.Nil(t, err)
+
+	_, err = repo.GetSchedule(schedule.ID)
+	require.ErrorIs(t, err, ErrScheduleNotFound)
+
+	err = repo.SetSchedulePaused(schedule.ID, false, nextFireTime)
+	require.ErrorIs(t, err, ErrScheduleNotFound)
+}
+
+func TestMongoDBScheduleRepo_ClaimScheduledRun(t *testing.T) {
+	repo := NewMongoDBScheduleRepo(GetMongoDBConnectionInstance())
+
+	nextFireTime := time.Date(2021, 12, 16, 2, 0, 0, 0, time.UTC)
+	schedule := models.Schedule{
+		ID:           models.GetScheduleID("claim-project", "dev", "carts", "evaluation"),
+		Project:      "claim-project",
+		Stage:        "dev",
+		Service:      "carts",
+		Sequence:     "evaluation",
+		Cron:         "0 2 * * *",
+		NextFireTime: nextFireTime,
+	}
+	err := repo.UpsertSchedule(schedule)
+	require.Nil(t, err)
+
+	run := models.ScheduleRun{Time: nextFireTime, KeptnContext: "my-context"}
+
+	claimed, err := repo.ClaimScheduledRun(schedule.ID, nextFireTime, nextFireTime.AddDate(0, 0, 1), run)
+	require.Nil(t, err)
+	require.True(t, claimed)
+
+	// the same run cannot be claimed twice
+	claimed, err = repo.ClaimScheduledRun(schedule.ID, nextFireTime, nextFireTime.AddDate(0, 0, 1), run)
+	require.Nil(t, err)
+	require.False(t, claimed)
+
+	storedSchedule, err := repo.GetSchedule(schedule.ID)
+	require.Nil(t, err)
+	require.Equal(t, nextFireTime.AddDate(0, 0, 1), storedSchedule.NextFireTime.UTC())
+	require.Equal(t, "my-context", storedSchedule.LastRun.KeptnContext)
+
+	// paused schedules cannot be claimed
+	err = repo.SetSchedulePaused(schedule.ID, true, storedSchedule.NextFireTime)
+	require.Nil(t, err)
+
+	claimed, err = repo.ClaimScheduledRun(schedule.ID, storedSchedule.NextFireTime, nextFireTime.AddDate(0, 0, 2), run)
+	require.Nil(t, err)
+	require.False(t, claimed)
+}
@@ -26,14 +26,8 @@ func NewMongoDBStateRepo(dbConnection *MongoDBConnection) *MongoDBStateRepo {
 }
 
 func (mdbrepo *MongoDBStateRepo) CreateSequenceState(state models.SequenceState) error {
-	if state.Project == "" {
-		return errors.New("project must be set")
-	}
-	if state.Shkeptncontext == "" {
-		return errors.New("shkeptncontext must be set")
-	}
-	if state.Name == "" {
-		return errors.New("name must be set")
+	if err := validateSequenceState(state); err != nil {
+		return err
 	}
 	err := mdbrepo.DBConnection.EnsureDBConnection()
 	if err != nil {
@@ -154,14 +148,8 @@ func (mdbrepo *MongoDBStateRepo) getSearchOptions(filter models.StateFilter) bso
 }
 
 func (mdbrepo *MongoDBStateRepo) UpdateSequenceState(state models.SequenceState) error {
-	if state.Project == "" {
-		return errors.New("project must be set")
-	}
-	if state.Shkeptncontext == "" {
-		return errors.New("shkeptncontext must be set")
-	}
-	if state.Name == "" {
-		return errors.New("name must be set")
+	if err := validateSequenceState(state); err != nil {
+		return err
 	}
 	err := mdbrepo.DBConnection.EnsureDBConnection()
 	if err != nil {
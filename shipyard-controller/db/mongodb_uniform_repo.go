@@ -4,13 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	keptnmodels "github.com/keptn/go-utils/pkg/api/models"
 	"github.com/keptn/keptn/shipyard-controller/models"
 	logger "github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
-	"strings"
 	"time"
 )
 
@@ -18,6 +16,7 @@ const uniformCollectionName = "keptnUniform"
 
 var ErrUniformRegistrationAlreadyExists = errors.New("uniform integration already exists")
 var ErrUniformRegistrationNotFound = errors.New("uniform integration not found")
+var ErrUniformSubscriptionNotFound = errors.New("uniform subscription not found")
 
 type MongoDBUniformRepo struct {
 	DbConnection *MongoDBConnection
@@ -104,19 +103,11 @@ func (mdbrepo *MongoDBUniformRepo) CreateOrUpdateSubscription(integrationID stri
 		return err
 	}
 	if len(integrations) == 0 {
-		return mongo.ErrNoDocuments
+		return ErrUniformRegistrationNotFound
 	}
 
 	integration := integrations[0]
-	var keepSubscriptions []keptnmodels.EventSubscription
-	subscriptions := integration.Subscriptions
-	for _, s := range subscriptions {
-		if s.ID != subscription.ID {
-			keepSubscriptions = append(keepSubscriptions, s)
-		}
-	}
-	keepSubscriptions = append(keepSubscriptions, keptnmodels.EventSubscription(subscription))
-	integration.Subscriptions = keepSubscriptions
+	replaceSubscription(&integration, subscription)
 
 	opts := options.Update().SetUpsert(true)
 	filter := bson.D{{"_id", integration.ID}}
@@ -140,18 +131,10 @@ func (mdbrepo *MongoDBUniformRepo) DeleteSubscription(integrationID, subscriptio
 	}
 
 	if len(integrations) == 0 {
-		return mongo.ErrNoDocuments
+		return ErrUniformRegistrationNotFound
 	}
 	integration := integrations[0]
-
-	var keepSubscriptions []keptnmodels.EventSubscription
-	subscriptions := integration.Subscriptions
-	for _, s := range subscriptions {
-		if s.ID != subscriptionID {
-			keepSubscriptions = append(keepSubscriptions, s)
-		}
-	}
-	integration.Subscriptions = keepSubscriptions
+	removeSubscription(&integration, subscriptionID)
 
 	opts := options.Update().SetUpsert(true)
 	filter := bson.D{{"_id", integration.ID}}
@@ -175,7 +158,7 @@ func (mdbrepo *MongoDBUniformRepo) GetSubscription(integrationID, subscriptionID
 	}
 
 	if len(integrations) == 0 {
-		return nil, mongo.ErrNoDocuments
+		return nil, ErrUniformRegistrationNotFound
 	}
 	integration := integrations[0]
 
@@ -185,7 +168,7 @@ func (mdbrepo *MongoDBUniformRepo) GetSubscription(integrationID, subscriptionID
 			return &returnSubscription, nil
 		}
 	}
-	return nil, mongo.ErrNoDocuments
+	return nil, ErrUniformSubscriptionNotFound
 }
 
 func (mdbrepo *MongoDBUniformRepo) GetSubscriptions(integrationID string) ([]models.Subscription, error) {
@@ -201,7 +184,7 @@ func (mdbrepo *MongoDBUniformRepo) GetSubscriptions(integrationID string) ([]mod
 	}
 
 	if len(integrations) == 0 {
-		return nil, mongo.ErrNoDocuments
+		return nil, ErrUniformRegistrationNotFound
 	}
 	integration := integrations[0]
 
@@ -333,34 +316,7 @@ func (mdbrepo *MongoDBUniformRepo) DeleteServiceFromSubscriptions(subscriptionNa
 			//log the error, but continue
 			logger.Errorf("could not decode integration: %s", err.Error())
 		}
-		services := strings.ReplaceAll(integration.Subscription.Filter.Service, subscriptionName+",", "")
-		services = strings.ReplaceAll(services, subscriptionName, "")
-		integration.Subscription.Filter.Service = services
-
-		totalSub := len(integration.Subscriptions)
-		for i := 0; i < totalSub; i++ {
-			subscription := &integration.Subscriptions[i]
-			newServices := []string{}
-
-			//remove subscription if it concerns only the deleted service
-			if len(subscription.Filter.Services) == 1 && subscription.Filter.Services[0] == subscriptionName {
-				copy(integration.Subscriptions[i:], integration.Subscriptions[i+1:])
-				integration.Subscriptions[totalSub-1] = keptnmodels.EventSubscription{}
-				integration.Subscriptions = integration.Subscriptions[:totalSub-1]
-				totalSub = len(integration.Subscriptions)
-				i--
-				continue
-			}
-
-			//otherwise remove service
-			for j, _ := range subscription.Filter.Services {
-				service := &subscription.Filter.Services[j]
-				if *service != subscriptionName {
-					newServices = append(newServices, *service)
-				}
-			}
-			subscription.Filter.Services = newServices
-		}
+		removeServiceFromIntegration(integration, subscriptionName)
 		opts := options.Update().SetUpsert(true)
 		filter := bson.D{{"_id", integration.ID}}
 		update := bson.D{{"$set", integration}}
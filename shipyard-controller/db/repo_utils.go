@@ -0,0 +1,212 @@
+package db
+
+import (
+	"errors"
+	keptnmodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/common"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	log "github.com/sirupsen/logrus"
+	"strings"
+	"time"
+)
+
+// the functions in this file contain the logic that is shared by the repositories of all storage backends
+
+func getStartedEventsForTriggeredID(repo EventRepo, eventScope models.EventScope) ([]models.Event, error) {
+	startedEventType, err := keptnv2.ReplaceEventTypeKind(eventScope.EventType, string(common.StartedEvent))
+	if err != nil {
+		return nil, err
+	}
+	// get corresponding 'started' event for the incoming 'finished' event
+	filter := common.EventFilter{
+		Type:        startedEventType,
+		TriggeredID: &eventScope.TriggeredID,
+	}
+	return repo.GetEventsWithRetry(eventScope.Project, filter, common.StartedEvent, maxRepoReadRetries)
+}
+
+func getEventsWithRetry(repo EventRepo, project string, filter common.EventFilter, status common.EventStatus, nrRetries int) ([]models.Event, error) {
+	for i := 0; i <= nrRetries; i++ {
+		events, err := repo.GetEvents(project, filter, status)
+		if err != nil && err == ErrNoEventFound {
+			<-time.After(2 * time.Second)
+		} else {
+			return events, err
+		}
+	}
+	return nil, nil
+}
+
+func getTaskSequenceTriggeredEvent(repo EventRepo, eventScope models.EventScope, taskSequenceName string) (*models.Event, error) {
+	events, err := repo.GetEvents(eventScope.Project, common.EventFilter{
+		Type:         keptnv2.GetTriggeredEventType(eventScope.Stage + "." + taskSequenceName),
+		Stage:        &eventScope.Stage,
+		KeptnContext: &eventScope.KeptnContext,
+	}, common.TriggeredEvent)
+
+	if err != nil {
+		log.Errorf("Could not load event that triggered task sequence %s.%s with KeptnContext %s", eventScope.Stage, taskSequenceName, eventScope.KeptnContext)
+		return nil, err
+	}
+
+	if len(events) > 0 {
+		return &events[0], nil
+	}
+	return nil, nil
+}
+
+func deleteAllFinishedEvents(repo EventRepo, eventScope models.EventScope) error {
+	// delete all finished events of this sequence
+	finishedEvents, err := repo.GetEvents(eventScope.Project, common.EventFilter{
+		Stage:        &eventScope.Stage,
+		KeptnContext: &eventScope.KeptnContext,
+	}, common.FinishedEvent)
+
+	if err != nil && err != ErrNoEventFound {
+		log.Errorf("could not retrieve task.finished events: %s", err.Error())
+		return err
+	}
+
+	for _, event := range finishedEvents {
+		err = repo.DeleteEvent(eventScope.Project, event.ID, common.FinishedEvent)
+		if err != nil {
+			log.Errorf("could not delete %s event with ID %s: %s", *event.Type, event.ID, err.Error())
+			return err
+		}
+	}
+
+	triggeredEvents, err := repo.GetEvents(eventScope.Project, common.EventFilter{
+		Stage:        &eventScope.Stage,
+		KeptnContext: &eventScope.KeptnContext,
+	}, common.TriggeredEvent)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range triggeredEvents {
+		err = repo.DeleteEvent(eventScope.Project, event.ID, common.TriggeredEvent)
+		if err != nil {
+			log.Errorf("could not delete %s event with ID %s: %s", *event.Type, event.ID, err.Error())
+			return err
+		}
+	}
+	return nil
+}
+
+func getFinishedEvents(repo EventRepo, eventScope models.EventScope) ([]models.Event, error) {
+	return repo.GetEvents(eventScope.Project, common.EventFilter{
+		Stage:        &eventScope.Stage,
+		KeptnContext: &eventScope.KeptnContext,
+	}, common.FinishedEvent)
+}
+
+func isSequenceOfEventPaused(repo EventQueueRepo, eventScope models.EventScope) bool {
+	states, err := repo.GetEventQueueSequenceStates(models.EventQueueSequenceState{Scope: models.EventScope{KeptnContext: eventScope.KeptnContext}})
+	if err != nil {
+		return false
+	} else if len(states) == 0 {
+		log.Infof("no state for sequence %s found", eventScope.KeptnContext)
+		return false
+	}
+
+	for _, state := range states {
+		if state.Scope.Stage == "" && state.State == models.SequencePaused {
+			// if the overall state is set to 'paused', this means that all stages are paused
+			return true
+		} else if state.Scope.Stage == eventScope.Stage && state.State == models.SequencePaused {
+			// if not the overall state is 'paused', but specifically for this stage, we return true as well
+			return true
+		}
+	}
+
+	return false
+}
+
+func updateProjectUpstream(repo ProjectRepo, projectName string, uri string, user string) error {
+	existingProject, err := repo.GetProject(projectName)
+	if err != nil {
+		return err
+	}
+	if existingProject == nil {
+		return nil
+	}
+	if existingProject.GitRemoteURI != uri || existingProject.GitUser != user {
+		existingProject.GitRemoteURI = uri
+		existingProject.GitUser = user
+		if err := repo.UpdateProject(existingProject); err != nil {
+			log.Errorf("could not update upstream credentials of project %s: %s", projectName, err.Error())
+			return err
+		}
+	}
+	return nil
+}
+
+func validateSequenceState(state models.SequenceState) error {
+	if state.Project == "" {
+		return errors.New("project must be set")
+	}
+	if state.Shkeptncontext == "" {
+		return errors.New("shkeptncontext must be set")
+	}
+	if state.Name == "" {
+		return errors.New("name must be set")
+	}
+	return nil
+}
+
+// replaceSubscription replaces the subscription with the same ID as the given one, or adds it to the integration if no such subscription exists
+func replaceSubscription(integration *models.Integration, subscription models.Subscription) {
+	var keepSubscriptions []keptnmodels.EventSubscription
+	for _, s := range integration.Subscriptions {
+		if s.ID != subscription.ID {
+			keepSubscriptions = append(keepSubscriptions, s)
+		}
+	}
+	keepSubscriptions = append(keepSubscriptions, keptnmodels.EventSubscription(subscription))
+	integration.Subscriptions = keepSubscriptions
+}
+
+// removeSubscription removes the subscription with the given ID from the integration
+func removeSubscription(integration *models.Integration, subscriptionID string) {
+	var keepSubscriptions []keptnmodels.EventSubscription
+	for _, s := range integration.Subscriptions {
+		if s.ID != subscriptionID {
+			keepSubscriptions = append(keepSubscriptions, s)
+		}
+	}
+	integration.Subscriptions = keepSubscriptions
+}
+
+// removeServiceFromIntegration removes the given service from the subscriptions of the integration. Subscriptions that only concern the
+// deleted service are removed
+func removeServiceFromIntegration(integration *models.Integration, serviceName string) {
+	services := strings.ReplaceAll(integration.Subscription.Filter.Service, serviceName+",", "")
+	services = strings.ReplaceAll(services, serviceName, "")
+	integration.Subscription.Filter.Service = services
+
+	totalSub := len(integration.Subscriptions)
+	for i := 0; i < totalSub; i++ {
+		subscription := &integration.Subscriptions[i]
+		newServices := []string{}
+
+		//remove subscription if it concerns only the deleted service
+		if len(subscription.Filter.Services) == 1 && subscription.Filter.Services[0] == serviceName {
+			copy(integration.Subscriptions[i:], integration.Subscriptions[i+1:])
+			integration.Subscriptions[totalSub-1] = keptnmodels.EventSubscription{}
+			integration.Subscriptions = integration.Subscriptions[:totalSub-1]
+			totalSub = len(integration.Subscriptions)
+			i--
+			continue
+		}
+
+		//otherwise remove service
+		for j := range subscription.Filter.Services {
+			service := &subscription.Filter.Services[j]
+			if *service != serviceName {
+				newServices = append(newServices, *service)
+			}
+		}
+		subscription.Filter.Services = newServices
+	}
+}
@@ -26,6 +26,8 @@ type UniformRepo interface {
 	GetSubscription(integrationID, subscriptionID string) (*models.Subscription, error)
 	GetSubscriptions(integrationID string) ([]models.Subscription, error)
 	UpdateLastSeen(integrationID string) (*models.Integration, error)
+	// SetupTTLIndex sets the duration after which integrations that have not been seen anymore are removed
+	SetupTTLIndex(duration time.Duration) error
 }
 
 //go:generate moq --skip-ensure -pkg db_mock -out ./mock/tasksequencerepo_mock.go . TaskSequenceRepo
@@ -40,6 +42,8 @@ type LogRepo interface {
 	CreateLogEntries(entries []models.LogEntry) error
 	GetLogEntries(filter models.GetLogParams) (*models.GetLogsResponse, error)
 	DeleteLogEntries(params models.DeleteLogParams) error
+	// SetupTTLIndex sets the duration after which log entries are removed
+	SetupTTLIndex(duration time.Duration) error
 }
 
 //go:generate moq --skip-ensure -pkg db_mock -out ./mock/eventqueuerepo_mock.go . EventQueueRepo
@@ -88,3 +92,39 @@ type SequenceQueueRepo interface {
 	GetQueuedSequences() ([]models.QueueItem, error)
 	DeleteQueuedSequences(itemFilter models.QueueItem) error
 }
+
+//go:generate moq --skip-ensure -pkg db_mock -out ./mock/leaserepo_mock.go . LeaseRepo
+// LeaseRepo defines the interface for acquiring and releasing leases that are shared between multiple instances of the shipyard-controller
+type LeaseRepo interface {
+	// AcquireLease tries to acquire (or renew) the lease with the given name for the given holder.
+	// It returns true if the holder owns the lease for the given duration after the call
+	AcquireLease(name, holder string, duration time.Duration) (bool, error)
+	// ReleaseLease releases the lease with the given name, if it is currently held by the given holder
+	ReleaseLease(name, holder string) error
+	// GetLease returns the current state of the lease with the given name
+	GetLease(name string) (*models.Lease, error)
+}
+
+//go:generate moq --skip-ensure -pkg db_mock -out ./mock/schedulerepo_mock.go . ScheduleRepo
+// ScheduleRepo defines the interface for storing the schedules that periodically trigger sequences
+type ScheduleRepo interface {
+	// GetSchedules returns all schedules of the given project. If the project is empty, the schedules of all projects are returned
+	GetSchedules(project string) ([]models.Schedule, error)
+	// GetSchedule returns the schedule with the given ID
+	GetSchedule(id string) (*models.Schedule, error)
+	// UpsertSchedule creates or updates the given schedule. The paused state and the last run of existing schedules are kept,
+	// and their next fire time is only updated if the cron expression has changed
+	UpsertSchedule(schedule models.Schedule) error
+	// ClaimScheduledRun sets the next fire time of the schedule, if it is not paused and its current next fire time equals the expected one.
+	// It returns false if the run has already been claimed by another caller, which ensures that a schedule is not fired twice
+	ClaimScheduledRun(id string, expectedNextFireTime, nextFireTime time.Time, run models.ScheduleRun) (bool, error)
+	// ReleaseScheduledRun restores the next fire time and the last run of the schedule, if its current next fire time still equals the claimed one.
+	// This is used to retry a claimed run whose sequence could not be triggered
+	ReleaseScheduledRun(id string, claimedNextFireTime, nextFireTime time.Time, lastRun *models.ScheduleRun) error
+	// SetLastScheduleRun stores the last run of the schedule
+	SetLastScheduleRun(id string, run models.ScheduleRun) error
+	// SetSchedulePaused pauses or resumes the schedule and sets its next fire time
+	SetSchedulePaused(id string, paused bool, nextFireTime time.Time) error
+	// DeleteSchedule deletes the schedule with the given ID
+	DeleteSchedule(id string) error
+}
@@ -0,0 +1,690 @@
+package db_test
+
+import (
+	"github.com/google/uuid"
+	keptnmodels "github.com/keptn/go-utils/pkg/api/models"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/common"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// runRepositoryConformanceTests verifies that the repositories created by the given factory behave the same way, regardless of the storage backend.
+// Since the repositories of a backend might share their collections with other tests, all tests use unique names
+func runRepositoryConformanceTests(t *testing.T, factory db.RepositoryFactory) {
+	t.Run("EventRepo", func(t *testing.T) {
+		testEventRepoConformance(t, factory.CreateEventRepo())
+	})
+	t.Run("TaskSequenceRepo", func(t *testing.T) {
+		testTaskSequenceRepoConformance(t, factory.CreateTaskSequenceRepo())
+	})
+	t.Run("EventQueueRepo", func(t *testing.T) {
+		testEventQueueRepoConformance(t, factory.CreateEventQueueRepo())
+	})
+	t.Run("SequenceQueueRepo", func(t *testing.T) {
+		testSequenceQueueRepoConformance(t, factory.CreateSequenceQueueRepo())
+	})
+	t.Run("UniformRepo", func(t *testing.T) {
+		testUniformRepoConformance(t, factory.CreateUniformRepo())
+	})
+	t.Run("LogRepo", func(t *testing.T) {
+		testLogRepoConformance(t, factory.CreateLogRepo())
+	})
+	t.Run("ProjectRepo", func(t *testing.T) {
+		testProjectRepoConformance(t, factory.CreateProjectRepo())
+	})
+	t.Run("SequenceStateRepo", func(t *testing.T) {
+		testSequenceStateRepoConformance(t, factory.CreateSequenceStateRepo())
+	})
+	t.Run("LeaseRepo", func(t *testing.T) {
+		testLeaseRepoConformance(t, factory.CreateLeaseRepo())
+	})
+	t.Run("ScheduleRepo", func(t *testing.T) {
+		testScheduleRepoConformance(t, factory.CreateScheduleRepo())
+	})
+}
+
+func TestMongoDBRepositories_Conformance(t *testing.T) {
+	runRepositoryConformanceTests(t, &db.MongoDBRepositoryFactory{})
+}
+
+func uniqueName(prefix string) string {
+	return prefix + "-" + uuid.New().String()[:8]
+}
+
+func stringp(s string) *string {
+	return &s
+}
+
+func testEventRepoConformance(t *testing.T, repo db.EventRepo) {
+	project := uniqueName("project")
+	keptnContext := uniqueName("context")
+
+	newEvent := func(id, eventType, stage string) models.Event {
+		return models.Event{
+			ID:             id,
+			Type:           stringp(eventType),
+			Source:         stringp("test"),
+			Shkeptncontext: keptnContext,
+			Triggeredid:    "triggered-" + id,
+			Data: map[string]interface{}{
+				"project": project,
+				"stage":   stage,
+				"service": "my-service",
+			},
+		}
+	}
+
+	err := repo.InsertEvent(project, newEvent("event-1", "sh.keptn.event.dev.delivery.triggered", "dev"), common.TriggeredEvent)
+	require.Nil(t, err)
+	err = repo.InsertEvent(project, newEvent("event-2", "sh.keptn.event.prod.delivery.triggered", "prod"), common.TriggeredEvent)
+	require.Nil(t, err)
+
+	// events with the same ID must not be stored twice
+	err = repo.InsertEvent(project, newEvent("event-1", "sh.keptn.event.dev.delivery.triggered", "dev"), common.TriggeredEvent)
+	require.NotNil(t, err)
+
+	events, err := repo.GetEvents(project, common.EventFilter{KeptnContext: &keptnContext}, common.TriggeredEvent)
+	require.Nil(t, err)
+	require.Len(t, events, 2)
+
+	events, err = repo.GetEvents(project, common.EventFilter{Stage: stringp("prod"), Service: stringp("my-service")}, common.TriggeredEvent)
+	require.Nil(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, "event-2", events[0].ID)
+	require.NotEmpty(t, events[0].Time)
+
+	events, err = repo.GetEvents(project, common.EventFilter{Type: "sh.keptn.event.dev.delivery.triggered", TriggeredID: stringp("triggered-event-1")}, common.TriggeredEvent)
+	require.Nil(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, "event-1", events[0].ID)
+
+	_, err = repo.GetEvents(project, common.EventFilter{ID: stringp("unknown")}, common.TriggeredEvent)
+	require.ErrorIs(t, err, db.ErrNoEventFound)
+
+	triggeredEvent, err := repo.GetTaskSequenceTriggeredEvent(models.EventScope{EventData: keptnDataForStage(project, "dev"), KeptnContext: keptnContext}, "delivery")
+	require.Nil(t, err)
+	require.NotNil(t, triggeredEvent)
+	require.Equal(t, "event-1", triggeredEvent.ID)
+
+	err = repo.DeleteEvent(project, "event-1", common.TriggeredEvent)
+	require.Nil(t, err)
+
+	_, err = repo.GetEvents(project, common.EventFilter{ID: stringp("event-1")}, common.TriggeredEvent)
+	require.ErrorIs(t, err, db.ErrNoEventFound)
+
+	for i := 0; i < 3; i++ {
+		err = repo.InsertEvent(project, newEvent(uniqueName("root"), "sh.keptn.event.dev.delivery.triggered", "dev"), common.RootEvent)
+		require.Nil(t, err)
+	}
+
+	rootEvents, err := repo.GetRootEvents(models.GetRootEventParams{Project: project, PageSize: 2})
+	require.Nil(t, err)
+	require.Len(t, rootEvents.Events, 2)
+	require.Equal(t, int64(3), rootEvents.TotalCount)
+	require.Equal(t, int64(2), rootEvents.NextPageKey)
+
+	rootEvents, err = repo.GetRootEvents(models.GetRootEventParams{Project: project, PageSize: 2, NextPageKey: 2})
+	require.Nil(t, err)
+	require.Len(t, rootEvents.Events, 1)
+	require.Equal(t, int64(0), rootEvents.NextPageKey)
+
+	err = repo.DeleteEventCollections(project)
+	require.Nil(t, err)
+
+	_, err = repo.GetEvents(project, common.EventFilter{}, common.TriggeredEvent)
+	require.ErrorIs(t, err, db.ErrNoEventFound)
+}
+
+func keptnDataForStage(project, stage string) keptnv2.EventData {
+	return keptnv2.EventData{Project: project, Stage: stage, Service: "my-service"}
+}
+
+func testTaskSequenceRepoConformance(t *testing.T, repo db.TaskSequenceRepo) {
+	project := uniqueName("project")
+
+	taskExecution := models.TaskExecution{
+		TaskSequenceName: "delivery",
+		TriggeredEventID: "triggered-1",
+		Task:             models.Task{TaskIndex: 1},
+		Stage:            "dev",
+		Service:          "my-service",
+		KeptnContext:     "context-1",
+	}
+	require.Nil(t, repo.CreateTaskExecution(project, taskExecution))
+
+	otherTaskExecution := taskExecution
+	otherTaskExecution.TriggeredEventID = "triggered-2"
+	otherTaskExecution.Stage = "prod"
+	require.Nil(t, repo.CreateTaskExecution(project, otherTaskExecution))
+
+	executions, err := repo.GetTaskExecutions(project, models.TaskExecution{TriggeredEventID: "triggered-1"})
+	require.Nil(t, err)
+	require.Len(t, executions, 1)
+	require.Equal(t, "dev", executions[0].Stage)
+	require.Equal(t, 1, executions[0].Task.TaskIndex)
+
+	executions, err = repo.GetTaskExecutions(project, models.TaskExecution{KeptnContext: "context-1", TaskSequenceName: "delivery"})
+	require.Nil(t, err)
+	require.Len(t, executions, 2)
+
+	require.Nil(t, repo.DeleteTaskExecution("context-1", project, "dev", "delivery"))
+
+	executions, err = repo.GetTaskExecutions(project, models.TaskExecution{KeptnContext: "context-1"})
+	require.Nil(t, err)
+	require.Len(t, executions, 1)
+	require.Equal(t, "triggered-2", executions[0].TriggeredEventID)
+
+	require.Nil(t, repo.DeleteRepo(project))
+
+	executions, err = repo.GetTaskExecutions(project, models.TaskExecution{})
+	require.Nil(t, err)
+	require.Empty(t, executions)
+}
+
+func testEventQueueRepoConformance(t *testing.T, repo db.EventQueueRepo) {
+	keptnContext := uniqueName("context")
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	dueItem := models.QueueItem{
+		Scope:     models.EventScope{EventData: keptnDataForStage("my-project", "dev"), KeptnContext: keptnContext},
+		EventID:   uniqueName("due"),
+		Timestamp: now.Add(-time.Minute),
+	}
+	futureItem := models.QueueItem{
+		Scope:     models.EventScope{EventData: keptnDataForStage("my-project", "prod"), KeptnContext: keptnContext},
+		EventID:   uniqueName("future"),
+		Timestamp: now.Add(time.Hour),
+	}
+	require.Nil(t, repo.QueueEvent(dueItem))
+	require.Nil(t, repo.QueueEvent(futureItem))
+	require.NotNil(t, repo.QueueEvent(dueItem))
+
+	queuedEvents, err := repo.GetQueuedEvents(now)
+	require.Nil(t, err)
+	require.True(t, containsQueueItem(queuedEvents, dueItem.EventID))
+	require.False(t, containsQueueItem(queuedEvents, futureItem.EventID))
+
+	inQueue, err := repo.IsEventInQueue(futureItem.EventID)
+	require.Nil(t, err)
+	require.True(t, inQueue)
+
+	require.Nil(t, repo.DeleteQueuedEvent(futureItem.EventID))
+
+	inQueue, err = repo.IsEventInQueue(futureItem.EventID)
+	require.Nil(t, err)
+	require.False(t, inQueue)
+
+	require.Nil(t, repo.DeleteQueuedEvents(models.EventScope{KeptnContext: keptnContext}))
+
+	inQueue, err = repo.IsEventInQueue(dueItem.EventID)
+	require.Nil(t, err)
+	require.False(t, inQueue)
+
+	// sequence states
+	_, err = repo.GetEventQueueSequenceStates(models.EventQueueSequenceState{Scope: models.EventScope{KeptnContext: keptnContext}})
+	require.ErrorIs(t, err, db.ErrNoEventFound)
+
+	devScope := models.EventScope{EventData: keptnDataForStage("my-project", "dev"), KeptnContext: keptnContext}
+	err = repo.CreateOrUpdateEventQueueState(models.EventQueueSequenceState{Scope: devScope, State: models.SequencePaused})
+	require.Nil(t, err)
+
+	require.True(t, repo.IsSequenceOfEventPaused(devScope))
+	require.False(t, repo.IsSequenceOfEventPaused(models.EventScope{EventData: keptnDataForStage("my-project", "prod"), KeptnContext: keptnContext}))
+
+	err = repo.CreateOrUpdateEventQueueState(models.EventQueueSequenceState{Scope: devScope, State: models.SequenceStartedState})
+	require.Nil(t, err)
+
+	states, err := repo.GetEventQueueSequenceStates(models.EventQueueSequenceState{Scope: models.EventScope{KeptnContext: keptnContext}})
+	require.Nil(t, err)
+	require.Len(t, states, 1)
+	require.False(t, repo.IsSequenceOfEventPaused(devScope))
+
+	// if the overall state is paused, all stages are paused
+	otherContext := uniqueName("context")
+	err = repo.CreateOrUpdateEventQueueState(models.EventQueueSequenceState{Scope: models.EventScope{KeptnContext: otherContext}, State: models.SequencePaused})
+	require.Nil(t, err)
+	require.True(t, repo.IsSequenceOfEventPaused(models.EventScope{EventData: keptnDataForStage("my-project", "prod"), KeptnContext: otherContext}))
+
+	require.Nil(t, repo.DeleteEventQueueStates(models.EventQueueSequenceState{Scope: models.EventScope{KeptnContext: keptnContext}}))
+	require.Nil(t, repo.DeleteEventQueueStates(models.EventQueueSequenceState{Scope: models.EventScope{KeptnContext: otherContext}}))
+
+	_, err = repo.GetEventQueueSequenceStates(models.EventQueueSequenceState{Scope: models.EventScope{KeptnContext: keptnContext}})
+	require.ErrorIs(t, err, db.ErrNoEventFound)
+}
+
+func testSequenceQueueRepoConformance(t *testing.T, repo db.SequenceQueueRepo) {
+	keptnContext := uniqueName("context")
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	newerItem := models.QueueItem{
+		Scope:     models.EventScope{EventData: keptnDataForStage("my-project", "dev"), KeptnContext: keptnContext},
+		EventID:   uniqueName("newer"),
+		Timestamp: now,
+	}
+	olderItem := models.QueueItem{
+		Scope:     models.EventScope{EventData: keptnDataForStage("my-project", "dev"), KeptnContext: keptnContext},
+		EventID:   uniqueName("older"),
+		Timestamp: now.Add(-time.Minute),
+	}
+	require.Nil(t, repo.QueueSequence(newerItem))
+	require.Nil(t, repo.QueueSequence(olderItem))
+	require.NotNil(t, repo.QueueSequence(olderItem))
+
+	queuedSequences, err := repo.GetQueuedSequences()
+	require.Nil(t, err)
+
+	// the sequences are returned from oldest to newest
+	ownSequences := []string{}
+	for _, item := range queuedSequences {
+		if item.Scope.KeptnContext == keptnContext {
+			ownSequences = append(ownSequences, item.EventID)
+		}
+	}
+	require.Equal(t, []string{olderItem.EventID, newerItem.EventID}, ownSequences)
+
+	require.Nil(t, repo.DeleteQueuedSequences(models.QueueItem{EventID: olderItem.EventID}))
+	require.Nil(t, repo.DeleteQueuedSequences(models.QueueItem{Scope: models.EventScope{KeptnContext: keptnContext}}))
+
+	queuedSequences, err = repo.GetQueuedSequences()
+	if err != nil {
+		require.ErrorIs(t, err, db.ErrNoEventFound)
+	}
+	require.False(t, containsQueueItem(queuedSequences, olderItem.EventID))
+	require.False(t, containsQueueItem(queuedSequences, newerItem.EventID))
+}
+
+func containsQueueItem(items []models.QueueItem, eventID string) bool {
+	for _, item := range items {
+		if item.EventID == eventID {
+			return true
+		}
+	}
+	return false
+}
+
+func testUniformRepoConformance(t *testing.T, repo db.UniformRepo) {
+	integrationID := uniqueName("integration")
+	project := uniqueName("project")
+
+	integration := models.Integration{
+		ID:   integrationID,
+		Name: integrationID,
+		MetaData: keptnmodels.MetaData{
+			Hostname: "my-host",
+			LastSeen: time.Now().UTC().Truncate(time.Millisecond),
+		},
+	}
+	integration.Subscription.Filter.Service = "my-service"
+	require.Nil(t, repo.CreateUniformIntegration(integration))
+	require.ErrorIs(t, repo.CreateUniformIntegration(integration), db.ErrUniformRegistrationAlreadyExists)
+
+	integrations, err := repo.GetUniformIntegrations(models.GetUniformIntegrationsParams{ID: integrationID})
+	require.Nil(t, err)
+	require.Len(t, integrations, 1)
+	require.Equal(t, "my-host", integrations[0].MetaData.Hostname)
+
+	subscription := models.Subscription{ID: "subscription-1", Event: "sh.keptn.event.test.triggered"}
+	subscription.Filter.Projects = []string{project}
+	subscription.Filter.Stages = []string{"dev"}
+	subscription.Filter.Services = []string{"my-service"}
+	require.Nil(t, repo.CreateOrUpdateSubscription(integrationID, subscription))
+
+	otherSubscription := models.Subscription{ID: "subscription-2", Event: "sh.keptn.event.deployment.triggered"}
+	otherSubscription.Filter.Projects = []string{project}
+	otherSubscription.Filter.Services = []string{"my-service", "other-service"}
+	require.Nil(t, repo.CreateOrUpdateSubscription(integrationID, otherSubscription))
+
+	require.ErrorIs(t, repo.CreateOrUpdateSubscription(uniqueName("unknown"), subscription), db.ErrUniformRegistrationNotFound)
+
+	integrations, err = repo.GetUniformIntegrations(models.GetUniformIntegrationsParams{Project: project, Stage: "dev"})
+	require.Nil(t, err)
+	require.Len(t, integrations, 1)
+
+	integrations, err = repo.GetUniformIntegrations(models.GetUniformIntegrationsParams{Project: project, Stage: "prod"})
+	require.Nil(t, err)
+	require.Empty(t, integrations)
+
+	fetchedSubscription, err := repo.GetSubscription(integrationID, "subscription-1")
+	require.Nil(t, err)
+	require.Equal(t, subscription.Event, fetchedSubscription.Event)
+	require.Equal(t, subscription.Filter.Projects, fetchedSubscription.Filter.Projects)
+
+	subscription.Event = "sh.keptn.event.evaluation.triggered"
+	require.Nil(t, repo.CreateOrUpdateSubscription(integrationID, subscription))
+
+	subscriptions, err := repo.GetSubscriptions(integrationID)
+	require.Nil(t, err)
+	require.Len(t, subscriptions, 2)
+	for _, s := range subscriptions {
+		if s.ID == subscription.ID {
+			require.Equal(t, "sh.keptn.event.evaluation.triggered", s.Event)
+		}
+	}
+
+	_, err = repo.GetSubscriptions(uniqueName("unknown"))
+	require.ErrorIs(t, err, db.ErrUniformRegistrationNotFound)
+
+	_, err = repo.GetSubscription(integrationID, "unknown")
+	require.ErrorIs(t, err, db.ErrUniformSubscriptionNotFound)
+
+	// subscriptions that only concern the deleted service are removed
+	require.Nil(t, repo.DeleteServiceFromSubscriptions("my-service"))
+
+	subscriptions, err = repo.GetSubscriptions(integrationID)
+	require.Nil(t, err)
+	require.Len(t, subscriptions, 1)
+	require.Equal(t, "subscription-2", subscriptions[0].ID)
+	require.Equal(t, []string{"other-service"}, subscriptions[0].Filter.Services)
+
+	require.Nil(t, repo.DeleteSubscription(integrationID, "subscription-2"))
+
+	subscriptions, err = repo.GetSubscriptions(integrationID)
+	require.Nil(t, err)
+	require.Empty(t, subscriptions)
+
+	lastSeen := integration.MetaData.LastSeen
+	updatedIntegration, err := repo.UpdateLastSeen(integrationID)
+	require.Nil(t, err)
+	require.False(t, updatedIntegration.MetaData.LastSeen.Before(lastSeen))
+
+	_, err = repo.UpdateLastSeen(uniqueName("unknown"))
+	require.ErrorIs(t, err, db.ErrUniformRegistrationNotFound)
+
+	integration.Name = "updated-name"
+	require.Nil(t, repo.CreateOrUpdateUniformIntegration(integration))
+
+	integrations, err = repo.GetUniformIntegrations(models.GetUniformIntegrationsParams{Name: "updated-name", ID: integrationID})
+	require.Nil(t, err)
+	require.Len(t, integrations, 1)
+
+	require.Nil(t, repo.DeleteUniformIntegration(integrationID))
+
+	integrations, err = repo.GetUniformIntegrations(models.GetUniformIntegrationsParams{ID: integrationID})
+	require.Nil(t, err)
+	require.Empty(t, integrations)
+}
+
+func testLogRepoConformance(t *testing.T, repo db.LogRepo) {
+	integrationID := uniqueName("integration")
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	err := repo.CreateLogEntries([]models.LogEntry{
+		{IntegrationID: integrationID, Message: "oldest", Time: now.Add(-2 * time.Minute)},
+		{IntegrationID: integrationID, Message: "newest", Time: now},
+		{IntegrationID: integrationID, Message: "middle", Time: now.Add(-time.Minute)},
+		{IntegrationID: uniqueName("integration"), Message: "other", Time: now},
+	})
+	require.Nil(t, err)
+
+	logs, err := repo.GetLogEntries(models.GetLogParams{LogFilter: models.LogFilter{IntegrationID: integrationID}, PageSize: 2})
+	require.Nil(t, err)
+	require.Equal(t, int64(3), logs.TotalCount)
+	require.Equal(t, int64(2), logs.NextPageKey)
+	require.Len(t, logs.Logs, 2)
+	require.Equal(t, "newest", logs.Logs[0].Message)
+	require.Equal(t, "middle", logs.Logs[1].Message)
+
+	logs, err = repo.GetLogEntries(models.GetLogParams{LogFilter: models.LogFilter{IntegrationID: integrationID}, PageSize: 2, NextPageKey: 2})
+	require.Nil(t, err)
+	require.Len(t, logs.Logs, 1)
+	require.Equal(t, "oldest", logs.Logs[0].Message)
+
+	fromTime := now.Add(-90 * time.Second).Format("2006-01-02T15:04:05.000Z")
+	logs, err = repo.GetLogEntries(models.GetLogParams{LogFilter: models.LogFilter{IntegrationID: integrationID, FromTime: fromTime}})
+	require.Nil(t, err)
+	require.Len(t, logs.Logs, 2)
+
+	beforeTime := now.Add(-30 * time.Second).Format("2006-01-02T15:04:05.000Z")
+	logs, err = repo.GetLogEntries(models.GetLogParams{LogFilter: models.LogFilter{IntegrationID: integrationID, FromTime: fromTime, BeforeTime: beforeTime}})
+	require.Nil(t, err)
+	require.Len(t, logs.Logs, 1)
+	require.Equal(t, "middle", logs.Logs[0].Message)
+
+	_, err = repo.GetLogEntries(models.GetLogParams{LogFilter: models.LogFilter{FromTime: "invalid"}})
+	require.NotNil(t, err)
+
+	require.Nil(t, repo.DeleteLogEntries(models.DeleteLogParams{LogFilter: models.LogFilter{IntegrationID: integrationID}}))
+
+	logs, err = repo.GetLogEntries(models.GetLogParams{LogFilter: models.LogFilter{IntegrationID: integrationID}})
+	require.Nil(t, err)
+	require.Empty(t, logs.Logs)
+}
+
+func testProjectRepoConformance(t *testing.T, repo db.ProjectRepo) {
+	projectName := uniqueName("project")
+
+	project, err := repo.GetProject(projectName)
+	require.Nil(t, err)
+	require.Nil(t, project)
+
+	newProject := &models.ExpandedProject{
+		ProjectName:  projectName,
+		GitRemoteURI: "http://my-remote",
+		GitUser:      "my-user",
+		Stages:       []*models.ExpandedStage{{StageName: "dev", Services: []*models.ExpandedService{{ServiceName: "my.service"}}}},
+	}
+	require.Nil(t, repo.CreateProject(newProject))
+
+	project, err = repo.GetProject(projectName)
+	require.Nil(t, err)
+	require.Equal(t, projectName, project.ProjectName)
+	require.Equal(t, "http://my-remote", project.GitRemoteURI)
+	require.Equal(t, "my.service", project.Stages[0].Services[0].ServiceName)
+
+	projects, err := repo.GetProjects()
+	require.Nil(t, err)
+	require.True(t, containsProject(projects, projectName))
+
+	require.Nil(t, repo.UpdateProjectUpstream(projectName, "http://other-remote", "other-user"))
+
+	project, err = repo.GetProject(projectName)
+	require.Nil(t, err)
+	require.Equal(t, "http://other-remote", project.GitRemoteURI)
+	require.Equal(t, "other-user", project.GitUser)
+
+	project.Shipyard = "my-shipyard"
+	require.Nil(t, repo.UpdateProject(project))
+
+	project, err = repo.GetProject(projectName)
+	require.Nil(t, err)
+	require.Equal(t, "my-shipyard", project.Shipyard)
+	require.Equal(t, "my.service", project.Stages[0].Services[0].ServiceName)
+
+	require.Nil(t, repo.DeleteProject(projectName))
+
+	project, err = repo.GetProject(projectName)
+	require.Nil(t, err)
+	require.Nil(t, project)
+}
+
+func containsProject(projects []*models.ExpandedProject, projectName string) bool {
+	for _, project := range projects {
+		if project.ProjectName == projectName {
+			return true
+		}
+	}
+	return false
+}
+
+func testSequenceStateRepoConformance(t *testing.T, repo db.SequenceStateRepo) {
+	project := uniqueName("project")
+
+	newState := func(keptnContext, stateTime string) models.SequenceState {
+		return models.SequenceState{
+			Name:           "delivery",
+			Service:        "my-service",
+			Project:        project,
+			Time:           stateTime,
+			Shkeptncontext: keptnContext,
+			State:          models.SequenceStartedState,
+			Stages:         []models.SequenceStateStage{},
+		}
+	}
+
+	require.NotNil(t, repo.CreateSequenceState(models.SequenceState{Project: project}))
+
+	require.Nil(t, repo.CreateSequenceState(newState("context-1", "2021-05-10T10:15:00.000Z")))
+	require.Nil(t, repo.CreateSequenceState(newState("context-2", "2021-05-10T10:16:00.000Z")))
+	require.Nil(t, repo.CreateSequenceState(newState("context-3", "2021-05-10T10:17:00.000Z")))
+	require.ErrorIs(t, repo.CreateSequenceState(newState("context-1", "2021-05-10T10:15:00.000Z")), db.ErrStateAlreadyExists)
+
+	_, err := repo.FindSequenceStates(models.StateFilter{})
+	require.NotNil(t, err)
+
+	states, err := repo.FindSequenceStates(models.StateFilter{GetSequenceStateParams: models.GetSequenceStateParams{Project: project, PageSize: 2}})
+	require.Nil(t, err)
+	require.Equal(t, int64(3), states.TotalCount)
+	require.Equal(t, int64(2), states.NextPageKey)
+	require.Len(t, states.States, 2)
+	require.Equal(t, "context-3", states.States[0].Shkeptncontext)
+	require.Equal(t, "context-2", states.States[1].Shkeptncontext)
+
+	states, err = repo.FindSequenceStates(models.StateFilter{GetSequenceStateParams: models.GetSequenceStateParams{Project: project, KeptnContext: "context-1, context-3"}})
+	require.Nil(t, err)
+	require.Len(t, states.States, 2)
+
+	states, err = repo.FindSequenceStates(models.StateFilter{GetSequenceStateParams: models.GetSequenceStateParams{
+		Project:    project,
+		FromTime:   "2021-05-10T10:15:00.000Z",
+		BeforeTime: "2021-05-10T10:17:00.000Z",
+	}})
+	require.Nil(t, err)
+	require.Len(t, states.States, 1)
+	require.Equal(t, "context-2", states.States[0].Shkeptncontext)
+
+	updatedState := newState("context-1", "2021-05-10T10:15:00.000Z")
+	updatedState.State = models.SequenceFinished
+	require.Nil(t, repo.UpdateSequenceState(updatedState))
+
+	states, err = repo.FindSequenceStates(models.StateFilter{GetSequenceStateParams: models.GetSequenceStateParams{Project: project, State: models.SequenceFinished}})
+	require.Nil(t, err)
+	require.Len(t, states.States, 1)
+	require.Equal(t, "context-1", states.States[0].Shkeptncontext)
+
+	require.NotNil(t, repo.DeleteSequenceStates(models.StateFilter{GetSequenceStateParams: models.GetSequenceStateParams{Project: project}}))
+	require.Nil(t, repo.DeleteSequenceStates(models.StateFilter{GetSequenceStateParams: models.GetSequenceStateParams{Project: project, KeptnContext: "context-1"}}))
+
+	states, err = repo.FindSequenceStates(models.StateFilter{GetSequenceStateParams: models.GetSequenceStateParams{Project: project}})
+	require.Nil(t, err)
+	require.Equal(t, int64(2), states.TotalCount)
+}
+
+func testLeaseRepoConformance(t *testing.T, repo db.LeaseRepo) {
+	leaseName := uniqueName("lease")
+
+	_, err := repo.GetLease(leaseName)
+	require.ErrorIs(t, err, db.ErrLeaseNotFound)
+
+	acquired, err := repo.AcquireLease(leaseName, "instance-1", 200*time.Millisecond)
+	require.Nil(t, err)
+	require.True(t, acquired)
+
+	acquired, err = repo.AcquireLease(leaseName, "instance-2", time.Minute)
+	require.Nil(t, err)
+	require.False(t, acquired)
+
+	acquired, err = repo.AcquireLease(leaseName, "instance-1", 200*time.Millisecond)
+	require.Nil(t, err)
+	require.True(t, acquired)
+
+	lease, err := repo.GetLease(leaseName)
+	require.Nil(t, err)
+	require.Equal(t, "instance-1", lease.Holder)
+
+	// expired leases can be acquired by other holders
+	time.Sleep(300 * time.Millisecond)
+	acquired, err = repo.AcquireLease(leaseName, "instance-2", time.Minute)
+	require.Nil(t, err)
+	require.True(t, acquired)
+
+	require.Nil(t, repo.ReleaseLease(leaseName, "instance-1"))
+
+	lease, err = repo.GetLease(leaseName)
+	require.Nil(t, err)
+	require.Equal(t, "instance-2", lease.Holder)
+
+	require.Nil(t, repo.ReleaseLease(leaseName, "instance-2"))
+
+	_, err = repo.GetLease(leaseName)
+	require.ErrorIs(t, err, db.ErrLeaseNotFound)
+}
+
+func testScheduleRepoConformance(t *testing.T, repo db.ScheduleRepo) {
+	project := uniqueName("project")
+	scheduleID := models.GetScheduleID(project, "dev", "my-service", "delivery")
+	nextFireTime := time.Date(2021, 5, 10, 10, 0, 0, 0, time.UTC)
+
+	_, err := repo.GetSchedule(scheduleID)
+	require.ErrorIs(t, err, db.ErrScheduleNotFound)
+
+	schedule := models.Schedule{
+		ID:           scheduleID,
+		Project:      project,
+		Stage:        "dev",
+		Service:      "my-service",
+		Sequence:     "delivery",
+		Cron:         "0 * * * *",
+		NextFireTime: nextFireTime,
+	}
+	require.Nil(t, repo.UpsertSchedule(schedule))
+
+	storedSchedule, err := repo.GetSchedule(scheduleID)
+	require.Nil(t, err)
+	require.True(t, nextFireTime.Equal(storedSchedule.NextFireTime))
+	require.Nil(t, storedSchedule.LastRun)
+
+	run := models.ScheduleRun{Time: nextFireTime, KeptnContext: "context-1"}
+	claimed, err := repo.ClaimScheduledRun(scheduleID, nextFireTime, nextFireTime.Add(time.Hour), run)
+	require.Nil(t, err)
+	require.True(t, claimed)
+
+	// the same run can not be claimed twice
+	claimed, err = repo.ClaimScheduledRun(scheduleID, nextFireTime, nextFireTime.Add(time.Hour), run)
+	require.Nil(t, err)
+	require.False(t, claimed)
+
+	// upserting the schedule with the same cron expression keeps the next fire time and the last run
+	require.Nil(t, repo.UpsertSchedule(schedule))
+
+	storedSchedule, err = repo.GetSchedule(scheduleID)
+	require.Nil(t, err)
+	require.True(t, nextFireTime.Add(time.Hour).Equal(storedSchedule.NextFireTime))
+	require.NotNil(t, storedSchedule.LastRun)
+	require.Equal(t, "context-1", storedSchedule.LastRun.KeptnContext)
+
+	require.Nil(t, repo.SetSchedulePaused(scheduleID, true, nextFireTime.Add(2*time.Hour)))
+
+	claimed, err = repo.ClaimScheduledRun(scheduleID, nextFireTime.Add(2*time.Hour), nextFireTime.Add(3*time.Hour), run)
+	require.Nil(t, err)
+	require.False(t, claimed)
+
+	// the paused state is kept, and the next fire time is updated if the cron expression has changed
+	schedule.Cron = "30 * * * *"
+	schedule.NextFireTime = nextFireTime.Add(30 * time.Minute)
+	require.Nil(t, repo.UpsertSchedule(schedule))
+
+	storedSchedule, err = repo.GetSchedule(scheduleID)
+	require.Nil(t, err)
+	require.True(t, storedSchedule.Paused)
+	require.Equal(t, "30 * * * *", storedSchedule.Cron)
+	require.True(t, schedule.NextFireTime.Equal(storedSchedule.NextFireTime))
+
+	require.Nil(t, repo.SetLastScheduleRun(scheduleID, models.ScheduleRun{KeptnContext: "context-2", Manual: true}))
+	require.ErrorIs(t, repo.SetLastScheduleRun(uniqueName("unknown"), run), db.ErrScheduleNotFound)
+	require.ErrorIs(t, repo.SetSchedulePaused(uniqueName("unknown"), false, nextFireTime), db.ErrScheduleNotFound)
+
+	schedules, err := repo.GetSchedules(project)
+	require.Nil(t, err)
+	require.Len(t, schedules, 1)
+	require.Equal(t, "context-2", schedules[0].LastRun.KeptnContext)
+
+	require.Nil(t, repo.DeleteSchedule(scheduleID))
+
+	schedules, err = repo.GetSchedules(project)
+	require.Nil(t, err)
+	require.Empty(t, schedules)
+}
@@ -979,6 +979,182 @@ var doc = `{
                 }
             }
         },
+        "/schedule/{project}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the cron schedules that periodically trigger sequences of a project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Get the schedules of a project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The project name",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Schedules"
+                        }
+                    },
+                    "404": {
+                        "description": "Project not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedule/{project}/{scheduleId}/control": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Pause/Resume a schedule. Runs that have been missed while the schedule was paused are not triggered after resuming it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Pause/Resume a schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The project name",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The ID of the schedule",
+                        "name": "scheduleId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Schedule Control Command",
+                        "name": "scheduleControl",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ScheduleControlCommand"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.Schedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedule/{project}/{scheduleId}/fire": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Immediately trigger the sequence of a schedule. The next regular run of the schedule is not affected",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Fire a schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The project name",
+                        "name": "project",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The ID of the schedule",
+                        "name": "scheduleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/models.FireScheduleResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Schedule not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    }
+                }
+            }
+        },
         "/sequence/{project}": {
             "get": {
                 "security": [
@@ -1076,7 +1252,7 @@ var doc = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Pause/Resume/Abort a task sequence, either for a specific stage, or for all stages involved in the sequence",
+                "description": "Pause/Resume/Abort a task sequence, either for a specific stage, or for all stages involved in the sequence. A finished sequence can be restarted in a stage, either from the task provided in fromTask, or from the first task that did not succeed",
                 "consumes": [
                     "application/json"
                 ],
@@ -1086,7 +1262,7 @@ var doc = `{
                 "tags": [
                     "Sequence"
                 ],
-                "summary": "Pause/Resume/Abort a task sequence",
+                "summary": "Pause/Resume/Abort/Restart a task sequence",
                 "parameters": [
                     {
                         "type": "string",
@@ -1125,6 +1301,18 @@ var doc = `{
                             "$ref": "#/definitions/models.Error"
                         }
                     },
+                    "404": {
+                        "description": "Sequence not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Sequence is still running",
+                        "schema": {
+                            "$ref": "#/definitions/models.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal error",
                         "schema": {
@@ -2081,6 +2269,14 @@ var doc = `{
                 }
             }
         },
+        "models.FireScheduleResponse": {
+            "type": "object",
+            "properties": {
+                "keptnContext": {
+                    "type": "string"
+                }
+            }
+        },
         "models.GetLogsResponse": {
             "type": "object",
             "properties": {
@@ -2222,12 +2418,91 @@ var doc = `{
                 }
             }
         },
+        "models.Schedule": {
+            "type": "object",
+            "properties": {
+                "cron": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastRun": {
+                    "description": "LastRun contains information about the last time the sequence has been triggered by the schedule",
+                    "$ref": "#/definitions/models.ScheduleRun"
+                },
+                "nextFireTime": {
+                    "description": "NextFireTime is the point in time at which the sequence will be triggered next",
+                    "type": "string"
+                },
+                "paused": {
+                    "type": "boolean"
+                },
+                "project": {
+                    "type": "string"
+                },
+                "properties": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "sequence": {
+                    "type": "string"
+                },
+                "service": {
+                    "type": "string"
+                },
+                "stage": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ScheduleControlCommand": {
+            "type": "object",
+            "required": [
+                "state"
+            ],
+            "properties": {
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ScheduleRun": {
+            "type": "object",
+            "properties": {
+                "keptnContext": {
+                    "type": "string"
+                },
+                "manual": {
+                    "description": "Manual indicates that the schedule has been fired manually via the API",
+                    "type": "boolean"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Schedules": {
+            "type": "object",
+            "properties": {
+                "schedules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Schedule"
+                    }
+                }
+            }
+        },
         "models.SequenceControlCommand": {
             "type": "object",
             "required": [
                 "state"
             ],
             "properties": {
+                "fromTask": {
+                    "description": "FromTask is the task a restarted sequence continues with. If not set, the sequence continues with the first task that has not been successful",
+                    "type": "string"
+                },
                 "stage": {
                     "type": "string"
                 },
@@ -2271,6 +2546,40 @@ var doc = `{
                 }
             }
         },
+        "models.SequenceStateAttempt": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "type": "integer"
+                },
+                "maxAttempts": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "nextAttempt": {
+                    "description": "NextAttempt is the time at which the task is triggered again. It is empty if the task has not been retried after this attempt",
+                    "type": "string"
+                },
+                "result": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "task": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "triggeredId": {
+                    "description": "TriggeredID is the ID of the '.triggered' event of the attempt",
+                    "type": "string"
+                }
+            }
+        },
         "models.SequenceStateEvaluation": {
             "type": "object",
             "properties": {
@@ -2314,8 +2623,43 @@ var doc = `{
                 "name": {
                     "type": "string"
                 },
+                "skippedTasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SkippedTask"
+                    }
+                },
                 "state": {
                     "type": "string"
+                },
+                "taskAttempts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SequenceStateAttempt"
+                    }
+                },
+                "timeout": {
+                    "$ref": "#/definitions/models.SequenceStateTimeout"
+                }
+            }
+        },
+        "models.SequenceStateTimeout": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "task": {
+                    "description": "Task is the task that has been running when the limit was exceeded",
+                    "type": "string"
+                },
+                "timeout": {
+                    "description": "Timeout is the duration of the exceeded limit",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Type is the limit that has been exceeded, i.e. taskStarted, task or sequence",
+                    "type": "string"
                 }
             }
         },
@@ -2342,6 +2686,23 @@ var doc = `{
                 }
             }
         },
+        "models.SkippedTask": {
+            "type": "object",
+            "properties": {
+                "condition": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "taskIndex": {
+                    "type": "integer"
+                }
+            }
+        },
         "models.Stages": {
             "type": "object",
             "properties": {
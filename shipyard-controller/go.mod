@@ -16,6 +16,7 @@ require (
 	github.com/stretchr/testify v1.7.0
 	github.com/swaggo/swag v1.7.6
 	github.com/tryvium-travels/memongo v0.3.2
+	go.etcd.io/bbolt v1.3.6
 	go.mongodb.org/mongo-driver v1.7.4
 	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b
 	k8s.io/api v0.22.3
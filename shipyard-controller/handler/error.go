@@ -25,3 +25,7 @@ var ErrSequenceBlocked = errors.New("sequence is currently blocked")
 var ErrNoMatchingEvent = errors.New("no matching event found")
 
 var ErrSequenceNotFound = errors.New("sequence not found")
+
+var ErrSequenceStillRunning = errors.New("sequence is still running")
+
+var ErrTaskNotFound = errors.New("task not found in sequence")
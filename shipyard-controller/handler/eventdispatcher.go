@@ -93,8 +93,8 @@ func (e *EventDispatcher) OnSequenceFinished(event models.Event) {
 	e.cleanupQueueOfSequence(event)
 }
 
-func (e *EventDispatcher) OnSequenceTimeout(event models.Event) {
-	e.cleanupQueueOfSequence(event)
+func (e *EventDispatcher) OnSequenceTimeout(timeout models.SequenceTimeout) {
+	e.cleanupQueueOfSequence(timeout.LastEvent)
 }
 
 func (e *EventDispatcher) OnSequencePaused(pause models.EventScope) {
@@ -584,7 +584,7 @@ func TestEventDispatcher_OnSequenceTimeout(t *testing.T) {
 		eventQueueRepo: eventQueueRepo,
 	}
 
-	dispatcher.OnSequenceTimeout(models.Event{Shkeptncontext: "my-context"})
+	dispatcher.OnSequenceTimeout(models.SequenceTimeout{LastEvent: models.Event{Shkeptncontext: "my-context"}})
 
 	require.Len(t, eventQueueRepo.DeleteEventQueueStatesCalls(), 1)
 	require.Len(t, eventQueueRepo.DeleteQueuedEventsCalls(), 1)
@@ -21,7 +21,7 @@ import (
 // 			RemoveFunc: func(eventScope models.EventScope) error {
 // 				panic("mock out the Remove method")
 // 			},
-// 			RunFunc: func(ctx context.Context, startSequenceFunc func(event models.Event) error)  {
+// 			RunFunc: func(ctx context.Context, startSequenceFunc func(event models.Event, restart *models.SequenceRestart) error, controlSequenceFunc func(control models.SequenceControl) error)  {
 // 				panic("mock out the Run method")
 // 			},
 // 		}
@@ -38,7 +38,7 @@ type ISequenceDispatcherMock struct {
 	RemoveFunc func(eventScope models.EventScope) error
 
 	// RunFunc mocks the Run method.
-	RunFunc func(ctx context.Context, startSequenceFunc func(event models.Event) error)
+	RunFunc func(ctx context.Context, startSequenceFunc func(event models.Event, restart *models.SequenceRestart) error, controlSequenceFunc func(control models.SequenceControl) error)
 
 	// calls tracks calls to the methods.
 	calls struct {
@@ -57,7 +57,9 @@ type ISequenceDispatcherMock struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// StartSequenceFunc is the startSequenceFunc argument value.
-			StartSequenceFunc func(event models.Event) error
+			StartSequenceFunc func(event models.Event, restart *models.SequenceRestart) error
+			// ControlSequenceFunc is the controlSequenceFunc argument value.
+			ControlSequenceFunc func(control models.SequenceControl) error
 		}
 	}
 	lockAdd    sync.RWMutex
@@ -128,33 +130,37 @@ func (mock *ISequenceDispatcherMock) RemoveCalls() []struct {
 }
 
 // Run calls RunFunc.
-func (mock *ISequenceDispatcherMock) Run(ctx context.Context, startSequenceFunc func(event models.Event) error) {
+func (mock *ISequenceDispatcherMock) Run(ctx context.Context, startSequenceFunc func(event models.Event, restart *models.SequenceRestart) error, controlSequenceFunc func(control models.SequenceControl) error) {
 	if mock.RunFunc == nil {
 		panic("ISequenceDispatcherMock.RunFunc: method is nil but ISequenceDispatcher.Run was just called")
 	}
 	callInfo := struct {
-		Ctx               context.Context
-		StartSequenceFunc func(event models.Event) error
+		Ctx                 context.Context
+		StartSequenceFunc   func(event models.Event, restart *models.SequenceRestart) error
+		ControlSequenceFunc func(control models.SequenceControl) error
 	}{
-		Ctx:               ctx,
-		StartSequenceFunc: startSequenceFunc,
+		Ctx:                 ctx,
+		StartSequenceFunc:   startSequenceFunc,
+		ControlSequenceFunc: controlSequenceFunc,
 	}
 	mock.lockRun.Lock()
 	mock.calls.Run = append(mock.calls.Run, callInfo)
 	mock.lockRun.Unlock()
-	mock.RunFunc(ctx, startSequenceFunc)
+	mock.RunFunc(ctx, startSequenceFunc, controlSequenceFunc)
 }
 
 // RunCalls gets all the calls that were made to Run.
 // Check the length with:
 //     len(mockedISequenceDispatcher.RunCalls())
 func (mock *ISequenceDispatcherMock) RunCalls() []struct {
-	Ctx               context.Context
-	StartSequenceFunc func(event models.Event) error
+	Ctx                 context.Context
+	StartSequenceFunc   func(event models.Event, restart *models.SequenceRestart) error
+	ControlSequenceFunc func(control models.SequenceControl) error
 } {
 	var calls []struct {
-		Ctx               context.Context
-		StartSequenceFunc func(event models.Event) error
+		Ctx                 context.Context
+		StartSequenceFunc   func(event models.Event, restart *models.SequenceRestart) error
+		ControlSequenceFunc func(control models.SequenceControl) error
 	}
 	mock.lockRun.RLock()
 	calls = mock.calls.Run
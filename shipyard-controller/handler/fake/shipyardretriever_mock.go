@@ -5,6 +5,7 @@ package fake
 
 import (
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/models"
 	"sync"
 )
 
@@ -17,6 +18,9 @@ import (
 // 			GetCachedShipyardFunc: func(projectName string) (*keptnv2.Shipyard, error) {
 // 				panic("mock out the GetCachedShipyard method")
 // 			},
+// 			GetCachedShipyardExtensionFunc: func(projectName string) (*models.ShipyardExtension, error) {
+// 				panic("mock out the GetCachedShipyardExtension method")
+// 			},
 // 			GetShipyardFunc: func(projectName string) (*keptnv2.Shipyard, error) {
 // 				panic("mock out the GetShipyard method")
 // 			},
@@ -30,6 +34,9 @@ type IShipyardRetrieverMock struct {
 	// GetCachedShipyardFunc mocks the GetCachedShipyard method.
 	GetCachedShipyardFunc func(projectName string) (*keptnv2.Shipyard, error)
 
+	// GetCachedShipyardExtensionFunc mocks the GetCachedShipyardExtension method.
+	GetCachedShipyardExtensionFunc func(projectName string) (*models.ShipyardExtension, error)
+
 	// GetShipyardFunc mocks the GetShipyard method.
 	GetShipyardFunc func(projectName string) (*keptnv2.Shipyard, error)
 
@@ -40,14 +47,20 @@ type IShipyardRetrieverMock struct {
 			// ProjectName is the projectName argument value.
 			ProjectName string
 		}
+		// GetCachedShipyardExtension holds details about calls to the GetCachedShipyardExtension method.
+		GetCachedShipyardExtension []struct {
+			// ProjectName is the projectName argument value.
+			ProjectName string
+		}
 		// GetShipyard holds details about calls to the GetShipyard method.
 		GetShipyard []struct {
 			// ProjectName is the projectName argument value.
 			ProjectName string
 		}
 	}
-	lockGetCachedShipyard sync.RWMutex
-	lockGetShipyard       sync.RWMutex
+	lockGetCachedShipyard          sync.RWMutex
+	lockGetCachedShipyardExtension sync.RWMutex
+	lockGetShipyard                sync.RWMutex
 }
 
 // GetCachedShipyard calls GetCachedShipyardFunc.
@@ -68,6 +81,7 @@ func (mock *IShipyardRetrieverMock) GetCachedShipyard(projectName string) (*kept
 
 // GetCachedShipyardCalls gets all the calls that were made to GetCachedShipyard.
 // Check the length with:
+//
 //     len(mockedIShipyardRetriever.GetCachedShipyardCalls())
 func (mock *IShipyardRetrieverMock) GetCachedShipyardCalls() []struct {
 	ProjectName string
@@ -81,6 +95,38 @@ func (mock *IShipyardRetrieverMock) GetCachedShipyardCalls() []struct {
 	return calls
 }
 
+// GetCachedShipyardExtension calls GetCachedShipyardExtensionFunc.
+func (mock *IShipyardRetrieverMock) GetCachedShipyardExtension(projectName string) (*models.ShipyardExtension, error) {
+	if mock.GetCachedShipyardExtensionFunc == nil {
+		panic("IShipyardRetrieverMock.GetCachedShipyardExtensionFunc: method is nil but IShipyardRetriever.GetCachedShipyardExtension was just called")
+	}
+	callInfo := struct {
+		ProjectName string
+	}{
+		ProjectName: projectName,
+	}
+	mock.lockGetCachedShipyardExtension.Lock()
+	mock.calls.GetCachedShipyardExtension = append(mock.calls.GetCachedShipyardExtension, callInfo)
+	mock.lockGetCachedShipyardExtension.Unlock()
+	return mock.GetCachedShipyardExtensionFunc(projectName)
+}
+
+// GetCachedShipyardExtensionCalls gets all the calls that were made to GetCachedShipyardExtension.
+// Check the length with:
+//
+//     len(mockedIShipyardRetriever.GetCachedShipyardExtensionCalls())
+func (mock *IShipyardRetrieverMock) GetCachedShipyardExtensionCalls() []struct {
+	ProjectName string
+} {
+	var calls []struct {
+		ProjectName string
+	}
+	mock.lockGetCachedShipyardExtension.RLock()
+	calls = mock.calls.GetCachedShipyardExtension
+	mock.lockGetCachedShipyardExtension.RUnlock()
+	return calls
+}
+
 // GetShipyard calls GetShipyardFunc.
 func (mock *IShipyardRetrieverMock) GetShipyard(projectName string) (*keptnv2.Shipyard, error) {
 	if mock.GetShipyardFunc == nil {
@@ -99,6 +145,7 @@ func (mock *IShipyardRetrieverMock) GetShipyard(projectName string) (*keptnv2.Sh
 
 // GetShipyardCalls gets all the calls that were made to GetShipyard.
 // Check the length with:
+//
 //     len(mockedIShipyardRetriever.GetShipyardCalls())
 func (mock *IShipyardRetrieverMock) GetShipyardCalls() []struct {
 	ProjectName string
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"context"
+	"github.com/benbjohnson/clock"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+// LeaderElectionLeaseName is the name of the lease that is held by the leading shipyard-controller instance
+const LeaderElectionLeaseName = "leader:shipyard-controller"
+
+// LeaderElector makes sure that only one of multiple shipyard-controller instances runs the components that must not run concurrently,
+// i.e. the dispatcher loops and the SequenceWatcher. The leader is the instance that holds the leader lease and renews it every retryPeriod.
+// If the leader fails to renew the lease, it steps down, and another instance takes over once the lease has expired.
+type LeaderElector struct {
+	leaseRepo     db.LeaseRepo
+	leaseName     string
+	holder        string
+	leaseDuration time.Duration
+	retryPeriod   time.Duration
+	theClock      clock.Clock
+}
+
+// NewLeaderElector creates a new LeaderElector
+func NewLeaderElector(leaseRepo db.LeaseRepo, leaseName, holder string, leaseDuration, retryPeriod time.Duration, theClock clock.Clock) *LeaderElector {
+	return &LeaderElector{
+		leaseRepo:     leaseRepo,
+		leaseName:     leaseName,
+		holder:        holder,
+		leaseDuration: leaseDuration,
+		retryPeriod:   retryPeriod,
+		theClock:      theClock,
+	}
+}
+
+// Run starts the leader election loop. As soon as the instance becomes the leader, onStartedLeading is called with a context that is cancelled
+// when the instance loses the leadership. In that case, onStoppedLeading is called as well.
+// When ctx is cancelled, the lease is released so that another instance can take over immediately.
+func (le *LeaderElector) Run(ctx context.Context, onStartedLeading func(ctx context.Context), onStoppedLeading func()) {
+	ticker := le.theClock.Ticker(le.retryPeriod)
+	go func() {
+		var cancelLeading context.CancelFunc
+		stopLeading := func() {
+			if cancelLeading == nil {
+				return
+			}
+			cancelLeading()
+			cancelLeading = nil
+			if onStoppedLeading != nil {
+				onStoppedLeading()
+			}
+		}
+		defer ticker.Stop()
+		for {
+			acquired, err := le.leaseRepo.AcquireLease(le.leaseName, le.holder, le.leaseDuration)
+			if err != nil {
+				log.WithError(err).Errorf("could not acquire leader lease %s", le.leaseName)
+			}
+			if acquired && cancelLeading == nil {
+				log.Infof("%s became the leader", le.holder)
+				var leaderCtx context.Context
+				leaderCtx, cancelLeading = context.WithCancel(ctx)
+				onStartedLeading(leaderCtx)
+			} else if !acquired && cancelLeading != nil {
+				log.Infof("%s lost the leadership", le.holder)
+				stopLeading()
+			}
+
+			select {
+			case <-ctx.Done():
+				if cancelLeading != nil {
+					stopLeading()
+					if err := le.leaseRepo.ReleaseLease(le.leaseName, le.holder); err != nil {
+						log.WithError(err).Errorf("could not release leader lease %s", le.leaseName)
+					}
+				}
+				log.Info("cancelling leader election loop")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
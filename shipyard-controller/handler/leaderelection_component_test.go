@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"context"
+	"github.com/benbjohnson/clock"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/common"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testControllerInstance struct {
+	name               string
+	sequenceDispatcher ISequenceDispatcher
+	leaderElector      *LeaderElector
+	startedSequences   []string
+	mutex              sync.Mutex
+}
+
+func newTestControllerInstance(name string) *testControllerInstance {
+	eventRepo := db.NewMongoDBEventsRepo(db.GetMongoDBConnectionInstance())
+	eventQueueRepo := db.NewMongoDBEventQueueRepo(db.GetMongoDBConnectionInstance())
+	sequenceQueueRepo := db.NewMongoDBSequenceQueueRepo(db.GetMongoDBConnectionInstance())
+	sequenceRepo := db.NewTaskSequenceMongoDBRepo(db.GetMongoDBConnectionInstance())
+	leaseRepo := db.NewMongoDBLeaseRepo(db.GetMongoDBConnectionInstance())
+
+	return &testControllerInstance{
+		name:               name,
+		sequenceDispatcher: NewSequenceDispatcher(eventRepo, eventQueueRepo, sequenceQueueRepo, sequenceRepo, nil, 100*time.Millisecond, clock.New()),
+		leaderElector:      NewLeaderElector(leaseRepo, LeaderElectionLeaseName, name, time.Second, 100*time.Millisecond, clock.New()),
+	}
+}
+
+func (i *testControllerInstance) run(ctx context.Context) {
+	i.leaderElector.Run(ctx, func(leaderCtx context.Context) {
+		i.sequenceDispatcher.Run(leaderCtx, func(event models.Event, restart *models.SequenceRestart) error {
+			i.mutex.Lock()
+			defer i.mutex.Unlock()
+			i.startedSequences = append(i.startedSequences, event.ID)
+			return nil
+		}, nil)
+	}, nil)
+}
+
+func (i *testControllerInstance) getStartedSequences() []string {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	return append([]string{}, i.startedSequences...)
+}
+
+func queueTestSequence(t *testing.T, instance *testControllerInstance, eventID, keptnContext string) {
+	eventRepo := db.NewMongoDBEventsRepo(db.GetMongoDBConnectionInstance())
+	scope := models.EventScope{
+		EventData: keptnv2.EventData{
+			Project: "my-project",
+			Stage:   "dev",
+			Service: "my-service",
+		},
+		KeptnContext: keptnContext,
+		EventType:    keptnv2.GetTriggeredEventType("dev.delivery"),
+	}
+	err := eventRepo.InsertEvent("my-project", models.Event{
+		Data:           scope.EventData,
+		ID:             eventID,
+		Shkeptncontext: keptnContext,
+		Type:           common.Stringp(scope.EventType),
+	}, common.TriggeredEvent)
+	require.Nil(t, err)
+
+	err = instance.sequenceDispatcher.Add(models.QueueItem{
+		Scope:     scope,
+		EventID:   eventID,
+		Timestamp: time.Now().UTC(),
+	})
+	require.Nil(t, err)
+}
+
+// Two shipyard-controller instances share the same database. Only the leader may dispatch sequences, and once the leader is gone, the other instance takes over
+func Test_LeaderElection_FailoverBetweenTwoInstances(t *testing.T) {
+	defer setupLocalMongoDB()()
+
+	instance1 := newTestControllerInstance("instance-1")
+	instance2 := newTestControllerInstance("instance-2")
+
+	ctx1, stopInstance1 := context.WithCancel(context.Background())
+	ctx2, stopInstance2 := context.WithCancel(context.Background())
+	defer stopInstance2()
+
+	instance1.run(ctx1)
+	require.Eventually(t, func() bool {
+		lease, err := instance1.leaderElector.leaseRepo.GetLease(LeaderElectionLeaseName)
+		return err == nil && lease.Holder == "instance-1"
+	}, 5*time.Second, 50*time.Millisecond)
+	instance2.run(ctx2)
+
+	// the sequence is received by the non-leading instance, which only queues it
+	queueTestSequence(t, instance2, "event-id-1", "context-1")
+
+	require.Eventually(t, func() bool {
+		return len(instance1.getStartedSequences()) == 1
+	}, 5*time.Second, 50*time.Millisecond)
+	require.Empty(t, instance2.getStartedSequences())
+
+	// stop the leading instance - the second instance should take over and dispatch the next sequence
+	stopInstance1()
+
+	require.Eventually(t, func() bool {
+		lease, err := instance2.leaderElector.leaseRepo.GetLease(LeaderElectionLeaseName)
+		return err == nil && lease.Holder == "instance-2"
+	}, 5*time.Second, 50*time.Millisecond)
+
+	queueTestSequence(t, instance1, "event-id-2", "context-2")
+
+	require.Eventually(t, func() bool {
+		return len(instance2.getStartedSequences()) == 1
+	}, 5*time.Second, 50*time.Millisecond)
+	require.Equal(t, []string{"event-id-1"}, instance1.getStartedSequences())
+	require.Equal(t, []string{"event-id-2"}, instance2.getStartedSequences())
+}
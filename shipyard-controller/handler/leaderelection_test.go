@@ -0,0 +1,338 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"github.com/benbjohnson/clock"
+	db_mock "github.com/keptn/keptn/shipyard-controller/db/mock"
+	"github.com/keptn/keptn/shipyard-controller/handler"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newInMemoryLeaseRepo returns a LeaseRepoMock that behaves like the MongoDB implementation, using the given clock to determine expired leases
+func newInMemoryLeaseRepo(theClock clock.Clock) *db_mock.LeaseRepoMock {
+	leases := map[string]models.Lease{}
+	mutex := &sync.Mutex{}
+	return &db_mock.LeaseRepoMock{
+		AcquireLeaseFunc: func(name string, holder string, duration time.Duration) (bool, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			lease, ok := leases[name]
+			if ok && lease.Holder != holder && lease.ExpiresAt.After(theClock.Now()) {
+				return false, nil
+			}
+			leases[name] = models.Lease{Name: name, Holder: holder, ExpiresAt: theClock.Now().Add(duration)}
+			return true, nil
+		},
+		ReleaseLeaseFunc: func(name string, holder string) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			if leases[name].Holder == holder {
+				delete(leases, name)
+			}
+			return nil
+		},
+		GetLeaseFunc: func(name string) (*models.Lease, error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			lease := leases[name]
+			return &lease, nil
+		},
+	}
+}
+
+type leadershipRecorder struct {
+	mutex   sync.Mutex
+	leading bool
+	started int
+	stopped int
+}
+
+func (r *leadershipRecorder) onStartedLeading(ctx context.Context) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.leading = true
+	r.started++
+}
+
+func (r *leadershipRecorder) onStoppedLeading() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.leading = false
+	r.stopped++
+}
+
+func (r *leadershipRecorder) isLeading() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.leading
+}
+
+func TestLeaderElector_OnlyOneInstanceLeads(t *testing.T) {
+	theClock := clock.NewMock()
+	leaseRepo := newInMemoryLeaseRepo(theClock)
+
+	recorder1 := &leadershipRecorder{}
+	recorder2 := &leadershipRecorder{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	elector1 := handler.NewLeaderElector(leaseRepo, handler.LeaderElectionLeaseName, "instance-1", 15*time.Second, 5*time.Second, theClock)
+	elector1.Run(ctx, recorder1.onStartedLeading, recorder1.onStoppedLeading)
+
+	require.Eventually(t, recorder1.isLeading, 5*time.Second, 10*time.Millisecond)
+
+	elector2 := handler.NewLeaderElector(leaseRepo, handler.LeaderElectionLeaseName, "instance-2", 15*time.Second, 5*time.Second, theClock)
+	elector2.Run(ctx, recorder2.onStartedLeading, recorder2.onStoppedLeading)
+
+	for i := 0; i < 10; i++ {
+		theClock.Add(5 * time.Second)
+		<-time.After(10 * time.Millisecond)
+	}
+
+	require.True(t, recorder1.isLeading())
+	require.False(t, recorder2.isLeading())
+	require.Equal(t, 1, recorder1.started)
+	require.Equal(t, 0, recorder2.started)
+}
+
+func TestLeaderElector_FailoverAfterLeaderCrashed(t *testing.T) {
+	theClock := clock.NewMock()
+	leaseRepo := newInMemoryLeaseRepo(theClock)
+
+	// the first instance loses its connection to the database after it became the leader
+	crashed := false
+	crashMutex := &sync.Mutex{}
+	crashingLeaseRepo := &db_mock.LeaseRepoMock{
+		AcquireLeaseFunc: func(name string, holder string, duration time.Duration) (bool, error) {
+			crashMutex.Lock()
+			defer crashMutex.Unlock()
+			if crashed {
+				return false, errors.New("connection lost")
+			}
+			return leaseRepo.AcquireLease(name, holder, duration)
+		},
+		ReleaseLeaseFunc: func(name string, holder string) error {
+			return errors.New("connection lost")
+		},
+	}
+
+	recorder1 := &leadershipRecorder{}
+	recorder2 := &leadershipRecorder{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	elector1 := handler.NewLeaderElector(crashingLeaseRepo, handler.LeaderElectionLeaseName, "instance-1", 15*time.Second, 5*time.Second, theClock)
+	elector1.Run(ctx, recorder1.onStartedLeading, recorder1.onStoppedLeading)
+	require.Eventually(t, recorder1.isLeading, 5*time.Second, 10*time.Millisecond)
+
+	elector2 := handler.NewLeaderElector(leaseRepo, handler.LeaderElectionLeaseName, "instance-2", 15*time.Second, 5*time.Second, theClock)
+	elector2.Run(ctx, recorder2.onStartedLeading, recorder2.onStoppedLeading)
+
+	crashMutex.Lock()
+	crashed = true
+	crashMutex.Unlock()
+
+	// the first renewal fails -> instance-1 steps down, but the lease is still valid, so instance-2 cannot take over yet
+	theClock.Add(5 * time.Second)
+	require.Eventually(t, func() bool { return !recorder1.isLeading() }, 5*time.Second, 10*time.Millisecond)
+	require.False(t, recorder2.isLeading())
+
+	// after the lease has expired, instance-2 becomes the leader
+	require.Eventually(t, func() bool {
+		theClock.Add(5 * time.Second)
+		return recorder2.isLeading()
+	}, 5*time.Second, 10*time.Millisecond)
+	require.False(t, recorder1.isLeading())
+}
+
+func TestLeaderElector_ReleasesLeaseWhenCancelled(t *testing.T) {
+	theClock := clock.NewMock()
+	leaseRepo := newInMemoryLeaseRepo(theClock)
+
+	recorder1 := &leadershipRecorder{}
+	recorder2 := &leadershipRecorder{}
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+
+	elector1 := handler.NewLeaderElector(leaseRepo, handler.LeaderElectionLeaseName, "instance-1", 15*time.Second, 5*time.Second, theClock)
+	elector1.Run(ctx1, recorder1.onStartedLeading, recorder1.onStoppedLeading)
+	require.Eventually(t, recorder1.isLeading, 5*time.Second, 10*time.Millisecond)
+
+	elector2 := handler.NewLeaderElector(leaseRepo, handler.LeaderElectionLeaseName, "instance-2", 15*time.Second, 5*time.Second, theClock)
+	elector2.Run(ctx2, recorder2.onStartedLeading, recorder2.onStoppedLeading)
+
+	cancel1()
+	require.Eventually(t, func() bool { return len(leaseRepo.ReleaseLeaseCalls()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	require.False(t, recorder1.isLeading())
+
+	// since the lease has been released, the second instance can take over with the next retry
+	theClock.Add(5 * time.Second)
+	require.Eventually(t, recorder2.isLeading, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestLeaseLocker(t *testing.T) {
+	theClock := clock.NewMock()
+	leaseRepo := newInMemoryLeaseRepo(theClock)
+
+	locker1 := handler.NewLeaseLocker(leaseRepo, "instance-1", 15*time.Second, time.Second, time.Minute, theClock, nil)
+	locker2 := handler.NewLeaseLocker(leaseRepo, "instance-2", 15*time.Second, time.Second, time.Minute, theClock, nil)
+
+	require.Nil(t, locker1.Lock("my-project"))
+
+	acquired := make(chan struct{})
+	go func() {
+		require.Nil(t, locker2.Lock("my-project"))
+		close(acquired)
+	}()
+
+	// the lock is still held by instance-1
+	theClock.Add(time.Second)
+	select {
+	case <-acquired:
+		t.Fatal("lock must not be acquired by two instances at the same time")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// locking a different key is not blocked
+	require.Nil(t, locker1.Lock("my-other-project"))
+	locker1.Unlock("my-other-project")
+
+	locker1.Unlock("my-project")
+
+	require.Eventually(t, func() bool {
+		theClock.Add(time.Second)
+		select {
+		case <-acquired:
+			return true
+		default:
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+	locker2.Unlock("my-project")
+}
+
+func TestLeaseLocker_LosesLease(t *testing.T) {
+	theClock := clock.NewMock()
+	leaseRepo := newInMemoryLeaseRepo(theClock)
+
+	lostLeases := make(chan string, 1)
+	locker := handler.NewLeaseLocker(leaseRepo, "instance-1", 15*time.Second, time.Second, time.Minute, theClock, func(key string) {
+		lostLeases <- key
+	})
+	lockCtx, err := locker.LockContext("my-project")
+	require.Nil(t, err)
+
+	// the lease has expired, e.g. because the instance was paused, and has been taken over by another instance
+	theClock.Add(16 * time.Second)
+	acquired, err := leaseRepo.AcquireLease("lock:my-project", "instance-2", 15*time.Second)
+	require.Nil(t, err)
+	require.True(t, acquired)
+
+	require.Eventually(t, func() bool {
+		theClock.Add(time.Second)
+		return lockCtx.Err() != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, "my-project", <-lostLeases)
+
+	// releasing the lost lock does not release the lease of the other instance
+	locker.Unlock("my-project")
+	lease, err := leaseRepo.GetLease("lock:my-project")
+	require.Nil(t, err)
+	require.Equal(t, "instance-2", lease.Holder)
+}
+
+func TestLeaseLocker_RenewalFails(t *testing.T) {
+	theClock := clock.NewMock()
+	leaseRepo := newInMemoryLeaseRepo(theClock)
+	acquireLease := leaseRepo.AcquireLeaseFunc
+	var databaseAvailable int32 = 1
+	leaseRepo.AcquireLeaseFunc = func(name string, holder string, duration time.Duration) (bool, error) {
+		if atomic.LoadInt32(&databaseAvailable) == 0 {
+			return false, errors.New("database not available")
+		}
+		return acquireLease(name, holder, duration)
+	}
+
+	locker := handler.NewLeaseLocker(leaseRepo, "instance-1", 15*time.Second, time.Second, time.Minute, theClock, nil)
+	lockCtx, err := locker.LockContext("my-project")
+	require.Nil(t, err)
+
+	atomic.StoreInt32(&databaseAvailable, 0)
+	require.Eventually(t, func() bool {
+		theClock.Add(time.Second)
+		return lockCtx.Err() != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	locker.Unlock("my-project")
+}
+
+func TestLeaseLocker_Timeout(t *testing.T) {
+	theClock := clock.NewMock()
+	leaseRepo := newInMemoryLeaseRepo(theClock)
+	acquireLease := leaseRepo.AcquireLeaseFunc
+	var databaseAvailable int32 = 1
+	leaseRepo.AcquireLeaseFunc = func(name string, holder string, duration time.Duration) (bool, error) {
+		if atomic.LoadInt32(&databaseAvailable) == 0 {
+			return false, errors.New("database not available")
+		}
+		return acquireLease(name, holder, duration)
+	}
+
+	locker1 := handler.NewLeaseLocker(leaseRepo, "instance-1", 15*time.Second, time.Second, 5*time.Second, theClock, nil)
+	locker2 := handler.NewLeaseLocker(leaseRepo, "instance-2", 15*time.Second, time.Second, 5*time.Second, theClock, nil)
+
+	tests := []struct {
+		name              string
+		databaseAvailable int32
+		wantErr           string
+	}{
+		{
+			name:              "lock held by another instance",
+			databaseAvailable: 1,
+			wantErr:           "could not acquire lock my-project within 5s: it is held by another instance",
+		},
+		{
+			name:              "database not available",
+			databaseAvailable: 0,
+			wantErr:           "could not acquire lock my-project within 5s: database not available",
+		},
+	}
+	require.Nil(t, locker1.Lock("my-project"))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atomic.StoreInt32(&databaseAvailable, tt.databaseAvailable)
+
+			lockErr := make(chan error)
+			go func() {
+				lockErr <- locker2.Lock("my-project")
+			}()
+
+			var err error
+			require.Eventually(t, func() bool {
+				theClock.Add(time.Second)
+				select {
+				case err = <-lockErr:
+					return true
+				default:
+					return false
+				}
+			}, 5*time.Second, 10*time.Millisecond)
+			require.EqualError(t, err, tt.wantErr)
+		})
+	}
+
+	// after a timeout, the lock can be acquired again once it has been released
+	atomic.StoreInt32(&databaseAvailable, 1)
+	locker1.Unlock("my-project")
+	require.Nil(t, locker2.Lock("my-project"))
+	locker2.Unlock("my-project")
+}
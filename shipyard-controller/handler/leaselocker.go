@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"github.com/benbjohnson/clock"
+	"github.com/keptn/keptn/shipyard-controller/common"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	log "github.com/sirupsen/logrus"
+	"sync"
+	"time"
+)
+
+const lockLeasePrefix = "lock:"
+
+// LeaseLocker is an implementation of common.Locker that provides mutual exclusion across multiple instances of the shipyard-controller.
+// Within the process, a key is protected by a local mutex, and across processes by a lease stored in the LeaseRepo.
+// While a lock is held, the lease is renewed periodically so that it does not expire. If the process crashes,
+// the lease expires after leaseDuration and the lock can be acquired by another instance.
+// If the lease cannot be acquired within lockTimeout, e.g. because the database is not available, acquiring the lock fails.
+// If renewing the lease fails, or the lease has been taken over by another instance in the meantime, the lock is considered lost:
+// the context of the holder is cancelled and onLeaseLost is called, since the mutual exclusion can no longer be guaranteed.
+type LeaseLocker struct {
+	leaseRepo     db.LeaseRepo
+	holder        string
+	leaseDuration time.Duration
+	retryInterval time.Duration
+	lockTimeout   time.Duration
+	theClock      clock.Clock
+	localLocker   *common.LocalLocker
+	renewals      map[string]context.CancelFunc
+	onLeaseLost   func(key string)
+	mutex         sync.Mutex
+}
+
+// NewLeaseLocker creates a new LeaseLocker. onLeaseLost is called with the key of a lock whose lease could not be renewed, and may be nil
+func NewLeaseLocker(leaseRepo db.LeaseRepo, holder string, leaseDuration, retryInterval, lockTimeout time.Duration, theClock clock.Clock, onLeaseLost func(key string)) *LeaseLocker {
+	return &LeaseLocker{
+		leaseRepo:     leaseRepo,
+		holder:        holder,
+		leaseDuration: leaseDuration,
+		retryInterval: retryInterval,
+		lockTimeout:   lockTimeout,
+		theClock:      theClock,
+		localLocker:   common.NewLocalLocker(),
+		renewals:      map[string]context.CancelFunc{},
+		onLeaseLost:   onLeaseLost,
+	}
+}
+
+// Lock blocks until the lock for the given key has been acquired, or returns an error if it could not be acquired within the lock timeout
+func (l *LeaseLocker) Lock(key string) error {
+	_, err := l.LockContext(key)
+	return err
+}
+
+// LockContext blocks until the lock for the given key has been acquired. The returned context is cancelled when the lock is released,
+// or when its lease is lost, i.e. the holder must stop acting on the locked resource once the context is done.
+// If the lease cannot be acquired within the lock timeout, an error is returned and the lock is not held
+func (l *LeaseLocker) LockContext(key string) (context.Context, error) {
+	// first, make sure no other goroutine of this process holds the lock, since they share the same lease holder identity
+	_ = l.localLocker.Lock(key)
+
+	leaseName := lockLeasePrefix + key
+	deadline := l.theClock.Now().Add(l.lockTimeout)
+	for {
+		acquired, err := l.leaseRepo.AcquireLease(leaseName, l.holder, l.leaseDuration)
+		if err == nil && acquired {
+			break
+		}
+		if !l.theClock.Now().Before(deadline) {
+			l.localLocker.Unlock(key)
+			if err != nil {
+				return nil, fmt.Errorf("could not acquire lock %s within %s: %w", key, l.lockTimeout.String(), err)
+			}
+			return nil, fmt.Errorf("could not acquire lock %s within %s: it is held by another instance", key, l.lockTimeout.String())
+		}
+		if err != nil {
+			log.WithError(err).Errorf("could not acquire lock %s. Retrying in %.2f seconds", key, l.retryInterval.Seconds())
+		} else {
+			log.Debugf("lock %s is held by another instance. Retrying in %.2f seconds", key, l.retryInterval.Seconds())
+		}
+		<-l.theClock.After(l.retryInterval)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	l.mutex.Lock()
+	l.renewals[key] = cancel
+	l.mutex.Unlock()
+
+	go l.renewLease(ctx, cancel, key)
+	return ctx, nil
+}
+
+// Unlock releases the lock for the given key
+func (l *LeaseLocker) Unlock(key string) {
+	l.mutex.Lock()
+	if cancel, ok := l.renewals[key]; ok {
+		cancel()
+		delete(l.renewals, key)
+	}
+	l.mutex.Unlock()
+
+	if err := l.leaseRepo.ReleaseLease(lockLeasePrefix+key, l.holder); err != nil {
+		log.WithError(err).Errorf("could not release lock %s", key)
+	}
+	l.localLocker.Unlock(key)
+}
+
+func (l *LeaseLocker) renewLease(ctx context.Context, cancel context.CancelFunc, key string) {
+	leaseName := lockLeasePrefix + key
+	ticker := l.theClock.Ticker(l.leaseDuration / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			acquired, err := l.leaseRepo.AcquireLease(leaseName, l.holder, l.leaseDuration)
+			if err != nil {
+				log.WithError(err).Errorf("could not renew lease %s", leaseName)
+			} else if !acquired {
+				log.Errorf("lease %s has been acquired by another instance", leaseName)
+			}
+			if err != nil || !acquired {
+				cancel()
+				if l.onLeaseLost != nil {
+					l.onLeaseLost(key)
+				}
+				return
+			}
+		}
+	}
+}
@@ -136,7 +136,10 @@ func (ph *ProjectHandler) CreateProject(c *gin.Context) {
 		return
 	}
 
-	common.LockProject(*createProjectParams.Name)
+	if err := common.LockProject(*createProjectParams.Name); err != nil {
+		SetInternalServerErrorResponse(err, c)
+		return
+	}
 	defer common.UnlockProject(*createProjectParams.Name)
 
 	if err := ph.sendProjectCreateStartedEvent(keptnContext, createProjectParams); err != nil {
@@ -190,7 +193,10 @@ func (ph *ProjectHandler) UpdateProject(c *gin.Context) {
 		return
 	}
 
-	common.LockProject(*params.Name)
+	if err := common.LockProject(*params.Name); err != nil {
+		SetInternalServerErrorResponse(err, c)
+		return
+	}
 	defer common.UnlockProject(*params.Name)
 
 	err, rollback := ph.ProjectManager.Update(params)
@@ -230,7 +236,10 @@ func (ph *ProjectHandler) DeleteProject(c *gin.Context) {
 	keptnContext := uuid.New().String()
 	projectName := c.Param("project")
 
-	common.LockProject(projectName)
+	if err := common.LockProject(projectName); err != nil {
+		SetInternalServerErrorResponse(err, c)
+		return
+	}
 	defer common.UnlockProject(projectName)
 	responseMessage, err := ph.ProjectManager.Delete(projectName)
 	if err != nil {
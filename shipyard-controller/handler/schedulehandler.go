@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"net/http"
+)
+
+type IScheduleHandler interface {
+	GetSchedules(context *gin.Context)
+	ControlSchedule(context *gin.Context)
+	FireSchedule(context *gin.Context)
+}
+
+type ScheduleHandler struct {
+	scheduler IScheduler
+}
+
+func NewScheduleHandler(scheduler IScheduler) *ScheduleHandler {
+	return &ScheduleHandler{scheduler: scheduler}
+}
+
+// GetSchedules godoc
+// @Summary Get the schedules of a project
+// @Description Get the cron schedules that periodically trigger sequences of a project
+// @Tags Schedule
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param   project     path    string  true   "The project name"
+// @Success 200 {object} models.Schedules	"ok"
+// @Failure 404 {object} models.Error "Project not found"
+// @Failure 500 {object} models.Error "Internal error"
+// @Router /schedule/{project} [get]
+func (sh *ScheduleHandler) GetSchedules(c *gin.Context) {
+	schedules, err := sh.scheduler.GetSchedules(c.Param("project"))
+	if err != nil {
+		if errors.Is(err, ErrProjectNotFound) {
+			SetNotFoundErrorResponse(err, c, "Could not find project")
+			return
+		}
+		SetInternalServerErrorResponse(err, c, "Unable to retrieve schedules")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.Schedules{Schedules: schedules})
+}
+
+// ControlSchedule godoc
+// @Summary Pause/Resume a schedule
+// @Description Pause/Resume a schedule. Runs that have been missed while the schedule was paused are not triggered after resuming it
+// @Tags Schedule
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param   project     		path    string  true   "The project name"
+// @Param   scheduleId			path	string	true	"The ID of the schedule"
+// @Param   scheduleControl     body    models.ScheduleControlCommand true "Schedule Control Command"
+// @Success 200 {object} models.Schedule	"ok"
+// @Failure 400 {object} models.Error "Invalid payload"
+// @Failure 404 {object} models.Error "Schedule not found"
+// @Failure 500 {object} models.Error "Internal error"
+// @Router /schedule/{project}/{scheduleId}/control [post]
+func (sh *ScheduleHandler) ControlSchedule(c *gin.Context) {
+	params := &models.ScheduleControlCommand{}
+	if err := c.ShouldBindJSON(params); err != nil {
+		SetBadRequestErrorResponse(err, c, "Invalid request format")
+		return
+	}
+
+	schedule, err := sh.scheduler.ControlSchedule(c.Param("project"), c.Param("scheduleId"), params.State)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidScheduleControl):
+			SetBadRequestErrorResponse(err, c, "Invalid request format")
+		case errors.Is(err, ErrScheduleNotFound):
+			SetNotFoundErrorResponse(err, c, "Could not control schedule")
+		default:
+			SetInternalServerErrorResponse(err, c, "Unable to control schedule")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// FireSchedule godoc
+// @Summary Fire a schedule
+// @Description Immediately trigger the sequence of a schedule. The next regular run of the schedule is not affected
+// @Tags Schedule
+// @Security ApiKeyAuth
+// @Accept  json
+// @Produce  json
+// @Param   project     		path    string  true   "The project name"
+// @Param   scheduleId			path	string	true	"The ID of the schedule"
+// @Success 200 {object} models.FireScheduleResponse	"ok"
+// @Failure 404 {object} models.Error "Schedule not found"
+// @Failure 500 {object} models.Error "Internal error"
+// @Router /schedule/{project}/{scheduleId}/fire [post]
+func (sh *ScheduleHandler) FireSchedule(c *gin.Context) {
+	response, err := sh.scheduler.FireSchedule(c.Param("project"), c.Param("scheduleId"))
+	if err != nil {
+		if errors.Is(err, ErrScheduleNotFound) {
+			SetNotFoundErrorResponse(err, c, "Could not fire schedule")
+			return
+		}
+		SetInternalServerErrorResponse(err, c, "Unable to fire schedule")
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
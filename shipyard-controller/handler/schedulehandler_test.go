@@ -0,0 +1,176 @@
+package handler_test
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/keptn/keptn/shipyard-controller/handler"
+	"github.com/keptn/keptn/shipyard-controller/handler/fake"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestScheduleHandler_GetSchedules(t *testing.T) {
+	tests := []struct {
+		name       string
+		scheduler  *fake.ISchedulerMock
+		wantStatus int
+	}{
+		{
+			name: "get schedules",
+			scheduler: &fake.ISchedulerMock{
+				GetSchedulesFunc: func(project string) ([]models.Schedule, error) {
+					require.Equal(t, "my-project", project)
+					return []models.Schedule{{ID: "my-project.dev.carts.delivery"}}, nil
+				},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "project not found",
+			scheduler: &fake.ISchedulerMock{
+				GetSchedulesFunc: func(project string) ([]models.Schedule, error) {
+					return nil, handler.ErrProjectNotFound
+				},
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "internal error",
+			scheduler: &fake.ISchedulerMock{
+				GetSchedulesFunc: func(project string) ([]models.Schedule, error) {
+					return nil, errors.New("oops")
+				},
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sh := handler.NewScheduleHandler(tt.scheduler)
+
+			router := gin.Default()
+			router.GET("/schedule/:project", sh.GetSchedules)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/schedule/my-project", nil))
+
+			require.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestScheduleHandler_ControlSchedule(t *testing.T) {
+	tests := []struct {
+		name       string
+		scheduler  *fake.ISchedulerMock
+		payload    string
+		wantStatus int
+	}{
+		{
+			name: "pause schedule",
+			scheduler: &fake.ISchedulerMock{
+				ControlScheduleFunc: func(project string, scheduleID string, state models.ScheduleControlState) (*models.Schedule, error) {
+					require.Equal(t, "my-project", project)
+					require.Equal(t, "my-project.dev.carts.delivery", scheduleID)
+					require.Equal(t, models.PauseSchedule, state)
+					return &models.Schedule{ID: scheduleID, Paused: true}, nil
+				},
+			},
+			payload:    `{"state": "pause"}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid payload",
+			scheduler:  &fake.ISchedulerMock{},
+			payload:    `foo`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid state",
+			scheduler: &fake.ISchedulerMock{
+				ControlScheduleFunc: func(project string, scheduleID string, state models.ScheduleControlState) (*models.Schedule, error) {
+					return nil, handler.ErrInvalidScheduleControl
+				},
+			},
+			payload:    `{"state": "stop"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "schedule not found",
+			scheduler: &fake.ISchedulerMock{
+				ControlScheduleFunc: func(project string, scheduleID string, state models.ScheduleControlState) (*models.Schedule, error) {
+					return nil, handler.ErrScheduleNotFound
+				},
+			},
+			payload:    `{"state": "resume"}`,
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sh := handler.NewScheduleHandler(tt.scheduler)
+
+			router := gin.Default()
+			router.POST("/schedule/:project/:scheduleId/control", sh.ControlSchedule)
+
+			w := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPost, "/schedule/my-project/my-project.dev.carts.delivery/control", bytes.NewBufferString(tt.payload))
+			router.ServeHTTP(w, request)
+
+			require.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestScheduleHandler_FireSchedule(t *testing.T) {
+	tests := []struct {
+		name       string
+		scheduler  *fake.ISchedulerMock
+		wantStatus int
+	}{
+		{
+			name: "fire schedule",
+			scheduler: &fake.ISchedulerMock{
+				FireScheduleFunc: func(project string, scheduleID string) (*models.FireScheduleResponse, error) {
+					return &models.FireScheduleResponse{KeptnContext: "my-context"}, nil
+				},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "schedule not found",
+			scheduler: &fake.ISchedulerMock{
+				FireScheduleFunc: func(project string, scheduleID string) (*models.FireScheduleResponse, error) {
+					return nil, handler.ErrScheduleNotFound
+				},
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "sequence cannot be triggered",
+			scheduler: &fake.ISchedulerMock{
+				FireScheduleFunc: func(project string, scheduleID string) (*models.FireScheduleResponse, error) {
+					return nil, errors.New("oops")
+				},
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sh := handler.NewScheduleHandler(tt.scheduler)
+
+			router := gin.Default()
+			router.POST("/schedule/:project/:scheduleId/fire", sh.FireSchedule)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/schedule/my-project/my-project.dev.carts.delivery/fire", nil))
+
+			require.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
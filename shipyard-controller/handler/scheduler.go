@@ -0,0 +1,299 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/benbjohnson/clock"
+	"github.com/google/uuid"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/common"
+	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	log "github.com/sirupsen/logrus"
+	"reflect"
+	"time"
+)
+
+var ErrScheduleNotFound = errors.New("schedule not found")
+
+var ErrInvalidScheduleControl = errors.New("invalid schedule control state")
+
+//go:generate moq -pkg fake -skip-ensure -out ./fake/scheduler.go . IScheduler
+// IScheduler is responsible for triggering sequences based on the schedules defined in the shipyard
+type IScheduler interface {
+	GetSchedules(project string) ([]models.Schedule, error)
+	ControlSchedule(project, scheduleID string, state models.ScheduleControlState) (*models.Schedule, error)
+	FireSchedule(project, scheduleID string) (*models.FireScheduleResponse, error)
+}
+
+type Scheduler struct {
+	scheduleRepo      db.ScheduleRepo
+	projectMVRepo     db.ProjectMVRepo
+	shipyardRetriever IShipyardRetriever
+	eventSender       common.EventSender
+	syncInterval      time.Duration
+	theClock          clock.Clock
+}
+
+// NewScheduler creates a new Scheduler
+func NewScheduler(
+	scheduleRepo db.ScheduleRepo,
+	projectMVRepo db.ProjectMVRepo,
+	shipyardRetriever IShipyardRetriever,
+	eventSender common.EventSender,
+	syncInterval time.Duration,
+	theClock clock.Clock,
+) *Scheduler {
+	return &Scheduler{
+		scheduleRepo:      scheduleRepo,
+		projectMVRepo:     projectMVRepo,
+		shipyardRetriever: shipyardRetriever,
+		eventSender:       eventSender,
+		syncInterval:      syncInterval,
+		theClock:          theClock,
+	}
+}
+
+// Run periodically synchronizes the schedules with the shipyard files of the projects and triggers the sequences of all schedules that are due
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := s.theClock.Ticker(s.syncInterval)
+	go func() {
+		s.syncAndFireSchedules()
+		for {
+			select {
+			case <-ctx.Done():
+				log.Info("Cancelling scheduler loop")
+				ticker.Stop()
+				return
+			case <-ticker.C:
+				log.Debugf("%.2f seconds have passed. Checking schedules", s.syncInterval.Seconds())
+				s.syncAndFireSchedules()
+			}
+		}
+	}()
+}
+
+func (s *Scheduler) syncAndFireSchedules() {
+	if err := s.syncSchedules(); err != nil {
+		log.WithError(err).Error("Could not synchronize schedules")
+	}
+	s.fireDueSchedules()
+}
+
+// syncSchedules creates a schedule for each service of a stage that has a scheduled sequence, and removes schedules
+// that are not defined in the shipyard anymore
+func (s *Scheduler) syncSchedules() error {
+	projects, err := s.projectMVRepo.GetProjects()
+	if err != nil {
+		return fmt.Errorf("could not load projects: %w", err)
+	}
+
+	existingSchedules, err := s.scheduleRepo.GetSchedules("")
+	if err != nil {
+		return fmt.Errorf("could not load schedules: %w", err)
+	}
+	existingSchedulesByID := map[string]models.Schedule{}
+	for _, schedule := range existingSchedules {
+		existingSchedulesByID[schedule.ID] = schedule
+	}
+
+	now := s.theClock.Now().UTC()
+	desiredSchedules := map[string]bool{}
+	for _, project := range projects {
+		shipyardExtension, err := s.shipyardRetriever.GetCachedShipyardExtension(project.ProjectName)
+		if err != nil {
+			log.WithError(err).Errorf("Could not load shipyard of project %s. Keeping its current schedules", project.ProjectName)
+			// keep the schedules of the project, since we cannot determine if they are still defined
+			for _, schedule := range existingSchedules {
+				if schedule.Project == project.ProjectName {
+					desiredSchedules[schedule.ID] = true
+				}
+			}
+			continue
+		}
+
+		for _, definition := range shipyardExtension.GetSchedules() {
+			cron, err := common.ParseCronSchedule(definition.Cron)
+			if err != nil {
+				log.WithError(err).Errorf("Invalid schedule of sequence %s in stage %s of project %s", definition.Sequence, definition.Stage, project.ProjectName)
+				continue
+			}
+			for _, service := range getServicesOfStage(project, definition.Stage) {
+				schedule := models.Schedule{
+					ID:         models.GetScheduleID(project.ProjectName, definition.Stage, service, definition.Sequence),
+					Project:    project.ProjectName,
+					Stage:      definition.Stage,
+					Service:    service,
+					Sequence:   definition.Sequence,
+					Cron:       definition.Cron,
+					Properties: definition.Properties,
+				}
+				desiredSchedules[schedule.ID] = true
+
+				existingSchedule, ok := existingSchedulesByID[schedule.ID]
+				if ok && existingSchedule.Cron == schedule.Cron && reflect.DeepEqual(existingSchedule.Properties, schedule.Properties) {
+					continue
+				}
+				// the next fire time is only applied to new schedules and schedules with a changed cron expression
+				schedule.NextFireTime = cron.Next(now)
+				log.Infof("Storing schedule %s with cron expression '%s'", schedule.ID, schedule.Cron)
+				if err := s.scheduleRepo.UpsertSchedule(schedule); err != nil {
+					log.WithError(err).Errorf("Could not store schedule %s", schedule.ID)
+				}
+			}
+		}
+	}
+
+	for _, schedule := range existingSchedules {
+		if desiredSchedules[schedule.ID] {
+			continue
+		}
+		log.Infof("Deleting schedule %s since it is not defined anymore", schedule.ID)
+		if err := s.scheduleRepo.DeleteSchedule(schedule.ID); err != nil {
+			log.WithError(err).Errorf("Could not delete schedule %s", schedule.ID)
+		}
+	}
+	return nil
+}
+
+// fireDueSchedules triggers the sequences of all schedules whose next fire time has been reached. If the shipyard-controller
+// has not been running at the time a schedule was due, the sequence is triggered once after the restart
+func (s *Scheduler) fireDueSchedules() {
+	schedules, err := s.scheduleRepo.GetSchedules("")
+	if err != nil {
+		log.WithError(err).Error("Could not load schedules")
+		return
+	}
+
+	now := s.theClock.Now().UTC()
+	for _, schedule := range schedules {
+		if schedule.Paused || schedule.NextFireTime.IsZero() || schedule.NextFireTime.After(now) {
+			continue
+		}
+		cron, err := common.ParseCronSchedule(schedule.Cron)
+		if err != nil {
+			log.WithError(err).Errorf("Invalid cron expression of schedule %s", schedule.ID)
+			continue
+		}
+
+		run := models.ScheduleRun{Time: now, KeptnContext: uuid.New().String()}
+		nextFireTime := cron.Next(now)
+		// claim the run before triggering the sequence to make sure it is triggered only once
+		claimed, err := s.scheduleRepo.ClaimScheduledRun(schedule.ID, schedule.NextFireTime, nextFireTime, run)
+		if err != nil {
+			log.WithError(err).Errorf("Could not claim run of schedule %s", schedule.ID)
+			continue
+		}
+		if !claimed {
+			log.Infof("Run of schedule %s has already been claimed", schedule.ID)
+			continue
+		}
+
+		log.Infof("Triggering sequence %s in stage %s for service %s based on schedule %s", schedule.Sequence, schedule.Stage, schedule.Service, schedule.ID)
+		if err := s.triggerSequence(schedule, run.KeptnContext); err != nil {
+			log.WithError(err).Errorf("Could not trigger sequence of schedule %s. Releasing the run to retry it", schedule.ID)
+			// release the claimed run, so it is triggered again in the next iteration
+			if err := s.scheduleRepo.ReleaseScheduledRun(schedule.ID, nextFireTime, schedule.NextFireTime, schedule.LastRun); err != nil {
+				log.WithError(err).Errorf("Could not release run of schedule %s", schedule.ID)
+			}
+		}
+	}
+}
+
+// GetSchedules returns the schedules of the given project
+func (s *Scheduler) GetSchedules(project string) ([]models.Schedule, error) {
+	if _, err := s.projectMVRepo.GetProject(project); err != nil {
+		return nil, ErrProjectNotFound
+	}
+	return s.scheduleRepo.GetSchedules(project)
+}
+
+// ControlSchedule pauses or resumes the given schedule. When a schedule is resumed, runs that were missed while it was paused are not triggered
+func (s *Scheduler) ControlSchedule(project, scheduleID string, state models.ScheduleControlState) (*models.Schedule, error) {
+	schedule, err := s.getSchedule(project, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch state {
+	case models.PauseSchedule:
+		schedule.Paused = true
+	case models.ResumeSchedule:
+		cron, err := common.ParseCronSchedule(schedule.Cron)
+		if err != nil {
+			return nil, err
+		}
+		schedule.Paused = false
+		schedule.NextFireTime = cron.Next(s.theClock.Now().UTC())
+	default:
+		return nil, ErrInvalidScheduleControl
+	}
+
+	if err := s.scheduleRepo.SetSchedulePaused(schedule.ID, schedule.Paused, schedule.NextFireTime); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// FireSchedule immediately triggers the sequence of the given schedule. The next fire time of the schedule is not changed
+func (s *Scheduler) FireSchedule(project, scheduleID string) (*models.FireScheduleResponse, error) {
+	schedule, err := s.getSchedule(project, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	run := models.ScheduleRun{Time: s.theClock.Now().UTC(), KeptnContext: uuid.New().String(), Manual: true}
+	if err := s.triggerSequence(*schedule, run.KeptnContext); err != nil {
+		return nil, err
+	}
+	if err := s.scheduleRepo.SetLastScheduleRun(schedule.ID, run); err != nil {
+		log.WithError(err).Errorf("Could not store last run of schedule %s", schedule.ID)
+	}
+	return &models.FireScheduleResponse{KeptnContext: run.KeptnContext}, nil
+}
+
+func (s *Scheduler) getSchedule(project, scheduleID string) (*models.Schedule, error) {
+	schedule, err := s.scheduleRepo.GetSchedule(scheduleID)
+	if err != nil {
+		if errors.Is(err, db.ErrScheduleNotFound) {
+			return nil, ErrScheduleNotFound
+		}
+		return nil, err
+	}
+	if schedule.Project != project {
+		return nil, ErrScheduleNotFound
+	}
+	return schedule, nil
+}
+
+// triggerSequence sends the '.triggered' event of the scheduled sequence via the event broker, the same way sequences triggered via the API are sent
+func (s *Scheduler) triggerSequence(schedule models.Schedule, keptnContext string) error {
+	eventData := map[string]interface{}{}
+	if schedule.Properties != nil {
+		eventData = common.Merge(eventData, schedule.Properties).(map[string]interface{})
+	}
+	eventData["project"] = schedule.Project
+	eventData["stage"] = schedule.Stage
+	eventData["service"] = schedule.Service
+
+	ce := common.CreateEventWithPayload(keptnContext, "", keptnv2.GetTriggeredEventType(schedule.Stage+"."+schedule.Sequence), eventData)
+	ce.SetTime(s.theClock.Now().UTC())
+	if err := s.eventSender.SendEvent(ce); err != nil {
+		return fmt.Errorf("could not send %s event: %w", ce.Type(), err)
+	}
+	return nil
+}
+
+func getServicesOfStage(project *models.ExpandedProject, stageName string) []string {
+	services := []string{}
+	for _, stage := range project.Stages {
+		if stage.StageName != stageName {
+			continue
+		}
+		for _, service := range stage.Services {
+			services = append(services, service.ServiceName)
+		}
+	}
+	return services
+}
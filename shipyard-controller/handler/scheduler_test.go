@@ -0,0 +1,322 @@
+package handler
+
+import (
+	"errors"
+	"github.com/benbjohnson/clock"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	keptnfake "github.com/keptn/go-utils/pkg/lib/v0_2_0/fake"
+	db_mock "github.com/keptn/keptn/shipyard-controller/db/mock"
+	"github.com/keptn/keptn/shipyard-controller/handler/fake"
+	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const testScheduledShipyard = `apiVersion: "spec.keptn.sh/0.2.3"
+kind: "Shipyard"
+metadata:
+  name: "shipyard-sockshop"
+spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "deployment"
+    - name: "production"
+      schedules:
+        - sequence: "evaluation"
+          cron: "0 * * * *"
+          properties:
+            evaluation:
+              timeframe: "1h"
+      sequences:
+        - name: "delivery"
+          schedule:
+            cron: "0 2 * * *"
+          tasks:
+            - name: "deployment"
+        - name: "evaluation"
+          tasks:
+            - name: "evaluation"`
+
+func getTestScheduler(scheduleRepo *db_mock.ScheduleRepoMock, eventSender *keptnfake.EventSender, theClock clock.Clock) *Scheduler {
+	projectMVRepo := &db_mock.ProjectMVRepoMock{
+		GetProjectsFunc: func() ([]*models.ExpandedProject, error) {
+			return []*models.ExpandedProject{
+				{
+					ProjectName: "my-project",
+					Stages: []*models.ExpandedStage{
+						{StageName: "dev", Services: []*models.ExpandedService{{ServiceName: "carts"}}},
+						{StageName: "production", Services: []*models.ExpandedService{{ServiceName: "carts"}, {ServiceName: "orders"}}},
+					},
+				},
+			}, nil
+		},
+		GetProjectFunc: func(projectName string) (*models.ExpandedProject, error) {
+			if projectName != "my-project" {
+				return nil, errors.New("project not found")
+			}
+			return &models.ExpandedProject{ProjectName: projectName}, nil
+		},
+	}
+	shipyardRetriever := &fake.IShipyardRetrieverMock{
+		GetCachedShipyardExtensionFunc: func(projectName string) (*models.ShipyardExtension, error) {
+			return models.UnmarshalShipyardExtension(testScheduledShipyard)
+		},
+	}
+	return NewScheduler(scheduleRepo, projectMVRepo, shipyardRetriever, eventSender, time.Minute, theClock)
+}
+
+func TestScheduler_SyncSchedules(t *testing.T) {
+	theClock := clock.NewMock()
+	theClock.Set(time.Date(2021, 12, 15, 10, 30, 0, 0, time.UTC))
+
+	scheduleRepo := &db_mock.ScheduleRepoMock{
+		GetSchedulesFunc: func(project string) ([]models.Schedule, error) {
+			return []models.Schedule{
+				{
+					// unchanged schedule - should not be updated
+					ID:       models.GetScheduleID("my-project", "production", "carts", "delivery"),
+					Project:  "my-project",
+					Stage:    "production",
+					Service:  "carts",
+					Sequence: "delivery",
+					Cron:     "0 2 * * *",
+				},
+				{
+					// schedule that is not defined in the shipyard anymore
+					ID:       models.GetScheduleID("my-project", "dev", "carts", "delivery"),
+					Project:  "my-project",
+					Stage:    "dev",
+					Service:  "carts",
+					Sequence: "delivery",
+					Cron:     "0 2 * * *",
+				},
+			}, nil
+		},
+		UpsertScheduleFunc: func(schedule models.Schedule) error {
+			return nil
+		},
+		DeleteScheduleFunc: func(id string) error {
+			return nil
+		},
+	}
+
+	scheduler := getTestScheduler(scheduleRepo, &keptnfake.EventSender{}, theClock)
+
+	err := scheduler.syncSchedules()
+	require.Nil(t, err)
+
+	upsertedSchedules := map[string]models.Schedule{}
+	for _, call := range scheduleRepo.UpsertScheduleCalls() {
+		upsertedSchedules[call.Schedule.ID] = call.Schedule
+	}
+	require.Len(t, upsertedSchedules, 3)
+
+	evaluationSchedule := upsertedSchedules["my-project.production.orders.evaluation"]
+	require.Equal(t, "0 * * * *", evaluationSchedule.Cron)
+	require.Equal(t, "orders", evaluationSchedule.Service)
+	require.Equal(t, time.Date(2021, 12, 15, 11, 0, 0, 0, time.UTC), evaluationSchedule.NextFireTime)
+	require.Equal(t, map[string]interface{}{"timeframe": "1h"}, evaluationSchedule.Properties["evaluation"])
+
+	deliverySchedule := upsertedSchedules["my-project.production.orders.delivery"]
+	require.Equal(t, time.Date(2021, 12, 16, 2, 0, 0, 0, time.UTC), deliverySchedule.NextFireTime)
+
+	require.Contains(t, upsertedSchedules, "my-project.production.carts.evaluation")
+	require.NotContains(t, upsertedSchedules, "my-project.production.carts.delivery")
+
+	require.Len(t, scheduleRepo.DeleteScheduleCalls(), 1)
+	require.Equal(t, "my-project.dev.carts.delivery", scheduleRepo.DeleteScheduleCalls()[0].Id)
+}
+
+func TestScheduler_FireDueSchedules(t *testing.T) {
+	theClock := clock.NewMock()
+	now := time.Date(2021, 12, 15, 11, 0, 30, 0, time.UTC)
+	theClock.Set(now)
+
+	dueSchedule := models.Schedule{
+		ID:           models.GetScheduleID("my-project", "production", "carts", "evaluation"),
+		Project:      "my-project",
+		Stage:        "production",
+		Service:      "carts",
+		Sequence:     "evaluation",
+		Cron:         "0 * * * *",
+		Properties:   map[string]interface{}{"evaluation": map[string]interface{}{"timeframe": "1h"}},
+		NextFireTime: time.Date(2021, 12, 15, 11, 0, 0, 0, time.UTC),
+	}
+	claimedByOtherInstance := dueSchedule
+	claimedByOtherInstance.ID = models.GetScheduleID("my-project", "production", "orders", "evaluation")
+	claimedByOtherInstance.Service = "orders"
+	pausedSchedule := dueSchedule
+	pausedSchedule.ID = models.GetScheduleID("my-project", "production", "carts", "delivery")
+	pausedSchedule.Paused = true
+	futureSchedule := dueSchedule
+	futureSchedule.ID = models.GetScheduleID("my-project", "production", "orders", "delivery")
+	futureSchedule.NextFireTime = time.Date(2021, 12, 16, 2, 0, 0, 0, time.UTC)
+
+	scheduleRepo := &db_mock.ScheduleRepoMock{
+		GetSchedulesFunc: func(project string) ([]models.Schedule, error) {
+			return []models.Schedule{dueSchedule, claimedByOtherInstance, pausedSchedule, futureSchedule}, nil
+		},
+		ClaimScheduledRunFunc: func(id string, expectedNextFireTime time.Time, nextFireTime time.Time, run models.ScheduleRun) (bool, error) {
+			return id == dueSchedule.ID, nil
+		},
+	}
+	eventSender := &keptnfake.EventSender{}
+
+	scheduler := getTestScheduler(scheduleRepo, eventSender, theClock)
+	scheduler.fireDueSchedules()
+
+	require.Len(t, scheduleRepo.ClaimScheduledRunCalls(), 2)
+	claimCall := scheduleRepo.ClaimScheduledRunCalls()[0]
+	require.Equal(t, dueSchedule.ID, claimCall.Id)
+	require.Equal(t, dueSchedule.NextFireTime, claimCall.ExpectedNextFireTime)
+	require.Equal(t, time.Date(2021, 12, 15, 12, 0, 0, 0, time.UTC), claimCall.NextFireTime)
+
+	// only the claimed run should have been sent via the event broker
+	require.Len(t, eventSender.SentEvents, 1)
+	triggeredEvent := eventSender.SentEvents[0]
+	require.Equal(t, keptnv2.GetTriggeredEventType("production.evaluation"), triggeredEvent.Type())
+	require.Equal(t, claimCall.Run.KeptnContext, triggeredEvent.Extensions()["shkeptncontext"])
+	require.Equal(t, now, triggeredEvent.Time())
+
+	eventData := &keptnv2.EvaluationTriggeredEventData{}
+	err := triggeredEvent.DataAs(eventData)
+	require.Nil(t, err)
+	require.Equal(t, "my-project", eventData.Project)
+	require.Equal(t, "production", eventData.Stage)
+	require.Equal(t, "carts", eventData.Service)
+	require.Equal(t, "1h", eventData.Evaluation.Timeframe)
+	require.Empty(t, scheduleRepo.ReleaseScheduledRunCalls())
+}
+
+func TestScheduler_FireDueSchedulesReleasesRunIfEventCannotBeSent(t *testing.T) {
+	theClock := clock.NewMock()
+	theClock.Set(time.Date(2021, 12, 15, 11, 0, 30, 0, time.UTC))
+
+	lastRun := &models.ScheduleRun{Time: time.Date(2021, 12, 15, 10, 0, 0, 0, time.UTC), KeptnContext: "previous-context"}
+	dueSchedule := models.Schedule{
+		ID:           models.GetScheduleID("my-project", "production", "carts", "evaluation"),
+		Project:      "my-project",
+		Stage:        "production",
+		Service:      "carts",
+		Sequence:     "evaluation",
+		Cron:         "0 * * * *",
+		NextFireTime: time.Date(2021, 12, 15, 11, 0, 0, 0, time.UTC),
+		LastRun:      lastRun,
+	}
+	scheduleRepo := &db_mock.ScheduleRepoMock{
+		GetSchedulesFunc: func(project string) ([]models.Schedule, error) {
+			return []models.Schedule{dueSchedule}, nil
+		},
+		ClaimScheduledRunFunc: func(id string, expectedNextFireTime time.Time, nextFireTime time.Time, run models.ScheduleRun) (bool, error) {
+			return true, nil
+		},
+		ReleaseScheduledRunFunc: func(id string, claimedNextFireTime time.Time, nextFireTime time.Time, lastRun *models.ScheduleRun) error {
+			return nil
+		},
+	}
+	eventSender := &keptnfake.EventSender{}
+	eventSender.AddReactor("*", func(event cloudevents.Event) error {
+		return errors.New("event broker not available")
+	})
+
+	scheduler := getTestScheduler(scheduleRepo, eventSender, theClock)
+	scheduler.fireDueSchedules()
+
+	require.Empty(t, eventSender.SentEvents)
+
+	// the claimed run should be released, so it is triggered again in the next iteration
+	require.Len(t, scheduleRepo.ReleaseScheduledRunCalls(), 1)
+	releaseCall := scheduleRepo.ReleaseScheduledRunCalls()[0]
+	require.Equal(t, dueSchedule.ID, releaseCall.Id)
+	require.Equal(t, scheduleRepo.ClaimScheduledRunCalls()[0].NextFireTime, releaseCall.ClaimedNextFireTime)
+	require.Equal(t, dueSchedule.NextFireTime, releaseCall.NextFireTime)
+	require.Equal(t, lastRun, releaseCall.LastRun)
+}
+
+func TestScheduler_ControlSchedule(t *testing.T) {
+	theClock := clock.NewMock()
+	theClock.Set(time.Date(2021, 12, 15, 10, 30, 0, 0, time.UTC))
+
+	schedule := models.Schedule{
+		ID:           models.GetScheduleID("my-project", "production", "carts", "evaluation"),
+		Project:      "my-project",
+		Cron:         "0 * * * *",
+		Paused:       true,
+		NextFireTime: time.Date(2021, 12, 14, 10, 0, 0, 0, time.UTC),
+	}
+	scheduleRepo := &db_mock.ScheduleRepoMock{
+		GetScheduleFunc: func(id string) (*models.Schedule, error) {
+			if id != schedule.ID {
+				return nil, errors.New("schedule not found")
+			}
+			result := schedule
+			return &result, nil
+		},
+		SetSchedulePausedFunc: func(id string, paused bool, nextFireTime time.Time) error {
+			return nil
+		},
+	}
+	scheduler := getTestScheduler(scheduleRepo, &keptnfake.EventSender{}, theClock)
+
+	// resuming the schedule should skip the runs that have been missed in the meantime
+	resumedSchedule, err := scheduler.ControlSchedule("my-project", schedule.ID, models.ResumeSchedule)
+	require.Nil(t, err)
+	require.False(t, resumedSchedule.Paused)
+	require.Equal(t, time.Date(2021, 12, 15, 11, 0, 0, 0, time.UTC), resumedSchedule.NextFireTime)
+	require.Len(t, scheduleRepo.SetSchedulePausedCalls(), 1)
+	require.False(t, scheduleRepo.SetSchedulePausedCalls()[0].Paused)
+
+	pausedSchedule, err := scheduler.ControlSchedule("my-project", schedule.ID, models.PauseSchedule)
+	require.Nil(t, err)
+	require.True(t, pausedSchedule.Paused)
+
+	_, err = scheduler.ControlSchedule("my-project", schedule.ID, "stop")
+	require.ErrorIs(t, err, ErrInvalidScheduleControl)
+
+	// schedules of other projects cannot be controlled
+	_, err = scheduler.ControlSchedule("other-project", schedule.ID, models.PauseSchedule)
+	require.ErrorIs(t, err, ErrScheduleNotFound)
+}
+
+func TestScheduler_FireSchedule(t *testing.T) {
+	theClock := clock.NewMock()
+	nextFireTime := time.Date(2021, 12, 16, 2, 0, 0, 0, time.UTC)
+
+	scheduleRepo := &db_mock.ScheduleRepoMock{
+		GetScheduleFunc: func(id string) (*models.Schedule, error) {
+			return &models.Schedule{
+				ID:           id,
+				Project:      "my-project",
+				Stage:        "production",
+				Service:      "carts",
+				Sequence:     "delivery",
+				Cron:         "0 2 * * *",
+				Paused:       true,
+				NextFireTime: nextFireTime,
+			}, nil
+		},
+		SetLastScheduleRunFunc: func(id string, run models.ScheduleRun) error {
+			return nil
+		},
+	}
+	eventSender := &keptnfake.EventSender{}
+	scheduler := getTestScheduler(scheduleRepo, eventSender, theClock)
+
+	response, err := scheduler.FireSchedule("my-project", "my-project.production.carts.delivery")
+	require.Nil(t, err)
+	require.NotEmpty(t, response.KeptnContext)
+
+	// paused schedules can be fired manually
+	require.Len(t, eventSender.SentEvents, 1)
+	require.Equal(t, keptnv2.GetTriggeredEventType("production.delivery"), eventSender.SentEvents[0].Type())
+	require.Equal(t, response.KeptnContext, eventSender.SentEvents[0].Extensions()["shkeptncontext"])
+
+	require.Len(t, scheduleRepo.SetLastScheduleRunCalls(), 1)
+	require.True(t, scheduleRepo.SetLastScheduleRunCalls()[0].Run.Manual)
+	require.Empty(t, scheduleRepo.ClaimScheduledRunCalls())
+}
@@ -18,20 +18,22 @@ import (
 // ISequenceDispatcher is responsible for dispatching events to be sent to the event broker
 type ISequenceDispatcher interface {
 	Add(queueItem models.QueueItem) error
-	Run(ctx context.Context, startSequenceFunc func(event models.Event) error)
+	Run(ctx context.Context, startSequenceFunc func(event models.Event, restart *models.SequenceRestart) error, controlSequenceFunc func(control models.SequenceControl) error)
 	Remove(eventScope models.EventScope) error
 }
 
 type SequenceDispatcher struct {
-	eventRepo          db.EventRepo
-	eventQueueRepo     db.EventQueueRepo
-	sequenceQueue      db.SequenceQueueRepo
-	sequenceRepo       db.TaskSequenceRepo
-	theClock           clock.Clock
-	syncInterval       time.Duration
-	startSequenceFunc  func(event models.Event) error
-	shipyardController shipyardController
-	mutex              sync.Mutex
+	eventRepo           db.EventRepo
+	eventQueueRepo      db.EventQueueRepo
+	sequenceQueue       db.SequenceQueueRepo
+	sequenceRepo        db.TaskSequenceRepo
+	shipyardRetriever   IShipyardRetriever
+	theClock            clock.Clock
+	syncInterval        time.Duration
+	startSequenceFunc   func(event models.Event, restart *models.SequenceRestart) error
+	controlSequenceFunc func(control models.SequenceControl) error
+	shipyardController  shipyardController
+	mutex               sync.Mutex
 }
 
 // NewSequenceDispatcher creates a new SequenceDispatcher
@@ -40,18 +42,20 @@ func NewSequenceDispatcher(
 	eventQueueRepo db.EventQueueRepo,
 	sequenceQueueRepo db.SequenceQueueRepo,
 	sequenceRepo db.TaskSequenceRepo,
+	shipyardRetriever IShipyardRetriever,
 	syncInterval time.Duration,
 	theClock clock.Clock,
 
 ) ISequenceDispatcher {
 	return &SequenceDispatcher{
-		eventRepo:      eventRepo,
-		eventQueueRepo: eventQueueRepo,
-		sequenceQueue:  sequenceQueueRepo,
-		sequenceRepo:   sequenceRepo,
-		theClock:       theClock,
-		syncInterval:   syncInterval,
-		mutex:          sync.Mutex{},
+		eventRepo:         eventRepo,
+		eventQueueRepo:    eventQueueRepo,
+		sequenceQueue:     sequenceQueueRepo,
+		sequenceRepo:      sequenceRepo,
+		shipyardRetriever: shipyardRetriever,
+		theClock:          theClock,
+		syncInterval:      syncInterval,
+		mutex:             sync.Mutex{},
 	}
 }
 
@@ -79,14 +83,21 @@ func (sd *SequenceDispatcher) Remove(eventScope models.EventScope) error {
 	})
 }
 
-func (sd *SequenceDispatcher) Run(ctx context.Context, startSequenceFunc func(event models.Event) error) {
+func (sd *SequenceDispatcher) Run(ctx context.Context, startSequenceFunc func(event models.Event, restart *models.SequenceRestart) error, controlSequenceFunc func(control models.SequenceControl) error) {
 	ticker := sd.theClock.Ticker(sd.syncInterval)
+	sd.mutex.Lock()
 	sd.startSequenceFunc = startSequenceFunc
+	sd.controlSequenceFunc = controlSequenceFunc
+	sd.mutex.Unlock()
 	go func() {
 		for {
 			select {
 			case <-ctx.Done():
 				log.Info("Cancelling sequence dispatcher loop")
+				sd.mutex.Lock()
+				sd.startSequenceFunc = nil
+				sd.controlSequenceFunc = nil
+				sd.mutex.Unlock()
 				return
 			case <-ticker.C:
 				log.Debugf("%.2f seconds have passed. Dispatching sequences", sd.syncInterval.Seconds())
@@ -107,6 +118,8 @@ func (sd *SequenceDispatcher) dispatchSequences() {
 		return
 	}
 
+	queuedSequences = sd.skipIntermediateSequences(queuedSequences)
+
 	for _, queuedSequence := range queuedSequences {
 		if err := sd.dispatchSequence(queuedSequence); err != nil {
 			if errors.Is(err, ErrSequenceBlocked) {
@@ -119,26 +132,44 @@ func (sd *SequenceDispatcher) dispatchSequences() {
 }
 
 func (sd *SequenceDispatcher) dispatchSequence(queuedSequence models.QueueItem) error {
+	if err := sd.cancelRunningSequences(queuedSequence); err != nil {
+		return err
+	}
+
 	sd.mutex.Lock()
 	defer sd.mutex.Unlock()
+	if sd.startSequenceFunc == nil {
+		// the dispatcher loop is not running on this instance (e.g. because another instance is the leader).
+		// In this case, the sequence will be queued and dispatched by the leading instance
+		return ErrSequenceBlocked
+	}
+	// make sure no other instance of the shipyard-controller dispatches a sequence for the same service in the stage at the same time
+	if err := common.LockServiceInStageOfProject(queuedSequence.Scope.Project, queuedSequence.Scope.Stage, queuedSequence.Scope.Service+":sequenceDispatcher"); err != nil {
+		return err
+	}
+	defer common.UnlockServiceInStageOfProject(queuedSequence.Scope.Project, queuedSequence.Scope.Stage, queuedSequence.Scope.Service+":sequenceDispatcher")
 	// first, check if the sequence is currently paused
 	if sd.eventQueueRepo.IsSequenceOfEventPaused(queuedSequence.Scope) {
 		log.Infof("Sequence %s is currently paused. Will not start it yet.", queuedSequence.Scope.KeptnContext)
 		return ErrSequenceBlocked
 	}
-	// fetch all sequences that are currently running in the stage of the project where the sequence should run
-	taskExecutions, err := sd.sequenceRepo.GetTaskExecutions(queuedSequence.Scope.Project, models.TaskExecution{
-		Stage:   queuedSequence.Scope.Stage,
-		Service: queuedSequence.Scope.Service,
-	})
-	if err != nil {
-		return err
-	}
+	concurrencyPolicy := sd.getConcurrencyPolicy(queuedSequence.Scope)
+	// with the policy 'cancel-running', the running sequences have already been aborted in favor of the new one
+	if concurrencyPolicy.Mode != models.ConcurrencyParallel && concurrencyPolicy.Mode != models.ConcurrencyCancelRunning {
+		// fetch all sequences that are currently running in the stage of the project where the sequence should run
+		taskExecutions, err := sd.sequenceRepo.GetTaskExecutions(queuedSequence.Scope.Project, models.TaskExecution{
+			Stage:   queuedSequence.Scope.Stage,
+			Service: queuedSequence.Scope.Service,
+		})
+		if err != nil {
+			return err
+		}
 
-	// if there is a sequence running in the stage, we cannot trigger this sequence yet
-	if sd.areActiveSequencesBlockingQueuedSequences(taskExecutions) {
-		log.Infof("Sequence %s cannot be started yet because sequences are still running in stage %s", queuedSequence.Scope.KeptnContext, queuedSequence.Scope.Stage)
-		return ErrSequenceBlocked
+		if sd.areActiveSequencesBlockingQueuedSequences(taskExecutions, concurrencyPolicy.MaxConcurrency) {
+			// if the maximum number of sequences are running in the stage, we cannot trigger this sequence yet
+			log.Infof("Sequence %s cannot be started yet because sequences are still running in stage %s", queuedSequence.Scope.KeptnContext, queuedSequence.Scope.Stage)
+			return ErrSequenceBlocked
+		}
 	}
 
 	events, err := sd.eventRepo.GetEvents(queuedSequence.Scope.Project, common.EventFilter{
@@ -155,22 +186,24 @@ func (sd *SequenceDispatcher) dispatchSequence(queuedSequence models.QueueItem)
 
 	sequenceTriggeredEvent := events[0]
 
-	if err := sd.startSequenceFunc(sequenceTriggeredEvent); err != nil {
+	if err := sd.startSequenceFunc(sequenceTriggeredEvent, queuedSequence.Restart); err != nil {
 		return fmt.Errorf("could not start task sequence %s: %s", queuedSequence.EventID, err.Error())
 	}
 
 	return sd.sequenceQueue.DeleteQueuedSequences(queuedSequence)
 }
 
-func (sd *SequenceDispatcher) areActiveSequencesBlockingQueuedSequences(sequenceTasks []models.TaskExecution) bool {
-	if len(sequenceTasks) == 0 {
-		// if there is no sequence currently running, we do not need to block
-		return false
-	}
-
-	tasksGroupedByContext := groupSequenceMappingsByContext(sequenceTasks)
+func (sd *SequenceDispatcher) areActiveSequencesBlockingQueuedSequences(sequenceTasks []models.TaskExecution, maxConcurrency int) bool {
+	// do not block if all active sequences are currently paused or handling an approval task
+	activeSequences := sd.getActiveSequences(sequenceTasks)
+	return len(activeSequences) > 0 && maxConcurrency > 0 && len(activeSequences) >= maxConcurrency
+}
 
-	for _, tasksOfContext := range tasksGroupedByContext {
+// getActiveSequences returns the last task of each sequence the given task executions belong to, except for sequences that are
+// currently paused or waiting for an approval
+func (sd *SequenceDispatcher) getActiveSequences(sequenceTasks []models.TaskExecution) map[string]models.TaskExecution {
+	activeSequences := map[string]models.TaskExecution{}
+	for keptnContext, tasksOfContext := range groupSequenceMappingsByContext(sequenceTasks) {
 		lastTaskOfSequence := getLastTaskOfSequence(tasksOfContext)
 		// first, check if the other sequence is currently paused
 		if sd.eventQueueRepo.IsSequenceOfEventPaused(
@@ -182,12 +215,110 @@ func (sd *SequenceDispatcher) areActiveSequencesBlockingQueuedSequences(sequence
 			continue
 		}
 		if lastTaskOfSequence.Task.Name != keptnv2.ApprovalTaskName {
-			// if there is a sequence running that is not waiting for an approval, we need to block
-			return true
+			activeSequences[keptnContext] = lastTaskOfSequence
 		}
 	}
-	// do not block if all active sequences are currently handling an approval task
-	return false
+	return activeSequences
+}
+
+func (sd *SequenceDispatcher) getConcurrencyPolicy(eventScope models.EventScope) models.ConcurrencyPolicy {
+	if sd.shipyardRetriever == nil {
+		return models.DefaultConcurrencyPolicy
+	}
+	shipyardExtension, err := sd.shipyardRetriever.GetCachedShipyardExtension(eventScope.Project)
+	if err != nil {
+		log.WithError(err).Errorf("Could not load shipyard of project %s. Using default concurrency policy", eventScope.Project)
+		return models.DefaultConcurrencyPolicy
+	}
+	return shipyardExtension.GetConcurrencyPolicy(eventScope.Stage)
+}
+
+// cancelRunningSequences aborts the active sequences for the service of the queued sequence if the stage has the concurrency policy 'cancel-running'.
+// Sequences that are paused or waiting for an approval are kept. The sequences to abort are determined while holding the lock of the dispatcher,
+// but aborted after releasing it, since aborting a sequence requires a round trip to the shipyard controller and the database
+func (sd *SequenceDispatcher) cancelRunningSequences(queuedSequence models.QueueItem) error {
+	sd.mutex.Lock()
+	controlSequenceFunc := sd.controlSequenceFunc
+	sequencesToAbort, err := sd.getSequencesToCancel(queuedSequence)
+	sd.mutex.Unlock()
+	if err != nil || controlSequenceFunc == nil {
+		return err
+	}
+
+	for keptnContext, lastTaskOfSequence := range sequencesToAbort {
+		log.Infof("Aborting sequence %s in stage %s in favor of a newer sequence", keptnContext, lastTaskOfSequence.Stage)
+		err := controlSequenceFunc(models.SequenceControl{
+			State:        models.AbortSequence,
+			KeptnContext: keptnContext,
+			Stage:        lastTaskOfSequence.Stage,
+			Project:      queuedSequence.Scope.Project,
+		})
+		if err != nil {
+			return fmt.Errorf("could not abort sequence %s: %w", keptnContext, err)
+		}
+	}
+	return nil
+}
+
+func (sd *SequenceDispatcher) getSequencesToCancel(queuedSequence models.QueueItem) (map[string]models.TaskExecution, error) {
+	if sd.controlSequenceFunc == nil || sd.getConcurrencyPolicy(queuedSequence.Scope).Mode != models.ConcurrencyCancelRunning {
+		return nil, nil
+	}
+	taskExecutions, err := sd.sequenceRepo.GetTaskExecutions(queuedSequence.Scope.Project, models.TaskExecution{
+		Stage:   queuedSequence.Scope.Stage,
+		Service: queuedSequence.Scope.Service,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sd.getActiveSequences(taskExecutions), nil
+}
+
+// skipIntermediateSequences removes queued sequences that have been superseded by a newer sequence for the same service in a stage
+// with the concurrency policy 'skip-intermediate' or 'cancel-running'. The superseded sequences are aborted
+func (sd *SequenceDispatcher) skipIntermediateSequences(queuedSequences []models.QueueItem) []models.QueueItem {
+	sd.mutex.Lock()
+	controlSequenceFunc := sd.controlSequenceFunc
+	sd.mutex.Unlock()
+	if controlSequenceFunc == nil {
+		return queuedSequences
+	}
+
+	latestSequences := map[string]models.QueueItem{}
+	for _, queuedSequence := range queuedSequences {
+		key := getQueueItemKey(queuedSequence)
+		if latest, ok := latestSequences[key]; !ok || !queuedSequence.Timestamp.Before(latest.Timestamp) {
+			latestSequences[key] = queuedSequence
+		}
+	}
+
+	result := []models.QueueItem{}
+	for _, queuedSequence := range queuedSequences {
+		if latestSequences[getQueueItemKey(queuedSequence)].EventID == queuedSequence.EventID {
+			result = append(result, queuedSequence)
+			continue
+		}
+		concurrencyPolicy := sd.getConcurrencyPolicy(queuedSequence.Scope)
+		if concurrencyPolicy.Mode != models.ConcurrencySkipIntermediate && concurrencyPolicy.Mode != models.ConcurrencyCancelRunning {
+			result = append(result, queuedSequence)
+			continue
+		}
+		log.Infof("Skipping queued sequence %s in stage %s in favor of a newer sequence", queuedSequence.Scope.KeptnContext, queuedSequence.Scope.Stage)
+		err := controlSequenceFunc(models.SequenceControl{
+			State:        models.AbortSequence,
+			KeptnContext: queuedSequence.Scope.KeptnContext,
+			Stage:        queuedSequence.Scope.Stage,
+			Project:      queuedSequence.Scope.Project,
+		})
+		if err != nil {
+			log.WithError(err).Errorf("Could not abort queued sequence %s", queuedSequence.Scope.KeptnContext)
+		}
+	}
+	return result
+}
+
+func getQueueItemKey(queueItem models.QueueItem) string {
+	return queueItem.Scope.Project + "/" + queueItem.Scope.Stage + "/" + queueItem.Scope.Service
 }
 
 func groupSequenceMappingsByContext(sequenceTasks []models.TaskExecution) map[string][]models.TaskExecution {
@@ -7,8 +7,10 @@ import (
 	"github.com/keptn/keptn/shipyard-controller/common"
 	db_mock "github.com/keptn/keptn/shipyard-controller/db/mock"
 	"github.com/keptn/keptn/shipyard-controller/handler"
+	"github.com/keptn/keptn/shipyard-controller/handler/fake"
 	"github.com/keptn/keptn/shipyard-controller/models"
 	"github.com/stretchr/testify/require"
+	"sync"
 	"testing"
 	"time"
 )
@@ -68,12 +70,12 @@ func TestSequenceDispatcher(t *testing.T) {
 		},
 	}
 
-	sequenceDispatcher := handler.NewSequenceDispatcher(mockEventRepo, mockEventQueueRepo, mockSequenceQueueRepo, mockTaskSequenceRepo, 10*time.Second, theClock)
+	sequenceDispatcher := handler.NewSequenceDispatcher(mockEventRepo, mockEventQueueRepo, mockSequenceQueueRepo, mockTaskSequenceRepo, nil, 10*time.Second, theClock)
 
-	sequenceDispatcher.Run(context.Background(), func(event models.Event) error {
+	sequenceDispatcher.Run(context.Background(), func(event models.Event, restart *models.SequenceRestart) error {
 		startSequenceCalls = append(startSequenceCalls, event)
 		return nil
-	})
+	}, nil)
 
 	// check if repos are queried
 	theClock.Add(11 * time.Second)
@@ -169,7 +171,7 @@ func TestSequenceDispatcher_Remove(t *testing.T) {
 		},
 	}
 
-	sequenceDispatcher := handler.NewSequenceDispatcher(nil, nil, mockSequenceQueueRepo, nil, 10*time.Second, nil)
+	sequenceDispatcher := handler.NewSequenceDispatcher(nil, nil, mockSequenceQueueRepo, nil, nil, 10*time.Second, nil)
 
 	myScope := models.EventScope{
 		EventData:    keptnv2.EventData{Project: "my-project"},
@@ -182,3 +184,239 @@ func TestSequenceDispatcher_Remove(t *testing.T) {
 	require.Len(t, mockSequenceQueueRepo.DeleteQueuedSequencesCalls(), 1)
 	require.Equal(t, models.QueueItem{Scope: myScope}, mockSequenceQueueRepo.DeleteQueuedSequencesCalls()[0].ItemFilter)
 }
+
+func TestSequenceDispatcher_ConcurrencyPolicy(t *testing.T) {
+	runningSequences := []models.TaskExecution{
+		{
+			TaskSequenceName: "delivery",
+			Stage:            "my-stage",
+			Service:          "my-service",
+			KeptnContext:     "running-context-1",
+			Task:             models.Task{Task: keptnv2.Task{Name: "deployment"}},
+		},
+		{
+			TaskSequenceName: "delivery",
+			Stage:            "my-stage",
+			Service:          "my-service",
+			KeptnContext:     "running-context-2",
+			Task:             models.Task{Task: keptnv2.Task{Name: "deployment"}},
+		},
+	}
+	// sequences that are paused or waiting for an approval are neither blocking nor aborted
+	inactiveSequences := []models.TaskExecution{
+		{
+			TaskSequenceName: "delivery",
+			Stage:            "my-stage",
+			Service:          "my-service",
+			KeptnContext:     "approval-context",
+			Task:             models.Task{Task: keptnv2.Task{Name: keptnv2.ApprovalTaskName}},
+		},
+		{
+			TaskSequenceName: "delivery",
+			Stage:            "my-stage",
+			Service:          "my-service",
+			KeptnContext:     "paused-context",
+			Task:             models.Task{Task: keptnv2.Task{Name: "deployment"}},
+		},
+	}
+
+	tests := []struct {
+		name                 string
+		concurrency          string
+		runningSequences     []models.TaskExecution
+		wantStarted          bool
+		wantAbortedSequences []string
+	}{
+		{
+			name:             "queue - blocked by running sequence",
+			concurrency:      "queue",
+			runningSequences: runningSequences[:1],
+			wantStarted:      false,
+		},
+		{
+			name:             "max concurrency not reached",
+			concurrency:      "3",
+			runningSequences: runningSequences,
+			wantStarted:      true,
+		},
+		{
+			name:             "max concurrency reached",
+			concurrency:      "2",
+			runningSequences: runningSequences,
+			wantStarted:      false,
+		},
+		{
+			name:             "parallel",
+			concurrency:      "parallel",
+			runningSequences: runningSequences,
+			wantStarted:      true,
+		},
+		{
+			name:             "queue - not blocked by paused sequence and sequence waiting for approval",
+			concurrency:      "queue",
+			runningSequences: inactiveSequences,
+			wantStarted:      true,
+		},
+		{
+			name:                 "cancel-running",
+			concurrency:          "cancel-running",
+			runningSequences:     append(append([]models.TaskExecution{}, runningSequences...), inactiveSequences...),
+			wantStarted:          true,
+			wantAbortedSequences: []string{"running-context-1", "running-context-2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEventRepo := &db_mock.EventRepoMock{
+				GetEventsFunc: func(project string, filter common.EventFilter, status ...common.EventStatus) ([]models.Event, error) {
+					return []models.Event{{ID: *filter.ID}}, nil
+				},
+			}
+			mockEventQueueRepo := &db_mock.EventQueueRepoMock{
+				IsSequenceOfEventPausedFunc: func(eventScope models.EventScope) bool {
+					return eventScope.KeptnContext == "paused-context"
+				},
+			}
+			mockSequenceQueueRepo := &db_mock.SequenceQueueRepoMock{
+				QueueSequenceFunc: func(item models.QueueItem) error {
+					return nil
+				},
+				DeleteQueuedSequencesFunc: func(itemFilter models.QueueItem) error {
+					return nil
+				},
+			}
+			mockTaskSequenceRepo := &db_mock.TaskSequenceRepoMock{
+				GetTaskExecutionsFunc: func(project string, filter models.TaskExecution) ([]models.TaskExecution, error) {
+					return tt.runningSequences, nil
+				},
+			}
+			mockShipyardRetriever := &fake.IShipyardRetrieverMock{
+				GetCachedShipyardExtensionFunc: func(projectName string) (*models.ShipyardExtension, error) {
+					return &models.ShipyardExtension{
+						Spec: models.ShipyardExtensionSpec{
+							Stages: []models.StageExtension{{Name: "my-stage", Concurrency: tt.concurrency}},
+						},
+					}, nil
+				},
+			}
+
+			startedSequences := []string{}
+			abortedSequences := []string{}
+			sequenceDispatcher := handler.NewSequenceDispatcher(mockEventRepo, mockEventQueueRepo, mockSequenceQueueRepo, mockTaskSequenceRepo, mockShipyardRetriever, 10*time.Second, clock.NewMock())
+			sequenceDispatcher.Run(context.Background(), func(event models.Event, restart *models.SequenceRestart) error {
+				startedSequences = append(startedSequences, event.ID)
+				return nil
+			}, func(control models.SequenceControl) error {
+				require.Equal(t, models.AbortSequence, control.State)
+				require.Equal(t, "my-project", control.Project)
+				require.Equal(t, "my-stage", control.Stage)
+				abortedSequences = append(abortedSequences, control.KeptnContext)
+				// aborting a sequence removes it from the queue of the dispatcher, which must not be locked at that time
+				return sequenceDispatcher.Remove(models.EventScope{KeptnContext: control.KeptnContext})
+			})
+
+			err := sequenceDispatcher.Add(models.QueueItem{
+				Scope: models.EventScope{
+					EventData:    keptnv2.EventData{Project: "my-project", Stage: "my-stage", Service: "my-service"},
+					KeptnContext: "my-context",
+				},
+				EventID: "my-event-id",
+			})
+			require.Nil(t, err)
+
+			if tt.wantStarted {
+				require.Equal(t, []string{"my-event-id"}, startedSequences)
+				require.Empty(t, mockSequenceQueueRepo.QueueSequenceCalls())
+			} else {
+				require.Empty(t, startedSequences)
+				require.Len(t, mockSequenceQueueRepo.QueueSequenceCalls(), 1)
+			}
+			require.ElementsMatch(t, tt.wantAbortedSequences, abortedSequences)
+		})
+	}
+}
+
+func TestSequenceDispatcher_SkipIntermediate(t *testing.T) {
+	theClock := clock.NewMock()
+	now := time.Now().UTC()
+
+	newQueueItem := func(keptnContext, service string, timestamp time.Time) models.QueueItem {
+		return models.QueueItem{
+			Scope: models.EventScope{
+				EventData:    keptnv2.EventData{Project: "my-project", Stage: "my-stage", Service: service},
+				KeptnContext: keptnContext,
+			},
+			EventID:   keptnContext + "-event",
+			Timestamp: timestamp,
+		}
+	}
+	mockQueue := []models.QueueItem{
+		newQueueItem("context-1", "my-service", now),
+		newQueueItem("context-2", "my-service", now.Add(time.Second)),
+		newQueueItem("context-3", "my-service", now.Add(2*time.Second)),
+		newQueueItem("context-4", "other-service", now),
+	}
+
+	mockEventRepo := &db_mock.EventRepoMock{
+		GetEventsFunc: func(project string, filter common.EventFilter, status ...common.EventStatus) ([]models.Event, error) {
+			return []models.Event{{ID: *filter.ID}}, nil
+		},
+	}
+	mockEventQueueRepo := &db_mock.EventQueueRepoMock{
+		IsSequenceOfEventPausedFunc: func(eventScope models.EventScope) bool {
+			return false
+		},
+	}
+	mockSequenceQueueRepo := &db_mock.SequenceQueueRepoMock{
+		GetQueuedSequencesFunc: func() ([]models.QueueItem, error) {
+			return mockQueue, nil
+		},
+		DeleteQueuedSequencesFunc: func(itemFilter models.QueueItem) error {
+			return nil
+		},
+	}
+	mockTaskSequenceRepo := &db_mock.TaskSequenceRepoMock{
+		GetTaskExecutionsFunc: func(project string, filter models.TaskExecution) ([]models.TaskExecution, error) {
+			return []models.TaskExecution{}, nil
+		},
+	}
+	mockShipyardRetriever := &fake.IShipyardRetrieverMock{
+		GetCachedShipyardExtensionFunc: func(projectName string) (*models.ShipyardExtension, error) {
+			return &models.ShipyardExtension{
+				Spec: models.ShipyardExtensionSpec{
+					Stages: []models.StageExtension{{Name: "my-stage", Concurrency: models.ConcurrencySkipIntermediate}},
+				},
+			}, nil
+		},
+	}
+
+	mutex := sync.Mutex{}
+	startedSequences := []string{}
+	abortedSequences := []string{}
+	sequenceDispatcher := handler.NewSequenceDispatcher(mockEventRepo, mockEventQueueRepo, mockSequenceQueueRepo, mockTaskSequenceRepo, mockShipyardRetriever, 10*time.Second, theClock)
+	sequenceDispatcher.Run(context.Background(), func(event models.Event, restart *models.SequenceRestart) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		startedSequences = append(startedSequences, event.ID)
+		return nil
+	}, func(control models.SequenceControl) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		abortedSequences = append(abortedSequences, control.KeptnContext)
+		return nil
+	})
+
+	theClock.Add(11 * time.Second)
+
+	require.Eventually(t, func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return len(startedSequences) == 2
+	}, 5*time.Second, 100*time.Millisecond)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	// only the latest sequence of each service should have been started, the intermediate ones are aborted
+	require.ElementsMatch(t, []string{"context-3-event", "context-4-event"}, startedSequences)
+	require.ElementsMatch(t, []string{"context-1", "context-2"}, abortedSequences)
+}
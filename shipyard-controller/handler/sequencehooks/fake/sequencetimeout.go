@@ -14,7 +14,7 @@ import (
 //
 // 		// make and configure a mocked sequencehooks.ISequenceTimeoutHook
 // 		mockedISequenceTimeoutHook := &ISequenceTimeoutHookMock{
-// 			OnSequenceTimeoutFunc: func(event models.Event)  {
+// 			OnSequenceTimeoutFunc: func(timeout models.SequenceTimeout) {
 // 				panic("mock out the OnSequenceTimeout method")
 // 			},
 // 		}
@@ -25,43 +25,44 @@ import (
 // 	}
 type ISequenceTimeoutHookMock struct {
 	// OnSequenceTimeoutFunc mocks the OnSequenceTimeout method.
-	OnSequenceTimeoutFunc func(event models.Event)
+	OnSequenceTimeoutFunc func(timeout models.SequenceTimeout)
 
 	// calls tracks calls to the methods.
 	calls struct {
 		// OnSequenceTimeout holds details about calls to the OnSequenceTimeout method.
 		OnSequenceTimeout []struct {
-			// Event is the event argument value.
-			Event models.Event
+			// Timeout is the timeout argument value.
+			Timeout models.SequenceTimeout
 		}
 	}
 	lockOnSequenceTimeout sync.RWMutex
 }
 
 // OnSequenceTimeout calls OnSequenceTimeoutFunc.
-func (mock *ISequenceTimeoutHookMock) OnSequenceTimeout(event models.Event) {
+func (mock *ISequenceTimeoutHookMock) OnSequenceTimeout(timeout models.SequenceTimeout) {
 	if mock.OnSequenceTimeoutFunc == nil {
 		panic("ISequenceTimeoutHookMock.OnSequenceTimeoutFunc: method is nil but ISequenceTimeoutHook.OnSequenceTimeout was just called")
 	}
 	callInfo := struct {
-		Event models.Event
+		Timeout models.SequenceTimeout
 	}{
-		Event: event,
+		Timeout: timeout,
 	}
 	mock.lockOnSequenceTimeout.Lock()
 	mock.calls.OnSequenceTimeout = append(mock.calls.OnSequenceTimeout, callInfo)
 	mock.lockOnSequenceTimeout.Unlock()
-	mock.OnSequenceTimeoutFunc(event)
+	mock.OnSequenceTimeoutFunc(timeout)
 }
 
 // OnSequenceTimeoutCalls gets all the calls that were made to OnSequenceTimeout.
 // Check the length with:
+//
 //     len(mockedISequenceTimeoutHook.OnSequenceTimeoutCalls())
 func (mock *ISequenceTimeoutHookMock) OnSequenceTimeoutCalls() []struct {
-	Event models.Event
+	Timeout models.SequenceTimeout
 } {
 	var calls []struct {
-		Event models.Event
+		Timeout models.SequenceTimeout
 	}
 	mock.lockOnSequenceTimeout.RLock()
 	calls = mock.calls.OnSequenceTimeout
@@ -24,11 +24,21 @@ type ISequenceTaskStartedHook interface {
 	OnSequenceTaskStarted(models.Event)
 }
 
+//go:generate moq -pkg fake -skip-ensure -out ./fake/sequencetaskskipped.go . ISequenceTaskSkippedHook
+type ISequenceTaskSkippedHook interface {
+	OnSequenceTaskSkipped(scope models.EventScope, task models.SkippedTask)
+}
+
 //go:generate moq -pkg fake -skip-ensure -out ./fake/sequencetaskfinished.go . ISequenceTaskFinishedHook
 type ISequenceTaskFinishedHook interface {
 	OnSequenceTaskFinished(models.Event)
 }
 
+//go:generate moq -pkg fake -skip-ensure -out ./fake/sequencetaskattemptfinished.go . ISequenceTaskAttemptFinishedHook
+type ISequenceTaskAttemptFinishedHook interface {
+	OnSequenceTaskAttemptFinished(attempt models.TaskAttempt)
+}
+
 //go:generate moq -pkg fake -skip-ensure -out ./fake/subsequencefinished.go . ISubSequenceFinishedHook
 type ISubSequenceFinishedHook interface {
 	OnSubSequenceFinished(event models.Event)
@@ -45,7 +55,7 @@ type ISequenceAbortedHook interface {
 
 //go:generate moq -pkg fake -skip-ensure -out ./fake/sequencetimeout.go . ISequenceTimeoutHook
 type ISequenceTimeoutHook interface {
-	OnSequenceTimeout(event models.Event)
+	OnSequenceTimeout(timeout models.SequenceTimeout)
 }
 
 //go:generate moq -pkg fake -skip-ensure -out ./fake/sequencepause.go . ISequencePausedHook
@@ -132,6 +132,83 @@ func (smv *SequenceStateMaterializedView) OnSequenceTaskFinished(event models.Ev
 	}
 }
 
+func (smv *SequenceStateMaterializedView) OnSequenceTaskSkipped(scope models.EventScope, task models.SkippedTask) {
+	smv.mutex.Lock()
+	defer smv.mutex.Unlock()
+	state, err := smv.findSequenceStateForEvent(scope)
+	if err != nil {
+		log.Errorf(sequenceStateRetrievalErrorMsg, scope.KeptnContext, err.Error())
+		return
+	}
+
+	stageFound := false
+	for index := range state.Stages {
+		if state.Stages[index].Name == scope.Stage {
+			stageFound = true
+			state.Stages[index].SkippedTasks = append(state.Stages[index].SkippedTasks, task)
+		}
+	}
+	if !stageFound {
+		state.Stages = append(state.Stages, models.SequenceStateStage{
+			Name:         scope.Stage,
+			State:        models.SequenceTriggeredState,
+			SkippedTasks: []models.SkippedTask{task},
+		})
+	}
+	if err := smv.SequenceStateRepo.UpdateSequenceState(*state); err != nil {
+		log.Errorf("could not update sequence state: %s", err.Error())
+	}
+}
+
+func (smv *SequenceStateMaterializedView) OnSequenceTaskAttemptFinished(attempt models.TaskAttempt) {
+	smv.mutex.Lock()
+	defer smv.mutex.Unlock()
+	eventScope, err := models.NewEventScope(attempt.FinishedEvent)
+	if err != nil {
+		log.WithError(err).Errorf(eventScopeErrorMessage)
+		return
+	}
+	state, err := smv.findSequenceStateForEvent(*eventScope)
+	if err != nil {
+		log.Errorf(sequenceStateRetrievalErrorMsg, eventScope.KeptnContext, err.Error())
+		return
+	}
+
+	stateAttempt := models.SequenceStateAttempt{
+		Attempt:     attempt.Attempt,
+		MaxAttempts: attempt.MaxAttempts,
+		TriggeredID: eventScope.TriggeredID,
+		Result:      string(eventScope.Result),
+		Status:      string(eventScope.Status),
+		Message:     eventScope.Message,
+		Time:        timeutils.GetKeptnTimeStamp(common.ParseTimestamp(attempt.FinishedEvent.Time, nil)),
+	}
+	if taskName, _, err := keptnv2.ParseTaskEventType(eventScope.EventType); err == nil {
+		stateAttempt.Task = taskName
+	}
+	if attempt.NextAttempt != nil {
+		stateAttempt.NextAttempt = timeutils.GetKeptnTimeStamp(*attempt.NextAttempt)
+	}
+
+	stageFound := false
+	for index := range state.Stages {
+		if state.Stages[index].Name == eventScope.Stage {
+			stageFound = true
+			state.Stages[index].TaskAttempts = append(state.Stages[index].TaskAttempts, stateAttempt)
+		}
+	}
+	if !stageFound {
+		state.Stages = append(state.Stages, models.SequenceStateStage{
+			Name:         eventScope.Stage,
+			State:        models.SequenceTriggeredState,
+			TaskAttempts: []models.SequenceStateAttempt{stateAttempt},
+		})
+	}
+	if err := smv.SequenceStateRepo.UpdateSequenceState(*state); err != nil {
+		log.Errorf("could not update sequence state: %s", err.Error())
+	}
+}
+
 func (smv *SequenceStateMaterializedView) OnSubSequenceFinished(event models.Event) {
 	smv.mutex.Lock()
 	defer smv.mutex.Unlock()
@@ -168,15 +245,42 @@ func (smv *SequenceStateMaterializedView) OnSequenceAborted(event models.Event)
 	smv.updateOverallSequenceState(*eventScope, models.SequenceAborted)
 }
 
-func (smv *SequenceStateMaterializedView) OnSequenceTimeout(event models.Event) {
+func (smv *SequenceStateMaterializedView) OnSequenceTimeout(timeout models.SequenceTimeout) {
 	smv.mutex.Lock()
 	defer smv.mutex.Unlock()
-	eventScope, err := models.NewEventScope(event)
+	eventScope, err := models.NewEventScope(timeout.LastEvent)
 	if err != nil {
 		log.WithError(err).Errorf(eventScopeErrorMessage)
 		return
 	}
-	smv.updateOverallSequenceState(*eventScope, models.TimedOut)
+	state, err := smv.findSequenceStateForEvent(*eventScope)
+	if err != nil {
+		log.Errorf(sequenceStateRetrievalErrorMsg, eventScope.KeptnContext, err.Error())
+		return
+	}
+
+	state.State = models.TimedOut
+	// store the limit that has been exceeded in the stage the sequence has been running in
+	for index := range state.Stages {
+		if state.Stages[index].Name != eventScope.Stage {
+			continue
+		}
+		stateTimeout := &models.SequenceStateTimeout{
+			Type:    timeout.Type,
+			Message: timeout.Message,
+		}
+		if taskName, _, err := keptnv2.ParseTaskEventType(*timeout.LastEvent.Type); err == nil {
+			stateTimeout.Task = taskName
+		}
+		if timeout.Timeout > 0 {
+			stateTimeout.Timeout = timeout.Timeout.String()
+		}
+		state.Stages[index].Timeout = stateTimeout
+		break
+	}
+	if err := smv.SequenceStateRepo.UpdateSequenceState(*state); err != nil {
+		log.Errorf("could not update sequence state: %s", err.Error())
+	}
 }
 
 func (smv *SequenceStateMaterializedView) OnSequencePaused(pause models.EventScope) {
@@ -11,6 +11,7 @@ import (
 	"github.com/keptn/keptn/shipyard-controller/models"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 type SequenceStateMVTestFields struct {
@@ -81,7 +82,7 @@ func TestSequenceStateMaterializedView_OnSequenceStarted(t *testing.T) {
 
 func TestSequenceStateMaterializedView_OnSequenceTimeOud(t *testing.T) {
 	type args struct {
-		event models.Event
+		timeout models.SequenceTimeout
 	}
 	tests := []struct {
 		name                   string
@@ -102,7 +103,7 @@ func TestSequenceStateMaterializedView_OnSequenceTimeOud(t *testing.T) {
 									Project:        "my-project",
 									Shkeptncontext: "my-context",
 									State:          "triggered",
-									Stages:         nil,
+									Stages:         []models.SequenceStateStage{{Name: "my-stage", State: "triggered"}},
 								},
 							},
 						}, nil
@@ -113,14 +114,20 @@ func TestSequenceStateMaterializedView_OnSequenceTimeOud(t *testing.T) {
 				},
 			},
 			args: args{
-				event: models.Event{
-					Data: keptnv2.EventData{
-						Project: "my-project",
-						Stage:   "my-stage",
-						Service: "my-service",
+				timeout: models.SequenceTimeout{
+					KeptnContext: "my-context",
+					LastEvent: models.Event{
+						Data: keptnv2.EventData{
+							Project: "my-project",
+							Stage:   "my-stage",
+							Service: "my-service",
+						},
+						Shkeptncontext: "my-context",
+						Type:           common.Stringp(keptnv2.GetTriggeredEventType(keptnv2.DeploymentTaskName)),
 					},
-					Shkeptncontext: "my-context",
-					Type:           common.Stringp("my-type"),
+					Type:    models.TaskTimeout,
+					Timeout: 30 * time.Minute,
+					Message: "my-message",
 				},
 			},
 			expectUpdateToBeCalled: true,
@@ -129,11 +136,18 @@ func TestSequenceStateMaterializedView_OnSequenceTimeOud(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			smv := sequencehooks.NewSequenceStateMaterializedView(tt.fields.SequenceStateRepo)
-			smv.OnSequenceTimeout(tt.args.event)
+			smv.OnSequenceTimeout(tt.args.timeout)
 
 			if tt.expectUpdateToBeCalled {
 				require.NotEmpty(t, tt.fields.SequenceStateRepo.UpdateSequenceStateCalls())
-				require.Equal(t, models.TimedOut, tt.fields.SequenceStateRepo.UpdateSequenceStateCalls()[0].State.State)
+				updatedState := tt.fields.SequenceStateRepo.UpdateSequenceStateCalls()[0].State
+				require.Equal(t, models.TimedOut, updatedState.State)
+				require.Equal(t, &models.SequenceStateTimeout{
+					Type:    models.TaskTimeout,
+					Task:    keptnv2.DeploymentTaskName,
+					Timeout: "30m0s",
+					Message: "my-message",
+				}, updatedState.Stages[0].Timeout)
 			} else {
 				require.Empty(t, tt.fields.SequenceStateRepo.UpdateSequenceStateCalls())
 			}
@@ -1035,3 +1049,194 @@ func TestSequenceStateMaterializedView_OnSubSequenceFinished(t *testing.T) {
 		})
 	}
 }
+
+func TestSequenceStateMaterializedView_OnSequenceTaskSkipped(t *testing.T) {
+	skippedTask := models.SkippedTask{
+		Name:      "test",
+		TaskIndex: 1,
+		Condition: `deployment.deploymentstrategy != "direct"`,
+		Reason:    "condition evaluated to false",
+	}
+	scope := models.EventScope{
+		EventData: keptnv2.EventData{
+			Project: "my-project",
+			Stage:   "my-stage",
+			Service: "my-service",
+		},
+		KeptnContext: "my-context",
+	}
+	type args struct {
+		stages []models.SequenceStateStage
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStages []models.SequenceStateStage
+	}{
+		{
+			name: "add skipped task to existing stage",
+			args: args{
+				stages: []models.SequenceStateStage{
+					{
+						Name:  "my-stage",
+						State: models.SequenceTriggeredState,
+					},
+				},
+			},
+			wantStages: []models.SequenceStateStage{
+				{
+					Name:         "my-stage",
+					State:        models.SequenceTriggeredState,
+					SkippedTasks: []models.SkippedTask{skippedTask},
+				},
+			},
+		},
+		{
+			name: "add stage if not available yet",
+			args: args{
+				stages: []models.SequenceStateStage{},
+			},
+			wantStages: []models.SequenceStateStage{
+				{
+					Name:         "my-stage",
+					State:        models.SequenceTriggeredState,
+					SkippedTasks: []models.SkippedTask{skippedTask},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sequenceStateRepo := &db_mock.SequenceStateRepoMock{
+				FindSequenceStatesFunc: func(filter models.StateFilter) (*models.SequenceStates, error) {
+					return &models.SequenceStates{
+						States: []models.SequenceState{
+							{
+								Name:           "my-sequence",
+								Service:        "my-service",
+								Project:        "my-project",
+								Shkeptncontext: "my-context",
+								State:          models.SequenceStartedState,
+								Stages:         tt.args.stages,
+							},
+						},
+					}, nil
+				},
+				UpdateSequenceStateFunc: func(state models.SequenceState) error {
+					return nil
+				},
+			}
+			smv := sequencehooks.NewSequenceStateMaterializedView(sequenceStateRepo)
+			smv.OnSequenceTaskSkipped(scope, skippedTask)
+
+			require.Len(t, sequenceStateRepo.UpdateSequenceStateCalls(), 1)
+			require.Equal(t, tt.wantStages, sequenceStateRepo.UpdateSequenceStateCalls()[0].State.Stages)
+		})
+	}
+}
+
+func TestSequenceStateMaterializedView_OnSequenceTaskAttemptFinished(t *testing.T) {
+	nextAttempt := time.Date(2021, 12, 1, 10, 0, 30, 0, time.UTC)
+	attempt := models.TaskAttempt{
+		FinishedEvent: models.Event{
+			Data: keptnv2.EventData{
+				Project: "my-project",
+				Stage:   "my-stage",
+				Service: "my-service",
+				Result:  keptnv2.ResultFailed,
+				Status:  keptnv2.StatusSucceeded,
+				Message: "tests failed",
+			},
+			ID:             "my-finished-id",
+			Shkeptncontext: "my-context",
+			Time:           "2021-12-01T10:00:00.000Z",
+			Triggeredid:    "my-triggered-id",
+			Type:           common.Stringp(keptnv2.GetFinishedEventType(keptnv2.TestTaskName)),
+		},
+		Attempt:     1,
+		MaxAttempts: 3,
+		NextAttempt: &nextAttempt,
+	}
+	wantAttempt := models.SequenceStateAttempt{
+		Task:        keptnv2.TestTaskName,
+		Attempt:     1,
+		MaxAttempts: 3,
+		TriggeredID: "my-triggered-id",
+		Result:      string(keptnv2.ResultFailed),
+		Status:      string(keptnv2.StatusSucceeded),
+		Message:     "tests failed",
+		Time:        "2021-12-01T10:00:00.000Z",
+		NextAttempt: "2021-12-01T10:00:30.000Z",
+	}
+	previousAttempt := models.SequenceStateAttempt{Task: keptnv2.TestTaskName, Attempt: 1, MaxAttempts: 3, TriggeredID: "my-first-triggered-id"}
+
+	type args struct {
+		stages []models.SequenceStateStage
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantStages []models.SequenceStateStage
+	}{
+		{
+			name: "add attempt to existing stage",
+			args: args{
+				stages: []models.SequenceStateStage{
+					{
+						Name:         "my-stage",
+						State:        models.SequenceTriggeredState,
+						TaskAttempts: []models.SequenceStateAttempt{previousAttempt},
+					},
+				},
+			},
+			wantStages: []models.SequenceStateStage{
+				{
+					Name:         "my-stage",
+					State:        models.SequenceTriggeredState,
+					TaskAttempts: []models.SequenceStateAttempt{previousAttempt, wantAttempt},
+				},
+			},
+		},
+		{
+			name: "add stage if not available yet",
+			args: args{
+				stages: []models.SequenceStateStage{},
+			},
+			wantStages: []models.SequenceStateStage{
+				{
+					Name:         "my-stage",
+					State:        models.SequenceTriggeredState,
+					TaskAttempts: []models.SequenceStateAttempt{wantAttempt},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sequenceStateRepo := &db_mock.SequenceStateRepoMock{
+				FindSequenceStatesFunc: func(filter models.StateFilter) (*models.SequenceStates, error) {
+					return &models.SequenceStates{
+						States: []models.SequenceState{
+							{
+								Name:           "my-sequence",
+								Service:        "my-service",
+								Project:        "my-project",
+								Shkeptncontext: "my-context",
+								State:          models.SequenceStartedState,
+								Stages:         tt.args.stages,
+							},
+						},
+					}, nil
+				},
+				UpdateSequenceStateFunc: func(state models.SequenceState) error {
+					return nil
+				},
+			}
+			smv := sequencehooks.NewSequenceStateMaterializedView(sequenceStateRepo)
+			smv.OnSequenceTaskAttemptFinished(attempt)
+
+			require.Len(t, sequenceStateRepo.UpdateSequenceStateCalls(), 1)
+			require.Equal(t, tt.wantStages, sequenceStateRepo.UpdateSequenceStateCalls()[0].State.Stages)
+		})
+	}
+}
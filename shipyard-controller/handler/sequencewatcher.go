@@ -13,22 +13,28 @@ import (
 	"time"
 )
 
+// SequenceWatcher periodically checks the open tasks and sequences of all projects. A sequence is timed out if one of its tasks
+// did not receive a .started or .finished event within the eventTimeout, or if a task or the sequence itself exceeds the timeout declared in the shipyard
 type SequenceWatcher struct {
 	cancelSequenceChannel chan models.SequenceTimeout
 	eventRepo             db.EventRepo
 	eventQueueRepo        db.EventQueueRepo
 	projectRepo           db.ProjectRepo
+	taskSequenceRepo      db.TaskSequenceRepo
+	shipyardRetriever     IShipyardRetriever
 	eventTimeout          time.Duration
 	syncInterval          time.Duration
 	theClock              clock.Clock
 }
 
-func NewSequenceWatcher(cancelSequenceChannel chan models.SequenceTimeout, eventRepo db.EventRepo, eventQueueRepo db.EventQueueRepo, projectRepo db.ProjectRepo, eventTimeout time.Duration, syncInterval time.Duration, theClock clock.Clock) *SequenceWatcher {
+func NewSequenceWatcher(cancelSequenceChannel chan models.SequenceTimeout, eventRepo db.EventRepo, eventQueueRepo db.EventQueueRepo, projectRepo db.ProjectRepo, taskSequenceRepo db.TaskSequenceRepo, shipyardRetriever IShipyardRetriever, eventTimeout time.Duration, syncInterval time.Duration, theClock clock.Clock) *SequenceWatcher {
 	return &SequenceWatcher{
 		cancelSequenceChannel: cancelSequenceChannel,
 		eventRepo:             eventRepo,
 		eventQueueRepo:        eventQueueRepo,
 		projectRepo:           projectRepo,
+		taskSequenceRepo:      taskSequenceRepo,
+		shipyardRetriever:     shipyardRetriever,
 		eventTimeout:          eventTimeout,
 		syncInterval:          syncInterval,
 		theClock:              theClock,
@@ -77,56 +83,173 @@ func (sw *SequenceWatcher) cleanUpOrphanedTasksOfProject(project string) error {
 		return fmt.Errorf("could not retrieve open triggered events: %s", err.Error())
 	}
 
+	shipyardExtension, err := sw.shipyardRetriever.GetCachedShipyardExtension(project)
+	if err != nil {
+		// the tasks can still be checked for a missing .started event
+		log.WithError(err).Errorf("could not load shipyard of project %s. Timeouts declared in the shipyard are not considered", project)
+	}
+
+	now := sw.theClock.Now().UTC()
+	taskEvents := []models.Event{}
+	sequenceEvents := []models.Event{}
 	for _, event := range events {
-		// only consider timed out tasks
 		if keptnv2.IsSequenceEventType(*event.Type) {
+			sequenceEvents = append(sequenceEvents, event)
+		} else {
+			taskEvents = append(taskEvents, event)
+		}
+	}
+
+	// a sequence is only timed out once, even if multiple limits are exceeded (e.g. by the tasks of a parallel task group)
+	timedOutSequences := map[string]bool{}
+	for _, event := range taskEvents {
+		eventSentTime, err := parseEventTime(event)
+		if err != nil {
+			log.WithError(err).Errorf("could not parse event timestamp of event with id %s.", event.ID)
+			continue
+		}
+
+		timeout := sw.getTaskTimeout(project, event, eventSentTime, now, shipyardExtension)
+		if timeout == nil {
 			continue
 		}
-		var eventSentTime time.Time
-		eventSentTime, err = time.Parse(timeutils.KeptnTimeFormatISO8601, event.Time)
+		sw.sendTimeout(project, *timeout, timedOutSequences)
+	}
+
+	if shipyardExtension == nil {
+		return nil
+	}
+	for _, event := range sequenceEvents {
+		eventSentTime, err := parseEventTime(event)
 		if err != nil {
-			// events in the .triggered collection were stored in this format previously
-			fallbackTimeFormat := "2006-01-02T15:04:05.000000000Z"
-			eventSentTime, err = time.Parse(fallbackTimeFormat, event.Time)
-			if err != nil {
-				log.WithError(err).Errorf("could not parse event timestamp of event with id %s.", event.ID)
-				continue
-			}
+			log.WithError(err).Errorf("could not parse event timestamp of event with id %s.", event.ID)
+			continue
 		}
+		stage, sequenceName, _, err := keptnv2.ParseSequenceEventType(*event.Type)
+		if err != nil {
+			continue
+		}
+		sequenceTimeout := shipyardExtension.GetSequence(stage, sequenceName).GetTimeout()
+		if sequenceTimeout == 0 || !now.After(eventSentTime.Add(sequenceTimeout)) {
+			continue
+		}
+		// the sequence is cancelled via the task that is currently running
+		openTask := getOpenTaskOfSequence(taskEvents, event.Shkeptncontext, stage)
+		if openTask == nil {
+			log.Debugf("sequence %s with context %s exceeded its timeout, but has no open task", sequenceName, event.Shkeptncontext)
+			continue
+		}
+		sw.sendTimeout(project, models.SequenceTimeout{
+			KeptnContext: event.Shkeptncontext,
+			LastEvent:    *openTask,
+			Type:         models.SequenceTimeoutLimit,
+			Timeout:      sequenceTimeout,
+		}, timedOutSequences)
+	}
+	return nil
+}
 
-		timeOut := eventSentTime.Add(sw.eventTimeout)
-		now := sw.theClock.Now().UTC()
-		if now.After(timeOut) {
-			isItemInQueue, err := sw.eventQueueRepo.IsEventInQueue(event.ID)
-			if err != nil {
-				log.WithError(err).Error("could not check if item is still in queue")
-			} else if isItemInQueue {
-				log.Info("triggered event is still in queue")
-				continue
-			}
-			// check if an event that reacted to the .triggered event has been received in the meantime
-			responseEvents, err := sw.eventRepo.GetEvents(project, common.EventFilter{
-				TriggeredID:  &event.ID,
-				KeptnContext: &event.Shkeptncontext,
-			})
-			if err != nil && err != db.ErrNoEventFound {
-				log.WithError(err).Errorf("could not fetch events with triggeredId %s", event.ID)
-				continue
-			}
-			if len(responseEvents) == 0 {
-				// time out -> tell shipyard controller to complete the task sequence
-				sequenceCancellation := models.SequenceTimeout{
-					KeptnContext: event.Shkeptncontext,
-					LastEvent:    event,
-				}
-
-				sw.cancelSequenceChannel <- sequenceCancellation
-				// clean up open .triggered event
-				if err := sw.eventRepo.DeleteEvent(project, event.ID, common.TriggeredEvent); err != nil {
-					log.WithError(err).Errorf("could not delete event %s", event.ID)
-				}
-			}
+// getTaskTimeout checks if the task of the given .triggered event did not receive a .started event within the eventTimeout,
+// or has not been finished within the timeout of the task declared in the shipyard. If none of the limits is exceeded, nil is returned
+func (sw *SequenceWatcher) getTaskTimeout(project string, event models.Event, eventSentTime, now time.Time, shipyardExtension *models.ShipyardExtension) *models.SequenceTimeout {
+	timeOut := eventSentTime.Add(sw.eventTimeout)
+	taskTimeout := sw.getDeclaredTaskTimeout(project, event, shipyardExtension)
+	if !now.After(timeOut) && (taskTimeout == 0 || !now.After(eventSentTime.Add(taskTimeout))) {
+		return nil
+	}
+
+	isItemInQueue, err := sw.eventQueueRepo.IsEventInQueue(event.ID)
+	if err != nil {
+		log.WithError(err).Error("could not check if item is still in queue")
+		return nil
+	} else if isItemInQueue {
+		log.Info("triggered event is still in queue")
+		return nil
+	}
+	// check if an event that reacted to the .triggered event has been received in the meantime
+	responseEvents, err := sw.eventRepo.GetEvents(project, common.EventFilter{
+		TriggeredID:  &event.ID,
+		KeptnContext: &event.Shkeptncontext,
+	})
+	if err != nil && err != db.ErrNoEventFound {
+		log.WithError(err).Errorf("could not fetch events with triggeredId %s", event.ID)
+		return nil
+	}
+	if len(responseEvents) == 0 && now.After(timeOut) {
+		return &models.SequenceTimeout{
+			KeptnContext: event.Shkeptncontext,
+			LastEvent:    event,
+			Type:         models.TaskStartedTimeout,
+			Timeout:      sw.eventTimeout,
+		}
+	}
+	if taskTimeout > 0 && now.After(eventSentTime.Add(taskTimeout)) && !containsFinishedEvent(responseEvents) {
+		return &models.SequenceTimeout{
+			KeptnContext: event.Shkeptncontext,
+			LastEvent:    event,
+			Type:         models.TaskTimeout,
+			Timeout:      taskTimeout,
 		}
 	}
 	return nil
 }
+
+// getDeclaredTaskTimeout returns the timeout declared in the shipyard for the task of the given .triggered event, or 0 if no timeout is declared
+func (sw *SequenceWatcher) getDeclaredTaskTimeout(project string, event models.Event, shipyardExtension *models.ShipyardExtension) time.Duration {
+	if shipyardExtension == nil {
+		return 0
+	}
+	taskExecutions, err := sw.taskSequenceRepo.GetTaskExecutions(project, models.TaskExecution{TriggeredEventID: event.ID})
+	if err != nil || len(taskExecutions) == 0 {
+		return 0
+	}
+	taskExecution := taskExecutions[0]
+	return shipyardExtension.GetSequence(taskExecution.Stage, taskExecution.TaskSequenceName).GetTask(taskExecution.Task.TaskIndex).GetTimeout()
+}
+
+// sendTimeout tells the shipyard controller to complete the sequence and cleans up the open .triggered event of the task
+func (sw *SequenceWatcher) sendTimeout(project string, timeout models.SequenceTimeout, timedOutSequences map[string]bool) {
+	if timedOutSequences[timeout.KeptnContext] {
+		return
+	}
+	timedOutSequences[timeout.KeptnContext] = true
+	log.Infof("sequence with context %s exceeded its %s timeout of %s", timeout.KeptnContext, timeout.Type, timeout.Timeout.String())
+
+	sw.cancelSequenceChannel <- timeout
+	if err := sw.eventRepo.DeleteEvent(project, timeout.LastEvent.ID, common.TriggeredEvent); err != nil {
+		log.WithError(err).Errorf("could not delete event %s", timeout.LastEvent.ID)
+	}
+}
+
+func parseEventTime(event models.Event) (time.Time, error) {
+	eventSentTime, err := time.Parse(timeutils.KeptnTimeFormatISO8601, event.Time)
+	if err != nil {
+		// events in the .triggered collection were stored in this format previously
+		fallbackTimeFormat := "2006-01-02T15:04:05.000000000Z"
+		return time.Parse(fallbackTimeFormat, event.Time)
+	}
+	return eventSentTime, nil
+}
+
+func getOpenTaskOfSequence(taskEvents []models.Event, keptnContext, stage string) *models.Event {
+	for index := range taskEvents {
+		if taskEvents[index].Shkeptncontext != keptnContext {
+			continue
+		}
+		eventScope, err := models.NewEventScope(taskEvents[index])
+		if err != nil || eventScope.Stage != stage {
+			continue
+		}
+		return &taskEvents[index]
+	}
+	return nil
+}
+
+func containsFinishedEvent(events []models.Event) bool {
+	for _, event := range events {
+		if event.Type != nil && keptnv2.IsFinishedEventType(*event.Type) {
+			return true
+		}
+	}
+	return false
+}
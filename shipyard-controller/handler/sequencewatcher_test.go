@@ -9,6 +9,7 @@ import (
 	"github.com/keptn/keptn/shipyard-controller/db"
 	db_mock "github.com/keptn/keptn/shipyard-controller/db/mock"
 	"github.com/keptn/keptn/shipyard-controller/handler"
+	"github.com/keptn/keptn/shipyard-controller/handler/fake"
 	"github.com/keptn/keptn/shipyard-controller/models"
 	"github.com/stretchr/testify/require"
 	"testing"
@@ -116,6 +117,16 @@ func TestSequenceWatcher(t *testing.T) {
 		eventRepoMock,
 		eventQueueMock,
 		projectRepoMock,
+		&db_mock.TaskSequenceRepoMock{
+			GetTaskExecutionsFunc: func(project string, filter models.TaskExecution) ([]models.TaskExecution, error) {
+				return nil, nil
+			},
+		},
+		&fake.IShipyardRetrieverMock{
+			GetCachedShipyardExtensionFunc: func(projectName string) (*models.ShipyardExtension, error) {
+				return &models.ShipyardExtension{}, nil
+			},
+		},
 		10*time.Minute,
 		1*time.Minute,
 		theClock,
@@ -146,3 +157,153 @@ func TestSequenceWatcher(t *testing.T) {
 	}
 	cancel()
 }
+
+const testTimeoutShipyard = `apiVersion: "spec.keptn.sh/0.2.3"
+kind: "Shipyard"
+metadata:
+  name: "shipyard-sockshop"
+spec:
+  stages:
+    - name: "my-stage"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "deployment"
+              timeout: "5m"
+        - name: "evaluation"
+          timeout: "8m"
+          tasks:
+            - name: "evaluation"`
+
+func TestSequenceWatcher_DeclaredTimeouts(t *testing.T) {
+	theClock := clock.NewMock()
+
+	nowTimeStamp := timeutils.GetKeptnTimeStamp(theClock.Now().UTC())
+	eventData := keptnv2.EventData{
+		Project: "my-project",
+		Stage:   "my-stage",
+		Service: "my-service",
+	}
+
+	openTriggeredEvents := []models.Event{
+		{
+			Data:           eventData,
+			ID:             "my-deployment-triggered-id",
+			Shkeptncontext: "my-delivery-context",
+			Time:           nowTimeStamp,
+			Type:           common.Stringp(keptnv2.GetTriggeredEventType(keptnv2.DeploymentTaskName)),
+		},
+		{
+			Data:           eventData,
+			ID:             "my-sequence-triggered-id",
+			Shkeptncontext: "my-evaluation-context",
+			Time:           nowTimeStamp,
+			Type:           common.Stringp(keptnv2.GetTriggeredEventType("my-stage.evaluation")),
+		},
+		{
+			Data:           eventData,
+			ID:             "my-evaluation-triggered-id",
+			Shkeptncontext: "my-evaluation-context",
+			Time:           nowTimeStamp,
+			Type:           common.Stringp(keptnv2.GetTriggeredEventType(keptnv2.EvaluationTaskName)),
+		},
+	}
+
+	eventRepoMock := &db_mock.EventRepoMock{
+		DeleteEventFunc: func(project string, eventID string, status common.EventStatus) error {
+			newOpenTriggeredEvents := []models.Event{}
+			for _, event := range openTriggeredEvents {
+				if event.ID != eventID {
+					newOpenTriggeredEvents = append(newOpenTriggeredEvents, event)
+				}
+			}
+			openTriggeredEvents = newOpenTriggeredEvents
+			return nil
+		},
+		GetEventsFunc: func(project string, filter common.EventFilter, status ...common.EventStatus) ([]models.Event, error) {
+			if len(status) > 0 && status[0] == common.TriggeredEvent {
+				return openTriggeredEvents, nil
+			}
+			// all tasks have been started
+			return []models.Event{
+				{
+					ID:             "my-started-id",
+					Triggeredid:    *filter.TriggeredID,
+					Shkeptncontext: *filter.KeptnContext,
+					Type:           common.Stringp(keptnv2.GetStartedEventType(keptnv2.DeploymentTaskName)),
+				},
+			}, nil
+		},
+	}
+
+	taskSequenceRepoMock := &db_mock.TaskSequenceRepoMock{
+		GetTaskExecutionsFunc: func(project string, filter models.TaskExecution) ([]models.TaskExecution, error) {
+			switch filter.TriggeredEventID {
+			case "my-deployment-triggered-id":
+				return []models.TaskExecution{{TaskSequenceName: "delivery", Stage: "my-stage", Task: models.Task{Task: keptnv2.Task{Name: "deployment"}}}}, nil
+			case "my-evaluation-triggered-id":
+				return []models.TaskExecution{{TaskSequenceName: "evaluation", Stage: "my-stage", Task: models.Task{Task: keptnv2.Task{Name: "evaluation"}}}}, nil
+			}
+			return nil, nil
+		},
+	}
+
+	cancelSequenceChannel := make(chan models.SequenceTimeout, 10)
+
+	watcher := handler.NewSequenceWatcher(
+		cancelSequenceChannel,
+		eventRepoMock,
+		&db_mock.EventQueueRepoMock{
+			IsEventInQueueFunc: func(eventID string) (bool, error) {
+				return false, nil
+			},
+		},
+		&db_mock.ProjectRepoMock{
+			GetProjectsFunc: func() ([]*models.ExpandedProject, error) {
+				return []*models.ExpandedProject{{ProjectName: "my-project"}}, nil
+			},
+		},
+		taskSequenceRepoMock,
+		&fake.IShipyardRetrieverMock{
+			GetCachedShipyardExtensionFunc: func(projectName string) (*models.ShipyardExtension, error) {
+				return models.UnmarshalShipyardExtension(testTimeoutShipyard)
+			},
+		},
+		1*time.Hour,
+		1*time.Minute,
+		theClock,
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	watcher.Run(ctx)
+
+	// after 6 minutes, the deployment task has exceeded its timeout
+	theClock.Add(6 * time.Minute)
+	select {
+	case timeout := <-cancelSequenceChannel:
+		require.Equal(t, "my-delivery-context", timeout.KeptnContext)
+		require.Equal(t, models.TaskTimeout, timeout.Type)
+		require.Equal(t, 5*time.Minute, timeout.Timeout)
+		require.Equal(t, "my-deployment-triggered-id", timeout.LastEvent.ID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("did not receive expected task timeout")
+	}
+
+	// after 9 minutes, the evaluation sequence has exceeded its timeout
+	theClock.Add(3 * time.Minute)
+	select {
+	case timeout := <-cancelSequenceChannel:
+		require.Equal(t, "my-evaluation-context", timeout.KeptnContext)
+		require.Equal(t, models.SequenceTimeoutLimit, timeout.Type)
+		require.Equal(t, 8*time.Minute, timeout.Timeout)
+		// the sequence is timed out via its open task
+		require.Equal(t, "my-evaluation-triggered-id", timeout.LastEvent.ID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("did not receive expected sequence timeout")
+	}
+
+	require.Eventually(t, func() bool {
+		return len(eventRepoMock.DeleteEventCalls()) == 2
+	}, 5*time.Second, 100*time.Millisecond)
+}
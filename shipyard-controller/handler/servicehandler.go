@@ -55,7 +55,10 @@ func (sh *ServiceHandler) CreateService(c *gin.Context) {
 		return
 	}
 
-	common.LockProject(projectName)
+	if err := common.LockProject(projectName); err != nil {
+		SetInternalServerErrorResponse(err, c)
+		return
+	}
 	defer common.UnlockProject(projectName)
 
 	if err := sh.sendServiceCreateStartedEvent(keptnContext, projectName, createServiceParams); err != nil {
@@ -107,7 +110,10 @@ func (sh *ServiceHandler) DeleteService(c *gin.Context) {
 		SetBadRequestErrorResponse(nil, c, "Must provide a service name")
 	}
 
-	common.LockProject(projectName)
+	if err := common.LockProject(projectName); err != nil {
+		SetInternalServerErrorResponse(err, c)
+		return
+	}
 	defer common.UnlockProject(projectName)
 
 	if err := sh.sendServiceDeleteStartedEvent(keptnContext, projectName, serviceName); err != nil {
@@ -30,24 +30,26 @@ type IShipyardController interface {
 }
 
 type shipyardController struct {
-	eventRepo                  db.EventRepo
-	taskSequenceRepo           db.TaskSequenceRepo
-	projectMvRepo              db.ProjectMVRepo
-	eventDispatcher            IEventDispatcher
-	sequenceDispatcher         ISequenceDispatcher
-	sequenceTimeoutChan        chan models.SequenceTimeout
-	sequenceTriggeredHooks     []sequencehooks.ISequenceTriggeredHook
-	sequenceStartedHooks       []sequencehooks.ISequenceStartedHook
-	sequenceTaskTriggeredHooks []sequencehooks.ISequenceTaskTriggeredHook
-	sequenceTaskStartedHooks   []sequencehooks.ISequenceTaskStartedHook
-	sequenceTaskFinishedHooks  []sequencehooks.ISequenceTaskFinishedHook
-	subSequenceFinishedHooks   []sequencehooks.ISubSequenceFinishedHook
-	sequenceFinishedHooks      []sequencehooks.ISequenceFinishedHook
-	sequenceAbortedHooks       []sequencehooks.ISequenceAbortedHook
-	sequenceTimoutHooks        []sequencehooks.ISequenceTimeoutHook
-	sequencePausedHooks        []sequencehooks.ISequencePausedHook
-	sequenceResumedHooks       []sequencehooks.ISequenceResumedHook
-	shipyardRetriever          IShipyardRetriever
+	eventRepo                        db.EventRepo
+	taskSequenceRepo                 db.TaskSequenceRepo
+	projectMvRepo                    db.ProjectMVRepo
+	eventDispatcher                  IEventDispatcher
+	sequenceDispatcher               ISequenceDispatcher
+	sequenceTimeoutChan              chan models.SequenceTimeout
+	sequenceTriggeredHooks           []sequencehooks.ISequenceTriggeredHook
+	sequenceStartedHooks             []sequencehooks.ISequenceStartedHook
+	sequenceTaskTriggeredHooks       []sequencehooks.ISequenceTaskTriggeredHook
+	sequenceTaskStartedHooks         []sequencehooks.ISequenceTaskStartedHook
+	sequenceTaskFinishedHooks        []sequencehooks.ISequenceTaskFinishedHook
+	sequenceTaskSkippedHooks         []sequencehooks.ISequenceTaskSkippedHook
+	sequenceTaskAttemptFinishedHooks []sequencehooks.ISequenceTaskAttemptFinishedHook
+	subSequenceFinishedHooks         []sequencehooks.ISubSequenceFinishedHook
+	sequenceFinishedHooks            []sequencehooks.ISequenceFinishedHook
+	sequenceAbortedHooks             []sequencehooks.ISequenceAbortedHook
+	sequenceTimoutHooks              []sequencehooks.ISequenceTimeoutHook
+	sequencePausedHooks              []sequencehooks.ISequencePausedHook
+	sequenceResumedHooks             []sequencehooks.ISequenceResumedHook
+	shipyardRetriever                IShipyardRetriever
 }
 
 func GetShipyardControllerInstance(
@@ -56,15 +58,15 @@ func GetShipyardControllerInstance(
 	sequenceDispatcher ISequenceDispatcher,
 	sequenceTimeoutChannel chan models.SequenceTimeout,
 	shipyardRetriever IShipyardRetriever,
+	eventRepo db.EventRepo,
+	taskSequenceRepo db.TaskSequenceRepo,
+	projectMvRepo db.ProjectMVRepo,
 ) *shipyardController {
 	if shipyardControllerInstance == nil {
-		cbConnectionInstance := db.GetMongoDBConnectionInstance()
 		shipyardControllerInstance = &shipyardController{
-			eventRepo:        db.NewMongoDBEventsRepo(cbConnectionInstance),
-			taskSequenceRepo: db.NewTaskSequenceMongoDBRepo(cbConnectionInstance),
-			projectMvRepo: db.NewProjectMVRepo(
-				db.NewMongoDBKeyEncodingProjectsRepo(cbConnectionInstance),
-				db.NewMongoDBEventsRepo(cbConnectionInstance)),
+			eventRepo:           eventRepo,
+			taskSequenceRepo:    taskSequenceRepo,
+			projectMvRepo:       projectMvRepo,
 			eventDispatcher:     eventDispatcher,
 			sequenceDispatcher:  sequenceDispatcher,
 			sequenceTimeoutChan: sequenceTimeoutChannel,
@@ -91,8 +93,13 @@ func (sc *shipyardController) run(ctx context.Context) {
 			}
 		}
 	}()
-	sc.eventDispatcher.Run(context.Background())
-	sc.sequenceDispatcher.Run(context.Background(), sc.StartTaskSequence)
+}
+
+// StartDispatchers starts the loops of the event dispatcher and the sequence dispatcher. The loops are stopped when ctx is cancelled.
+// If multiple instances of the shipyard-controller are running, this should only be done by the current leader
+func (sc *shipyardController) StartDispatchers(ctx context.Context) {
+	sc.eventDispatcher.Run(ctx)
+	sc.sequenceDispatcher.Run(ctx, sc.startTaskSequence, sc.ControlSequence)
 }
 
 func (sc *shipyardController) ControlSequence(controlSequence models.SequenceControl) error {
@@ -118,6 +125,9 @@ func (sc *shipyardController) ControlSequence(controlSequence models.SequenceCon
 			},
 			KeptnContext: controlSequence.KeptnContext,
 		})
+	case models.RestartSequence:
+		log.Info("Processing RESTART sequence control")
+		return sc.restartSequence(controlSequence)
 	}
 	return nil
 }
@@ -264,7 +274,9 @@ func (sc *shipyardController) handleTaskFinished(event models.Event) error {
 		return nil
 	}
 
-	common.LockServiceInStageOfProject(eventScope.Project, eventScope.Stage, eventScope.Service+":taskFinisher")
+	if err := common.LockServiceInStageOfProject(eventScope.Project, eventScope.Stage, eventScope.Service+":taskFinisher"); err != nil {
+		return fmt.Errorf("unable to handle %s event: %w", eventScope.EventType, err)
+	}
 	defer common.UnlockServiceInStageOfProject(eventScope.Project, eventScope.Stage, eventScope.Service+":taskFinisher")
 
 	startedEvents, err := sc.eventRepo.GetStartedEventsForTriggeredID(*eventScope)
@@ -311,6 +323,15 @@ func (sc *shipyardController) handleTaskFinished(event models.Event) error {
 			return fmt.Errorf("unable to get open task execution: %w", err)
 		}
 
+		retried, err := sc.retryTask(*eventScope, *taskExecution, triggeredEvents[0])
+		if err != nil {
+			return fmt.Errorf("unable to retry task %s: %w", taskExecution.Task.Name, err)
+		}
+		if retried {
+			sc.onSequenceTaskFinished(eventScope.WrappedEvent)
+			return nil
+		}
+
 		shipyard, err := sc.shipyardRetriever.GetCachedShipyard(eventScope.Project)
 		if err != nil {
 			return fmt.Errorf("unable to fetch shipyard: %w", err)
@@ -321,6 +342,18 @@ func (sc *shipyardController) handleTaskFinished(event models.Event) error {
 			return fmt.Errorf("unable to find task %s.%s found in shipyard: %w", taskExecution.Stage, taskExecution.TaskSequenceName, err)
 		}
 
+		if taskExecution.Task.Group != "" {
+			groupFinished, err := sc.aggregateParallelTaskGroup(eventScope, *taskExecution)
+			if err != nil {
+				return fmt.Errorf("unable to determine state of parallel task group %s: %w", taskExecution.Task.Group, err)
+			}
+			if !groupFinished {
+				log.Infof("Waiting for remaining tasks of parallel task group %s with keptn context %s", taskExecution.Task.Group, eventScope.KeptnContext)
+				sc.onSequenceTaskFinished(eventScope.WrappedEvent)
+				return nil
+			}
+		}
+
 		finishedEventsData, err := sc.getFinishedEventData(*eventScope)
 		if err != nil {
 			return fmt.Errorf("unable to gather task '.finished' event data: %w", err)
@@ -332,6 +365,70 @@ func (sc *shipyardController) handleTaskFinished(event models.Event) error {
 	return nil
 }
 
+// aggregateParallelTaskGroup checks whether all tasks of the parallel task group the given task execution belongs to have been finished.
+// If this is the case, the result and status of the event scope are set to the aggregated result and status of the group
+func (sc *shipyardController) aggregateParallelTaskGroup(eventScope *models.EventScope, taskExecution models.TaskExecution) (bool, error) {
+	expectedTasks := 0
+	shipyardExtension, err := sc.shipyardRetriever.GetCachedShipyardExtension(eventScope.Project)
+	if err != nil {
+		log.Errorf("Could not load shipyard extension of project %s: %s", eventScope.Project, err.Error())
+	} else {
+		expectedTasks = len(shipyardExtension.GetSequence(taskExecution.Stage, taskExecution.TaskSequenceName).GetTask(taskExecution.Task.TaskIndex).Parallel)
+	}
+
+	taskExecutions, err := sc.taskSequenceRepo.GetTaskExecutions(eventScope.Project, models.TaskExecution{
+		TaskSequenceName: taskExecution.TaskSequenceName,
+		Stage:            taskExecution.Stage,
+		Service:          taskExecution.Service,
+		KeptnContext:     taskExecution.KeptnContext,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	groupExecutions := []models.TaskExecution{}
+	// retried tasks have one execution per attempt, therefore the tasks of the group are counted by their name
+	groupTasks := map[string]bool{}
+	for _, execution := range taskExecutions {
+		if execution.Task.Group == taskExecution.Task.Group && execution.Task.TaskIndex == taskExecution.Task.TaskIndex {
+			groupExecutions = append(groupExecutions, execution)
+			groupTasks[execution.Task.Name] = true
+		}
+	}
+	if len(groupTasks) < expectedTasks {
+		return false, nil
+	}
+
+	groupEventsData := []keptnv2.EventData{}
+	for _, execution := range groupExecutions {
+		triggeredEventID := execution.TriggeredEventID
+		// the .triggered event of a task is removed once the task has been finished
+		triggeredEvents, err := sc.eventRepo.GetEvents(eventScope.Project, common.EventFilter{ID: &triggeredEventID}, common.TriggeredEvent)
+		if err != nil && err != db.ErrNoEventFound {
+			return false, err
+		}
+		if len(triggeredEvents) > 0 {
+			return false, nil
+		}
+
+		finishedEvents, err := sc.eventRepo.GetEvents(eventScope.Project, common.EventFilter{TriggeredID: &triggeredEventID}, common.FinishedEvent)
+		if err != nil && err != db.ErrNoEventFound {
+			return false, err
+		}
+		for _, finishedEvent := range finishedEvents {
+			eventData := keptnv2.EventData{}
+			if err := keptnv2.Decode(finishedEvent.Data, &eventData); err != nil {
+				log.Errorf("Could not decode data of event %s: %s", finishedEvent.ID, err.Error())
+				continue
+			}
+			groupEventsData = append(groupEventsData, eventData)
+		}
+	}
+
+	eventScope.Result, eventScope.Status = aggregateTaskResults(groupEventsData, eventScope.Result, eventScope.Status)
+	return true, nil
+}
+
 func (sc *shipyardController) wasTaskTriggered(eventScope models.EventScope) (bool, error) {
 	taskContext, err := sc.getOpenTaskExecution(eventScope)
 	if err != nil {
@@ -439,6 +536,139 @@ func (sc *shipyardController) cancelQueuedSequence(cancel models.SequenceControl
 	return sc.forceTaskSequenceCompletion(sequenceTriggeredEvent, sequenceName)
 }
 
+// restartSequence triggers a sequence that is not running anymore again in the same keptn context, starting with the task given in the sequence control.
+// The event history of the sequence is rebuilt from the '.finished' events of the tasks before that task
+func (sc *shipyardController) restartSequence(restart models.SequenceControl) error {
+	events, err := sc.eventRepo.GetEvents(restart.Project, common.EventFilter{KeptnContext: &restart.KeptnContext, Stage: &restart.Stage})
+	if err != nil {
+		if err == db.ErrNoEventFound {
+			return ErrSequenceNotFound
+		}
+		return err
+	}
+	run := getSequenceRunToRestart(getSequenceRuns(events))
+	if run == nil {
+		return ErrSequenceNotFound
+	}
+	sequenceTriggeredEvent := run.triggeredEvent
+	eventScope, err := models.NewEventScope(sequenceTriggeredEvent)
+	if err != nil {
+		return err
+	}
+	_, sequenceName, _, err := keptnv2.ParseSequenceEventType(*sequenceTriggeredEvent.Type)
+	if err != nil {
+		return err
+	}
+
+	// the '.triggered' event of a sequence is kept until the sequence is finished
+	openSequenceEvents, err := sc.eventRepo.GetEvents(restart.Project, common.EventFilter{ID: &sequenceTriggeredEvent.ID}, common.TriggeredEvent)
+	if err != nil && err != db.ErrNoEventFound {
+		return err
+	}
+	taskExecutions, err := sc.taskSequenceRepo.GetTaskExecutions(restart.Project, models.TaskExecution{KeptnContext: restart.KeptnContext, Stage: eventScope.Stage})
+	if err != nil {
+		return err
+	}
+	if len(openSequenceEvents) > 0 || len(taskExecutions) > 0 {
+		return ErrSequenceStillRunning
+	}
+
+	shipyard, err := sc.shipyardRetriever.GetCachedShipyard(restart.Project)
+	if err != nil {
+		return err
+	}
+	taskSequence, err := GetTaskSequenceInStage(eventScope.Stage, sequenceName, shipyard)
+	if err != nil {
+		return err
+	}
+	shipyardExtension, err := sc.shipyardRetriever.GetCachedShipyardExtension(restart.Project)
+	if err != nil {
+		log.Errorf("Could not load shipyard extension of project %s: %s", restart.Project, err.Error())
+	}
+	sequenceExtension := shipyardExtension.GetSequence(eventScope.Stage, sequenceName)
+
+	fromIndex, err := getRestartTaskIndex(taskSequence, sequenceExtension, run, restart.FromTask)
+	if err != nil {
+		return err
+	}
+	log.Infof("Restarting sequence %s.%s with keptn context %s from task %s", eventScope.Stage, sequenceName, restart.KeptnContext, taskSequence.Tasks[fromIndex].Name)
+
+	// restore the events the sequence would have stored when reaching the task
+	if err := sc.eventRepo.InsertEvent(restart.Project, sequenceTriggeredEvent, common.TriggeredEvent); err != nil {
+		return fmt.Errorf("could not store event that triggered task sequence: %w", err)
+	}
+	for index := 0; index < fromIndex; index++ {
+		for _, taskName := range getTaskNamesAtIndex(taskSequence, sequenceExtension, index) {
+			finishedEvents, _ := run.getLatestExecutionOfTask(taskName)
+			for _, finishedEvent := range finishedEvents {
+				if err := sc.eventRepo.InsertEvent(restart.Project, finishedEvent, common.FinishedEvent); err != nil {
+					return fmt.Errorf("could not restore %s event with ID %s: %w", *finishedEvent.Type, finishedEvent.ID, err)
+				}
+			}
+		}
+	}
+
+	// the restarted sequence is queued like any other triggered sequence to respect the concurrency policy of the stage.
+	// Once it is dispatched, it continues with the task it has been restarted from
+	return sc.sequenceDispatcher.Add(models.QueueItem{
+		Scope:     *eventScope,
+		EventID:   sequenceTriggeredEvent.ID,
+		Timestamp: time.Now().UTC(),
+		Restart:   &models.SequenceRestart{FromTaskIndex: fromIndex},
+	})
+}
+
+// getRestoredSequenceProgress returns the event history and the last task of a sequence that has been restarted from the task with the given index.
+// The progress is restored from the '.finished' events of the run that has been triggered by the event with the given ID
+func (sc *shipyardController) getRestoredSequenceProgress(eventScope *models.EventScope, taskSequence *keptnv2.Sequence, triggeredID string, fromTaskIndex int) ([]interface{}, *models.TaskExecution, error) {
+	events, err := sc.eventRepo.GetEvents(eventScope.Project, common.EventFilter{KeptnContext: &eventScope.KeptnContext, Stage: &eventScope.Stage})
+	if err != nil && err != db.ErrNoEventFound {
+		return nil, nil, fmt.Errorf("could not retrieve events of restarted sequence: %w", err)
+	}
+	var run *sequenceRun
+	for _, sequenceRun := range getSequenceRuns(events) {
+		if sequenceRun.triggeredEvent.ID == triggeredID {
+			run = sequenceRun
+			break
+		}
+	}
+	if run == nil {
+		return nil, nil, fmt.Errorf("could not find run of restarted sequence with triggered ID %s", triggeredID)
+	}
+
+	shipyardExtension, err := sc.shipyardRetriever.GetCachedShipyardExtension(eventScope.Project)
+	if err != nil {
+		log.Errorf("Could not load shipyard extension of project %s: %s", eventScope.Project, err.Error())
+	}
+	sequenceExtension := shipyardExtension.GetSequence(eventScope.Stage, taskSequence.Name)
+
+	eventHistory := []interface{}{}
+	var previousTask *models.TaskExecution
+	for index := 0; index < fromTaskIndex && index < len(taskSequence.Tasks); index++ {
+		for _, taskName := range getTaskNamesAtIndex(taskSequence, sequenceExtension, index) {
+			finishedEvents, _ := run.getLatestExecutionOfTask(taskName)
+			for _, finishedEvent := range finishedEvents {
+				eventData := keptnv2.EventData{}
+				if err := keptnv2.Decode(finishedEvent.Data, &eventData); err == nil {
+					eventScope.Result, eventScope.Status = eventData.Result, eventData.Status
+				}
+				marshal, _ := json.Marshal(finishedEvent.Data)
+				var tmp interface{}
+				_ = json.Unmarshal(marshal, &tmp)
+				eventHistory = append(eventHistory, tmp)
+			}
+		}
+		previousTask = &models.TaskExecution{
+			TaskSequenceName: taskSequence.Name,
+			Stage:            eventScope.Stage,
+			Service:          eventScope.Service,
+			KeptnContext:     eventScope.KeptnContext,
+			Task:             models.Task{Task: taskSequence.Tasks[index], TaskIndex: index},
+		}
+	}
+	return eventHistory, previousTask, nil
+}
+
 func (sc *shipyardController) timeoutSequence(timeout models.SequenceTimeout) error {
 	log.Infof("sequence %s has been timed out", timeout.KeptnContext)
 	eventScope, err := models.NewEventScope(timeout.LastEvent)
@@ -446,10 +676,6 @@ func (sc *shipyardController) timeoutSequence(timeout models.SequenceTimeout) er
 		return err
 	}
 
-	eventScope.Status = keptnv2.StatusErrored
-	eventScope.Result = keptnv2.ResultFailed
-	eventScope.Message = fmt.Sprintf("sequence timed out while waiting for task %s to receive a correlating .started or .finished event", *timeout.LastEvent.Type)
-
 	taskExecutions, err := sc.taskSequenceRepo.GetTaskExecutions(eventScope.Project, models.TaskExecution{TriggeredEventID: timeout.LastEvent.ID})
 	if err != nil {
 		return fmt.Errorf("Could not retrieve task executions associated to eventID %s: %s", timeout.LastEvent.ID, err.Error())
@@ -460,7 +686,17 @@ func (sc *shipyardController) timeoutSequence(timeout models.SequenceTimeout) er
 		return nil
 	}
 	taskContext := taskExecutions[0]
-	sc.onSequenceTimeout(timeout.LastEvent)
+
+	timeout.Message = getSequenceTimeoutMessage(timeout, taskContext)
+	eventScope.Status = keptnv2.StatusErrored
+	eventScope.Result = keptnv2.ResultFailed
+	eventScope.Message = timeout.Message
+
+	if taskContext.Task.Group != "" {
+		// the remaining tasks of the parallel task group will not be awaited anymore
+		sc.deleteOpenTriggeredEventsOfSequence(eventScope.Project, taskContext)
+	}
+	sc.onSequenceTimeout(timeout)
 	taskSequenceTriggeredEvent, err := sc.eventRepo.GetTaskSequenceTriggeredEvent(*eventScope, taskContext.TaskSequenceName)
 	if err != nil {
 		return err
@@ -473,6 +709,39 @@ func (sc *shipyardController) timeoutSequence(timeout models.SequenceTimeout) er
 	return nil
 }
 
+// getSequenceTimeoutMessage describes which limit has caused the sequence to time out
+func getSequenceTimeoutMessage(timeout models.SequenceTimeout, taskContext models.TaskExecution) string {
+	switch timeout.Type {
+	case models.TaskTimeout:
+		return fmt.Sprintf("sequence timed out because task %s has not been finished within its timeout of %s", taskContext.Task.Name, timeout.Timeout.String())
+	case models.SequenceTimeoutLimit:
+		return fmt.Sprintf("sequence %s has not been finished within its timeout of %s. Task %s has been cancelled", taskContext.TaskSequenceName, timeout.Timeout.String(), taskContext.Task.Name)
+	default:
+		return fmt.Sprintf("sequence timed out while waiting for task %s to receive a correlating .started or .finished event", *timeout.LastEvent.Type)
+	}
+}
+
+func (sc *shipyardController) deleteOpenTriggeredEventsOfSequence(project string, taskExecution models.TaskExecution) {
+	taskExecutions, err := sc.taskSequenceRepo.GetTaskExecutions(project, models.TaskExecution{
+		TaskSequenceName: taskExecution.TaskSequenceName,
+		Stage:            taskExecution.Stage,
+		Service:          taskExecution.Service,
+		KeptnContext:     taskExecution.KeptnContext,
+	})
+	if err != nil {
+		log.WithError(err).Error("could not retrieve task executions")
+		return
+	}
+	for _, execution := range taskExecutions {
+		if execution.TriggeredEventID == taskExecution.TriggeredEventID {
+			continue
+		}
+		if err := sc.eventRepo.DeleteEvent(project, execution.TriggeredEventID, common.TriggeredEvent); err != nil {
+			log.WithError(err).Error("could not delete event")
+		}
+	}
+}
+
 func (sc *shipyardController) triggerSequenceFailed(eventScope models.EventScope, msg string, taskSequenceName string) error {
 	event := eventScope.WrappedEvent
 	sc.onSequenceTriggered(event) //TODO: remove?
@@ -496,6 +765,11 @@ func (sc *shipyardController) triggerSequenceFailed(eventScope models.EventScope
 }
 
 func (sc *shipyardController) StartTaskSequence(event models.Event) error {
+	return sc.startTaskSequence(event, nil)
+}
+
+// startTaskSequence starts the given sequence. A restarted sequence continues with the task it has been restarted from
+func (sc *shipyardController) startTaskSequence(event models.Event, restart *models.SequenceRestart) error {
 	eventScope, err := models.NewEventScope(event)
 	if err != nil {
 		return err
@@ -516,9 +790,18 @@ func (sc *shipyardController) StartTaskSequence(event models.Event) error {
 		msg := fmt.Sprintf("could not get definition of task sequence %s: %s", taskSequenceName, err.Error())
 		return sc.triggerSequenceFailed(*eventScope, msg, taskSequenceName)
 	}
+
+	eventHistory := []interface{}{}
+	var previousTask *models.TaskExecution
+	if restart != nil {
+		eventHistory, previousTask, err = sc.getRestoredSequenceProgress(eventScope, taskSequence, event.ID, restart.FromTaskIndex)
+		if err != nil {
+			return err
+		}
+	}
 	sc.onSequenceStarted(event)
 
-	return sc.proceedTaskSequence(*eventScope, taskSequence, []interface{}{}, nil)
+	return sc.proceedTaskSequence(*eventScope, taskSequence, eventHistory, previousTask)
 }
 
 func (sc *shipyardController) getOpenTaskExecution(eventScope models.EventScope) (*models.TaskExecution, error) {
@@ -606,7 +889,26 @@ func (sc *shipyardController) proceedTaskSequence(eventScope models.EventScope,
 		return err
 	}
 
-	task := GetNextTaskOfSequence(taskSequence, previousTask, &eventScope, eventHistory)
+	shipyardExtension, err := sc.shipyardRetriever.GetCachedShipyardExtension(eventScope.Project)
+	if err != nil {
+		log.Errorf("Could not load shipyard extension of project %s: %s", eventScope.Project, err.Error())
+	}
+
+	task, skippedTasks, err := GetNextTaskOfSequence(taskSequence, shipyardExtension.GetSequence(eventScope.Stage, taskSequence.Name), previousTask, &eventScope, eventHistory)
+	for _, skippedTask := range skippedTasks {
+		sc.onSequenceTaskSkipped(eventScope, skippedTask)
+	}
+	if err != nil {
+		// the sequence is finished with a failure, since the remaining tasks can not be executed reliably
+		log.Errorf("Could not determine next task of sequence %s.%s with KeptnContext %s: %s", eventScope.Stage, taskSequence.Name, eventScope.KeptnContext, err.Error())
+		eventScope.Status = keptnv2.StatusErrored
+		eventScope.Result = keptnv2.ResultFailed
+		eventScope.Message = err.Error()
+		task = nil
+	}
+	if task != nil && task.IsParallelGroup() {
+		return sc.sendParallelTaskTriggeredEvents(eventScope, taskSequence.Name, *task, eventHistory)
+	}
 	if task == nil {
 		// task sequence completed -> send .finished event and check if a new task sequence should be triggered by the completion
 		err = sc.completeTaskSequence(eventScope, taskSequence.Name, inputEvent.ID)
@@ -615,7 +917,12 @@ func (sc *shipyardController) proceedTaskSequence(eventScope models.EventScope,
 			return err
 		}
 
-		return sc.triggerNextTaskSequences(eventScope, taskSequence, eventHistory, inputEvent, previousTask.Task.Name)
+		// if the sequence ends with a parallel task group, the group is the task that has been completed, rather than the last finished task of the group
+		previousTaskName := ""
+		if previousTask != nil {
+			previousTaskName = previousTask.Task.GetSequenceTaskName()
+		}
+		return sc.triggerNextTaskSequences(eventScope, taskSequence, eventHistory, inputEvent, previousTaskName)
 	}
 	return sc.sendTaskTriggeredEvent(eventScope, taskSequence.Name, *task, eventHistory)
 }
@@ -645,7 +952,7 @@ func (sc *shipyardController) triggerNextTaskSequences(eventScope models.EventSc
 	if err != nil {
 		return err
 	}
-	nextSequences := GetTaskSequencesByTrigger(eventScope, completedSequence.Name, shipyard, previousTask)
+	nextSequences := GetTaskSequencesByTrigger(eventScope, completedSequence.Name, shipyard, previousTask, eventHistory)
 
 	if len(nextSequences) == 0 {
 		sc.onSequenceFinished(*inputEvent)
@@ -730,8 +1037,25 @@ func (sc *shipyardController) sendTaskSequenceFinishedEvent(eventScope models.Ev
 	return sc.eventDispatcher.Add(models.DispatcherEvent{TimeStamp: time.Now().UTC(), Event: event}, true)
 }
 
+func (sc *shipyardController) sendParallelTaskTriggeredEvents(eventScope models.EventScope, taskSequenceName string, group models.Task, eventHistory []interface{}) error {
+	log.Infof("Triggering %d tasks of parallel task group %s", len(group.ParallelTasks), group.Name)
+	for _, parallelTask := range group.ParallelTasks {
+		task := models.Task{
+			Task:      parallelTask,
+			TaskIndex: group.TaskIndex,
+			Group:     group.Name,
+		}
+		if err := sc.sendTaskTriggeredEvent(eventScope, taskSequenceName, task, eventHistory); err != nil {
+			return fmt.Errorf("unable to trigger task %s of parallel task group %s: %w", parallelTask.Name, group.Name, err)
+		}
+	}
+	return nil
+}
+
 func (sc *shipyardController) sendTaskTriggeredEvent(eventScope models.EventScope, taskSequenceName string, task models.Task, eventHistory []interface{}) error {
-	common.LockServiceInStageOfProject(eventScope.Project, eventScope.Stage, eventScope.Service)
+	if err := common.LockServiceInStageOfProject(eventScope.Project, eventScope.Stage, eventScope.Service); err != nil {
+		return err
+	}
 	defer common.UnlockServiceInStageOfProject(eventScope.Project, eventScope.Stage, eventScope.Service)
 	eventPayload := map[string]interface{}{}
 
@@ -761,12 +1085,6 @@ func (sc *shipyardController) sendTaskTriggeredEvent(eventScope models.EventScop
 
 	event := common.CreateEventWithPayload(eventScope.KeptnContext, "", keptnv2.GetTriggeredEventType(task.Name), eventPayload)
 
-	storeEvent := &models.Event{}
-	if err := keptnv2.Decode(event, storeEvent); err != nil {
-		log.Errorf("could not transform CloudEvent for storage in mongodb: %s", err.Error())
-		return err
-	}
-
 	sendTaskTimestamp := time.Now().UTC()
 	if task.TriggeredAfter != "" {
 		if duration, err := time.ParseDuration(task.TriggeredAfter); err == nil {
@@ -777,6 +1095,77 @@ func (sc *shipyardController) sendTaskTriggeredEvent(eventScope models.EventScop
 		}
 		log.Infof("queueing %s event with ID %s to be sent at %s", event.Type(), event.ID(), sendTaskTimestamp.String())
 	}
+	return sc.dispatchTaskTriggeredEvent(eventScope, taskSequenceName, task, event, sendTaskTimestamp)
+}
+
+// retryTask triggers the task of the given task execution again if the retry policy of the task applies to the result of its '.finished' event.
+// The data of the new '.triggered' event is taken from the '.triggered' event of the previous attempt. It returns true if the task has been retried
+func (sc *shipyardController) retryTask(eventScope models.EventScope, taskExecution models.TaskExecution, triggeredEvent models.Event) (bool, error) {
+	shipyardExtension, err := sc.shipyardRetriever.GetCachedShipyardExtension(eventScope.Project)
+	if err != nil {
+		log.Errorf("Could not load shipyard extension of project %s: %s", eventScope.Project, err.Error())
+		return false, nil
+	}
+	retryPolicy := shipyardExtension.GetSequence(taskExecution.Stage, taskExecution.TaskSequenceName).GetTask(taskExecution.Task.TaskIndex).Retry
+	if retryPolicy == nil {
+		return false, nil
+	}
+
+	attempt := taskExecution.Task.GetAttempt()
+	taskAttempt := models.TaskAttempt{
+		FinishedEvent: eventScope.WrappedEvent,
+		Attempt:       attempt,
+		MaxAttempts:   retryPolicy.MaxAttempts,
+	}
+	if !retryPolicy.ShouldRetry(eventScope.Result, eventScope.Status, attempt) {
+		sc.onSequenceTaskAttemptFinished(taskAttempt)
+		return false, nil
+	}
+
+	// the '.finished' events of the failed attempt must not be considered for the result of the task and the data of subsequent tasks
+	finishedEvents, err := sc.eventRepo.GetEvents(eventScope.Project, common.EventFilter{TriggeredID: &eventScope.TriggeredID}, common.FinishedEvent)
+	if err != nil && err != db.ErrNoEventFound {
+		return false, fmt.Errorf("unable to retrieve '.finished' events of task: %w", err)
+	}
+	for _, finishedEvent := range finishedEvents {
+		if err := sc.eventRepo.DeleteEvent(eventScope.Project, finishedEvent.ID, common.FinishedEvent); err != nil {
+			return false, fmt.Errorf("unable to delete '.finished' event with ID %s: %w", finishedEvent.ID, err)
+		}
+	}
+
+	nextAttemptTimestamp := time.Now().UTC().Add(retryPolicy.GetBackoff(attempt))
+	taskAttempt.NextAttempt = &nextAttemptTimestamp
+	sc.onSequenceTaskAttemptFinished(taskAttempt)
+
+	eventPayload := map[string]interface{}{}
+	if err := keptnv2.Decode(triggeredEvent.Data, &eventPayload); err != nil {
+		return false, fmt.Errorf("unable to decode data of '.triggered' event with ID %s: %w", triggeredEvent.ID, err)
+	}
+	eventPayload["retry"] = models.TaskRetryEventData{
+		Attempt:     attempt + 1,
+		MaxAttempts: retryPolicy.MaxAttempts,
+	}
+
+	task := taskExecution.Task
+	task.Attempt = attempt + 1
+	event := common.CreateEventWithPayload(eventScope.KeptnContext, "", keptnv2.GetTriggeredEventType(task.Name), eventPayload)
+	log.Infof("Task %s of sequence with keptn context %s has been finished with result %s and status %s. Triggering attempt %d of %d at %s",
+		task.Name, eventScope.KeptnContext, eventScope.Result, eventScope.Status, task.Attempt, retryPolicy.MaxAttempts, nextAttemptTimestamp.String())
+
+	if err := common.LockServiceInStageOfProject(eventScope.Project, eventScope.Stage, eventScope.Service); err != nil {
+		return true, err
+	}
+	defer common.UnlockServiceInStageOfProject(eventScope.Project, eventScope.Stage, eventScope.Service)
+	return true, sc.dispatchTaskTriggeredEvent(eventScope, taskExecution.TaskSequenceName, task, event, nextAttemptTimestamp)
+}
+
+// dispatchTaskTriggeredEvent stores the '.triggered' event of a task, passes it to the event dispatcher and creates the task execution for it
+func (sc *shipyardController) dispatchTaskTriggeredEvent(eventScope models.EventScope, taskSequenceName string, task models.Task, event cloudevents.Event, sendTaskTimestamp time.Time) error {
+	storeEvent := &models.Event{}
+	if err := keptnv2.Decode(event, storeEvent); err != nil {
+		log.Errorf("could not transform CloudEvent for storage in mongodb: %s", err.Error())
+		return err
+	}
 	storeEvent.Time = timeutils.GetKeptnTimeStamp(sendTaskTimestamp)
 
 	if err := sc.eventRepo.InsertEvent(eventScope.Project, *storeEvent, common.TriggeredEvent); err != nil {
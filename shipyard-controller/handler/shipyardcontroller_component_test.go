@@ -846,7 +846,7 @@ func Test_shipyardController_TimeoutSequence(t *testing.T) {
 
 	sc := getTestShipyardController("")
 
-	fakeTimeoutHook := &fakehooks.ISequenceTimeoutHookMock{OnSequenceTimeoutFunc: func(event models.Event) {}}
+	fakeTimeoutHook := &fakehooks.ISequenceTimeoutHookMock{OnSequenceTimeoutFunc: func(timeout models.SequenceTimeout) {}}
 	sc.AddSequenceTimeoutHook(fakeTimeoutHook)
 
 	// insert the test data
@@ -875,7 +875,7 @@ func Test_shipyardController_TimeoutSequence(t *testing.T) {
 	sc.taskSequenceRepo.CreateTaskExecution("my-project", models.TaskExecution{
 		TaskSequenceName: "delivery",
 		TriggeredEventID: "my-task-triggered-id",
-		Task:             models.Task{},
+		Task:             models.Task{Task: keptnv2.Task{Name: "my-task"}},
 		Stage:            "my-stage",
 		KeptnContext:     "my-keptn-context-id",
 	})
@@ -893,10 +893,15 @@ func Test_shipyardController_TimeoutSequence(t *testing.T) {
 			ID:             "my-task-triggered-id",
 			Shkeptncontext: "my-keptn-context-id",
 		},
+		Type:    models.TaskTimeout,
+		Timeout: 30 * time.Minute,
 	})
 
 	require.Nil(t, err)
 	require.Len(t, fakeTimeoutHook.OnSequenceTimeoutCalls(), 1)
+	timeout := fakeTimeoutHook.OnSequenceTimeoutCalls()[0].Timeout
+	require.Equal(t, models.TaskTimeout, timeout.Type)
+	require.Equal(t, "sequence timed out because task my-task has not been finished within its timeout of 30m0s", timeout.Message)
 }
 
 func Test_shipyardController_CancelSequence(t *testing.T) {
@@ -1112,7 +1117,18 @@ func getTestShipyardController(shipyardContent string) *shipyardController {
 	eventQueueRepo := db.NewMongoDBEventQueueRepo(db.GetMongoDBConnectionInstance())
 	sequenceQueueRepo := db.NewMongoDBSequenceQueueRepo(db.GetMongoDBConnectionInstance())
 	sequenceRepo := db.NewTaskSequenceMongoDBRepo(db.GetMongoDBConnectionInstance())
-	sequenceDispatcher := NewSequenceDispatcher(eventRepo, eventQueueRepo, sequenceQueueRepo, sequenceRepo, time.Second, clock.New())
+	shipyardRetriever := &fake.IShipyardRetrieverMock{
+		GetShipyardFunc: func(projectName string) (*keptnv2.Shipyard, error) {
+			return common.UnmarshalShipyard(shipyardContent)
+		},
+		GetCachedShipyardFunc: func(projectName string) (*keptnv2.Shipyard, error) {
+			return common.UnmarshalShipyard(shipyardContent)
+		},
+		GetCachedShipyardExtensionFunc: func(projectName string) (*models.ShipyardExtension, error) {
+			return models.UnmarshalShipyardExtension(shipyardContent)
+		},
+	}
+	sequenceDispatcher := NewSequenceDispatcher(eventRepo, eventQueueRepo, sequenceQueueRepo, sequenceRepo, shipyardRetriever, time.Second, clock.New())
 	sc := &shipyardController{
 		projectMvRepo:    db.NewProjectMVRepo(db.NewMongoDBKeyEncodingProjectsRepo(db.GetMongoDBConnectionInstance()), db.NewMongoDBEventsRepo(db.GetMongoDBConnectionInstance())),
 		eventRepo:        eventRepo,
@@ -1126,14 +1142,7 @@ func getTestShipyardController(shipyardContent string) *shipyardController {
 			},
 		},
 		sequenceDispatcher: sequenceDispatcher,
-		shipyardRetriever: &fake.IShipyardRetrieverMock{
-			GetShipyardFunc: func(projectName string) (*keptnv2.Shipyard, error) {
-				return common.UnmarshalShipyard(shipyardContent)
-			},
-			GetCachedShipyardFunc: func(projectName string) (*keptnv2.Shipyard, error) {
-				return common.UnmarshalShipyard(shipyardContent)
-			},
-		},
+		shipyardRetriever:  shipyardRetriever,
 	}
 	sc.eventDispatcher.(*fake.IEventDispatcherMock).AddFunc = func(event models.DispatcherEvent) error {
 		ev := &models.Event{}
@@ -1145,6 +1154,7 @@ func getTestShipyardController(shipyardContent string) *shipyardController {
 		return nil
 	}
 	sc.run(context.Background())
+	sc.StartDispatchers(context.Background())
 	return sc
 }
 
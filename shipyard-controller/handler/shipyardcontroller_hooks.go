@@ -25,6 +25,14 @@ func (sc *shipyardController) AddSequenceTaskFinishedHook(hook sequencehooks.ISe
 	sc.sequenceTaskFinishedHooks = append(sc.sequenceTaskFinishedHooks, hook)
 }
 
+func (sc *shipyardController) AddSequenceTaskSkippedHook(hook sequencehooks.ISequenceTaskSkippedHook) {
+	sc.sequenceTaskSkippedHooks = append(sc.sequenceTaskSkippedHooks, hook)
+}
+
+func (sc *shipyardController) AddSequenceTaskAttemptFinishedHook(hook sequencehooks.ISequenceTaskAttemptFinishedHook) {
+	sc.sequenceTaskAttemptFinishedHooks = append(sc.sequenceTaskAttemptFinishedHooks, hook)
+}
+
 func (sc *shipyardController) AddSubSequenceFinishedHook(hook sequencehooks.ISubSequenceFinishedHook) {
 	sc.subSequenceFinishedHooks = append(sc.subSequenceFinishedHooks, hook)
 }
@@ -79,6 +87,18 @@ func (sc *shipyardController) onSequenceTaskFinished(event models.Event) {
 	}
 }
 
+func (sc *shipyardController) onSequenceTaskSkipped(scope models.EventScope, task models.SkippedTask) {
+	for _, hook := range sc.sequenceTaskSkippedHooks {
+		hook.OnSequenceTaskSkipped(scope, task)
+	}
+}
+
+func (sc *shipyardController) onSequenceTaskAttemptFinished(attempt models.TaskAttempt) {
+	for _, hook := range sc.sequenceTaskAttemptFinishedHooks {
+		hook.OnSequenceTaskAttemptFinished(attempt)
+	}
+}
+
 func (sc *shipyardController) onSubSequenceFinished(event models.Event) {
 	for _, hook := range sc.subSequenceFinishedHooks {
 		hook.OnSubSequenceFinished(event)
@@ -97,9 +117,9 @@ func (sc *shipyardController) onSequenceAborted(event models.Event) {
 	}
 }
 
-func (sc *shipyardController) onSequenceTimeout(event models.Event) {
+func (sc *shipyardController) onSequenceTimeout(timeout models.SequenceTimeout) {
 	for _, hook := range sc.sequenceTimoutHooks {
-		hook.OnSequenceTimeout(event)
+		hook.OnSequenceTimeout(timeout)
 	}
 }
 
@@ -2,7 +2,9 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	"github.com/go-test/deep"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	"github.com/keptn/keptn/shipyard-controller/common"
 	"github.com/keptn/keptn/shipyard-controller/db"
 	db_mock "github.com/keptn/keptn/shipyard-controller/db/mock"
@@ -10,6 +12,7 @@ import (
 	fakehooks "github.com/keptn/keptn/shipyard-controller/handler/sequencehooks/fake"
 	"github.com/keptn/keptn/shipyard-controller/models"
 	"github.com/stretchr/testify/require"
+	"path/filepath"
 	"reflect"
 	"testing"
 )
@@ -308,3 +311,562 @@ func TestHandleFinishedEvent(t *testing.T) {
 		})
 	}
 }
+
+func Test_aggregateParallelTaskGroup(t *testing.T) {
+	shipyardExtension := &models.ShipyardExtension{
+		Spec: models.ShipyardExtensionSpec{
+			Stages: []models.StageExtension{
+				{
+					Name: "dev",
+					Sequences: []models.SequenceExtension{
+						{
+							Name: "delivery",
+							Tasks: []models.TaskExtension{
+								{Name: "deployment"},
+								{Name: "tests", Parallel: []keptnv2.Task{{Name: "test"}, {Name: "securityscan"}}},
+								{Name: "evaluation"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	testExecution := models.TaskExecution{
+		TaskSequenceName: "delivery",
+		TriggeredEventID: "test-triggered-id",
+		Task:             models.Task{Task: keptnv2.Task{Name: "test"}, TaskIndex: 1, Group: "tests"},
+		Stage:            "dev",
+		Service:          "my-service",
+		KeptnContext:     "my-context",
+	}
+	securityScanExecution := models.TaskExecution{
+		TaskSequenceName: "delivery",
+		TriggeredEventID: "securityscan-triggered-id",
+		Task:             models.Task{Task: keptnv2.Task{Name: "securityscan"}, TaskIndex: 1, Group: "tests"},
+		Stage:            "dev",
+		Service:          "my-service",
+		KeptnContext:     "my-context",
+	}
+	deploymentExecution := models.TaskExecution{
+		TaskSequenceName: "delivery",
+		TriggeredEventID: "deployment-triggered-id",
+		Task:             models.Task{Task: keptnv2.Task{Name: "deployment"}, TaskIndex: 0},
+		Stage:            "dev",
+		Service:          "my-service",
+		KeptnContext:     "my-context",
+	}
+
+	finishedEvents := map[string]models.Event{
+		"test-triggered-id":         {ID: "test-finished-id", Data: keptnv2.EventData{Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded}},
+		"securityscan-triggered-id": {ID: "securityscan-finished-id", Data: keptnv2.EventData{Result: keptnv2.ResultWarning, Status: keptnv2.StatusSucceeded}},
+	}
+
+	type fields struct {
+		taskExecutions  []models.TaskExecution
+		openTriggeredID string
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		want       bool
+		wantResult keptnv2.ResultType
+	}{
+		{
+			name: "task execution of the other task has not been created yet",
+			fields: fields{
+				taskExecutions: []models.TaskExecution{deploymentExecution, testExecution},
+			},
+			want:       false,
+			wantResult: keptnv2.ResultPass,
+		},
+		{
+			name: "other task of the group is still running",
+			fields: fields{
+				taskExecutions:  []models.TaskExecution{deploymentExecution, testExecution, securityScanExecution},
+				openTriggeredID: "securityscan-triggered-id",
+			},
+			want:       false,
+			wantResult: keptnv2.ResultPass,
+		},
+		{
+			name: "all tasks of the group are finished",
+			fields: fields{
+				taskExecutions: []models.TaskExecution{deploymentExecution, testExecution, securityScanExecution},
+			},
+			want:       true,
+			wantResult: keptnv2.ResultWarning,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := &shipyardController{
+				eventRepo: &db_mock.EventRepoMock{
+					GetEventsFunc: func(project string, filter common.EventFilter, status ...common.EventStatus) ([]models.Event, error) {
+						if status[0] == common.TriggeredEvent {
+							if *filter.ID == tt.fields.openTriggeredID {
+								return []models.Event{{ID: *filter.ID}}, nil
+							}
+							return nil, db.ErrNoEventFound
+						}
+						if status[0] == common.FinishedEvent {
+							return []models.Event{finishedEvents[*filter.TriggeredID]}, nil
+						}
+						return nil, errors.New("received unexpected request")
+					},
+				},
+				taskSequenceRepo: &db_mock.TaskSequenceRepoMock{
+					GetTaskExecutionsFunc: func(project string, filter models.TaskExecution) ([]models.TaskExecution, error) {
+						return tt.fields.taskExecutions, nil
+					},
+				},
+				shipyardRetriever: &fake.IShipyardRetrieverMock{
+					GetCachedShipyardExtensionFunc: func(projectName string) (*models.ShipyardExtension, error) {
+						return shipyardExtension, nil
+					},
+				},
+			}
+
+			eventScope := &models.EventScope{
+				EventData: keptnv2.EventData{
+					Project: "my-project",
+					Stage:   "dev",
+					Service: "my-service",
+					Result:  keptnv2.ResultPass,
+					Status:  keptnv2.StatusSucceeded,
+				},
+				KeptnContext: "my-context",
+			}
+			got, err := sc.aggregateParallelTaskGroup(eventScope, testExecution)
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantResult, eventScope.Result)
+		})
+	}
+}
+
+func Test_proceedTaskSequence_SequenceEndsWithParallelTaskGroup(t *testing.T) {
+	shipyard := &keptnv2.Shipyard{
+		Spec: keptnv2.ShipyardSpec{
+			Stages: []keptnv2.Stage{
+				{
+					Name: "dev",
+					Sequences: []keptnv2.Sequence{
+						{
+							Name:  "delivery",
+							Tasks: []keptnv2.Task{{Name: "deployment"}, {Name: "tests"}},
+						},
+					},
+				},
+				{
+					Name: "production",
+					Sequences: []keptnv2.Sequence{
+						{
+							Name: "delivery",
+							TriggeredOn: []keptnv2.Trigger{
+								{
+									Event:    "dev.delivery.finished",
+									Selector: keptnv2.Selector{Match: map[string]string{"tests.result": "pass"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	shipyardExtension := &models.ShipyardExtension{
+		Spec: models.ShipyardExtensionSpec{
+			Stages: []models.StageExtension{
+				{
+					Name: "dev",
+					Sequences: []models.SequenceExtension{
+						{
+							Name: "delivery",
+							Tasks: []models.TaskExtension{
+								{Name: "deployment"},
+								{Name: "tests", Parallel: []keptnv2.Task{{Name: "test"}, {Name: "securityscan"}}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	insertedEvents := []models.Event{}
+	sc := &shipyardController{
+		eventRepo: &db_mock.EventRepoMock{
+			GetTaskSequenceTriggeredEventFunc: func(eventScope models.EventScope, taskSequenceName string) (*models.Event, error) {
+				return &models.Event{ID: "sequence-triggered-id", Data: map[string]interface{}{"project": "my-project"}}, nil
+			},
+			DeleteAllFinishedEventsFunc: func(eventScope models.EventScope) error {
+				return nil
+			},
+			InsertEventFunc: func(project string, event models.Event, status common.EventStatus) error {
+				insertedEvents = append(insertedEvents, event)
+				return nil
+			},
+		},
+		taskSequenceRepo: &db_mock.TaskSequenceRepoMock{
+			DeleteTaskExecutionFunc: func(keptnContext string, project string, stage string, taskSequenceName string) error {
+				return nil
+			},
+		},
+		shipyardRetriever: &fake.IShipyardRetrieverMock{
+			GetCachedShipyardFunc: func(projectName string) (*keptnv2.Shipyard, error) {
+				return shipyard, nil
+			},
+			GetCachedShipyardExtensionFunc: func(projectName string) (*models.ShipyardExtension, error) {
+				return shipyardExtension, nil
+			},
+		},
+		eventDispatcher: &fake.IEventDispatcherMock{
+			AddFunc: func(event models.DispatcherEvent) error {
+				return nil
+			},
+		},
+	}
+
+	eventScope := models.EventScope{
+		EventData: keptnv2.EventData{
+			Project: "my-project",
+			Stage:   "dev",
+			Service: "my-service",
+			Result:  keptnv2.ResultPass,
+			Status:  keptnv2.StatusSucceeded,
+		},
+		KeptnContext: "my-context",
+	}
+	// securityscan is the last finished task of the parallel task group tests
+	previousTask := &models.TaskExecution{
+		TaskSequenceName: "delivery",
+		Task:             models.Task{Task: keptnv2.Task{Name: "securityscan"}, TaskIndex: 1, Group: "tests"},
+	}
+	eventHistory := []interface{}{
+		map[string]interface{}{"result": "pass", "securityscan": map[string]interface{}{"vulnerabilities": 0}},
+	}
+
+	err := sc.proceedTaskSequence(eventScope, &shipyard.Spec.Stages[0].Sequences[0], eventHistory, previousTask)
+	require.Nil(t, err)
+
+	// the selector refers to the result of the parallel task group, rather than to the result of one of its tasks
+	require.Len(t, insertedEvents, 1)
+	require.Equal(t, keptnv2.GetTriggeredEventType("production.delivery"), *insertedEvents[0].Type)
+}
+
+const testShipyardFileWithRetry = `apiVersion: spec.keptn.sh/0.2.2
+kind: Shipyard
+metadata:
+  name: test-shipyard
+spec:
+  stages:
+    - name: dev
+      sequences:
+        - name: artifact-delivery
+          tasks:
+            - name: test
+              retry:
+                maxAttempts: 2
+            - name: release`
+
+func Test_shipyardController_RetryTask(t *testing.T) {
+	tests := []struct {
+		name                 string
+		results              []keptnv2.ResultType
+		wantTriggeredEvents  []string
+		wantNextAttemptsSet  []bool
+		wantSequenceFinished bool
+	}{
+		{
+			name:                "second attempt succeeds",
+			results:             []keptnv2.ResultType{keptnv2.ResultFailed, keptnv2.ResultPass},
+			wantTriggeredEvents: []string{keptnv2.GetTriggeredEventType(keptnv2.TestTaskName), keptnv2.GetTriggeredEventType(keptnv2.TestTaskName), keptnv2.GetTriggeredEventType(keptnv2.ReleaseTaskName)},
+			wantNextAttemptsSet: []bool{true, false},
+		},
+		{
+			name:                 "all attempts fail",
+			results:              []keptnv2.ResultType{keptnv2.ResultFailed, keptnv2.ResultFailed},
+			wantTriggeredEvents:  []string{keptnv2.GetTriggeredEventType(keptnv2.TestTaskName), keptnv2.GetTriggeredEventType(keptnv2.TestTaskName), keptnv2.GetFinishedEventType("dev.artifact-delivery")},
+			wantNextAttemptsSet:  []bool{true, false},
+			wantSequenceFinished: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			embeddedDB, err := db.NewEmbeddedDB(filepath.Join(t.TempDir(), "shipyard-controller.db"))
+			require.Nil(t, err)
+			defer embeddedDB.Close()
+
+			eventDispatcher := &fake.IEventDispatcherMock{
+				AddFunc: func(event models.DispatcherEvent) error {
+					return nil
+				},
+			}
+			attemptHook := &fakehooks.ISequenceTaskAttemptFinishedHookMock{
+				OnSequenceTaskAttemptFinishedFunc: func(attempt models.TaskAttempt) {},
+			}
+			sc := &shipyardController{
+				eventRepo:        db.NewEmbeddedEventsRepo(embeddedDB),
+				taskSequenceRepo: db.NewEmbeddedTaskSequenceRepo(embeddedDB),
+				eventDispatcher:  eventDispatcher,
+				shipyardRetriever: &fake.IShipyardRetrieverMock{
+					GetCachedShipyardFunc: func(projectName string) (*keptnv2.Shipyard, error) {
+						return common.UnmarshalShipyard(testShipyardFileWithRetry)
+					},
+					GetCachedShipyardExtensionFunc: func(projectName string) (*models.ShipyardExtension, error) {
+						return models.UnmarshalShipyardExtension(testShipyardFileWithRetry)
+					},
+				},
+			}
+			sc.AddSequenceTaskAttemptFinishedHook(attemptHook)
+
+			sequenceTriggeredEvent := getArtifactDeliveryTriggeredEvent("dev")
+			require.Nil(t, sc.eventRepo.InsertEvent("test-project", sequenceTriggeredEvent, common.TriggeredEvent))
+			require.Nil(t, sc.StartTaskSequence(sequenceTriggeredEvent))
+
+			for i, result := range tt.results {
+				triggeredEvent := eventDispatcher.AddCalls()[i].Event.Event
+				require.Equal(t, keptnv2.GetTriggeredEventType(keptnv2.TestTaskName), triggeredEvent.Type())
+
+				startedEvent := getStartedEvent("dev", triggeredEvent.ID(), keptnv2.TestTaskName, "test-source")
+				require.Nil(t, sc.handleTaskStarted(startedEvent))
+
+				finishedEvent := getTestTaskFinishedEvent("dev", triggeredEvent.ID())
+				finishedEvent.ID = fmt.Sprintf("test-finished-id-%d", i)
+				finishedEvent.Data = keptnv2.EventData{Project: "test-project", Stage: "dev", Service: "carts", Status: keptnv2.StatusSucceeded, Result: result}
+				require.Nil(t, sc.handleTaskFinished(finishedEvent))
+			}
+
+			addCalls := eventDispatcher.AddCalls()
+			require.Len(t, addCalls, len(tt.wantTriggeredEvents))
+			for i, wantType := range tt.wantTriggeredEvents {
+				require.Equal(t, wantType, addCalls[i].Event.Event.Type())
+			}
+
+			// the second .triggered event of the test task contains the attempt counter
+			retryData := struct {
+				Retry models.TaskRetryEventData `json:"retry"`
+			}{}
+			require.Nil(t, addCalls[1].Event.Event.DataAs(&retryData))
+			require.Equal(t, models.TaskRetryEventData{Attempt: 2, MaxAttempts: 2}, retryData.Retry)
+
+			attempts := attemptHook.OnSequenceTaskAttemptFinishedCalls()
+			require.Len(t, attempts, len(tt.results))
+			for i := range attempts {
+				require.Equal(t, i+1, attempts[i].Attempt.Attempt)
+				require.Equal(t, 2, attempts[i].Attempt.MaxAttempts)
+				require.Equal(t, tt.wantNextAttemptsSet[i], attempts[i].Attempt.NextAttempt != nil)
+			}
+
+			taskExecutions, _ := sc.taskSequenceRepo.GetTaskExecutions("test-project", models.TaskExecution{KeptnContext: "test-context"})
+			if tt.wantSequenceFinished {
+				require.Empty(t, taskExecutions)
+			} else {
+				// the failed attempt must not be part of the data of the subsequent task
+				finishedEvents, err := sc.eventRepo.GetEvents("test-project", common.EventFilter{KeptnContext: common.Stringp("test-context")}, common.FinishedEvent)
+				require.Nil(t, err)
+				require.Len(t, finishedEvents, 1)
+				require.Equal(t, "test-finished-id-1", finishedEvents[0].ID)
+			}
+		})
+	}
+}
+
+const testShipyardFileForRestart = `apiVersion: spec.keptn.sh/0.2.2
+kind: Shipyard
+metadata:
+  name: test-shipyard
+spec:
+  stages:
+    - name: dev
+      sequences:
+        - name: artifact-delivery
+          tasks:
+            - name: deployment
+            - name: test
+            - name: release`
+
+func Test_shipyardController_RestartSequence(t *testing.T) {
+	tests := []struct {
+		name              string
+		keptnContext      string
+		fromTask          string
+		wantErr           error
+		wantTriggeredTask string
+		wantFromTaskIndex int
+	}{
+		{
+			name:              "restart from failed task",
+			keptnContext:      "test-context",
+			wantTriggeredTask: keptnv2.TestTaskName,
+			wantFromTaskIndex: 1,
+		},
+		{
+			name:              "restart from given task",
+			keptnContext:      "test-context",
+			fromTask:          keptnv2.DeploymentTaskName,
+			wantTriggeredTask: keptnv2.DeploymentTaskName,
+			wantFromTaskIndex: 0,
+		},
+		{
+			name:         "unknown task",
+			keptnContext: "test-context",
+			fromTask:     "unknown",
+			wantErr:      ErrTaskNotFound,
+		},
+		{
+			name:         "unknown sequence",
+			keptnContext: "unknown-context",
+			wantErr:      ErrSequenceNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			embeddedDB, err := db.NewEmbeddedDB(filepath.Join(t.TempDir(), "shipyard-controller.db"))
+			require.Nil(t, err)
+			defer embeddedDB.Close()
+
+			eventDispatcher := &fake.IEventDispatcherMock{
+				AddFunc: func(event models.DispatcherEvent) error {
+					return nil
+				},
+			}
+			sequenceDispatcher := &fake.ISequenceDispatcherMock{
+				AddFunc: func(queueItem models.QueueItem) error {
+					return nil
+				},
+			}
+			sc := &shipyardController{
+				eventRepo:          db.NewEmbeddedEventsRepo(embeddedDB),
+				taskSequenceRepo:   db.NewEmbeddedTaskSequenceRepo(embeddedDB),
+				eventDispatcher:    eventDispatcher,
+				sequenceDispatcher: sequenceDispatcher,
+				shipyardRetriever: &fake.IShipyardRetrieverMock{
+					GetCachedShipyardFunc: func(projectName string) (*keptnv2.Shipyard, error) {
+						return common.UnmarshalShipyard(testShipyardFileForRestart)
+					},
+					GetCachedShipyardExtensionFunc: func(projectName string) (*models.ShipyardExtension, error) {
+						return models.UnmarshalShipyardExtension(testShipyardFileForRestart)
+					},
+				},
+			}
+
+			// run the sequence until the test task fails
+			sequenceTriggeredEvent := getArtifactDeliveryTriggeredEvent("dev")
+			require.Nil(t, sc.eventRepo.InsertEvent("test-project", sequenceTriggeredEvent, common.TriggeredEvent))
+			require.Nil(t, sc.StartTaskSequence(sequenceTriggeredEvent))
+
+			deploymentTriggeredEvent := eventDispatcher.AddCalls()[0].Event.Event
+			require.Nil(t, sc.handleTaskStarted(getStartedEvent("dev", deploymentTriggeredEvent.ID(), keptnv2.DeploymentTaskName, "test-source")))
+			require.Nil(t, sc.handleTaskFinished(getDeploymentFinishedEvent("dev", deploymentTriggeredEvent.ID(), "test-source", keptnv2.ResultPass)))
+
+			testTriggeredEvent := eventDispatcher.AddCalls()[1].Event.Event
+			require.Nil(t, sc.handleTaskStarted(getStartedEvent("dev", testTriggeredEvent.ID(), keptnv2.TestTaskName, "test-source")))
+			testFinishedEvent := getTestTaskFinishedEvent("dev", testTriggeredEvent.ID())
+			testFinishedEvent.Data = keptnv2.EventData{Project: "test-project", Stage: "dev", Service: "carts", Status: keptnv2.StatusSucceeded, Result: keptnv2.ResultFailed}
+			require.Nil(t, sc.handleTaskFinished(testFinishedEvent))
+
+			require.Len(t, eventDispatcher.AddCalls(), 3)
+			require.Equal(t, keptnv2.GetFinishedEventType("dev.artifact-delivery"), eventDispatcher.AddCalls()[2].Event.Event.Type())
+
+			err = sc.ControlSequence(models.SequenceControl{
+				State:        models.RestartSequence,
+				KeptnContext: tt.keptnContext,
+				Stage:        "dev",
+				Project:      "test-project",
+				FromTask:     tt.fromTask,
+			})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.Len(t, eventDispatcher.AddCalls(), 3)
+				require.Empty(t, sequenceDispatcher.AddCalls())
+				return
+			}
+			require.Nil(t, err)
+
+			// the restarted sequence is queued instead of being triggered directly
+			require.Len(t, eventDispatcher.AddCalls(), 3)
+			require.Len(t, sequenceDispatcher.AddCalls(), 1)
+			queueItem := sequenceDispatcher.AddCalls()[0].QueueItem
+			require.Equal(t, sequenceTriggeredEvent.ID, queueItem.EventID)
+			require.Equal(t, "test-context", queueItem.Scope.KeptnContext)
+			require.Equal(t, "dev", queueItem.Scope.Stage)
+			require.Equal(t, &models.SequenceRestart{FromTaskIndex: tt.wantFromTaskIndex}, queueItem.Restart)
+
+			// the sequence can not be restarted while it is queued
+			err = sc.ControlSequence(models.SequenceControl{
+				State:        models.RestartSequence,
+				KeptnContext: tt.keptnContext,
+				Stage:        "dev",
+				Project:      "test-project",
+			})
+			require.ErrorIs(t, err, ErrSequenceStillRunning)
+
+			// once dispatched, the sequence continues with the restarted task
+			queuedEvents, err := sc.eventRepo.GetEvents("test-project", common.EventFilter{ID: &queueItem.EventID}, common.TriggeredEvent)
+			require.Nil(t, err)
+			require.Len(t, queuedEvents, 1)
+			require.Nil(t, sc.startTaskSequence(queuedEvents[0], queueItem.Restart))
+
+			addCalls := eventDispatcher.AddCalls()
+			require.Len(t, addCalls, 4)
+			restartedEvent := addCalls[3].Event.Event
+			require.Equal(t, keptnv2.GetTriggeredEventType(tt.wantTriggeredTask), restartedEvent.Type())
+			require.Equal(t, "test-context", restartedEvent.Extensions()["shkeptncontext"])
+
+			if tt.wantTriggeredTask == keptnv2.TestTaskName {
+				// the data of the tasks before the restarted task is passed on
+				deploymentData := keptnv2.DeploymentFinishedEventData{}
+				require.Nil(t, restartedEvent.DataAs(&deploymentData))
+				require.Equal(t, []string{"deployment-1"}, deploymentData.Deployment.DeploymentNames)
+			}
+
+			// the sequence can not be restarted while it is running
+			err = sc.ControlSequence(models.SequenceControl{
+				State:        models.RestartSequence,
+				KeptnContext: tt.keptnContext,
+				Stage:        "dev",
+				Project:      "test-project",
+			})
+			require.ErrorIs(t, err, ErrSequenceStillRunning)
+		})
+	}
+}
+
+func Test_shipyardController_StartTaskSequenceIgnoresFinishedEventsOfPreviousRuns(t *testing.T) {
+	embeddedDB, err := db.NewEmbeddedDB(filepath.Join(t.TempDir(), "shipyard-controller.db"))
+	require.Nil(t, err)
+	defer embeddedDB.Close()
+
+	eventDispatcher := &fake.IEventDispatcherMock{
+		AddFunc: func(event models.DispatcherEvent) error {
+			return nil
+		},
+	}
+	sc := &shipyardController{
+		eventRepo:        db.NewEmbeddedEventsRepo(embeddedDB),
+		taskSequenceRepo: db.NewEmbeddedTaskSequenceRepo(embeddedDB),
+		eventDispatcher:  eventDispatcher,
+		shipyardRetriever: &fake.IShipyardRetrieverMock{
+			GetCachedShipyardFunc: func(projectName string) (*keptnv2.Shipyard, error) {
+				return common.UnmarshalShipyard(testShipyardFileForRestart)
+			},
+			GetCachedShipyardExtensionFunc: func(projectName string) (*models.ShipyardExtension, error) {
+				return models.UnmarshalShipyardExtension(testShipyardFileForRestart)
+			},
+		},
+	}
+
+	// a previous run of a sequence in the same keptn context and stage has left a '.finished' event
+	staleFinishedEvent := getDeploymentFinishedEvent("dev", "previous-deployment-triggered-id", "test-source", keptnv2.ResultPass)
+	require.Nil(t, sc.eventRepo.InsertEvent("test-project", staleFinishedEvent, common.FinishedEvent))
+
+	sequenceTriggeredEvent := getArtifactDeliveryTriggeredEvent("dev")
+	require.Nil(t, sc.eventRepo.InsertEvent("test-project", sequenceTriggeredEvent, common.TriggeredEvent))
+	require.Nil(t, sc.StartTaskSequence(sequenceTriggeredEvent))
+
+	// a sequence that has not been restarted always starts with its first task
+	require.Len(t, eventDispatcher.AddCalls(), 1)
+	require.Equal(t, keptnv2.GetTriggeredEventType(keptnv2.DeploymentTaskName), eventDispatcher.AddCalls()[0].Event.Event.Type())
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/keptn/keptn/shipyard-controller/common"
 	"github.com/keptn/keptn/shipyard-controller/models"
 	log "github.com/sirupsen/logrus"
+	"sort"
 )
 
 func GetTaskSequenceInStage(stageName, taskSequenceName string, shipyard *keptnv2.Shipyard) (*keptnv2.Sequence, error) {
@@ -36,50 +37,133 @@ func GetTaskSequenceInStage(stageName, taskSequenceName string, shipyard *keptnv
 	return nil, fmt.Errorf("no stage with name %s", stageName)
 }
 
-func GetNextTaskOfSequence(taskSequence *keptnv2.Sequence, previousTask *models.TaskExecution, eventScope *models.EventScope, eventHistory []interface{}) *models.Task {
+// GetNextTaskOfSequence returns the task that should be executed after the given previous task, together with the tasks that have been skipped.
+// Tasks are skipped if their 'if' condition does not match the data of the sequence, or if a previous task has failed and 'alwaysRun' is not set.
+// If a condition cannot be evaluated, an error is returned
+func GetNextTaskOfSequence(taskSequence *keptnv2.Sequence, sequenceExtension *models.SequenceExtension, previousTask *models.TaskExecution, eventScope *models.EventScope, eventHistory []interface{}) (*models.Task, []models.SkippedTask, error) {
+	failed := false
 	if previousTask != nil {
 		for _, e := range eventHistory {
 			eventData := keptnv2.EventData{}
 			_ = keptnv2.Decode(e, &eventData)
 
-			// if one of the tasks has failed previously, no further task should be executed
+			// if one of the tasks has failed previously, only tasks with 'alwaysRun' should be executed
 			if eventData.Status == keptnv2.StatusErrored || eventData.Result == keptnv2.ResultFailed {
 				eventScope.Status = eventData.Status
 				eventScope.Result = eventData.Result
-				return nil
+				failed = true
+				break
 			}
 		}
 	}
 
 	if len(taskSequence.Tasks) == 0 {
 		log.Infof("Task sequence %s does not contain any tasks", taskSequence.Name)
-		return nil
+		return nil, nil, nil
 	}
-	if previousTask == nil {
-		log.Infof("Returning first task of task sequence %s", taskSequence.Name)
-		return &models.Task{
-			Task:      taskSequence.Tasks[0],
-			TaskIndex: 0,
+
+	nextIndex := 0
+	if previousTask != nil {
+		previousTaskName := previousTask.Task.GetSequenceTaskName()
+		log.Infof("Getting task that should be executed after task %s", previousTaskName)
+		nextIndex = previousTask.Task.TaskIndex + 1
+		if nextIndex > len(taskSequence.Tasks) || taskSequence.Tasks[nextIndex-1].Name != previousTaskName {
+			log.Info("No further tasks detected")
+			return nil, nil, nil
 		}
 	}
 
-	log.Infof("Getting task that should be executed after task %s", previousTask.Task.Name)
+	var skippedTasks []models.SkippedTask
+	var conditionData map[string]interface{}
+	for ; nextIndex < len(taskSequence.Tasks); nextIndex++ {
+		task := taskSequence.Tasks[nextIndex]
+		taskExtension := sequenceExtension.GetTask(nextIndex)
 
-	nextIndex := previousTask.Task.TaskIndex + 1
-	if len(taskSequence.Tasks) > nextIndex && taskSequence.Tasks[nextIndex-1].Name == previousTask.Task.Name {
-		log.Infof("found next task: %s", taskSequence.Tasks[nextIndex].Name)
-		return &models.Task{
-			Task:      taskSequence.Tasks[nextIndex],
-			TaskIndex: nextIndex,
+		if failed && !taskExtension.AlwaysRun {
+			skippedTasks = append(skippedTasks, models.SkippedTask{
+				Name:      task.Name,
+				TaskIndex: nextIndex,
+				Reason:    "a previous task of the sequence has failed",
+			})
+			continue
+		}
+
+		if taskExtension.If != "" {
+			if conditionData == nil {
+				conditionData = getConditionData(eventScope, eventHistory)
+			}
+			matches, err := common.EvaluateCondition(taskExtension.If, conditionData)
+			if err != nil {
+				// the task must neither be executed nor skipped silently, since it is unknown whether the condition is fulfilled
+				return nil, skippedTasks, fmt.Errorf("could not evaluate condition of task %s: %w", task.Name, err)
+			}
+			if !matches {
+				log.Infof("Skipping task %s of sequence %s", task.Name, taskSequence.Name)
+				skippedTasks = append(skippedTasks, models.SkippedTask{
+					Name:      task.Name,
+					TaskIndex: nextIndex,
+					Condition: taskExtension.If,
+					Reason:    "condition evaluated to false",
+				})
+				continue
+			}
 		}
+
+		log.Infof("found next task: %s", task.Name)
+		return &models.Task{
+			Task:          task,
+			TaskIndex:     nextIndex,
+			ParallelTasks: taskExtension.Parallel,
+		}, skippedTasks, nil
 	}
 
 	log.Info("No further tasks detected")
-	return nil
+	return nil, skippedTasks, nil
+}
+
+// aggregateTaskResults determines the overall result and status of a set of tasks: a failed or errored task determines the outcome of all tasks,
+// followed by tasks that finished with a warning
+func aggregateTaskResults(eventsData []keptnv2.EventData, result keptnv2.ResultType, status keptnv2.StatusType) (keptnv2.ResultType, keptnv2.StatusType) {
+	for _, eventData := range eventsData {
+		if eventData.Result == keptnv2.ResultFailed || (eventData.Result == keptnv2.ResultWarning && result != keptnv2.ResultFailed) || result == "" {
+			result = eventData.Result
+		}
+		if eventData.Status == keptnv2.StatusErrored || status == "" {
+			status = eventData.Status
+		}
+	}
+	return result, status
 }
 
-func GetTaskSequencesByTrigger(eventScope models.EventScope, completedTaskSequence string, shipyard *keptnv2.Shipyard, previousTask string) []NextTaskSequence {
+// getConditionData merges the data of all events of the sequence into a single object that is used to evaluate task conditions.
+// The 'result' and 'status' properties reflect the current state of the sequence
+func getConditionData(eventScope *models.EventScope, eventHistory []interface{}) map[string]interface{} {
+	conditionData := map[string]interface{}{}
+	for _, e := range eventHistory {
+		var eventData map[string]interface{}
+		marshal, err := json.Marshal(e)
+		if err != nil {
+			continue
+		}
+		if err := json.Unmarshal(marshal, &eventData); err != nil {
+			continue
+		}
+		common.Merge(conditionData, eventData)
+	}
+	if eventScope.Result != "" {
+		conditionData["result"] = string(eventScope.Result)
+	}
+	if eventScope.Status != "" {
+		conditionData["status"] = string(eventScope.Status)
+	}
+	return conditionData
+}
+
+// GetTaskSequencesByTrigger returns the sequences that should be triggered by the completion of the given sequence.
+// The selector of a trigger matches the properties of the events of the completed sequence, where the 'result' property, as well as '<previousTask>.result', refer to the result of the sequence
+func GetTaskSequencesByTrigger(eventScope models.EventScope, completedTaskSequence string, shipyard *keptnv2.Shipyard, previousTask string, eventHistory []interface{}) []NextTaskSequence {
 	var result []NextTaskSequence
+	var selectorData map[string]interface{}
 
 	for _, stage := range shipyard.Spec.Stages {
 		for tsIndex, taskSequence := range stage.Sequences {
@@ -87,17 +171,19 @@ func GetTaskSequencesByTrigger(eventScope models.EventScope, completedTaskSequen
 				if trigger.Event == eventScope.Stage+"."+completedTaskSequence+".finished" {
 					appendSequence := false
 					// default behavior if no selector is available: 'pass', as well as 'warning' results trigger this sequence
-					if trigger.Selector.Match == nil {
+					if len(trigger.Selector.Match) == 0 {
 						if eventScope.Result == keptnv2.ResultPass || eventScope.Result == keptnv2.ResultWarning {
 							appendSequence = true
 						}
 					} else {
-						// if a selector is there, compare the 'result' property
-						if string(eventScope.Result) == trigger.Selector.Match["result"] {
-							appendSequence = true
-						} else if string(eventScope.Result) == trigger.Selector.Match[previousTask+".result"] {
-							appendSequence = true
+						if selectorData == nil {
+							selectorData = getConditionData(&eventScope, eventHistory)
+						}
+						matches, err := common.MatchTriggerSelector(trigger.Selector.Match, string(eventScope.Result), previousTask, selectorData)
+						if err != nil {
+							log.Errorf("Could not evaluate selector of sequence %s.%s: %s", stage.Name, taskSequence.Name, err.Error())
 						}
+						appendSequence = matches
 					}
 					if appendSequence {
 						result = append(result, NextTaskSequence{
@@ -144,3 +230,143 @@ func ObjToJSON(obj interface{}) string {
 	}
 	return string(indent)
 }
+
+// sequenceRun contains the '.triggered' event of a sequence in a stage, together with the events of the tasks that have been executed afterwards in the same stage
+type sequenceRun struct {
+	stage          string
+	triggeredEvent models.Event
+	taskEvents     []models.Event
+}
+
+// getSequenceRuns assigns the task events of a keptn context to the sequence that has been triggered before them in the same stage.
+// The runs are returned in the order the sequences have been triggered
+func getSequenceRuns(events []models.Event) []*sequenceRun {
+	sortedEvents := make([]models.Event, len(events))
+	copy(sortedEvents, events)
+	sort.SliceStable(sortedEvents, func(i, j int) bool {
+		return sortedEvents[i].Time < sortedEvents[j].Time
+	})
+
+	runs := []*sequenceRun{}
+	currentRuns := map[string]*sequenceRun{}
+	for _, event := range sortedEvents {
+		if event.Type == nil {
+			continue
+		}
+		eventData := keptnv2.EventData{}
+		if err := keptnv2.Decode(event.Data, &eventData); err != nil {
+			continue
+		}
+		if keptnv2.IsSequenceEventType(*event.Type) {
+			if _, _, kind, err := keptnv2.ParseSequenceEventType(*event.Type); err == nil && kind == string(common.TriggeredEvent) {
+				run := &sequenceRun{stage: eventData.Stage, triggeredEvent: event}
+				currentRuns[eventData.Stage] = run
+				runs = append(runs, run)
+			}
+		} else if keptnv2.IsTaskEventType(*event.Type) {
+			if run, ok := currentRuns[eventData.Stage]; ok {
+				run.taskEvents = append(run.taskEvents, event)
+			}
+		}
+	}
+	return runs
+}
+
+// getSequenceRunToRestart returns the latest sequence run that contains a task that has not been successful. If there is no such run, the latest run is returned
+func getSequenceRunToRestart(runs []*sequenceRun) *sequenceRun {
+	for i := len(runs) - 1; i >= 0; i-- {
+		if runs[i].hasUnsuccessfulTask() {
+			return runs[i]
+		}
+	}
+	if len(runs) > 0 {
+		return runs[len(runs)-1]
+	}
+	return nil
+}
+
+// getLatestExecutionOfTask returns the '.finished' events of the latest execution of the given task. The second return value is false if the task has not been triggered
+func (r *sequenceRun) getLatestExecutionOfTask(taskName string) ([]models.Event, bool) {
+	var latestTriggeredEvent *models.Event
+	for index := range r.taskEvents {
+		if *r.taskEvents[index].Type == keptnv2.GetTriggeredEventType(taskName) {
+			latestTriggeredEvent = &r.taskEvents[index]
+		}
+	}
+	if latestTriggeredEvent == nil {
+		return nil, false
+	}
+	finishedEvents := []models.Event{}
+	for _, event := range r.taskEvents {
+		if *event.Type == keptnv2.GetFinishedEventType(taskName) && event.Triggeredid == latestTriggeredEvent.ID {
+			finishedEvents = append(finishedEvents, event)
+		}
+	}
+	return finishedEvents, true
+}
+
+// isTaskUnsuccessful returns true if the latest execution of the given task has not been finished, or has been finished with result 'fail' or status 'errored'
+func (r *sequenceRun) isTaskUnsuccessful(taskName string) bool {
+	finishedEvents, triggered := r.getLatestExecutionOfTask(taskName)
+	if !triggered {
+		return false
+	}
+	if len(finishedEvents) == 0 {
+		return true
+	}
+	for _, finishedEvent := range finishedEvents {
+		eventData := keptnv2.EventData{}
+		if err := keptnv2.Decode(finishedEvent.Data, &eventData); err == nil && (eventData.Result == keptnv2.ResultFailed || eventData.Status == keptnv2.StatusErrored) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *sequenceRun) hasUnsuccessfulTask() bool {
+	for _, event := range r.taskEvents {
+		taskName, kind, err := keptnv2.ParseTaskEventType(*event.Type)
+		if err == nil && kind == string(common.TriggeredEvent) && r.isTaskUnsuccessful(taskName) {
+			return true
+		}
+	}
+	return false
+}
+
+// getTaskNamesAtIndex returns the name of the task at the given index of the sequence, or the names of the tasks of a parallel task group
+func getTaskNamesAtIndex(taskSequence *keptnv2.Sequence, sequenceExtension *models.SequenceExtension, index int) []string {
+	taskExtension := sequenceExtension.GetTask(index)
+	if !taskExtension.IsParallelGroup() {
+		return []string{taskSequence.Tasks[index].Name}
+	}
+	taskNames := []string{}
+	for _, parallelTask := range taskExtension.Parallel {
+		taskNames = append(taskNames, parallelTask.Name)
+	}
+	return taskNames
+}
+
+// getRestartTaskIndex returns the index of the task a sequence run is restarted with. If fromTask is empty, this is the first task that has not been successful
+func getRestartTaskIndex(taskSequence *keptnv2.Sequence, sequenceExtension *models.SequenceExtension, run *sequenceRun, fromTask string) (int, error) {
+	if len(taskSequence.Tasks) == 0 {
+		return 0, fmt.Errorf("%w: sequence %s does not contain any tasks", ErrTaskNotFound, taskSequence.Name)
+	}
+	if fromTask != "" {
+		for index := range taskSequence.Tasks {
+			for _, taskName := range getTaskNamesAtIndex(taskSequence, sequenceExtension, index) {
+				if taskName == fromTask {
+					return index, nil
+				}
+			}
+		}
+		return 0, fmt.Errorf("%w: %s", ErrTaskNotFound, fromTask)
+	}
+	for index := range taskSequence.Tasks {
+		for _, taskName := range getTaskNamesAtIndex(taskSequence, sequenceExtension, index) {
+			if run.isTaskUnsuccessful(taskName) {
+				return index, nil
+			}
+		}
+	}
+	return 0, nil
+}
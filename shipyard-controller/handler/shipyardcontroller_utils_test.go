@@ -3,8 +3,10 @@ package handler
 import (
 	"github.com/go-test/deep"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/common"
 	"github.com/keptn/keptn/shipyard-controller/db"
 	"github.com/keptn/keptn/shipyard-controller/models"
+	"github.com/stretchr/testify/require"
 	"reflect"
 	"testing"
 )
@@ -142,6 +144,7 @@ func Test_GetTaskSequencesByTrigger(t *testing.T) {
 		completedTaskSequence string
 		shipyard              *keptnv2.Shipyard
 		previousTask          string
+		eventHistory          []interface{}
 	}
 	tests := []struct {
 		name string
@@ -445,9 +448,453 @@ func Test_GetTaskSequencesByTrigger(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := GetTaskSequencesByTrigger(tt.args.eventScope, tt.args.completedTaskSequence, tt.args.shipyard, tt.args.previousTask); !reflect.DeepEqual(got, tt.want) {
+			if got := GetTaskSequencesByTrigger(tt.args.eventScope, tt.args.completedTaskSequence, tt.args.shipyard, tt.args.previousTask, tt.args.eventHistory); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("GetTaskSequencesByTrigger() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func Test_GetTaskSequencesByTrigger_Selectors(t *testing.T) {
+	newSequence := func(name string, match map[string]string) keptnv2.Sequence {
+		return keptnv2.Sequence{
+			Name: name,
+			TriggeredOn: []keptnv2.Trigger{
+				{
+					Event:    "dev.delivery.finished",
+					Selector: keptnv2.Selector{Match: match},
+				},
+			},
+		}
+	}
+	shipyard := &keptnv2.Shipyard{
+		Spec: keptnv2.ShipyardSpec{
+			Stages: []keptnv2.Stage{
+				{
+					Name: "dev",
+					Sequences: []keptnv2.Sequence{
+						{Name: "delivery"},
+					},
+				},
+				{
+					Name: "production",
+					Sequences: []keptnv2.Sequence{
+						newSequence("promote-frontend", map[string]string{"service": "in(carts, orders)", "result": "warning"}),
+						newSequence("promote-on-pass", map[string]string{"service": "in(carts, orders)", "result": "pass"}),
+						newSequence("legacy-previous-task-result", map[string]string{"result": "pass", "evaluation.result": "warning"}),
+						newSequence("promote-blue-green", map[string]string{"deployment.deploymentstrategy": "=~ ^blue_green"}),
+						newSequence("promote-team-b", map[string]string{"labels.team": "b"}),
+					},
+				},
+				{
+					Name: "hardening",
+					Sequences: []keptnv2.Sequence{
+						newSequence("low-score", map[string]string{"evaluation.score": "< 90"}),
+						newSequence("evaluation-warning", map[string]string{"evaluation.result": "warning"}),
+						newSequence("evaluation-result-with-operator", map[string]string{"evaluation.result": "!= fail"}),
+						newSequence("invalid-selector", map[string]string{"evaluation.score": "< ninety"}),
+					},
+				},
+			},
+		},
+	}
+	eventScope := models.EventScope{
+		EventData: keptnv2.EventData{
+			Project: "my-project",
+			Stage:   "dev",
+			Service: "carts",
+			Result:  keptnv2.ResultWarning,
+		},
+	}
+	eventHistory := []interface{}{
+		map[string]interface{}{
+			"project":    "my-project",
+			"stage":      "dev",
+			"service":    "carts",
+			"labels":     map[string]interface{}{"team": "a"},
+			"deployment": map[string]interface{}{"deploymentstrategy": "blue_green_service"},
+		},
+		map[string]interface{}{
+			"result":     "pass",
+			"evaluation": map[string]interface{}{"score": 75, "result": "pass"},
+		},
+	}
+
+	got := GetTaskSequencesByTrigger(eventScope, "delivery", shipyard, "evaluation", eventHistory)
+
+	names := []string{}
+	for _, sequence := range got {
+		names = append(names, sequence.StageName+"."+sequence.Sequence.Name)
+	}
+	// 'result' and '<previousTask>.result' are compared literally with the result of the sequence, and one of them has to match
+	require.Equal(t, []string{"production.promote-frontend", "production.legacy-previous-task-result", "production.promote-blue-green", "hardening.low-score", "hardening.evaluation-warning"}, names)
+}
+
+func Test_GetNextTaskOfSequence(t *testing.T) {
+	sequence := &keptnv2.Sequence{
+		Name: "delivery",
+		Tasks: []keptnv2.Task{
+			{Name: "deployment"},
+			{Name: "test"},
+			{Name: "evaluation"},
+			{Name: "release"},
+			{Name: "notify"},
+		},
+	}
+	sequenceExtension := &models.SequenceExtension{
+		Name: "delivery",
+		Tasks: []models.TaskExtension{
+			{Name: "deployment"},
+			{Name: "test", If: `deployment.deploymentstrategy != "direct"`},
+			{Name: "evaluation"},
+			{Name: "release", If: `evaluation.result == "pass"`},
+			{Name: "notify", AlwaysRun: true},
+		},
+	}
+
+	type args struct {
+		sequenceExtension *models.SequenceExtension
+		previousTask      *models.TaskExecution
+		eventHistory      []interface{}
+	}
+	tests := []struct {
+		name              string
+		args              args
+		wantTask          string
+		wantParallelTasks []string
+		wantSkippedTasks  []models.SkippedTask
+		wantResult        keptnv2.ResultType
+		wantErr           bool
+	}{
+		{
+			name: "first task of the sequence",
+			args: args{
+				sequenceExtension: sequenceExtension,
+			},
+			wantTask: "deployment",
+		},
+		{
+			name: "condition of next task matches",
+			args: args{
+				sequenceExtension: sequenceExtension,
+				previousTask:      &models.TaskExecution{Task: models.Task{Task: keptnv2.Task{Name: "deployment"}, TaskIndex: 0}},
+				eventHistory: []interface{}{
+					map[string]interface{}{"result": "pass", "deployment": map[string]interface{}{"deploymentstrategy": "blue_green_service"}},
+				},
+			},
+			wantTask: "test",
+		},
+		{
+			name: "condition of next task does not match",
+			args: args{
+				sequenceExtension: sequenceExtension,
+				previousTask:      &models.TaskExecution{Task: models.Task{Task: keptnv2.Task{Name: "deployment"}, TaskIndex: 0}},
+				eventHistory: []interface{}{
+					map[string]interface{}{"result": "pass", "deployment": map[string]interface{}{"deploymentstrategy": "direct"}},
+				},
+			},
+			wantTask: "evaluation",
+			wantSkippedTasks: []models.SkippedTask{
+				{Name: "test", TaskIndex: 1, Condition: `deployment.deploymentstrategy != "direct"`, Reason: "condition evaluated to false"},
+			},
+		},
+		{
+			name: "condition that cannot be evaluated fails the sequence",
+			args: args{
+				sequenceExtension: &models.SequenceExtension{
+					Tasks: []models.TaskExtension{
+						{Name: "deployment"},
+						{Name: "test", If: `deployment.replicas > "many"`},
+					},
+				},
+				previousTask: &models.TaskExecution{Task: models.Task{Task: keptnv2.Task{Name: "deployment"}, TaskIndex: 0}},
+				eventHistory: []interface{}{
+					map[string]interface{}{"result": "pass", "deployment": map[string]interface{}{"replicas": "many"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed task - only tasks with alwaysRun are executed",
+			args: args{
+				sequenceExtension: sequenceExtension,
+				previousTask:      &models.TaskExecution{Task: models.Task{Task: keptnv2.Task{Name: "test"}, TaskIndex: 1}},
+				eventHistory: []interface{}{
+					map[string]interface{}{"result": "pass"},
+					map[string]interface{}{"result": "fail"},
+				},
+			},
+			wantTask: "notify",
+			wantSkippedTasks: []models.SkippedTask{
+				{Name: "evaluation", TaskIndex: 2, Reason: "a previous task of the sequence has failed"},
+				{Name: "release", TaskIndex: 3, Reason: "a previous task of the sequence has failed"},
+			},
+			wantResult: keptnv2.ResultFailed,
+		},
+		{
+			name: "failed task without extension - no further task",
+			args: args{
+				previousTask: &models.TaskExecution{Task: models.Task{Task: keptnv2.Task{Name: "test"}, TaskIndex: 1}},
+				eventHistory: []interface{}{
+					map[string]interface{}{"result": "fail"},
+				},
+			},
+			wantSkippedTasks: []models.SkippedTask{
+				{Name: "evaluation", TaskIndex: 2, Reason: "a previous task of the sequence has failed"},
+				{Name: "release", TaskIndex: 3, Reason: "a previous task of the sequence has failed"},
+				{Name: "notify", TaskIndex: 4, Reason: "a previous task of the sequence has failed"},
+			},
+			wantResult: keptnv2.ResultFailed,
+		},
+		{
+			name: "parallel task group",
+			args: args{
+				sequenceExtension: &models.SequenceExtension{
+					Tasks: []models.TaskExtension{
+						{Name: "deployment"},
+						{Name: "test", Parallel: []keptnv2.Task{{Name: "functional-test"}, {Name: "securityscan"}}},
+					},
+				},
+				previousTask: &models.TaskExecution{Task: models.Task{Task: keptnv2.Task{Name: "deployment"}, TaskIndex: 0}},
+				eventHistory: []interface{}{
+					map[string]interface{}{"result": "pass"},
+				},
+			},
+			wantTask:          "test",
+			wantParallelTasks: []string{"functional-test", "securityscan"},
+		},
+		{
+			name: "task after parallel task group",
+			args: args{
+				sequenceExtension: &models.SequenceExtension{
+					Tasks: []models.TaskExtension{
+						{Name: "deployment"},
+						{Name: "test", Parallel: []keptnv2.Task{{Name: "functional-test"}, {Name: "securityscan"}}},
+					},
+				},
+				previousTask: &models.TaskExecution{Task: models.Task{Task: keptnv2.Task{Name: "securityscan"}, TaskIndex: 1, Group: "test"}},
+				eventHistory: []interface{}{
+					map[string]interface{}{"result": "pass"},
+				},
+			},
+			wantTask: "evaluation",
+		},
+		{
+			name: "last task of the sequence",
+			args: args{
+				sequenceExtension: sequenceExtension,
+				previousTask:      &models.TaskExecution{Task: models.Task{Task: keptnv2.Task{Name: "notify"}, TaskIndex: 4}},
+				eventHistory: []interface{}{
+					map[string]interface{}{"result": "pass"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventScope := &models.EventScope{}
+			task, skippedTasks, err := GetNextTaskOfSequence(sequence, tt.args.sequenceExtension, tt.args.previousTask, eventScope, tt.args.eventHistory)
+			if tt.wantErr {
+				require.NotNil(t, err)
+			} else {
+				require.Nil(t, err)
+			}
+			if tt.wantTask == "" {
+				require.Nil(t, task)
+			} else {
+				require.NotNil(t, task)
+				require.Equal(t, tt.wantTask, task.Task.Name)
+				parallelTasks := []string{}
+				for _, parallelTask := range task.ParallelTasks {
+					parallelTasks = append(parallelTasks, parallelTask.Name)
+				}
+				if tt.wantParallelTasks == nil {
+					require.Empty(t, parallelTasks)
+				} else {
+					require.Equal(t, tt.wantParallelTasks, parallelTasks)
+				}
+			}
+			if tt.wantSkippedTasks != nil {
+				require.Equal(t, tt.wantSkippedTasks, skippedTasks)
+			}
+			require.Equal(t, tt.wantResult, eventScope.Result)
+		})
+	}
+}
+
+func Test_aggregateTaskResults(t *testing.T) {
+	tests := []struct {
+		name       string
+		eventsData []keptnv2.EventData
+		wantResult keptnv2.ResultType
+		wantStatus keptnv2.StatusType
+	}{
+		{
+			name: "all tasks passed",
+			eventsData: []keptnv2.EventData{
+				{Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded},
+				{Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded},
+			},
+			wantResult: keptnv2.ResultPass,
+			wantStatus: keptnv2.StatusSucceeded,
+		},
+		{
+			name: "warning overrides pass",
+			eventsData: []keptnv2.EventData{
+				{Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded},
+				{Result: keptnv2.ResultWarning, Status: keptnv2.StatusSucceeded},
+				{Result: keptnv2.ResultPass, Status: keptnv2.StatusSucceeded},
+			},
+			wantResult: keptnv2.ResultWarning,
+			wantStatus: keptnv2.StatusSucceeded,
+		},
+		{
+			name: "fail overrides warning",
+			eventsData: []keptnv2.EventData{
+				{Result: keptnv2.ResultFailed, Status: keptnv2.StatusErrored},
+				{Result: keptnv2.ResultWarning, Status: keptnv2.StatusSucceeded},
+			},
+			wantResult: keptnv2.ResultFailed,
+			wantStatus: keptnv2.StatusErrored,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, status := aggregateTaskResults(tt.eventsData, "", "")
+			require.Equal(t, tt.wantResult, result)
+			require.Equal(t, tt.wantStatus, status)
+		})
+	}
+}
+
+func newRestartTestEvent(id, eventType, triggeredID, time string, result keptnv2.ResultType) models.Event {
+	return models.Event{
+		ID:          id,
+		Type:        common.Stringp(eventType),
+		Triggeredid: triggeredID,
+		Time:        time,
+		Data: keptnv2.EventData{
+			Project: "my-project",
+			Stage:   "dev",
+			Service: "my-service",
+			Result:  result,
+			Status:  keptnv2.StatusSucceeded,
+		},
+	}
+}
+
+func Test_getSequenceRuns(t *testing.T) {
+	events := []models.Event{
+		newRestartTestEvent("test-finished-2", keptnv2.GetFinishedEventType("test"), "test-triggered-2", "2021-01-01T00:00:08.000Z", keptnv2.ResultPass),
+		newRestartTestEvent("sequence-triggered-1", keptnv2.GetTriggeredEventType("dev.delivery"), "", "2021-01-01T00:00:00.000Z", ""),
+		newRestartTestEvent("deployment-triggered-1", keptnv2.GetTriggeredEventType("deployment"), "", "2021-01-01T00:00:01.000Z", ""),
+		newRestartTestEvent("deployment-finished-1", keptnv2.GetFinishedEventType("deployment"), "deployment-triggered-1", "2021-01-01T00:00:02.000Z", keptnv2.ResultPass),
+		newRestartTestEvent("test-triggered-1", keptnv2.GetTriggeredEventType("test"), "", "2021-01-01T00:00:03.000Z", ""),
+		newRestartTestEvent("test-finished-1", keptnv2.GetFinishedEventType("test"), "test-triggered-1", "2021-01-01T00:00:04.000Z", keptnv2.ResultFailed),
+		newRestartTestEvent("sequence-finished-1", keptnv2.GetFinishedEventType("dev.delivery"), "sequence-triggered-1", "2021-01-01T00:00:05.000Z", keptnv2.ResultFailed),
+		newRestartTestEvent("sequence-triggered-2", keptnv2.GetTriggeredEventType("dev.delivery"), "", "2021-01-01T00:00:06.000Z", ""),
+		newRestartTestEvent("test-triggered-2", keptnv2.GetTriggeredEventType("test"), "", "2021-01-01T00:00:07.000Z", ""),
+	}
+
+	runs := getSequenceRuns(events)
+	require.Len(t, runs, 2)
+
+	require.Equal(t, "dev", runs[0].stage)
+	require.Equal(t, "sequence-triggered-1", runs[0].triggeredEvent.ID)
+	require.Len(t, runs[0].taskEvents, 4)
+	require.True(t, runs[0].hasUnsuccessfulTask())
+	require.True(t, runs[0].isTaskUnsuccessful("test"))
+	require.False(t, runs[0].isTaskUnsuccessful("deployment"))
+
+	require.Equal(t, "sequence-triggered-2", runs[1].triggeredEvent.ID)
+	require.Len(t, runs[1].taskEvents, 2)
+	require.False(t, runs[1].hasUnsuccessfulTask())
+
+	finishedEvents, triggered := runs[1].getLatestExecutionOfTask("test")
+	require.True(t, triggered)
+	require.Len(t, finishedEvents, 1)
+	require.Equal(t, "test-finished-2", finishedEvents[0].ID)
+
+	_, triggered = runs[1].getLatestExecutionOfTask("deployment")
+	require.False(t, triggered)
+
+	require.Equal(t, runs[0], getSequenceRunToRestart(runs))
+	require.Equal(t, runs[1], getSequenceRunToRestart(runs[1:]))
+	require.Nil(t, getSequenceRunToRestart(nil))
+}
+
+func Test_getRestartTaskIndex(t *testing.T) {
+	taskSequence := &keptnv2.Sequence{
+		Name: "delivery",
+		Tasks: []keptnv2.Task{
+			{Name: "deployment"},
+			{Name: "tests"},
+			{Name: "release"},
+		},
+	}
+	sequenceExtension := &models.SequenceExtension{
+		Name: "delivery",
+		Tasks: []models.TaskExtension{
+			{},
+			{Parallel: []keptnv2.Task{{Name: "test"}, {Name: "load-test"}}},
+		},
+	}
+	run := &sequenceRun{
+		stage: "dev",
+		taskEvents: []models.Event{
+			newRestartTestEvent("deployment-triggered", keptnv2.GetTriggeredEventType("deployment"), "", "2021-01-01T00:00:01.000Z", ""),
+			newRestartTestEvent("deployment-finished", keptnv2.GetFinishedEventType("deployment"), "deployment-triggered", "2021-01-01T00:00:02.000Z", keptnv2.ResultPass),
+			newRestartTestEvent("test-triggered", keptnv2.GetTriggeredEventType("test"), "", "2021-01-01T00:00:03.000Z", ""),
+			newRestartTestEvent("load-test-triggered", keptnv2.GetTriggeredEventType("load-test"), "", "2021-01-01T00:00:03.000Z", ""),
+			newRestartTestEvent("test-finished", keptnv2.GetFinishedEventType("test"), "test-triggered", "2021-01-01T00:00:04.000Z", keptnv2.ResultPass),
+			newRestartTestEvent("load-test-finished", keptnv2.GetFinishedEventType("load-test"), "load-test-triggered", "2021-01-01T00:00:04.000Z", keptnv2.ResultFailed),
+		},
+	}
+	tests := []struct {
+		name         string
+		taskSequence *keptnv2.Sequence
+		fromTask     string
+		want         int
+		wantErr      bool
+	}{
+		{
+			name:         "restart from first unsuccessful task",
+			taskSequence: taskSequence,
+			want:         1,
+		},
+		{
+			name:         "restart from given task",
+			taskSequence: taskSequence,
+			fromTask:     "release",
+			want:         2,
+		},
+		{
+			name:         "restart from task of parallel group",
+			taskSequence: taskSequence,
+			fromTask:     "test",
+			want:         1,
+		},
+		{
+			name:         "unknown task",
+			taskSequence: taskSequence,
+			fromTask:     "unknown",
+			wantErr:      true,
+		},
+		{
+			name:         "sequence without tasks",
+			taskSequence: &keptnv2.Sequence{Name: "empty"},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getRestartTaskIndex(tt.taskSequence, sequenceExtension, run, tt.fromTask)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrTaskNotFound)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
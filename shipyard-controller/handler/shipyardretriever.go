@@ -5,8 +5,8 @@ import (
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	"github.com/keptn/keptn/shipyard-controller/common"
 	"github.com/keptn/keptn/shipyard-controller/db"
+	"github.com/keptn/keptn/shipyard-controller/models"
 	log "github.com/sirupsen/logrus"
-	"gopkg.in/yaml.v3"
 )
 
 // IShipyardRetriever godoc
@@ -14,6 +14,7 @@ import (
 type IShipyardRetriever interface {
 	GetShipyard(projectName string) (*keptnv2.Shipyard, error)
 	GetCachedShipyard(projectName string) (*keptnv2.Shipyard, error)
+	GetCachedShipyardExtension(projectName string) (*models.ShipyardExtension, error)
 }
 
 type ShipyardRetriever struct {
@@ -39,13 +40,8 @@ func (sr *ShipyardRetriever) GetShipyard(projectName string) (*keptnv2.Shipyard,
 		return nil, fmt.Errorf("could not unmarshal shipyard.yaml of project %s: %w", projectName, err)
 	}
 
-	// update the shipyard content of the project
-	shipyardContent, err := yaml.Marshal(shipyard)
-	if err != nil {
-		// log the error but continue
-		log.Errorf("could not encode shipyard file of project %s: %v", projectName, err)
-	}
-	if err := sr.projectRepo.UpdateShipyard(projectName, string(shipyardContent)); err != nil {
+	// update the shipyard content of the project. The original content is stored, since it may contain properties that are not part of the keptnv2.Shipyard type
+	if err := sr.projectRepo.UpdateShipyard(projectName, resource.ResourceContent); err != nil {
 		// log the error but continue
 		log.Errorf("could not update shipyard content of project %s: %v", projectName, err)
 	}
@@ -73,3 +69,13 @@ func (sr *ShipyardRetriever) GetCachedShipyard(projectName string) (*keptnv2.Shi
 	}
 	return shipyard, nil
 }
+
+// GetCachedShipyardExtension returns the shipyard-controller specific properties of the shipyard that is stored for the project in the materialized view
+func (sr *ShipyardRetriever) GetCachedShipyardExtension(projectName string) (*models.ShipyardExtension, error) {
+	project, err := sr.projectRepo.GetProject(projectName)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.UnmarshalShipyardExtension(project.Shipyard)
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/keptn/keptn/shipyard-controller/db"
 	"github.com/keptn/keptn/shipyard-controller/models"
@@ -64,8 +65,8 @@ func (sh *StateHandler) GetSequenceState(c *gin.Context) {
 }
 
 // ControlSequenceState godoc
-// @Summary Pause/Resume/Abort a task sequence
-// @Description Pause/Resume/Abort a task sequence, either for a specific stage, or for all stages involved in the sequence
+// @Summary Pause/Resume/Abort/Restart a task sequence
+// @Description Pause/Resume/Abort a task sequence, either for a specific stage, or for all stages involved in the sequence. A finished sequence can be restarted in a stage, either from the task provided in fromTask, or from the first task that did not succeed
 // @Tags Sequence
 // @Security ApiKeyAuth
 // @Accept  json
@@ -75,6 +76,8 @@ func (sh *StateHandler) GetSequenceState(c *gin.Context) {
 // @Param   sequenceControl     body    models.SequenceControlCommand true "Sequence Control Command"
 // @Success 200 {object} models.SequenceControlResponse	"ok"
 // @Failure 400 {object} models.Error "Invalid payload"
+// @Failure 404 {object} models.Error "Sequence not found"
+// @Failure 409 {object} models.Error "Sequence is still running"
 // @Failure 500 {object} models.Error "Internal error"
 // @Router /sequence/{project}/{keptnContext}/control [post]
 func (sh *StateHandler) ControlSequenceState(c *gin.Context) {
@@ -92,12 +95,19 @@ func (sh *StateHandler) ControlSequenceState(c *gin.Context) {
 		KeptnContext: keptnContext,
 		Stage:        params.Stage,
 		Project:      project,
+		FromTask:     params.FromTask,
 	})
 	if err != nil {
-		if err == ErrSequenceNotFound {
+		switch {
+		case errors.Is(err, ErrSequenceNotFound):
 			SetNotFoundErrorResponse(err, c, "Could not control sequence")
+		case errors.Is(err, ErrSequenceStillRunning):
+			SetConflictErrorResponse(err, c, "Could not restart sequence")
+		case errors.Is(err, ErrTaskNotFound):
+			SetBadRequestErrorResponse(err, c, "Could not restart sequence")
+		default:
+			SetInternalServerErrorResponse(err, c, "Unable to control sequence")
 		}
-		SetInternalServerErrorResponse(err, c, "Unable to control sequence")
 		return
 	}
 
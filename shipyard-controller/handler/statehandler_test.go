@@ -2,14 +2,17 @@ package handler_test
 
 import (
 	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/keptn/go-utils/pkg/common/timeutils"
 	db_mock "github.com/keptn/keptn/shipyard-controller/db/mock"
 	"github.com/keptn/keptn/shipyard-controller/handler"
+	"github.com/keptn/keptn/shipyard-controller/handler/fake"
 	"github.com/keptn/keptn/shipyard-controller/models"
 	"github.com/stretchr/testify/require"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -87,6 +90,93 @@ func TestStateHandler_GetState(t *testing.T) {
 	}
 }
 
+func TestStateHandler_ControlSequenceState(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		controlErr   error
+		wantStatus   int
+		wantCalled   bool
+		wantFromTask string
+		wantState    models.SequenceControlState
+	}{
+		{
+			name:         "restart sequence from task",
+			body:         `{"state":"restart","stage":"dev","fromTask":"deployment"}`,
+			wantStatus:   http.StatusOK,
+			wantCalled:   true,
+			wantFromTask: "deployment",
+			wantState:    models.RestartSequence,
+		},
+		{
+			name:       "sequence not found",
+			body:       `{"state":"restart","stage":"dev"}`,
+			controlErr: handler.ErrSequenceNotFound,
+			wantStatus: http.StatusNotFound,
+			wantCalled: true,
+			wantState:  models.RestartSequence,
+		},
+		{
+			name:       "sequence still running",
+			body:       `{"state":"restart","stage":"dev"}`,
+			controlErr: handler.ErrSequenceStillRunning,
+			wantStatus: http.StatusConflict,
+			wantCalled: true,
+			wantState:  models.RestartSequence,
+		},
+		{
+			name:         "task not found",
+			body:         `{"state":"restart","stage":"dev","fromTask":"unknown"}`,
+			controlErr:   fmt.Errorf("%w: unknown", handler.ErrTaskNotFound),
+			wantStatus:   http.StatusBadRequest,
+			wantCalled:   true,
+			wantFromTask: "unknown",
+			wantState:    models.RestartSequence,
+		},
+		{
+			name:       "unexpected error",
+			body:       `{"state":"abort"}`,
+			controlErr: errors.New("oops"),
+			wantStatus: http.StatusInternalServerError,
+			wantCalled: true,
+			wantState:  models.AbortSequence,
+		},
+		{
+			name:       "invalid payload",
+			body:       `{"stage":"dev"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shipyardController := &fake.IShipyardControllerMock{
+				ControlSequenceFunc: func(controlSequence models.SequenceControl) error {
+					return tt.controlErr
+				},
+			}
+			sh := handler.NewStateHandler(nil, shipyardController)
+
+			router := gin.Default()
+			router.POST("/sequence/:project/:keptnContext/control", func(c *gin.Context) {
+				sh.ControlSequenceState(c)
+			})
+			w := performRequest(router, httptest.NewRequest("POST", "/sequence/my-project/my-context/control", strings.NewReader(tt.body)))
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			if !tt.wantCalled {
+				require.Empty(t, shipyardController.ControlSequenceCalls())
+				return
+			}
+			require.Len(t, shipyardController.ControlSequenceCalls(), 1)
+			controlSequence := shipyardController.ControlSequenceCalls()[0].ControlSequence
+			require.Equal(t, "my-project", controlSequence.Project)
+			require.Equal(t, "my-context", controlSequence.KeptnContext)
+			require.Equal(t, tt.wantState, controlSequence.State)
+			require.Equal(t, tt.wantFromTask, controlSequence.FromTask)
+		})
+	}
+}
+
 func performRequest(r http.Handler, request *http.Request) *httptest.ResponseRecorder {
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, request)
@@ -14,7 +14,6 @@ import (
 	keptnmodels "github.com/keptn/go-utils/pkg/api/models"
 	"github.com/keptn/keptn/shipyard-controller/db"
 	"github.com/keptn/keptn/shipyard-controller/models"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type IUniformIntegrationHandler interface {
@@ -259,7 +258,7 @@ func (rh *UniformIntegrationHandler) CreateSubscription(c *gin.Context) {
 
 	err := rh.uniformRepo.CreateOrUpdateSubscription(integrationID, *subscription)
 	if err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
+		if errors.Is(err, db.ErrUniformRegistrationNotFound) || errors.Is(err, db.ErrUniformSubscriptionNotFound) {
 			SetNotFoundErrorResponse(err, c)
 			return
 		}
@@ -359,7 +358,7 @@ func (rh *UniformIntegrationHandler) GetSubscription(c *gin.Context) {
 
 	subscription, err := rh.uniformRepo.GetSubscription(integrationID, subscriptionID)
 	if err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
+		if errors.Is(err, db.ErrUniformRegistrationNotFound) || errors.Is(err, db.ErrUniformSubscriptionNotFound) {
 			SetNotFoundErrorResponse(err, c)
 			return
 		}
@@ -388,7 +387,7 @@ func (rh *UniformIntegrationHandler) GetSubscriptions(c *gin.Context) {
 
 	subscriptions, err := rh.uniformRepo.GetSubscriptions(integrationID)
 	if err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
+		if errors.Is(err, db.ErrUniformRegistrationNotFound) || errors.Is(err, db.ErrUniformSubscriptionNotFound) {
 			SetNotFoundErrorResponse(err, c)
 			return
 		}
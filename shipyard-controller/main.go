@@ -13,6 +13,7 @@ import (
 
 	"github.com/benbjohnson/clock"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/keptn/go-utils/pkg/common/osutils"
 	keptncommon "github.com/keptn/go-utils/pkg/lib/keptn"
 	"github.com/keptn/go-utils/pkg/lib/v0_2_0"
@@ -52,11 +53,27 @@ const envVarTaskStartedWaitDuration = "TASK_STARTED_WAIT_DURATION"
 const envVarUniformIntegrationTTL = "UNIFORM_INTEGRATION_TTL"
 const envVarLogTTL = "LOG_TTL"
 const envVarLogLevel = "LOG_LEVEL"
+const envVarPodName = "POD_NAME"
+const envVarLeaseDuration = "LEASE_DURATION"
+const envVarLockRetryInterval = "LOCK_RETRY_INTERVAL"
+const envVarLockTimeout = "LOCK_TIMEOUT"
+const envVarScheduleSyncInterval = "SCHEDULE_SYNC_INTERVAL"
+const envVarDatabaseBackend = "DATABASE_BACKEND"
+const envVarEmbeddedDatabasePath = "EMBEDDED_DATABASE_PATH"
 const envVarEventDispatchIntervalSecDefault = "10"
 const envVarSequenceDispatchIntervalSecDefault = "10s"
 const envVarLogsTTLDefault = "120h" // 5 days
 const envVarUniformTTLDefault = "1m"
 const envVarTaskStartedWaitDurationDefault = "10m"
+const envVarLeaseDurationDefault = "15s"
+const envVarLockRetryIntervalDefault = "200ms"
+const envVarLockTimeoutDefault = "1m"
+const envVarScheduleSyncIntervalDefault = "30s"
+const envVarDatabaseBackendDefault = "mongodb"
+const envVarEmbeddedDatabasePathDefault = "/data/shipyard-controller.db"
+
+// repositoryFactory creates the repositories of the storage backend that has been selected via the DATABASE_BACKEND env var
+var repositoryFactory db.RepositoryFactory
 
 func main() {
 	log.SetLevel(log.InfoLevel)
@@ -76,6 +93,14 @@ func main() {
 		gin.DefaultWriter = ioutil.Discard
 	}
 
+	storageBackend := db.StorageBackend(osutils.GetOSEnvOrDefault(envVarDatabaseBackend, envVarDatabaseBackendDefault))
+	factory, err := db.NewRepositoryFactory(storageBackend, osutils.GetOSEnvOrDefault(envVarEmbeddedDatabasePath, envVarEmbeddedDatabasePathDefault))
+	if err != nil {
+		log.Fatalf("could not initialize storage backend %s: %s", storageBackend, err.Error())
+	}
+	repositoryFactory = factory
+	log.Infof("Using storage backend %s", storageBackend)
+
 	eventDispatcherSyncInterval, err := strconv.Atoi(osutils.GetOSEnvOrDefault(envVarEventDispatchIntervalSec, envVarEventDispatchIntervalSecDefault))
 	if err != nil {
 		log.Fatalf("Unexpected value of EVENT_DISPATCH_INTERVAL_SEC environment variable. Need to be a number")
@@ -96,6 +121,22 @@ func main() {
 		log.Fatal(err)
 	}
 
+	// locks and the leadership are held via leases in the database, which allows running multiple replicas of the shipyard-controller
+	instanceID := getInstanceID()
+	leaseDuration := getDurationFromEnvVar(envVarLeaseDuration, envVarLeaseDurationDefault)
+	common.SetLocker(handler.NewLeaseLocker(
+		createLeaseRepo(),
+		instanceID,
+		leaseDuration,
+		getDurationFromEnvVar(envVarLockRetryInterval, envVarLockRetryIntervalDefault),
+		getDurationFromEnvVar(envVarLockTimeout, envVarLockTimeoutDefault),
+		clock.New(),
+		func(key string) {
+			// the context of the holder has been cancelled, and the lock can be acquired again once it has been released by the holder
+			log.Errorf("lost the lease of lock %s", key)
+		},
+	))
+
 	projectMVRepo := createProjectMVRepo()
 	projectManager := handler.NewProjectManager(
 		common.NewGitConfigurationStore(csEndpoint.String()),
@@ -121,27 +162,31 @@ func main() {
 	stageManager := handler.NewStageManager(projectMVRepo)
 
 	eventDispatcher := handler.NewEventDispatcher(createEventsRepo(), createEventQueueRepo(), createTaskSequenceRepo(), eventSender, time.Duration(eventDispatcherSyncInterval)*time.Second)
+	shipyardRetriever := handler.NewShipyardRetriever(
+		common.NewGitConfigurationStore(csEndpoint.String()),
+		projectMVRepo,
+	)
+
 	sequenceDispatcher := handler.NewSequenceDispatcher(
 		createEventsRepo(),
 		createEventQueueRepo(),
 		createSequenceQueueRepo(),
 		createTaskSequenceRepo(),
+		shipyardRetriever,
 		getDurationFromEnvVar(envVarSequenceDispatchIntervalSec, envVarSequenceDispatchIntervalSecDefault),
 		clock.New(),
 	)
 
 	sequenceTimeoutChannel := make(chan models.SequenceTimeout)
-
-	shipyardRetriever := handler.NewShipyardRetriever(
-		common.NewGitConfigurationStore(csEndpoint.String()),
-		projectMVRepo,
-	)
 	shipyardController := handler.GetShipyardControllerInstance(
 		context.Background(),
 		eventDispatcher,
 		sequenceDispatcher,
 		sequenceTimeoutChannel,
 		shipyardRetriever,
+		createEventsRepo(),
+		createTaskSequenceRepo(),
+		projectMVRepo,
 	)
 
 	engine := gin.Default()
@@ -176,10 +221,22 @@ func main() {
 	evaluationController := controller.NewEvaluationController(evaluationHandler)
 	evaluationController.Inject(apiV1)
 
-	stateHandler := handler.NewStateHandler(db.NewMongoDBStateRepo(db.GetMongoDBConnectionInstance()), shipyardController)
+	stateHandler := handler.NewStateHandler(createStateRepo(), shipyardController)
 	stateController := controller.NewStateController(stateHandler)
 	stateController.Inject(apiV1)
 
+	scheduler := handler.NewScheduler(
+		createScheduleRepo(),
+		projectMVRepo,
+		shipyardRetriever,
+		eventSender,
+		getDurationFromEnvVar(envVarScheduleSyncInterval, envVarScheduleSyncIntervalDefault),
+		clock.New(),
+	)
+	scheduleHandler := handler.NewScheduleHandler(scheduler)
+	scheduleController := controller.NewScheduleController(scheduleHandler)
+	scheduleController.Inject(apiV1)
+
 	sequenceStateMaterializedView := sequencehooks.NewSequenceStateMaterializedView(createStateRepo())
 	shipyardController.AddSequenceTriggeredHook(sequenceStateMaterializedView)
 	shipyardController.AddSequenceStartedHook(sequenceStateMaterializedView)
@@ -189,6 +246,8 @@ func main() {
 	shipyardController.AddSequenceTaskStartedHook(projectMVRepo)
 	shipyardController.AddSequenceTaskFinishedHook(sequenceStateMaterializedView)
 	shipyardController.AddSequenceTaskFinishedHook(projectMVRepo)
+	shipyardController.AddSequenceTaskSkippedHook(sequenceStateMaterializedView)
+	shipyardController.AddSequenceTaskAttemptFinishedHook(sequenceStateMaterializedView)
 	shipyardController.AddSubSequenceFinishedHook(sequenceStateMaterializedView)
 	shipyardController.AddSequenceFinishedHook(sequenceStateMaterializedView)
 	shipyardController.AddSequenceTimeoutHook(sequenceStateMaterializedView)
@@ -206,12 +265,31 @@ func main() {
 		createEventsRepo(),
 		createEventQueueRepo(),
 		createProjectRepo(),
+		createTaskSequenceRepo(),
+		shipyardRetriever,
 		taskStartedWaitDuration,
 		1*time.Minute,
 		clock.New(),
 	)
 
-	watcher.Run(context.Background())
+	leaderElectionCtx, stopLeaderElection := context.WithCancel(context.Background())
+	leaderElector := handler.NewLeaderElector(
+		createLeaseRepo(),
+		handler.LeaderElectionLeaseName,
+		instanceID,
+		leaseDuration,
+		leaseDuration/3,
+		clock.New(),
+	)
+	// only the leading instance runs the dispatcher loops, the sequence watcher and the scheduler. They are started with the context of the
+	// leadership, which is cancelled as soon as the leadership is lost, so that they are not run by two instances at the same time
+	leaderElector.Run(leaderElectionCtx, func(ctx context.Context) {
+		shipyardController.StartDispatchers(ctx)
+		watcher.Run(ctx)
+		scheduler.Run(ctx)
+	}, func() {
+		log.Warn("lost the leadership. Stopped the dispatcher loops, the sequence watcher and the scheduler until the leadership is acquired again")
+	})
 
 	uniformHandler := handler.NewUniformIntegrationHandler(uniformRepo)
 	uniformController := controller.NewUniformIntegrationController(uniformHandler)
@@ -226,13 +304,16 @@ func main() {
 	logController := controller.NewLogController(logHandler)
 	logController.Inject(apiV1)
 
-	log.Info("Migrating project key format")
-	projectsMigrator := migration.NewProjectMVMigrator(db.GetMongoDBConnectionInstance())
-	err = projectsMigrator.MigrateKeys()
-	if err != nil {
-		log.Errorf("Unable to run projects migrator: %v", err)
+	if storageBackend == db.MongoDBBackend {
+		// the keys of projects only need to be encoded in the MongoDB backend
+		log.Info("Migrating project key format")
+		projectsMigrator := migration.NewProjectMVMigrator(db.GetMongoDBConnectionInstance())
+		err = projectsMigrator.MigrateKeys()
+		if err != nil {
+			log.Errorf("Unable to run projects migrator: %v", err)
+		}
+		log.Info("Finished migrating project key format")
 	}
-	log.Info("Finished migrating project key format")
 
 	healthHandler := handler.NewHealthHandler()
 	healthController := controller.NewHealthController(healthHandler)
@@ -253,7 +334,7 @@ func main() {
 	}()
 
 	GracefulShutdown(wg, srv)
-
+	stopLeaderElection()
 }
 
 func GracefulShutdown(wg *sync.WaitGroup, srv *http.Server) {
@@ -275,43 +356,60 @@ func GracefulShutdown(wg *sync.WaitGroup, srv *http.Server) {
 }
 
 func createProjectMVRepo() *db.MongoDBProjectMVRepo {
-	return db.NewProjectMVRepo(db.NewMongoDBKeyEncodingProjectsRepo(db.GetMongoDBConnectionInstance()), db.NewMongoDBEventsRepo(db.GetMongoDBConnectionInstance()))
+	return db.NewProjectMVRepo(createProjectRepo(), createEventsRepo())
 }
 
-func createUniformRepo() *db.MongoDBUniformRepo {
-	return db.NewMongoDBUniformRepo(db.GetMongoDBConnectionInstance())
+func createUniformRepo() db.UniformRepo {
+	return repositoryFactory.CreateUniformRepo()
 }
 
-func createStateRepo() *db.MongoDBStateRepo {
-	return db.NewMongoDBStateRepo(db.GetMongoDBConnectionInstance())
+func createStateRepo() db.SequenceStateRepo {
+	return repositoryFactory.CreateSequenceStateRepo()
 }
 
-func createProjectRepo() *db.MongoDBKeyEncodingProjectsRepo {
-	return db.NewMongoDBKeyEncodingProjectsRepo(db.GetMongoDBConnectionInstance())
+func createProjectRepo() db.ProjectRepo {
+	return repositoryFactory.CreateProjectRepo()
 }
 
-func createEventsRepo() *db.MongoDBEventsRepo {
-	return db.NewMongoDBEventsRepo(db.GetMongoDBConnectionInstance())
+func createEventsRepo() db.EventRepo {
+	return repositoryFactory.CreateEventRepo()
 }
 
-func createSequenceQueueRepo() *db.MongoDBSequenceQueueRepo {
-	return db.NewMongoDBSequenceQueueRepo(db.GetMongoDBConnectionInstance())
+func createSequenceQueueRepo() db.SequenceQueueRepo {
+	return repositoryFactory.CreateSequenceQueueRepo()
 }
 
-func createEventQueueRepo() *db.MongoDBEventQueueRepo {
-	return db.NewMongoDBEventQueueRepo(db.GetMongoDBConnectionInstance())
+func createEventQueueRepo() db.EventQueueRepo {
+	return repositoryFactory.CreateEventQueueRepo()
 }
 
-func createTaskSequenceRepo() *db.TaskSequenceMongoDBRepo {
-	return db.NewTaskSequenceMongoDBRepo(db.GetMongoDBConnectionInstance())
+func createTaskSequenceRepo() db.TaskSequenceRepo {
+	return repositoryFactory.CreateTaskSequenceRepo()
 }
 
 func createSecretStore(kubeAPI *kubernetes.Clientset) *common.K8sSecretStore {
 	return common.NewK8sSecretStore(kubeAPI)
 }
 
-func createLogRepo() *db.MongoDBLogRepo {
-	return db.NewMongoDBLogRepo(db.GetMongoDBConnectionInstance())
+func createLeaseRepo() db.LeaseRepo {
+	return repositoryFactory.CreateLeaseRepo()
+}
+
+func createScheduleRepo() db.ScheduleRepo {
+	return repositoryFactory.CreateScheduleRepo()
+}
+
+// getInstanceID returns an identifier that is unique for each replica of the shipyard-controller
+func getInstanceID() string {
+	podName := os.Getenv(envVarPodName)
+	if podName == "" {
+		podName, _ = os.Hostname()
+	}
+	return podName + "-" + uuid.New().String()
+}
+
+func createLogRepo() db.LogRepo {
+	return repositoryFactory.CreateLogRepo()
 }
 
 // GetKubeAPI godoc
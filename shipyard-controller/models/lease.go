@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// Lease is a time limited, exclusive claim of a named resource by one instance of the shipyard-controller
+type Lease struct {
+	Name      string    `json:"name" bson:"_id"`
+	Holder    string    `json:"holder" bson:"holder"`
+	ExpiresAt time.Time `json:"expiresAt" bson:"expiresAt"`
+}
@@ -105,6 +105,14 @@ func (createProjectParams *CreateProjectParams) Validate() error {
 		return fmt.Errorf("provided shipyard file is not valid: %s", err.Error())
 	}
 
+	if err := common.ValidateTriggerSelectors(shipyard); err != nil {
+		return fmt.Errorf("provided shipyard file is not valid: %s", err.Error())
+	}
+
+	if err := validateShipyardExtension(decodeString); err != nil {
+		return fmt.Errorf("provided shipyard file is not valid: %s", err.Error())
+	}
+
 	if err := common.ValidateGitRemoteURL(createProjectParams.GitRemoteURL); err != nil {
 		return fmt.Errorf("provided gitRemoteURL is not valid: %s", err.Error())
 	}
@@ -140,6 +148,14 @@ func (updateProjectParams *UpdateProjectParams) Validate() error {
 		if err := common.ValidateShipyardStages(shipyard); err != nil {
 			return fmt.Errorf("provided shipyard file is not valid: %s", err.Error())
 		}
+
+		if err := common.ValidateTriggerSelectors(shipyard); err != nil {
+			return fmt.Errorf("provided shipyard file is not valid: %s", err.Error())
+		}
+
+		if err := validateShipyardExtension(decodeString); err != nil {
+			return fmt.Errorf("provided shipyard file is not valid: %s", err.Error())
+		}
 	}
 
 	if err := common.ValidateGitRemoteURL(updateProjectParams.GitRemoteURL); err != nil {
@@ -148,3 +164,11 @@ func (updateProjectParams *UpdateProjectParams) Validate() error {
 
 	return nil
 }
+
+func validateShipyardExtension(shipyardContent []byte) error {
+	extension, err := UnmarshalShipyardExtension(string(shipyardContent))
+	if err != nil {
+		return err
+	}
+	return extension.Validate()
+}
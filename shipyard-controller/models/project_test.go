@@ -0,0 +1,63 @@
+package models
+
+import (
+	"encoding/base64"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestUpdateProjectParams_ValidateTriggerSelectors(t *testing.T) {
+	shipyardWithSelector := func(selector string) *string {
+		shipyard := `apiVersion: "spec.keptn.sh/0.2.2"
+kind: "Shipyard"
+metadata:
+  name: "shipyard-sockshop"
+spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "deployment"
+    - name: "production"
+      sequences:
+        - name: "delivery"
+          triggeredOn:
+            - event: "dev.delivery.finished"
+              selector:
+                match:
+                  ` + selector + `
+          tasks:
+            - name: "deployment"`
+		encoded := base64.StdEncoding.EncodeToString([]byte(shipyard))
+		return &encoded
+	}
+	projectName := "my-project"
+
+	tests := []struct {
+		name     string
+		selector string
+		wantErr  bool
+	}{
+		{name: "legacy selector", selector: `delivery.result: "pass"`},
+		{name: "valid selector", selector: `service: "in(carts, orders)"`},
+		{name: "valid numeric selector", selector: `evaluation.score: ">= 90"`},
+		{name: "invalid numeric selector", selector: `evaluation.score: ">= ninety"`, wantErr: true},
+		{name: "invalid regex", selector: `deployment.deploymentstrategy: "=~ (blue"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &UpdateProjectParams{
+				Name:     &projectName,
+				Shipyard: shipyardWithSelector(tt.selector),
+			}
+			err := params.Validate()
+			if tt.wantErr {
+				require.NotNil(t, err)
+				require.Contains(t, err.Error(), "provided shipyard file is not valid")
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
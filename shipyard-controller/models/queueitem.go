@@ -9,6 +9,13 @@ type QueueItem struct {
 	Scope     EventScope `json:"scope" bson:"scope"`
 	EventID   string     `json:"eventID" bson:"eventID"`
 	Timestamp time.Time  `json:"timestamp" bson:"timestamp"`
+	// Restart is set if the queued sequence has been restarted from one of its tasks
+	Restart *SequenceRestart `json:"restart,omitempty" bson:"restart,omitempty"`
+}
+
+// SequenceRestart contains the task a restarted sequence continues with
+type SequenceRestart struct {
+	FromTaskIndex int `json:"fromTaskIndex" bson:"fromTaskIndex"`
 }
 
 type EventQueueSequenceState struct {
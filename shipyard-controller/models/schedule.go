@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// Schedule periodically triggers a sequence for a service in a stage, based on a cron expression
+type Schedule struct {
+	ID         string                 `json:"id" bson:"_id"`
+	Project    string                 `json:"project" bson:"project"`
+	Stage      string                 `json:"stage" bson:"stage"`
+	Service    string                 `json:"service" bson:"service"`
+	Sequence   string                 `json:"sequence" bson:"sequence"`
+	Cron       string                 `json:"cron" bson:"cron"`
+	Properties map[string]interface{} `json:"properties,omitempty" bson:"properties,omitempty"`
+	Paused     bool                   `json:"paused" bson:"paused"`
+	// NextFireTime is the point in time at which the sequence will be triggered next
+	NextFireTime time.Time `json:"nextFireTime" bson:"nextFireTime"`
+	// LastRun contains information about the last time the sequence has been triggered by the schedule
+	LastRun *ScheduleRun `json:"lastRun,omitempty" bson:"lastRun,omitempty"`
+}
+
+// ScheduleRun describes a sequence that has been triggered by a schedule
+type ScheduleRun struct {
+	Time         time.Time `json:"time" bson:"time"`
+	KeptnContext string    `json:"keptnContext" bson:"keptnContext"`
+	// Manual indicates that the schedule has been fired manually via the API
+	Manual bool `json:"manual" bson:"manual"`
+}
+
+// GetScheduleID returns the ID of the schedule of the given sequence
+func GetScheduleID(project, stage, service, sequence string) string {
+	return project + "." + stage + "." + service + "." + sequence
+}
+
+type Schedules struct {
+	Schedules []Schedule `json:"schedules"`
+}
+
+type ScheduleControlState string
+
+const (
+	PauseSchedule  ScheduleControlState = "pause"
+	ResumeSchedule ScheduleControlState = "resume"
+)
+
+type ScheduleControlCommand struct {
+	State ScheduleControlState `json:"state" binding:"required"`
+}
+
+type FireScheduleResponse struct {
+	KeptnContext string `json:"keptnContext"`
+}
@@ -1,8 +1,28 @@
 package models
 
+import "time"
+
+// SequenceTimeoutType describes which limit has caused a sequence to time out
+type SequenceTimeoutType string
+
+const (
+	// TaskStartedTimeout indicates that a task has not received a correlating .started or .finished event within the configured wait duration
+	TaskStartedTimeout SequenceTimeoutType = "taskStarted"
+	// TaskTimeout indicates that a task has not been finished within the timeout declared for the task in the shipyard
+	TaskTimeout SequenceTimeoutType = "task"
+	// SequenceTimeoutLimit indicates that a sequence has not been finished within the timeout declared for the sequence in the shipyard
+	SequenceTimeoutLimit SequenceTimeoutType = "sequence"
+)
+
 type SequenceTimeout struct {
 	KeptnContext string
 	LastEvent    Event
+	// Type is the limit that has been exceeded
+	Type SequenceTimeoutType
+	// Timeout is the duration of the limit that has been exceeded
+	Timeout time.Duration
+	// Message describes why the sequence has been timed out
+	Message string
 }
 
 type SequenceControlState string
@@ -11,6 +31,8 @@ const (
 	PauseSequence  SequenceControlState = "pause"
 	ResumeSequence SequenceControlState = "resume"
 	AbortSequence  SequenceControlState = "abort"
+	// RestartSequence triggers the tasks of a sequence that is not running anymore again, starting with a given task
+	RestartSequence SequenceControlState = "restart"
 )
 
 type SequenceControl struct {
@@ -18,4 +40,6 @@ type SequenceControl struct {
 	KeptnContext string
 	Stage        string
 	Project      string
+	// FromTask is the task a restarted sequence continues with
+	FromTask string
 }
@@ -3,6 +3,8 @@ package models
 type SequenceControlCommand struct {
 	State SequenceControlState `json:"state" binding:"required"`
 	Stage string               `json:"stage"`
+	// FromTask is the task a restarted sequence continues with. If not set, the sequence continues with the first task that has not been successful
+	FromTask string `json:"fromTask,omitempty"`
 }
 
 type SequenceControlResponse struct {
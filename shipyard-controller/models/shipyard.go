@@ -0,0 +1,390 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/shipyard-controller/common"
+	"gopkg.in/yaml.v3"
+)
+
+// ShipyardExtension contains the properties of a shipyard that are evaluated by the shipyard-controller in addition to
+// the properties covered by the keptnv2.Shipyard type. It is decoded from the same shipyard.yaml content, and its stages, sequences and tasks
+// are in the same order as the ones of the keptnv2.Shipyard
+type ShipyardExtension struct {
+	Spec ShipyardExtensionSpec `json:"spec" yaml:"spec"`
+}
+
+// ShipyardExtensionSpec consists of any number of stages
+type ShipyardExtensionSpec struct {
+	Stages []StageExtension `json:"stages" yaml:"stages"`
+}
+
+// StageExtension contains the additional properties of a stage
+type StageExtension struct {
+	Name string `json:"name" yaml:"name"`
+	// Concurrency defines how sequences for the same service are dispatched within the stage.
+	// Possible values are 'queue', 'cancel-running', 'skip-intermediate', 'parallel', or the maximum number of sequences that can run at the same time
+	Concurrency string `json:"concurrency,omitempty" yaml:"concurrency,omitempty"`
+	// Schedules contains cron schedules that trigger sequences of the stage
+	Schedules []ScheduleExtension `json:"schedules,omitempty" yaml:"schedules,omitempty"`
+	Sequences []SequenceExtension `json:"sequences" yaml:"sequences"`
+}
+
+// ScheduleExtension defines a cron schedule that periodically triggers a sequence for all services of a stage
+type ScheduleExtension struct {
+	// Sequence is the name of the sequence that is triggered. It is only required for schedules defined on the stage level
+	Sequence string `json:"sequence,omitempty" yaml:"sequence,omitempty"`
+	// Cron is a cron expression, e.g. '0 2 * * *'
+	Cron string `json:"cron" yaml:"cron"`
+	// Properties are added to the data of the '.triggered' event of the sequence
+	Properties map[string]interface{} `json:"properties,omitempty" yaml:"properties,omitempty"`
+}
+
+// ScheduleDefinition is a schedule of the shipyard, together with the stage and sequence it belongs to
+type ScheduleDefinition struct {
+	Stage      string
+	Sequence   string
+	Cron       string
+	Properties map[string]interface{}
+}
+
+const (
+	// ConcurrencyQueue queues new sequences while other sequences are running (default)
+	ConcurrencyQueue = "queue"
+	// ConcurrencyCancelRunning aborts running sequences in favor of the new sequence
+	ConcurrencyCancelRunning = "cancel-running"
+	// ConcurrencySkipIntermediate queues new sequences, but only keeps the latest sequence in the queue
+	ConcurrencySkipIntermediate = "skip-intermediate"
+	// ConcurrencyParallel starts new sequences immediately
+	ConcurrencyParallel = "parallel"
+)
+
+// ConcurrencyPolicy describes how sequences for the same service are dispatched within a stage
+type ConcurrencyPolicy struct {
+	Mode string
+	// MaxConcurrency is the number of sequences that can run at the same time. 0 means that the number is not limited
+	MaxConcurrency int
+}
+
+// DefaultConcurrencyPolicy allows one sequence per service to run in a stage, while new sequences are queued
+var DefaultConcurrencyPolicy = ConcurrencyPolicy{Mode: ConcurrencyQueue, MaxConcurrency: 1}
+
+// ParseConcurrencyPolicy parses the concurrency property of a stage
+func ParseConcurrencyPolicy(concurrency string) (ConcurrencyPolicy, error) {
+	switch concurrency {
+	case "", ConcurrencyQueue:
+		return DefaultConcurrencyPolicy, nil
+	case ConcurrencyCancelRunning, ConcurrencySkipIntermediate:
+		return ConcurrencyPolicy{Mode: concurrency, MaxConcurrency: 1}, nil
+	case ConcurrencyParallel:
+		return ConcurrencyPolicy{Mode: ConcurrencyParallel}, nil
+	}
+	maxConcurrency, err := strconv.Atoi(concurrency)
+	if err != nil || maxConcurrency < 1 {
+		return ConcurrencyPolicy{}, fmt.Errorf("invalid concurrency '%s': must be one of %s, %s, %s, %s or a positive number",
+			concurrency, ConcurrencyQueue, ConcurrencyCancelRunning, ConcurrencySkipIntermediate, ConcurrencyParallel)
+	}
+	return ConcurrencyPolicy{Mode: ConcurrencyQueue, MaxConcurrency: maxConcurrency}, nil
+}
+
+// SequenceExtension contains the additional properties of a sequence
+type SequenceExtension struct {
+	Name string `json:"name" yaml:"name"`
+	// Schedule is a cron schedule that triggers the sequence
+	Schedule *ScheduleExtension `json:"schedule,omitempty" yaml:"schedule,omitempty"`
+	// Timeout is the maximum duration (e.g. 2h) between the triggering of the sequence and its completion
+	Timeout string          `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	Tasks   []TaskExtension `json:"tasks" yaml:"tasks"`
+}
+
+// TaskExtension contains the additional properties of a task
+type TaskExtension struct {
+	Name string `json:"name" yaml:"name"`
+	// If is a condition that is evaluated against the data of the events of the sequence. If it evaluates to false, the task is skipped
+	If string `json:"if,omitempty" yaml:"if,omitempty"`
+	// AlwaysRun indicates that the task should be executed even if a previous task of the sequence has failed
+	AlwaysRun bool `json:"alwaysRun,omitempty" yaml:"alwaysRun,omitempty"`
+	// Parallel contains the tasks that are executed at the same time. If set, the task is a parallel task group,
+	// and the next task of the sequence is triggered once all tasks of the group are finished
+	Parallel []keptnv2.Task `json:"parallel,omitempty" yaml:"parallel,omitempty"`
+	// Timeout is the maximum duration (e.g. 30m) between the triggering of the task and its completion.
+	// For a parallel task group, the timeout applies to each task of the group
+	Timeout string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	// Retry defines if and how often the task is triggered again if it has not been successful.
+	// For a parallel task group, the policy applies to each task of the group
+	Retry *RetryPolicy `json:"retry,omitempty" yaml:"retry,omitempty"`
+}
+
+const (
+	// RetryOnFail retries a task that has been finished with the result 'fail'
+	RetryOnFail = "fail"
+	// RetryOnWarning retries a task that has been finished with the result 'warning'
+	RetryOnWarning = "warning"
+	// RetryOnErrored retries a task that has been finished with the status 'errored'
+	RetryOnErrored = "errored"
+)
+
+// RetryPolicy describes how a task is retried if it has not been successful
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of executions of the task, including the first one
+	MaxAttempts int `json:"maxAttempts" yaml:"maxAttempts"`
+	// Backoff is the duration (e.g. 30s) to wait before the task is triggered again. If not set, the task is triggered again immediately
+	Backoff string `json:"backoff,omitempty" yaml:"backoff,omitempty"`
+	// BackoffFactor is multiplied with the backoff after each attempt, e.g. a factor of 2 doubles the time between two attempts. Defaults to 1
+	BackoffFactor float64 `json:"backoffFactor,omitempty" yaml:"backoffFactor,omitempty"`
+	// On contains the results (fail, warning) and statuses (errored) of a task that cause it to be retried. Defaults to fail and errored
+	On []string `json:"on,omitempty" yaml:"on,omitempty"`
+}
+
+// ShouldRetry returns true if a task that has been finished with the given result and status in the given attempt should be triggered again
+func (r *RetryPolicy) ShouldRetry(result keptnv2.ResultType, status keptnv2.StatusType, attempt int) bool {
+	if r == nil || attempt >= r.MaxAttempts {
+		return false
+	}
+	retryOn := r.On
+	if len(retryOn) == 0 {
+		retryOn = []string{RetryOnFail, RetryOnErrored}
+	}
+	for _, on := range retryOn {
+		switch on {
+		case RetryOnFail, RetryOnWarning:
+			if string(result) == on {
+				return true
+			}
+		case RetryOnErrored:
+			if status == keptnv2.StatusErrored {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GetBackoff returns the duration to wait before the task is triggered again after the given attempt has not been successful
+func (r *RetryPolicy) GetBackoff(attempt int) time.Duration {
+	if r == nil {
+		return 0
+	}
+	backoff, err := time.ParseDuration(r.Backoff)
+	if err != nil || backoff <= 0 {
+		return 0
+	}
+	if r.BackoffFactor > 1 && attempt > 1 {
+		backoff = time.Duration(float64(backoff) * math.Pow(r.BackoffFactor, float64(attempt-1)))
+	}
+	return backoff
+}
+
+func (r RetryPolicy) validate() error {
+	if r.MaxAttempts < 1 {
+		return errors.New("invalid retry policy: maxAttempts must be at least 1")
+	}
+	if r.Backoff != "" {
+		if backoff, err := time.ParseDuration(r.Backoff); err != nil || backoff < 0 {
+			return fmt.Errorf("invalid retry policy: invalid backoff '%s'", r.Backoff)
+		}
+	}
+	if r.BackoffFactor != 0 && r.BackoffFactor < 1 {
+		return errors.New("invalid retry policy: backoffFactor must be at least 1")
+	}
+	for _, on := range r.On {
+		if on != RetryOnFail && on != RetryOnWarning && on != RetryOnErrored {
+			return fmt.Errorf("invalid retry policy: '%s' must be one of %s, %s, %s", on, RetryOnFail, RetryOnWarning, RetryOnErrored)
+		}
+	}
+	return nil
+}
+
+// IsParallelGroup returns true if the task is a group of tasks that are executed in parallel
+func (t TaskExtension) IsParallelGroup() bool {
+	return len(t.Parallel) > 0
+}
+
+// UnmarshalShipyardExtension decodes the shipyard-controller specific properties of the given shipyard content
+func UnmarshalShipyardExtension(shipyardContent string) (*ShipyardExtension, error) {
+	extension := &ShipyardExtension{}
+	if err := yaml.Unmarshal([]byte(shipyardContent), extension); err != nil {
+		return nil, errors.New("Could not decode shipyard file: " + err.Error())
+	}
+	return extension, nil
+}
+
+// Validate checks if the shipyard-controller specific properties of the shipyard are valid
+func (s *ShipyardExtension) Validate() error {
+	for _, stage := range s.Spec.Stages {
+		if _, err := ParseConcurrencyPolicy(stage.Concurrency); err != nil {
+			return fmt.Errorf("invalid stage %s: %w", stage.Name, err)
+		}
+		if err := stage.validateSchedules(); err != nil {
+			return fmt.Errorf("invalid stage %s: %w", stage.Name, err)
+		}
+		for _, sequence := range stage.Sequences {
+			if _, err := ParseTimeout(sequence.Timeout); err != nil {
+				return fmt.Errorf("invalid sequence %s.%s: %w", stage.Name, sequence.Name, err)
+			}
+			for _, task := range sequence.Tasks {
+				if err := task.validate(); err != nil {
+					return fmt.Errorf("invalid task %s in sequence %s.%s: %w", task.Name, stage.Name, sequence.Name, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s StageExtension) validateSchedules() error {
+	scheduledSequences := map[string]bool{}
+	for _, schedule := range s.getSchedules() {
+		if !s.hasSequence(schedule.Sequence) {
+			return fmt.Errorf("schedule refers to unknown sequence '%s'", schedule.Sequence)
+		}
+		if scheduledSequences[schedule.Sequence] {
+			return fmt.Errorf("sequence %s has more than one schedule", schedule.Sequence)
+		}
+		scheduledSequences[schedule.Sequence] = true
+		if _, err := common.ParseCronSchedule(schedule.Cron); err != nil {
+			return fmt.Errorf("invalid schedule of sequence %s: %w", schedule.Sequence, err)
+		}
+	}
+	return nil
+}
+
+func (s StageExtension) hasSequence(sequenceName string) bool {
+	for _, sequence := range s.Sequences {
+		if sequence.Name == sequenceName {
+			return true
+		}
+	}
+	return false
+}
+
+func (s StageExtension) getSchedules() []ScheduleDefinition {
+	schedules := []ScheduleDefinition{}
+	for _, schedule := range s.Schedules {
+		schedules = append(schedules, ScheduleDefinition{Stage: s.Name, Sequence: schedule.Sequence, Cron: schedule.Cron, Properties: schedule.Properties})
+	}
+	for _, sequence := range s.Sequences {
+		if sequence.Schedule != nil {
+			schedules = append(schedules, ScheduleDefinition{Stage: s.Name, Sequence: sequence.Name, Cron: sequence.Schedule.Cron, Properties: sequence.Schedule.Properties})
+		}
+	}
+	return schedules
+}
+
+// GetSchedules returns the schedules that are defined for the stages and sequences of the shipyard
+func (s *ShipyardExtension) GetSchedules() []ScheduleDefinition {
+	schedules := []ScheduleDefinition{}
+	if s == nil {
+		return schedules
+	}
+	for _, stage := range s.Spec.Stages {
+		schedules = append(schedules, stage.getSchedules()...)
+	}
+	return schedules
+}
+
+func (t TaskExtension) validate() error {
+	if t.If != "" {
+		if _, err := common.ParseCondition(t.If); err != nil {
+			return err
+		}
+	}
+	if _, err := ParseTimeout(t.Timeout); err != nil {
+		return err
+	}
+	if t.Retry != nil {
+		if err := t.Retry.validate(); err != nil {
+			return err
+		}
+	}
+	taskNames := map[string]bool{}
+	for _, parallelTask := range t.Parallel {
+		if parallelTask.Name == "" {
+			return errors.New("all tasks within a parallel task group must have a name")
+		}
+		if taskNames[parallelTask.Name] {
+			return fmt.Errorf("task %s is contained multiple times in the parallel task group", parallelTask.Name)
+		}
+		taskNames[parallelTask.Name] = true
+	}
+	return nil
+}
+
+// GetConcurrencyPolicy returns the concurrency policy of the given stage. If the stage does not define a valid policy, DefaultConcurrencyPolicy is returned
+func (s *ShipyardExtension) GetConcurrencyPolicy(stageName string) ConcurrencyPolicy {
+	if s == nil {
+		return DefaultConcurrencyPolicy
+	}
+	for _, stage := range s.Spec.Stages {
+		if stage.Name == stageName {
+			policy, err := ParseConcurrencyPolicy(stage.Concurrency)
+			if err != nil {
+				return DefaultConcurrencyPolicy
+			}
+			return policy
+		}
+	}
+	return DefaultConcurrencyPolicy
+}
+
+// GetSequence returns the extension of the sequence with the given name in the given stage.
+// If no such sequence is found, an empty SequenceExtension is returned
+func (s *ShipyardExtension) GetSequence(stageName, sequenceName string) *SequenceExtension {
+	if s == nil {
+		return &SequenceExtension{Name: sequenceName}
+	}
+	for _, stage := range s.Spec.Stages {
+		if stage.Name != stageName {
+			continue
+		}
+		for index := range stage.Sequences {
+			if stage.Sequences[index].Name == sequenceName {
+				return &stage.Sequences[index]
+			}
+		}
+	}
+	return &SequenceExtension{Name: sequenceName}
+}
+
+// GetTimeout returns the timeout of the sequence, or 0 if the sequence has no valid timeout
+func (s *SequenceExtension) GetTimeout() time.Duration {
+	if s == nil {
+		return 0
+	}
+	timeout, _ := ParseTimeout(s.Timeout)
+	return timeout
+}
+
+// GetTimeout returns the timeout of the task, or 0 if the task has no valid timeout
+func (t TaskExtension) GetTimeout() time.Duration {
+	timeout, _ := ParseTimeout(t.Timeout)
+	return timeout
+}
+
+// ParseTimeout parses a timeout declared in the shipyard. An empty string results in a timeout of 0, meaning that no timeout is set
+func ParseTimeout(timeout string) (time.Duration, error) {
+	if timeout == "" {
+		return 0, nil
+	}
+	duration, err := time.ParseDuration(timeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout '%s': %w", timeout, err)
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("invalid timeout '%s': must be a positive duration", timeout)
+	}
+	return duration, nil
+}
+
+// GetTask returns the extension of the task at the given index. If no such task is found, an empty TaskExtension is returned
+func (s *SequenceExtension) GetTask(index int) TaskExtension {
+	if s == nil || index < 0 || index >= len(s.Tasks) {
+		return TaskExtension{}
+	}
+	return s.Tasks[index]
+}
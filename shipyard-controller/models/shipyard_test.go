@@ -0,0 +1,369 @@
+package models
+
+import (
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestShipyardExtension_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		shipyard string
+		wantErr  bool
+	}{
+		{
+			name: "valid conditions and parallel task group",
+			shipyard: `apiVersion: "spec.keptn.sh/0.2.2"
+kind: "Shipyard"
+metadata:
+  name: "shipyard-sockshop"
+spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "deployment"
+            - name: "tests"
+              if: 'deployment.deploymentstrategy != "direct"'
+              parallel:
+                - name: "test"
+                  properties:
+                    teststrategy: "functional"
+                - name: "securityscan"
+            - name: "evaluation"
+            - name: "notify"
+              alwaysRun: true`,
+		},
+		{
+			name: "invalid condition",
+			shipyard: `spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "test"
+              if: 'deployment.deploymentstrategy = "direct"'`,
+			wantErr: true,
+		},
+		{
+			name: "task of parallel group without name",
+			shipyard: `spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "tests"
+              parallel:
+                - name: "test"
+                - properties:
+                    foo: "bar"`,
+			wantErr: true,
+		},
+		{
+			name: "duplicate task in parallel group",
+			shipyard: `spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "tests"
+              parallel:
+                - name: "test"
+                - name: "test"`,
+			wantErr: true,
+		},
+		{
+			name: "valid schedules",
+			shipyard: `spec:
+  stages:
+    - name: "dev"
+      schedules:
+        - sequence: "evaluation"
+          cron: "@hourly"
+      sequences:
+        - name: "delivery"
+          schedule:
+            cron: "0 2 * * mon-fri"
+        - name: "evaluation"`,
+			wantErr: false,
+		},
+		{
+			name: "invalid cron expression",
+			shipyard: `spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          schedule:
+            cron: "0 25 * * *"`,
+			wantErr: true,
+		},
+		{
+			name: "schedule for unknown sequence",
+			shipyard: `spec:
+  stages:
+    - name: "dev"
+      schedules:
+        - sequence: "evaluation"
+          cron: "@hourly"
+      sequences:
+        - name: "delivery"`,
+			wantErr: true,
+		},
+		{
+			name: "multiple schedules for the same sequence",
+			shipyard: `spec:
+  stages:
+    - name: "dev"
+      schedules:
+        - sequence: "delivery"
+          cron: "@hourly"
+      sequences:
+        - name: "delivery"
+          schedule:
+            cron: "@daily"`,
+			wantErr: true,
+		},
+		{
+			name: "invalid concurrency",
+			shipyard: `spec:
+  stages:
+    - name: "dev"
+      concurrency: "sometimes"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "deployment"`,
+			wantErr: true,
+		},
+		{
+			name: "valid timeouts",
+			shipyard: `spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          timeout: "2h"
+          tasks:
+            - name: "deployment"
+              timeout: "30m"`,
+		},
+		{
+			name: "invalid sequence timeout",
+			shipyard: `spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          timeout: "two hours"
+          tasks:
+            - name: "deployment"`,
+			wantErr: true,
+		},
+		{
+			name: "negative task timeout",
+			shipyard: `spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "deployment"
+              timeout: "-5m"`,
+			wantErr: true,
+		},
+		{
+			name: "valid retry policy",
+			shipyard: `spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "test"
+              retry:
+                maxAttempts: 3
+                backoff: "30s"
+                backoffFactor: 2
+                on:
+                  - "fail"
+                  - "warning"`,
+		},
+		{
+			name: "retry policy without maxAttempts",
+			shipyard: `spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "test"
+              retry:
+                backoff: "30s"`,
+			wantErr: true,
+		},
+		{
+			name: "retry policy with invalid backoff",
+			shipyard: `spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "test"
+              retry:
+                maxAttempts: 3
+                backoff: "a while"`,
+			wantErr: true,
+		},
+		{
+			name: "retry policy with unknown result",
+			shipyard: `spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "test"
+              retry:
+                maxAttempts: 3
+                on:
+                  - "pass"`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extension, err := UnmarshalShipyardExtension(tt.shipyard)
+			require.Nil(t, err)
+			err = extension.Validate()
+			if tt.wantErr {
+				require.NotNil(t, err)
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestShipyardExtension_GetSequence(t *testing.T) {
+	extension, err := UnmarshalShipyardExtension(`spec:
+  stages:
+    - name: "dev"
+      sequences:
+        - name: "delivery"
+          tasks:
+            - name: "deployment"
+            - name: "tests"
+              parallel:
+                - name: "test"
+                - name: "securityscan"`)
+	require.Nil(t, err)
+
+	sequence := extension.GetSequence("dev", "delivery")
+	require.False(t, sequence.GetTask(0).IsParallelGroup())
+	require.True(t, sequence.GetTask(1).IsParallelGroup())
+	require.Len(t, sequence.GetTask(1).Parallel, 2)
+	require.Equal(t, TaskExtension{}, sequence.GetTask(2))
+
+	require.Empty(t, extension.GetSequence("prod", "delivery").Tasks)
+
+	var nilExtension *ShipyardExtension
+	require.Empty(t, nilExtension.GetSequence("dev", "delivery").Tasks)
+}
+
+func TestParseTimeout(t *testing.T) {
+	timeout, err := ParseTimeout("")
+	require.Nil(t, err)
+	require.Equal(t, time.Duration(0), timeout)
+
+	timeout, err = ParseTimeout("1h30m")
+	require.Nil(t, err)
+	require.Equal(t, 90*time.Minute, timeout)
+
+	_, err = ParseTimeout("0s")
+	require.NotNil(t, err)
+
+	_, err = ParseTimeout("soon")
+	require.NotNil(t, err)
+}
+
+func TestRetryPolicy_ShouldRetry(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 3}
+	require.True(t, policy.ShouldRetry(keptnv2.ResultFailed, keptnv2.StatusSucceeded, 1))
+	require.True(t, policy.ShouldRetry(keptnv2.ResultPass, keptnv2.StatusErrored, 2))
+	require.False(t, policy.ShouldRetry(keptnv2.ResultWarning, keptnv2.StatusSucceeded, 1))
+	require.False(t, policy.ShouldRetry(keptnv2.ResultPass, keptnv2.StatusSucceeded, 1))
+	require.False(t, policy.ShouldRetry(keptnv2.ResultFailed, keptnv2.StatusSucceeded, 3))
+
+	policy = &RetryPolicy{MaxAttempts: 2, On: []string{RetryOnWarning}}
+	require.True(t, policy.ShouldRetry(keptnv2.ResultWarning, keptnv2.StatusSucceeded, 1))
+	require.False(t, policy.ShouldRetry(keptnv2.ResultFailed, keptnv2.StatusErrored, 1))
+
+	var nilPolicy *RetryPolicy
+	require.False(t, nilPolicy.ShouldRetry(keptnv2.ResultFailed, keptnv2.StatusErrored, 1))
+}
+
+func TestRetryPolicy_GetBackoff(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 4, Backoff: "10s"}
+	require.Equal(t, 10*time.Second, policy.GetBackoff(1))
+	require.Equal(t, 10*time.Second, policy.GetBackoff(3))
+
+	policy.BackoffFactor = 2
+	require.Equal(t, 10*time.Second, policy.GetBackoff(1))
+	require.Equal(t, 20*time.Second, policy.GetBackoff(2))
+	require.Equal(t, 40*time.Second, policy.GetBackoff(3))
+
+	require.Equal(t, time.Duration(0), (&RetryPolicy{MaxAttempts: 2}).GetBackoff(1))
+}
+
+func TestParseConcurrencyPolicy(t *testing.T) {
+	tests := []struct {
+		concurrency string
+		want        ConcurrencyPolicy
+		wantErr     bool
+	}{
+		{concurrency: "", want: DefaultConcurrencyPolicy},
+		{concurrency: "queue", want: DefaultConcurrencyPolicy},
+		{concurrency: "cancel-running", want: ConcurrencyPolicy{Mode: ConcurrencyCancelRunning, MaxConcurrency: 1}},
+		{concurrency: "skip-intermediate", want: ConcurrencyPolicy{Mode: ConcurrencySkipIntermediate, MaxConcurrency: 1}},
+		{concurrency: "parallel", want: ConcurrencyPolicy{Mode: ConcurrencyParallel}},
+		{concurrency: "3", want: ConcurrencyPolicy{Mode: ConcurrencyQueue, MaxConcurrency: 3}},
+		{concurrency: "0", wantErr: true},
+		{concurrency: "-1", wantErr: true},
+		{concurrency: "sometimes", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.concurrency, func(t *testing.T) {
+			got, err := ParseConcurrencyPolicy(tt.concurrency)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestShipyardExtension_GetConcurrencyPolicy(t *testing.T) {
+	extension, err := UnmarshalShipyardExtension(`spec:
+  stages:
+    - name: "dev"
+      concurrency: "parallel"
+    - name: "prod"`)
+	require.Nil(t, err)
+
+	require.Equal(t, ConcurrencyPolicy{Mode: ConcurrencyParallel}, extension.GetConcurrencyPolicy("dev"))
+	require.Equal(t, DefaultConcurrencyPolicy, extension.GetConcurrencyPolicy("prod"))
+	require.Equal(t, DefaultConcurrencyPolicy, extension.GetConcurrencyPolicy("unknown"))
+
+	var nilExtension *ShipyardExtension
+	require.Equal(t, DefaultConcurrencyPolicy, nilExtension.GetConcurrencyPolicy("dev"))
+}
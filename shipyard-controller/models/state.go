@@ -75,6 +75,35 @@ type SequenceStateStage struct {
 	LatestEvaluation  *SequenceStateEvaluation `json:"latestEvaluation,omitempty" bson:"latestEvaluation"`
 	LatestEvent       *SequenceStateEvent      `json:"latestEvent,omitempty" bson:"latestEvent"`
 	LatestFailedEvent *SequenceStateEvent      `json:"latestFailedEvent,omitempty" bson:"latestFailedEvent"`
+	SkippedTasks      []SkippedTask            `json:"skippedTasks,omitempty" bson:"skippedTasks,omitempty"`
+	Timeout           *SequenceStateTimeout    `json:"timeout,omitempty" bson:"timeout,omitempty"`
+	TaskAttempts      []SequenceStateAttempt   `json:"taskAttempts,omitempty" bson:"taskAttempts,omitempty"`
+}
+
+// SequenceStateAttempt describes a finished execution of a task that has a retry policy
+type SequenceStateAttempt struct {
+	Task        string `json:"task" bson:"task"`
+	Attempt     int    `json:"attempt" bson:"attempt"`
+	MaxAttempts int    `json:"maxAttempts" bson:"maxAttempts"`
+	// TriggeredID is the ID of the '.triggered' event of the attempt
+	TriggeredID string `json:"triggeredId" bson:"triggeredId"`
+	Result      string `json:"result" bson:"result"`
+	Status      string `json:"status" bson:"status"`
+	Message     string `json:"message,omitempty" bson:"message,omitempty"`
+	Time        string `json:"time" bson:"time"`
+	// NextAttempt is the time at which the task is triggered again. It is empty if the task has not been retried after this attempt
+	NextAttempt string `json:"nextAttempt,omitempty" bson:"nextAttempt,omitempty"`
+}
+
+// SequenceStateTimeout describes the limit that has caused the sequence to time out in a stage
+type SequenceStateTimeout struct {
+	// Type is the limit that has been exceeded, i.e. taskStarted, task or sequence
+	Type SequenceTimeoutType `json:"type" bson:"type"`
+	// Task is the task that has been running when the limit was exceeded
+	Task string `json:"task,omitempty" bson:"task,omitempty"`
+	// Timeout is the duration of the exceeded limit
+	Timeout string `json:"timeout,omitempty" bson:"timeout,omitempty"`
+	Message string `json:"message,omitempty" bson:"message,omitempty"`
 }
 
 type SequenceState struct {
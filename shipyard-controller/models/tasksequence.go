@@ -1,6 +1,10 @@
 package models
 
-import keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+import (
+	"time"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
 
 // TaskExecution godoc
 type TaskExecution struct {
@@ -15,4 +19,55 @@ type TaskExecution struct {
 type Task struct {
 	keptnv2.Task
 	TaskIndex int
+	// Group is the name of the parallel task group the task belongs to
+	Group string `json:"group,omitempty" bson:"group,omitempty"`
+	// ParallelTasks contains the tasks of a parallel task group
+	ParallelTasks []keptnv2.Task `json:"parallelTasks,omitempty" bson:"parallelTasks,omitempty"`
+	// Attempt is the number of the current execution of the task. It is only set if the task has been retried
+	Attempt int `json:"attempt,omitempty" bson:"attempt,omitempty"`
+}
+
+// GetAttempt returns the number of the current execution of the task, starting with 1
+func (t Task) GetAttempt() int {
+	if t.Attempt < 1 {
+		return 1
+	}
+	return t.Attempt
+}
+
+// IsParallelGroup returns true if the task is a group of tasks that are executed in parallel
+func (t Task) IsParallelGroup() bool {
+	return len(t.ParallelTasks) > 0
+}
+
+// GetSequenceTaskName returns the name of the task as defined in the sequence, i.e. the name of the parallel task group for tasks that belong to a group
+func (t Task) GetSequenceTaskName() string {
+	if t.Group != "" {
+		return t.Group
+	}
+	return t.Name
+}
+
+// SkippedTask describes a task of a sequence that has not been executed because its condition was not fulfilled
+type SkippedTask struct {
+	Name      string `json:"name" bson:"name"`
+	TaskIndex int    `json:"taskIndex" bson:"taskIndex"`
+	Condition string `json:"condition" bson:"condition"`
+	Reason    string `json:"reason,omitempty" bson:"reason,omitempty"`
+}
+
+// TaskRetryEventData is added as 'retry' property to the data of the '.triggered' event of a task that is executed again
+type TaskRetryEventData struct {
+	Attempt     int `json:"attempt"`
+	MaxAttempts int `json:"maxAttempts"`
+}
+
+// TaskAttempt describes a finished execution of a task that has a retry policy
+type TaskAttempt struct {
+	// FinishedEvent is the '.finished' event that completed the attempt
+	FinishedEvent Event
+	Attempt       int
+	MaxAttempts   int
+	// NextAttempt is the time at which the task is triggered again. It is nil if the task is not retried after this attempt
+	NextAttempt *time.Time
 }
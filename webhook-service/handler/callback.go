@@ -0,0 +1,405 @@
+package handler
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/go-sdk/pkg/sdk"
+	"github.com/keptn/keptn/webhook-service/lib"
+	logger "github.com/sirupsen/logrus"
+)
+
+// CallbackAPIPath is the path the callbacks of asynchronous webhooks are received at
+const CallbackAPIPath = "/v1/callback/"
+
+const maxCallbackSize = 1 << 20
+
+var errCallbacksDisabled = errors.New("asynchronous webhooks are not enabled")
+
+// Callback contains the URL and the token that need to be used by the called system to finish an asynchronous webhook
+type Callback struct {
+	ID    string
+	URL   string
+	Token string
+}
+
+// CallbackRequest is the payload of a callback. The data is added to the task's data of the .finished event
+type CallbackRequest struct {
+	Result  keptnv2.ResultType     `json:"result,omitempty"`
+	Status  keptnv2.StatusType     `json:"status,omitempty"`
+	Message string                 `json:"message,omitempty"`
+	Data    map[string]interface{} `json:"data,omitempty"`
+}
+
+type pendingCallback struct {
+	tokenHash    [sha256.Size]byte
+	keptnHandler sdk.IKeptn
+	event        sdk.KeptnEvent
+	taskName     string
+	result       map[string]interface{}
+	timeout      time.Duration
+	// stored is true if the callback has been persisted in the callback store
+	stored bool
+	// ready is closed once the requests of the webhook have been executed, or if the callback has been removed
+	ready chan struct{}
+	timer *time.Timer
+}
+
+// CallbackRegistry keeps track of the asynchronous webhooks waiting for a callback, and sends the .finished event once the
+// callback has been received or the deadline has been exceeded. If a callback store is configured, pending callbacks are
+// persisted once the requests of the webhook have been executed, so that they can be received by any replica and are
+// restored after a restart
+type CallbackRegistry struct {
+	baseURL string
+	store   lib.ICallbackStore
+	// keptnHandler is used to send the .finished events of callbacks loaded from the store
+	keptnHandler sdk.IKeptn
+	mutex        sync.Mutex
+	callbacks    map[string]*pendingCallback
+}
+
+type CallbackRegistryOption func(registry *CallbackRegistry)
+
+// WithCallbackStore persists the pending callbacks in the given store
+func WithCallbackStore(store lib.ICallbackStore) CallbackRegistryOption {
+	return func(registry *CallbackRegistry) {
+		registry.store = store
+	}
+}
+
+func NewCallbackRegistry(baseURL string, opts ...CallbackRegistryOption) *CallbackRegistry {
+	registry := &CallbackRegistry{
+		baseURL:   strings.TrimSuffix(baseURL, "/"),
+		callbacks: map[string]*pendingCallback{},
+	}
+	for _, opt := range opts {
+		opt(registry)
+	}
+	return registry
+}
+
+// Register creates a callback for the given triggered event
+func (r *CallbackRegistry) Register(keptnHandler sdk.IKeptn, event sdk.KeptnEvent, taskName string) (*Callback, error) {
+	id, err := randomHex(16)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate callback ID: %w", err)
+	}
+	token, err := randomHex(32)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate callback token: %w", err)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.callbacks[id] = &pendingCallback{
+		tokenHash:    sha256.Sum256([]byte(token)),
+		keptnHandler: keptnHandler,
+		event:        event,
+		taskName:     taskName,
+		ready:        make(chan struct{}),
+	}
+	return &Callback{
+		ID:    id,
+		URL:   r.baseURL + CallbackAPIPath + id,
+		Token: token,
+	}, nil
+}
+
+// Activate is called once the requests of the webhook have been executed. The result is used as data for the .finished event.
+// If no callback is received within the deadline, the task is finished with an error
+func (r *CallbackRegistry) Activate(id string, result map[string]interface{}, deadline time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	callback, ok := r.callbacks[id]
+	if !ok {
+		return
+	}
+	callback.result = result
+	callback.timeout = deadline
+	if r.store != nil {
+		err := r.store.Save(lib.StoredCallback{
+			ID:        id,
+			TokenHash: hex.EncodeToString(callback.tokenHash[:]),
+			Event:     callback.event,
+			TaskName:  callback.taskName,
+			Result:    result,
+			Timeout:   deadline,
+			Deadline:  time.Now().Add(deadline),
+		})
+		if err != nil {
+			logger.WithError(err).Errorf("could not persist callback for event %s, it will be lost if the webhook-service is restarted", callback.event.ID)
+		} else {
+			callback.stored = true
+		}
+	}
+	callback.timer = r.startDeadlineTimer(id, deadline)
+	close(callback.ready)
+}
+
+// Restore loads the callbacks persisted by a previous instance of the webhook-service and re-arms their deadlines.
+// Callbacks whose deadline has already passed are finished immediately. The given handler is used to send their .finished events
+func (r *CallbackRegistry) Restore(keptnHandler sdk.IKeptn) error {
+	r.mutex.Lock()
+	r.keptnHandler = keptnHandler
+	r.mutex.Unlock()
+	if r.store == nil {
+		return nil
+	}
+	storedCallbacks, err := r.store.List()
+	if err != nil {
+		return err
+	}
+	for _, storedCallback := range storedCallbacks {
+		callback, err := newStoredPendingCallback(keptnHandler, storedCallback)
+		if err != nil {
+			logger.WithError(err).Errorf("could not restore callback %s", storedCallback.ID)
+			continue
+		}
+		r.mutex.Lock()
+		if _, ok := r.callbacks[storedCallback.ID]; !ok {
+			callback.timer = r.startDeadlineTimer(storedCallback.ID, time.Until(storedCallback.Deadline))
+			r.callbacks[storedCallback.ID] = callback
+		}
+		r.mutex.Unlock()
+	}
+	logger.Infof("restored %d pending callbacks", len(storedCallbacks))
+	return nil
+}
+
+// Remove removes a callback, e.g. if the execution of the webhook requests failed
+func (r *CallbackRegistry) Remove(id string) {
+	if callback, _ := r.take(id); callback != nil {
+		close(callback.ready)
+	}
+}
+
+// take removes the callback from the registry and the store and returns it, if it is still pending.
+// If the callback has already been handled by another replica, nil is returned
+func (r *CallbackRegistry) take(id string) (*pendingCallback, error) {
+	r.mutex.Lock()
+	callback, ok := r.callbacks[id]
+	if ok {
+		delete(r.callbacks, id)
+		if callback.timer != nil {
+			callback.timer.Stop()
+		}
+	}
+	r.mutex.Unlock()
+	if !ok || !callback.stored {
+		return callback, nil
+	}
+	if deleted, err := r.store.Delete(id); err != nil || !deleted {
+		return nil, err
+	}
+	return callback, nil
+}
+
+// takeStored removes a callback that is not kept in memory, e.g. because it has been registered by another replica, from the store
+func (r *CallbackRegistry) takeStored(id string, callback *pendingCallback) (*pendingCallback, error) {
+	if deleted, err := r.store.Delete(id); err != nil || !deleted {
+		return nil, err
+	}
+	return callback, nil
+}
+
+func (r *CallbackRegistry) get(id string) *pendingCallback {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.callbacks[id]
+}
+
+// load reads a callback that is not kept in memory from the store
+func (r *CallbackRegistry) load(id string) *pendingCallback {
+	r.mutex.Lock()
+	keptnHandler := r.keptnHandler
+	r.mutex.Unlock()
+	if r.store == nil || keptnHandler == nil {
+		return nil
+	}
+	storedCallback, err := r.store.Get(id)
+	if err != nil {
+		logger.WithError(err).Errorf("could not load callback %s", id)
+		return nil
+	} else if storedCallback == nil {
+		return nil
+	}
+	callback, err := newStoredPendingCallback(keptnHandler, *storedCallback)
+	if err != nil {
+		logger.WithError(err).Errorf("could not load callback %s", id)
+		return nil
+	}
+	return callback
+}
+
+func (r *CallbackRegistry) startDeadlineTimer(id string, deadline time.Duration) *time.Timer {
+	return time.AfterFunc(deadline, func() {
+		r.onDeadlineExceeded(id)
+	})
+}
+
+func (r *CallbackRegistry) onDeadlineExceeded(id string) {
+	callback, err := r.take(id)
+	if err != nil {
+		logger.WithError(err).Errorf("could not remove callback %s", id)
+		return
+	} else if callback == nil {
+		return
+	}
+	logger.Infof("no callback received for event %s within %s", callback.event.ID, callback.timeout)
+	callback.result["result"] = keptnv2.ResultFailed
+	callback.result["status"] = keptnv2.StatusErrored
+	callback.result["message"] = fmt.Sprintf("no callback received within %s", callback.timeout)
+	if err := callback.keptnHandler.SendFinishedEvent(callback.event, callback.result); err != nil {
+		logger.WithError(err).Error("could not send .finished event")
+	}
+}
+
+func (r *CallbackRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		writeCallbackError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	id := strings.TrimPrefix(req.URL.Path, CallbackAPIPath)
+	if id == "" {
+		writeCallbackError(w, http.StatusNotFound, "callback not found")
+		return
+	}
+	callback := r.get(id)
+	inMemory := callback != nil
+	if !inMemory {
+		callback = r.load(id)
+	}
+	if callback == nil {
+		writeCallbackError(w, http.StatusNotFound, "callback not found")
+		return
+	}
+
+	tokenHash := sha256.Sum256([]byte(strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")))
+	if subtle.ConstantTimeCompare(tokenHash[:], callback.tokenHash[:]) != 1 {
+		writeCallbackError(w, http.StatusUnauthorized, "invalid callback token")
+		return
+	}
+
+	callbackRequest := &CallbackRequest{}
+	if req.ContentLength != 0 {
+		if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxCallbackSize)).Decode(callbackRequest); err != nil {
+			writeCallbackError(w, http.StatusBadRequest, fmt.Sprintf("could not decode callback: %s", err.Error()))
+			return
+		}
+	}
+	if err := callbackRequest.validate(); err != nil {
+		writeCallbackError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// wait until the requests of the webhook have been executed, in case the callback is received before
+	select {
+	case <-callback.ready:
+	case <-req.Context().Done():
+		return
+	}
+
+	var err error
+	if inMemory {
+		callback, err = r.take(id)
+	} else {
+		callback, err = r.takeStored(id, callback)
+	}
+	if err != nil {
+		logger.WithError(err).Errorf("could not remove callback %s", id)
+		writeCallbackError(w, http.StatusInternalServerError, "could not remove callback")
+		return
+	} else if callback == nil {
+		writeCallbackError(w, http.StatusNotFound, "callback not found")
+		return
+	}
+	if err := callback.keptnHandler.SendFinishedEvent(callback.event, callbackRequest.apply(callback.result, callback.taskName)); err != nil {
+		logger.WithError(err).Error("could not send .finished event")
+		writeCallbackError(w, http.StatusInternalServerError, "could not send .finished event")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (c CallbackRequest) validate() error {
+	switch c.Result {
+	case "", keptnv2.ResultPass, keptnv2.ResultWarning, keptnv2.ResultFailed:
+	default:
+		return fmt.Errorf("invalid result '%s'", c.Result)
+	}
+	switch c.Status {
+	case "", keptnv2.StatusSucceeded, keptnv2.StatusErrored, keptnv2.StatusUnknown:
+	default:
+		return fmt.Errorf("invalid status '%s'", c.Status)
+	}
+	return nil
+}
+
+// apply adds the result, status, message and data of the callback to the data of the .finished event
+func (c CallbackRequest) apply(result map[string]interface{}, taskName string) map[string]interface{} {
+	if c.Result != "" {
+		result["result"] = c.Result
+	}
+	if c.Status != "" {
+		result["status"] = c.Status
+	}
+	if c.Message != "" {
+		result["message"] = c.Message
+	}
+	taskData, ok := result[taskName].(map[string]interface{})
+	if !ok {
+		taskData = map[string]interface{}{}
+		result[taskName] = taskData
+	}
+	for key, value := range c.Data {
+		taskData[key] = value
+	}
+	return result
+}
+
+// newStoredPendingCallback creates a pending callback from a callback loaded from the store
+func newStoredPendingCallback(keptnHandler sdk.IKeptn, storedCallback lib.StoredCallback) (*pendingCallback, error) {
+	callback := &pendingCallback{
+		keptnHandler: keptnHandler,
+		event:        storedCallback.Event,
+		taskName:     storedCallback.TaskName,
+		result:       storedCallback.Result,
+		timeout:      storedCallback.Timeout,
+		stored:       true,
+		ready:        make(chan struct{}),
+	}
+	tokenHash, err := hex.DecodeString(storedCallback.TokenHash)
+	if err != nil || len(tokenHash) != sha256.Size {
+		return nil, errors.New("invalid token hash")
+	}
+	copy(callback.tokenHash[:], tokenHash)
+	if callback.result == nil {
+		callback.result = map[string]interface{}{}
+	}
+	close(callback.ready)
+	return callback, nil
+}
+
+func writeCallbackError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"code": code, "message": message})
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
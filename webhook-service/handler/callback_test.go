@@ -0,0 +1,288 @@
+package handler_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/keptn/keptn/go-sdk/pkg/sdk"
+	"github.com/keptn/keptn/webhook-service/handler"
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/keptn/keptn/webhook-service/lib/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+const webHookContentWithCallback = `apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      callback:
+        deadline: %s
+      requests:
+        - method: POST
+          url: "http://jenkins:8080/job/deploy/build"
+          payload: '{"callbackURL": "{{.callback.url}}", "token": "{{.callback.token}}"}'`
+
+func newCallbackTestSetup(deadline string, executeFunc func(request lib.Request) (*lib.HTTPResponse, error), opts ...handler.CallbackRegistryOption) (*sdk.FakeKeptn, *handler.CallbackRegistry, *fake.IHTTPExecutorMock) {
+	httpExecutorMock := &fake.IHTTPExecutorMock{ExecuteFunc: executeFunc}
+	callbackRegistry := handler.NewCallbackRegistry("http://webhook-service:8082/", opts...)
+	taskHandler := handler.NewTaskHandler(&lib.TemplateEngine{}, &fake.ICurlExecutorMock{}, httpExecutorMock, &fake.ISecretReaderMock{}, handler.WithCallbackRegistry(callbackRegistry))
+
+	fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: fmt.Sprintf(webHookContentWithCallback, deadline)})
+	fakeKeptn.AddTaskHandler("*", taskHandler)
+	fakeKeptn.SetAutomaticResponse(false)
+	fakeKeptn.Start()
+	return fakeKeptn, callbackRegistry, httpExecutorMock
+}
+
+// getCallback extracts the callback URL and token injected into the payload of the executed request
+func getCallback(t *testing.T, httpExecutorMock *fake.IHTTPExecutorMock) (string, string) {
+	require.Len(t, httpExecutorMock.ExecuteCalls(), 1)
+	payload := httpExecutorMock.ExecuteCalls()[0].Request.Payload
+	parts := strings.Split(payload, `"`)
+	require.Len(t, parts, 9)
+	return parts[3], parts[7]
+}
+
+func sendCallback(registry *handler.CallbackRegistry, callbackURL, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, callbackURL, bytes.NewBufferString(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	registry.ServeHTTP(w, req)
+	return w
+}
+
+func TestCallbackRegistry_CallbackReceived(t *testing.T) {
+	fakeKeptn, registry, httpExecutorMock := newCallbackTestSetup("500ms", func(request lib.Request) (*lib.HTTPResponse, error) {
+		return &lib.HTTPResponse{StatusCode: 201, Body: "queued"}, nil
+	})
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+	callbackURL, token := getCallback(t, httpExecutorMock)
+	require.True(t, strings.HasPrefix(callbackURL, "http://webhook-service:8082"+handler.CallbackAPIPath))
+	require.NotEmpty(t, token)
+
+	// only the .started event is sent before the callback is received
+	require.Equal(t, 1, len(fakeKeptn.GetEventSender().SentEvents))
+	assert.Equal(t, "sh.keptn.event.webhook.started", fakeKeptn.GetEventSender().SentEvents[0].Type())
+
+	w := sendCallback(registry, callbackURL, "invalid-token", "")
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = sendCallback(registry, callbackURL, token, `{"result": "unknown"}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = sendCallback(registry, callbackURL, token, `{"result": "warning", "message": "build unstable", "data": {"buildNumber": 42}}`)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	require.Equal(t, 2, len(fakeKeptn.GetEventSender().SentEvents))
+	assert.Equal(t, "sh.keptn.event.webhook.finished", fakeKeptn.GetEventSender().SentEvents[1].Type())
+
+	finishedEvent, err := keptnv2.ToKeptnEvent(fakeKeptn.GetEventSender().SentEvents[1])
+	require.Nil(t, err)
+	eventData := map[string]interface{}{}
+	require.Nil(t, keptnv2.EventDataAs(finishedEvent, &eventData))
+	assert.Equal(t, string(keptnv2.ResultWarning), eventData["result"])
+	assert.Equal(t, string(keptnv2.StatusSucceeded), eventData["status"])
+	assert.Equal(t, "build unstable", eventData["message"])
+	assert.Equal(t, map[string]interface{}{
+		"responses":   []interface{}{"queued"},
+		"buildNumber": float64(42),
+	}, eventData["webhook"])
+
+	// the callback can only be used once, and the deadline does not lead to another .finished event
+	w = sendCallback(registry, callbackURL, token, "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+	time.Sleep(600 * time.Millisecond)
+	require.Equal(t, 2, len(fakeKeptn.GetEventSender().SentEvents))
+}
+
+func TestCallbackRegistry_DeadlineExceeded(t *testing.T) {
+	fakeKeptn, registry, httpExecutorMock := newCallbackTestSetup("50ms", func(request lib.Request) (*lib.HTTPResponse, error) {
+		return &lib.HTTPResponse{StatusCode: 201, Body: "queued"}, nil
+	})
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+	callbackURL, token := getCallback(t, httpExecutorMock)
+
+	require.Eventually(t, func() bool {
+		return len(fakeKeptn.GetEventSender().SentEvents) == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, "sh.keptn.event.webhook.finished", fakeKeptn.GetEventSender().SentEvents[1].Type())
+
+	finishedEvent, err := keptnv2.ToKeptnEvent(fakeKeptn.GetEventSender().SentEvents[1])
+	eventData := &keptnv2.EventData{}
+	keptnv2.EventDataAs(finishedEvent, eventData)
+	require.Nil(t, err)
+	assert.Equal(t, keptnv2.StatusErrored, eventData.Status)
+	assert.Equal(t, keptnv2.ResultFailed, eventData.Result)
+	assert.Equal(t, "no callback received within 50ms", eventData.Message)
+
+	w := sendCallback(registry, callbackURL, token, "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCallbackRegistry_RequestFails(t *testing.T) {
+	fakeKeptn, registry, httpExecutorMock := newCallbackTestSetup("50ms", func(request lib.Request) (*lib.HTTPResponse, error) {
+		return nil, errors.New("could not reach jenkins " + strings.Split(request.Payload, `"`)[7])
+	})
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+	callbackURL, token := getCallback(t, httpExecutorMock)
+
+	require.Equal(t, 2, len(fakeKeptn.GetEventSender().SentEvents))
+	finishedEvent, err := keptnv2.ToKeptnEvent(fakeKeptn.GetEventSender().SentEvents[1])
+	eventData := &keptnv2.EventData{}
+	keptnv2.EventDataAs(finishedEvent, eventData)
+	require.Nil(t, err)
+	assert.Equal(t, keptnv2.StatusErrored, eventData.Status)
+	assert.NotContains(t, eventData.Message, token)
+
+	w := sendCallback(registry, callbackURL, token, "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, 2, len(fakeKeptn.GetEventSender().SentEvents))
+}
+
+func TestCallbackRegistry_StoredCallbackReceived(t *testing.T) {
+	store := lib.NewK8sCallbackStore(k8sfake.NewSimpleClientset())
+	fakeKeptn, registry, httpExecutorMock := newCallbackTestSetup("200ms", func(request lib.Request) (*lib.HTTPResponse, error) {
+		return &lib.HTTPResponse{StatusCode: 201, Body: "queued"}, nil
+	}, handler.WithCallbackStore(store))
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+	callbackURL, token := getCallback(t, httpExecutorMock)
+
+	storedCallbacks, err := store.List()
+	require.Nil(t, err)
+	require.Len(t, storedCallbacks, 1)
+	assert.NotContains(t, storedCallbacks[0].TokenHash, token)
+
+	// the callback is received by another replica
+	otherKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+	otherRegistry := handler.NewCallbackRegistry("http://webhook-service:8082/", handler.WithCallbackStore(store))
+	require.Nil(t, otherRegistry.Restore(otherKeptn))
+
+	w := sendCallback(otherRegistry, callbackURL, "invalid-token", "")
+	require.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = sendCallback(otherRegistry, callbackURL, token, `{"data": {"buildNumber": 42}}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, 1, len(otherKeptn.GetEventSender().SentEvents))
+	assert.Equal(t, "sh.keptn.event.webhook.finished", otherKeptn.GetEventSender().SentEvents[0].Type())
+
+	storedCallbacks, err = store.List()
+	require.Nil(t, err)
+	require.Empty(t, storedCallbacks)
+
+	// neither the replica that registered the callback, nor its deadline lead to another .finished event
+	w = sendCallback(registry, callbackURL, token, "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+	time.Sleep(300 * time.Millisecond)
+	require.Equal(t, 1, len(fakeKeptn.GetEventSender().SentEvents))
+	require.Equal(t, 1, len(otherKeptn.GetEventSender().SentEvents))
+}
+
+func TestCallbackRegistry_Restore(t *testing.T) {
+	store := lib.NewK8sCallbackStore(k8sfake.NewSimpleClientset())
+	fakeKeptn, _, httpExecutorMock := newCallbackTestSetup("1h", func(request lib.Request) (*lib.HTTPResponse, error) {
+		return &lib.HTTPResponse{StatusCode: 201, Body: "queued"}, nil
+	}, handler.WithCallbackStore(store))
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+	callbackURL, token := getCallback(t, httpExecutorMock)
+
+	// the webhook-service is restarted
+	restartedKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+	restartedRegistry := handler.NewCallbackRegistry("http://webhook-service:8082/", handler.WithCallbackStore(store))
+	require.Nil(t, restartedRegistry.Restore(restartedKeptn))
+
+	w := sendCallback(restartedRegistry, callbackURL, token, `{"result": "warning"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, 1, len(restartedKeptn.GetEventSender().SentEvents))
+
+	finishedEvent, err := keptnv2.ToKeptnEvent(restartedKeptn.GetEventSender().SentEvents[0])
+	require.Nil(t, err)
+	eventData := map[string]interface{}{}
+	require.Nil(t, keptnv2.EventDataAs(finishedEvent, &eventData))
+	assert.Equal(t, string(keptnv2.ResultWarning), eventData["result"])
+	assert.Equal(t, map[string]interface{}{
+		"responses": []interface{}{"queued"},
+	}, eventData["webhook"])
+
+	w = sendCallback(restartedRegistry, callbackURL, token, "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCallbackRegistry_RestoreDeadlineExceeded(t *testing.T) {
+	store := lib.NewK8sCallbackStore(k8sfake.NewSimpleClientset())
+	fakeKeptn, _, httpExecutorMock := newCallbackTestSetup("100ms", func(request lib.Request) (*lib.HTTPResponse, error) {
+		return &lib.HTTPResponse{StatusCode: 201, Body: "queued"}, nil
+	}, handler.WithCallbackStore(store))
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+	callbackURL, token := getCallback(t, httpExecutorMock)
+
+	// the webhook-service is restarted after the deadline has passed, and the callback of the previous instance has not been removed
+	storedCallbacks, err := store.List()
+	require.Nil(t, err)
+	require.Len(t, storedCallbacks, 1)
+	_, err = store.Delete(storedCallbacks[0].ID)
+	require.Nil(t, err)
+	time.Sleep(200 * time.Millisecond)
+	require.Nil(t, store.Save(storedCallbacks[0]))
+
+	restartedKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+	restartedRegistry := handler.NewCallbackRegistry("http://webhook-service:8082/", handler.WithCallbackStore(store))
+	require.Nil(t, restartedRegistry.Restore(restartedKeptn))
+
+	require.Eventually(t, func() bool {
+		return len(restartedKeptn.GetEventSender().SentEvents) == 1
+	}, time.Second, 10*time.Millisecond)
+	finishedEvent, err := keptnv2.ToKeptnEvent(restartedKeptn.GetEventSender().SentEvents[0])
+	require.Nil(t, err)
+	eventData := &keptnv2.EventData{}
+	require.Nil(t, keptnv2.EventDataAs(finishedEvent, eventData))
+	assert.Equal(t, keptnv2.ResultFailed, eventData.Result)
+	assert.Equal(t, "no callback received within 100ms", eventData.Message)
+
+	w := sendCallback(restartedRegistry, callbackURL, token, "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCallbackRegistry_ServeHTTP(t *testing.T) {
+	registry := handler.NewCallbackRegistry("http://webhook-service:8082")
+
+	w := httptest.NewRecorder()
+	registry.ServeHTTP(w, httptest.NewRequest(http.MethodGet, handler.CallbackAPIPath+"my-id", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+	w = httptest.NewRecorder()
+	registry.ServeHTTP(w, httptest.NewRequest(http.MethodPost, handler.CallbackAPIPath+"unknown-id", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestTaskHandler_CallbacksDisabled(t *testing.T) {
+	httpExecutorMock := &fake.IHTTPExecutorMock{}
+	taskHandler := handler.NewTaskHandler(&lib.TemplateEngine{}, &fake.ICurlExecutorMock{}, httpExecutorMock, &fake.ISecretReaderMock{})
+
+	fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: fmt.Sprintf(webHookContentWithCallback, "1h")})
+	fakeKeptn.AddTaskHandler("*", taskHandler)
+	fakeKeptn.SetAutomaticResponse(false)
+	fakeKeptn.Start()
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+	require.Empty(t, httpExecutorMock.ExecuteCalls())
+	require.Equal(t, 2, len(fakeKeptn.GetEventSender().SentEvents))
+	assert.Equal(t, "sh.keptn.event.webhook.finished", fakeKeptn.GetEventSender().SentEvents[1].Type())
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/keptn/go-utils/pkg/api/models"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
@@ -21,15 +22,39 @@ type SecretEnv struct {
 type TaskHandler struct {
 	templateEngine lib.ITemplateEngine
 	curlExecutor   lib.ICurlExecutor
+	httpExecutor   lib.IHTTPExecutor
 	secretReader   lib.ISecretReader
+	callbacks      *CallbackRegistry
+	circuitBreaker *lib.CircuitBreaker
 }
 
-func NewTaskHandler(templateEngine lib.ITemplateEngine, curlExecutor lib.ICurlExecutor, secretReader lib.ISecretReader) *TaskHandler {
-	return &TaskHandler{
+type TaskHandlerOption func(taskHandler *TaskHandler)
+
+// WithCallbackRegistry enables asynchronous webhooks, which are finished by a callback of the called system
+func WithCallbackRegistry(callbacks *CallbackRegistry) TaskHandlerOption {
+	return func(taskHandler *TaskHandler) {
+		taskHandler.callbacks = callbacks
+	}
+}
+
+// WithCircuitBreaker rejects requests to targets that failed repeatedly
+func WithCircuitBreaker(circuitBreaker *lib.CircuitBreaker) TaskHandlerOption {
+	return func(taskHandler *TaskHandler) {
+		taskHandler.circuitBreaker = circuitBreaker
+	}
+}
+
+func NewTaskHandler(templateEngine lib.ITemplateEngine, curlExecutor lib.ICurlExecutor, httpExecutor lib.IHTTPExecutor, secretReader lib.ISecretReader, opts ...TaskHandlerOption) *TaskHandler {
+	taskHandler := &TaskHandler{
 		templateEngine: templateEngine,
 		curlExecutor:   curlExecutor,
+		httpExecutor:   httpExecutor,
 		secretReader:   secretReader,
 	}
+	for _, o := range opts {
+		o(taskHandler)
+	}
+	return taskHandler
 }
 
 func (th *TaskHandler) Execute(keptnHandler sdk.IKeptn, event sdk.KeptnEvent) (interface{}, *sdk.Error) {
@@ -61,12 +86,43 @@ func (th *TaskHandler) Execute(keptnHandler sdk.IKeptn, event sdk.KeptnEvent) (i
 		return nil, sdkError(removeSecretsFromMessage(err.Error(), secretEnvVars), err)
 	}
 	eventAdapter.Add("env", secretEnvVars)
-	responses, err = th.performWebhookRequests(*webhook, eventAdapter, responses)
+
+	credentials, err := th.gatherRequestCredentials(*webhook)
 	if err != nil {
 		onError(err, secretEnvVars)
 		return nil, sdkError(removeSecretsFromMessage(err.Error(), secretEnvVars), err)
 	}
 
+	// for asynchronous webhooks, the URL and token of the callback are available within the requests
+	callback, err := th.registerCallback(keptnHandler, event, webhook)
+	if err != nil {
+		onError(lib.NewWebhookExecutionError(true, err), secretEnvVars)
+		return nil, sdkError(err.Error(), err)
+	}
+	hiddenValues := map[string]string{}
+	for key, value := range secretEnvVars {
+		hiddenValues[key] = value
+	}
+	if callback != nil {
+		eventAdapter.Add("callback", map[string]interface{}{
+			"url":   callback.URL,
+			"token": callback.Token,
+		})
+		hiddenValues["callbackToken"] = callback.Token
+	}
+	if credentials != nil && credentials.SigningKey != "" {
+		hiddenValues["signingKey"] = credentials.SigningKey
+	}
+
+	responses, mappedValues, err := th.performWebhookRequests(*webhook, eventAdapter, responses, credentials)
+	if err != nil {
+		if callback != nil {
+			th.callbacks.Remove(callback.ID)
+		}
+		onError(err, hiddenValues)
+		return nil, sdkError(removeSecretsFromMessage(err.Error(), hiddenValues), err)
+	}
+
 	// check if the incoming event was a task.triggered event, and if the 'sendFinished'  property of the webhook was set to true
 	// only in this case, the result should be sent back to Keptn in the form of a .finished event
 	if keptnv2.IsTaskEventType(*event.Type) && keptnv2.IsTriggeredEventType(*event.Type) && webhook.SendFinished {
@@ -74,14 +130,23 @@ func (th *TaskHandler) Execute(keptnHandler sdk.IKeptn, event sdk.KeptnEvent) (i
 		if err != nil {
 			return nil, sdkError(fmt.Sprintf("could not derive task name from event type %s", *event.Type), err)
 		}
+		// the values mapped from the responses are added to the task's data, next to the raw responses
+		taskData := map[string]interface{}{}
+		for key, value := range mappedValues {
+			taskData[key] = value
+		}
+		taskData[lib.ResponsesField] = responses
 		result := map[string]interface{}{
 			"project": eventAdapter.Project(),
 			"stage":   eventAdapter.Stage(),
 			"service": eventAdapter.Service(),
 			"labels":  eventAdapter.Labels(),
-			taskName: map[string]interface{}{
-				"responses": responses,
-			},
+			taskName:  taskData,
+		}
+		if callback != nil {
+			// the .finished event is sent once the callback has been received
+			th.callbacks.Activate(callback.ID, result, webhook.Callback.GetDeadline())
+			return nil, nil
 		}
 		err = keptnHandler.SendFinishedEvent(event, result)
 		if err != nil {
@@ -93,6 +158,21 @@ func (th *TaskHandler) Execute(keptnHandler sdk.IKeptn, event sdk.KeptnEvent) (i
 	return nil, nil
 }
 
+// registerCallback creates the callback for asynchronous webhooks. For synchronous webhooks, nil is returned
+func (th *TaskHandler) registerCallback(keptnHandler sdk.IKeptn, event sdk.KeptnEvent, webhook *lib.Webhook) (*Callback, error) {
+	if webhook.Callback == nil || !keptnv2.IsTaskEventType(*event.Type) || !keptnv2.IsTriggeredEventType(*event.Type) {
+		return nil, nil
+	}
+	if th.callbacks == nil {
+		return nil, errCallbacksDisabled
+	}
+	taskName, _, err := keptnv2.ParseTaskEventType(*event.Type)
+	if err != nil {
+		return nil, fmt.Errorf("could not derive task name from event type %s: %w", *event.Type, err)
+	}
+	return th.callbacks.Register(keptnHandler, event, taskName)
+}
+
 func (th *TaskHandler) onPreExecutionError(keptnHandler sdk.IKeptn, event sdk.KeptnEvent, eventAdapter *lib.EventDataAdapter, err error) (interface{}, *sdk.Error) {
 	// in this case, send .started and .finished event immediately
 	if err := keptnHandler.SendStartedEvent(event); err != nil {
@@ -114,8 +194,12 @@ func (th *TaskHandler) onPreExecutionError(keptnHandler sdk.IKeptn, event sdk.Ke
 
 func (th *TaskHandler) getErrorCallbackForWebhookConfig(keptnHandler sdk.IKeptn, event sdk.KeptnEvent, eventAdapter *lib.EventDataAdapter, webhook *lib.Webhook) func(err error, secrets map[string]string) {
 	return func(err error, secrets map[string]string) {
-		logger.WithError(err).Error("error during webhook execution")
 		whe, ok := err.(*lib.WebhookExecutionError)
+		if ok && whe.Attempts > 0 {
+			logger.WithError(err).WithField("attempts", whe.Attempts).Error("error during webhook execution")
+		} else {
+			logger.WithError(err).Error("error during webhook execution")
+		}
 
 		result := map[string]interface{}{
 			"project": eventAdapter.Project(),
@@ -126,6 +210,10 @@ func (th *TaskHandler) getErrorCallbackForWebhookConfig(keptnHandler sdk.IKeptn,
 			"status":  keptnv2.StatusErrored,
 			"message": removeSecretsFromMessage(err.Error(), secrets),
 		}
+		if lib.IsSuccessConditionError(err) {
+			// the request has been executed, but the response indicates that the task failed
+			result["status"] = keptnv2.StatusSucceeded
+		}
 
 		if ok && whe.PreExecutionError {
 			if webhook.SendFinished {
@@ -184,24 +272,83 @@ func (th *TaskHandler) onStartedWebhookExecution(keptnHandler sdk.IKeptn, event
 	return nil
 }
 
-func (th *TaskHandler) performWebhookRequests(webhook lib.Webhook, eventAdapter *lib.EventDataAdapter, responses []string) ([]string, error) {
+func (th *TaskHandler) performWebhookRequests(webhook lib.Webhook, eventAdapter *lib.EventDataAdapter, responses []string, credentials *lib.RequestCredentials) ([]string, map[string]interface{}, error) {
 	executedRequests := 0
+	mappedValues := map[string]interface{}{}
 	logger.Infof("executing webhooks for subscriptionID %s", webhook.SubscriptionID)
 	for _, req := range webhook.Requests {
 		// parse the data from the event, together with the secret env vars
-		parsedCurlCommand, err := th.templateEngine.ParseTemplate(eventAdapter.Get(), req)
+		parsedRequest, err := th.parseRequest(eventAdapter, req)
 		if err != nil {
-			return nil, lib.NewWebhookExecutionError(true, fmt.Errorf("could not parse request '%s' : %s", req, err.Error()), lib.WithNrOfExecutedRequests(executedRequests))
+			return nil, nil, lib.NewWebhookExecutionError(true, fmt.Errorf("could not parse request '%s' : %s", req, err.Error()), lib.WithNrOfExecutedRequests(executedRequests))
 		}
+		parsedRequest.Credentials = credentials
 		// perform the request
-		response, err := th.curlExecutor.Curl(parsedCurlCommand)
+		response, attempts, err := th.executeRequestWithRetries(*parsedRequest, webhook.Retry)
 		if err != nil {
-			return nil, lib.NewWebhookExecutionError(true, fmt.Errorf("could not execute request '%s': %s", req, err.Error()), lib.WithNrOfExecutedRequests(executedRequests))
+			return nil, nil, lib.NewWebhookExecutionError(true, fmt.Errorf("could not execute request '%s' (attempts: %d): %s", req, attempts, err.Error()), lib.WithNrOfExecutedRequests(executedRequests), lib.WithAttempts(attempts))
+		}
+		if err := parsedRequest.CheckSuccessCondition(response); err != nil {
+			return nil, nil, lib.NewWebhookExecutionError(true, fmt.Errorf("request '%s' was not successful: %w", req, err), lib.WithNrOfExecutedRequests(executedRequests))
+		}
+		if err := parsedRequest.MapResponse(response.Body, mappedValues); err != nil {
+			return nil, nil, lib.NewWebhookExecutionError(true, fmt.Errorf("could not map response of request '%s': %s", req, err.Error()), lib.WithNrOfExecutedRequests(executedRequests))
 		}
 		executedRequests = executedRequests + 1
-		responses = append(responses, response)
+		responses = append(responses, response.Body)
+	}
+	return responses, mappedValues, nil
+}
+
+// parseRequest fills the templates of a request with the data from the event. For structured requests, the URL, the header values and the payload are parsed
+func (th *TaskHandler) parseRequest(eventAdapter *lib.EventDataAdapter, req lib.Request) (*lib.Request, error) {
+	return req.Render(th.templateEngine, eventAdapter.Get())
+}
+
+// executeRequestWithRetries executes the request until it succeeds, a non-retryable error occurs, or the attempts or the deadline of the retry settings are exhausted.
+// It returns the number of attempts made
+func (th *TaskHandler) executeRequestWithRetries(req lib.Request, retry *lib.Retry) (*lib.HTTPResponse, int, error) {
+	start := time.Now()
+	attempt := 1
+	for {
+		response, err := th.executeRequestOnTarget(req)
+		if err == nil || attempt >= retry.GetAttempts() || !retry.IsRetryable(err) {
+			return response, attempt, err
+		}
+		attempt = attempt + 1
+		backoff := retry.GetBackoff(attempt)
+		if deadline := retry.GetDeadline(); deadline > 0 && time.Since(start)+backoff > deadline {
+			return nil, attempt - 1, fmt.Errorf("retry deadline of %s exceeded: %w", deadline, err)
+		}
+		logger.Infof("request '%s' failed, retrying in %s: %s", req, backoff, err.Error())
+		time.Sleep(backoff)
+	}
+}
+
+// executeRequestOnTarget executes the request, unless the circuit of its target is open
+func (th *TaskHandler) executeRequestOnTarget(req lib.Request) (*lib.HTTPResponse, error) {
+	target := req.Target()
+	if th.circuitBreaker == nil || target == "" {
+		return th.executeRequest(req)
+	}
+	if err := th.circuitBreaker.Allow(target); err != nil {
+		return nil, err
 	}
-	return responses, nil
+	response, err := th.executeRequest(req)
+	th.circuitBreaker.Record(target, lib.IsTargetError(err))
+	return response, err
+}
+
+func (th *TaskHandler) executeRequest(req lib.Request) (*lib.HTTPResponse, error) {
+	if req.IsCurlCommand() {
+		response, err := th.curlExecutor.Curl(req.Curl)
+		if err != nil {
+			return nil, err
+		}
+		// the status code of curl requests is not available, since they fail for all status codes >= 400
+		return &lib.HTTPResponse{Body: response}, nil
+	}
+	return th.httpExecutor.Execute(req)
 }
 
 func (th *TaskHandler) gatherSecretEnvVars(webhook lib.Webhook) (map[string]string, error) {
@@ -216,6 +363,51 @@ func (th *TaskHandler) gatherSecretEnvVars(webhook lib.Webhook) (map[string]stri
 	return secretEnvVars, nil
 }
 
+// gatherRequestCredentials reads the signing key and the client certificate referenced by the webhook. If neither is set, nil is returned
+func (th *TaskHandler) gatherRequestCredentials(webhook lib.Webhook) (*lib.RequestCredentials, error) {
+	if webhook.Signing == nil && webhook.TLS == nil {
+		return nil, nil
+	}
+	credentials := &lib.RequestCredentials{}
+	if webhook.Signing != nil {
+		signingKey, err := th.readSecret(webhook.Signing.SecretRef)
+		if err != nil {
+			return nil, err
+		}
+		credentials.SigningKey = signingKey
+	}
+	if webhook.TLS != nil {
+		clientCert, err := th.readSecret(webhook.TLS.ClientCertRef)
+		if err != nil {
+			return nil, err
+		}
+		clientKey, err := th.readSecret(webhook.TLS.ClientKeyRef)
+		if err != nil {
+			return nil, err
+		}
+		caCert := ""
+		if webhook.TLS.CARef != nil {
+			if caCert, err = th.readSecret(*webhook.TLS.CARef); err != nil {
+				return nil, err
+			}
+		}
+		tlsConfig, err := lib.NewTLSConfig(clientCert, clientKey, caCert)
+		if err != nil {
+			return nil, lib.NewWebhookExecutionError(true, fmt.Errorf("could not create TLS configuration: %s", err.Error()))
+		}
+		credentials.TLSConfig = tlsConfig
+	}
+	return credentials, nil
+}
+
+func (th *TaskHandler) readSecret(secretRef lib.WebHookSecretRef) (string, error) {
+	secretValue, err := th.secretReader.ReadSecret(secretRef.Name, secretRef.Key)
+	if err != nil {
+		return "", lib.NewWebhookExecutionError(true, fmt.Errorf("could not read secret %s.%s", secretRef.Name, secretRef.Key))
+	}
+	return secretValue, nil
+}
+
 func sdkError(msg string, err error) *sdk.Error {
 	return &sdk.Error{
 		StatusType: keptnv2.StatusErrored,
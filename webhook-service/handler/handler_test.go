@@ -3,6 +3,7 @@ package handler_test
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/keptn/go-utils/pkg/api/models"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
@@ -15,6 +16,7 @@ import (
 	"io/ioutil"
 	"log"
 	"testing"
+	"time"
 )
 
 const webHookContent = `apiVersion: webhookconfig.keptn.sh/v1alpha1
@@ -32,6 +34,87 @@ spec:
       requests:
         - "curl http://localhost:8080 {{.data.project}} {{.env.mysecret}}"`
 
+const webHookContentWithStructuredRequest = `apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      envFrom:
+        - secretRef:
+          name: mysecret
+      requests:
+        - method: POST
+          url: "http://my-webhook:8080/{{.data.project}}"
+          headers:
+            - key: x-token
+              value: "{{.env.mysecret}}"
+          payload: '{"stage": "{{.data.stage}}"}'
+          expectedStatusCodes:
+            - 201
+        - "curl http://localhost:8080 {{.data.project}} {{.env.mysecret}}"`
+
+const webHookContentWithResponseMappings = `apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      requests:
+        - method: POST
+          url: "http://my-ticket-system:8080/tickets"
+          responseMappings:
+            - path: ".id"
+              field: "ticket.id"
+            - path: ".links.self"
+              field: "ticket.url"
+          successCondition:
+            statusCodes:
+              - 201
+            body:
+              path: ".status"
+              matches: "^open$"`
+
+const webHookContentWithRetry = `apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      retry:
+        attempts: 3
+        initialBackoff: 1ms
+        maxBackoff: 2ms
+      requests:
+        - url: "http://my-webhook:8080/{{.data.project}}"`
+
+const webHookContentWithSigning = `apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      signing:
+        secretRef:
+          name: webhook-signing
+          key: key
+      requests:
+        - method: POST
+          url: "http://my-webhook:8080/{{.data.project}}"
+          payload: '{"project": "{{.data.project}}"}'`
+
 const webHookContentWithStartedEvent = `apiVersion: webhookconfig.keptn.sh/v1alpha1
 kind: WebhookConfig
 metadata:
@@ -152,7 +235,7 @@ func Test_HandleIncomingTriggeredEvent(t *testing.T) {
 		return "success", nil
 	}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -178,6 +261,270 @@ func Test_HandleIncomingTriggeredEvent(t *testing.T) {
 	assert.Equal(t, keptnv2.ResultPass, eventData.Result)
 }
 
+func Test_HandleIncomingTriggeredEvent_StructuredRequest(t *testing.T) {
+	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
+		tplE := &lib.TemplateEngine{}
+		return tplE.ParseTemplate(data, templateStr)
+	}}
+
+	secretReaderMock := &fake.ISecretReaderMock{}
+	secretReaderMock.ReadSecretFunc = func(name string, key string) (string, error) {
+		return "my-secret-value", nil
+	}
+
+	curlExecutorMock := &fake.ICurlExecutorMock{}
+	curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
+		return "success", nil
+	}
+
+	httpExecutorMock := &fake.IHTTPExecutorMock{}
+	httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+		return &lib.HTTPResponse{StatusCode: 201, Body: "created"}, nil
+	}
+
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, httpExecutorMock, secretReaderMock)
+
+	fakeKeptn := sdk.NewFakeKeptn(
+		"test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithStructuredRequest})
+	fakeKeptn.AddTaskHandler("*", taskHandler)
+	fakeKeptn.SetAutomaticResponse(false)
+	fakeKeptn.Start()
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+	require.Len(t, httpExecutorMock.ExecuteCalls(), 1)
+	assert.Equal(t, lib.Request{
+		Method:              "POST",
+		URL:                 "http://my-webhook:8080/myproject",
+		Headers:             []lib.Header{{Key: "x-token", Value: "my-secret-value"}},
+		Payload:             `{"stage": "mystage"}`,
+		ExpectedStatusCodes: []int{201},
+	}, httpExecutorMock.ExecuteCalls()[0].Request)
+
+	require.Len(t, curlExecutorMock.CurlCalls(), 1)
+	assert.Equal(t, "curl http://localhost:8080 myproject my-secret-value", curlExecutorMock.CurlCalls()[0].CurlCmd)
+
+	//verify sent events
+	require.Equal(t, 2, len(fakeKeptn.GetEventSender().SentEvents))
+	assert.Equal(t, "sh.keptn.event.webhook.started", fakeKeptn.GetEventSender().SentEvents[0].Type())
+	assert.Equal(t, "sh.keptn.event.webhook.finished", fakeKeptn.GetEventSender().SentEvents[1].Type())
+
+	finishedEvent, err := keptnv2.ToKeptnEvent(fakeKeptn.GetEventSender().SentEvents[1])
+	require.Nil(t, err)
+	eventData := map[string]interface{}{}
+	require.Nil(t, keptnv2.EventDataAs(finishedEvent, &eventData))
+	assert.Equal(t, map[string]interface{}{"responses": []interface{}{"created", "success"}}, eventData["webhook"])
+}
+
+func Test_HandleIncomingTriggeredEvent_ResponseMappings(t *testing.T) {
+	httpExecutorMock := &fake.IHTTPExecutorMock{}
+	httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+		return &lib.HTTPResponse{StatusCode: 201, Body: `{"id": "TICKET-1", "status": "open", "links": {"self": "https://tickets/TICKET-1"}}`}, nil
+	}
+
+	taskHandler := handler.NewTaskHandler(&lib.TemplateEngine{}, &fake.ICurlExecutorMock{}, httpExecutorMock, &fake.ISecretReaderMock{})
+
+	fakeKeptn := sdk.NewFakeKeptn(
+		"test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithResponseMappings})
+	fakeKeptn.AddTaskHandler("*", taskHandler)
+	fakeKeptn.SetAutomaticResponse(false)
+	fakeKeptn.Start()
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+	//verify sent events
+	require.Equal(t, 2, len(fakeKeptn.GetEventSender().SentEvents))
+	assert.Equal(t, "sh.keptn.event.webhook.finished", fakeKeptn.GetEventSender().SentEvents[1].Type())
+
+	finishedEvent, err := keptnv2.ToKeptnEvent(fakeKeptn.GetEventSender().SentEvents[1])
+	require.Nil(t, err)
+	eventData := map[string]interface{}{}
+	require.Nil(t, keptnv2.EventDataAs(finishedEvent, &eventData))
+	assert.Equal(t, string(keptnv2.ResultPass), eventData["result"])
+	assert.Equal(t, map[string]interface{}{
+		"responses": []interface{}{`{"id": "TICKET-1", "status": "open", "links": {"self": "https://tickets/TICKET-1"}}`},
+		"ticket": map[string]interface{}{
+			"id":  "TICKET-1",
+			"url": "https://tickets/TICKET-1",
+		},
+	}, eventData["webhook"])
+}
+
+func Test_HandleIncomingTriggeredEvent_SuccessConditionNotMet(t *testing.T) {
+	httpExecutorMock := &fake.IHTTPExecutorMock{}
+	httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+		return &lib.HTTPResponse{StatusCode: 201, Body: `{"id": "TICKET-1", "status": "rejected"}`}, nil
+	}
+
+	taskHandler := handler.NewTaskHandler(&lib.TemplateEngine{}, &fake.ICurlExecutorMock{}, httpExecutorMock, &fake.ISecretReaderMock{})
+
+	fakeKeptn := sdk.NewFakeKeptn(
+		"test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithResponseMappings})
+	fakeKeptn.AddTaskHandler("*", taskHandler)
+	fakeKeptn.SetAutomaticResponse(false)
+	fakeKeptn.Start()
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+	//verify sent events
+	require.Equal(t, 2, len(fakeKeptn.GetEventSender().SentEvents))
+	assert.Equal(t, "sh.keptn.event.webhook.finished", fakeKeptn.GetEventSender().SentEvents[1].Type())
+
+	finishedEvent, err := keptnv2.ToKeptnEvent(fakeKeptn.GetEventSender().SentEvents[1])
+	eventData := &keptnv2.EventData{}
+	keptnv2.EventDataAs(finishedEvent, eventData)
+	require.Nil(t, err)
+	assert.Equal(t, keptnv2.StatusSucceeded, eventData.Status)
+	assert.Equal(t, keptnv2.ResultFailed, eventData.Result)
+	assert.Contains(t, eventData.Message, "response value 'rejected' does not match '^open$'")
+}
+
+func TestTaskHandler_RetryRequests(t *testing.T) {
+	tests := []struct {
+		name         string
+		responses    []error
+		wantAttempts int
+		wantStatus   keptnv2.StatusType
+	}{
+		{
+			name:         "succeeds after retries",
+			responses:    []error{lib.NewRequestError(errors.New("bad gateway"), 502), lib.NewRequestError(errors.New("connection refused"), 0), nil},
+			wantAttempts: 3,
+			wantStatus:   keptnv2.StatusSucceeded,
+		},
+		{
+			name:         "fails after all attempts",
+			responses:    []error{lib.NewRequestError(errors.New("bad gateway"), 502), lib.NewRequestError(errors.New("bad gateway"), 502), lib.NewRequestError(errors.New("bad gateway"), 502)},
+			wantAttempts: 3,
+			wantStatus:   keptnv2.StatusErrored,
+		},
+		{
+			name:         "non retryable error",
+			responses:    []error{lib.NewRequestError(errors.New("bad request"), 400)},
+			wantAttempts: 1,
+			wantStatus:   keptnv2.StatusErrored,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpExecutorMock := &fake.IHTTPExecutorMock{}
+			httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+				if err := tt.responses[len(httpExecutorMock.ExecuteCalls())-1]; err != nil {
+					return nil, err
+				}
+				return &lib.HTTPResponse{StatusCode: 200, Body: "success"}, nil
+			}
+			taskHandler := handler.NewTaskHandler(&lib.TemplateEngine{}, &fake.ICurlExecutorMock{}, httpExecutorMock, &fake.ISecretReaderMock{})
+
+			fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+			fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithRetry})
+			fakeKeptn.AddTaskHandler("*", taskHandler)
+			fakeKeptn.SetAutomaticResponse(false)
+			fakeKeptn.Start()
+			fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+			require.Len(t, httpExecutorMock.ExecuteCalls(), tt.wantAttempts)
+
+			require.Equal(t, 2, len(fakeKeptn.GetEventSender().SentEvents))
+			finishedEvent, err := keptnv2.ToKeptnEvent(fakeKeptn.GetEventSender().SentEvents[1])
+			require.Nil(t, err)
+			eventData := &keptnv2.EventData{}
+			keptnv2.EventDataAs(finishedEvent, eventData)
+			assert.Equal(t, tt.wantStatus, eventData.Status)
+			if tt.wantStatus == keptnv2.StatusErrored {
+				assert.Contains(t, eventData.Message, fmt.Sprintf("(attempts: %d)", tt.wantAttempts))
+			}
+		})
+	}
+}
+
+func TestTaskHandler_CircuitBreaker(t *testing.T) {
+	httpExecutorMock := &fake.IHTTPExecutorMock{}
+	httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+		return nil, lib.NewRequestError(errors.New("service unavailable"), 503)
+	}
+	taskHandler := handler.NewTaskHandler(&lib.TemplateEngine{}, &fake.ICurlExecutorMock{}, httpExecutorMock, &fake.ISecretReaderMock{},
+		handler.WithCircuitBreaker(lib.NewCircuitBreaker(5, time.Minute)))
+
+	fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithRetry})
+	fakeKeptn.AddTaskHandler("*", taskHandler)
+	fakeKeptn.SetAutomaticResponse(false)
+	fakeKeptn.Start()
+
+	// the first event leads to three failed attempts, the second one opens the circuit after two further attempts
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+	require.Len(t, httpExecutorMock.ExecuteCalls(), 5)
+
+	// requests of further events are rejected without being executed
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+	require.Len(t, httpExecutorMock.ExecuteCalls(), 5)
+
+	require.Equal(t, 6, len(fakeKeptn.GetEventSender().SentEvents))
+	finishedEvent, err := keptnv2.ToKeptnEvent(fakeKeptn.GetEventSender().SentEvents[5])
+	require.Nil(t, err)
+	eventData := &keptnv2.EventData{}
+	keptnv2.EventDataAs(finishedEvent, eventData)
+	assert.Equal(t, keptnv2.StatusErrored, eventData.Status)
+	assert.Contains(t, eventData.Message, "circuit breaker for my-webhook:8080 is open")
+}
+
+func TestTaskHandler_SignedRequest(t *testing.T) {
+	httpExecutorMock := &fake.IHTTPExecutorMock{}
+	httpExecutorMock.ExecuteFunc = func(request lib.Request) (*lib.HTTPResponse, error) {
+		return &lib.HTTPResponse{StatusCode: 200, Body: "success"}, nil
+	}
+	secretReaderMock := &fake.ISecretReaderMock{}
+	secretReaderMock.ReadSecretFunc = func(name, key string) (string, error) {
+		return "my-signing-key", nil
+	}
+	taskHandler := handler.NewTaskHandler(&lib.TemplateEngine{}, &fake.ICurlExecutorMock{}, httpExecutorMock, secretReaderMock)
+
+	fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithSigning})
+	fakeKeptn.AddTaskHandler("*", taskHandler)
+	fakeKeptn.SetAutomaticResponse(false)
+	fakeKeptn.Start()
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+	require.Len(t, secretReaderMock.ReadSecretCalls(), 1)
+	assert.Equal(t, "webhook-signing", secretReaderMock.ReadSecretCalls()[0].Name)
+	assert.Equal(t, "key", secretReaderMock.ReadSecretCalls()[0].Key)
+
+	require.Len(t, httpExecutorMock.ExecuteCalls(), 1)
+	executedRequest := httpExecutorMock.ExecuteCalls()[0].Request
+	require.NotNil(t, executedRequest.Credentials)
+	assert.Equal(t, "my-signing-key", executedRequest.Credentials.SigningKey)
+	assert.Nil(t, executedRequest.Credentials.TLSConfig)
+	assert.Equal(t, `{"project": "myproject"}`, executedRequest.Payload)
+}
+
+func TestTaskHandler_SignedRequestSecretNotFound(t *testing.T) {
+	httpExecutorMock := &fake.IHTTPExecutorMock{}
+	secretReaderMock := &fake.ISecretReaderMock{}
+	secretReaderMock.ReadSecretFunc = func(name, key string) (string, error) {
+		return "", errors.New("secret not found")
+	}
+	taskHandler := handler.NewTaskHandler(&lib.TemplateEngine{}, &fake.ICurlExecutorMock{}, httpExecutorMock, secretReaderMock)
+
+	fakeKeptn := sdk.NewFakeKeptn("test-webhook-svc")
+	fakeKeptn.SetResourceHandler(sdk.StringResourceHandler{ResourceContent: webHookContentWithSigning})
+	fakeKeptn.AddTaskHandler("*", taskHandler)
+	fakeKeptn.SetAutomaticResponse(false)
+	fakeKeptn.Start()
+	fakeKeptn.NewEvent(newWebhookTriggeredEvent("test/events/test-webhook.triggered-0.json"))
+
+	require.Empty(t, httpExecutorMock.ExecuteCalls())
+	require.Equal(t, 2, len(fakeKeptn.GetEventSender().SentEvents))
+	finishedEvent, err := keptnv2.ToKeptnEvent(fakeKeptn.GetEventSender().SentEvents[1])
+	require.Nil(t, err)
+	eventData := &keptnv2.EventData{}
+	keptnv2.EventDataAs(finishedEvent, eventData)
+	assert.Equal(t, keptnv2.StatusErrored, eventData.Status)
+	assert.Equal(t, "could not read secret webhook-signing.key", eventData.Message)
+}
+
 func Test_HandleIncomingStartedEvent(t *testing.T) {
 	templateEngineMock := &fake.ITemplateEngineMock{ParseTemplateFunc: func(data interface{}, templateStr string) (string, error) {
 		tplE := &lib.TemplateEngine{}
@@ -194,7 +541,7 @@ func Test_HandleIncomingStartedEvent(t *testing.T) {
 		return "success", nil
 	}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -227,7 +574,7 @@ func Test_HandleIncomingStartedEventWithResultingError(t *testing.T) {
 		return "", errors.New("oops")
 	}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -260,7 +607,7 @@ func Test_HandleIncomingFinishedEvent(t *testing.T) {
 		return "success", nil
 	}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -293,7 +640,7 @@ func Test_HandleIncomingFinishedEventWithResultingError(t *testing.T) {
 		return "", errors.New("oops")
 	}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -326,7 +673,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequests(t *testing.T) {
 		return "success", nil
 	}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -370,7 +717,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableFinished(t *te
 		return "success", nil
 	}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -415,7 +762,7 @@ func Test_HandleIncomingTriggeredEvent_SendMultipleRequestsDisableFinishedOneReq
 		return "success", nil
 	}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -459,7 +806,7 @@ func Test_HandleIncomingTriggeredEvent_NoMatchingWebhookFound(t *testing.T) {
 		return "success", nil
 	}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -490,7 +837,7 @@ func TestTaskHandler_Execute_WebhookCannotBeRetrieved(t *testing.T) {
 	secretReaderMock := &fake.ISecretReaderMock{}
 	curlExecutorMock := &fake.ICurlExecutorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -519,7 +866,7 @@ func TestTaskHandler_Execute_NoSubscriptionIDInEvent(t *testing.T) {
 	secretReaderMock := &fake.ISecretReaderMock{}
 	curlExecutorMock := &fake.ICurlExecutorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -541,7 +888,7 @@ func TestTaskHandler_Execute_InvalidEvent(t *testing.T) {
 	secretReaderMock := &fake.ISecretReaderMock{}
 	curlExecutorMock := &fake.ICurlExecutorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -566,7 +913,7 @@ func TestTaskHandler_CannotReadSecret(t *testing.T) {
 	}
 	curlExecutorMock := &fake.ICurlExecutorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -601,7 +948,7 @@ func TestTaskHandler_IncompleteDataForTemplate(t *testing.T) {
 	}
 	curlExecutorMock := &fake.ICurlExecutorMock{}
 
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -642,7 +989,7 @@ func TestTaskHandler_CurlExecutorFails(t *testing.T) {
 	curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
 		return "", errors.New("unable to execute curl call")
 	}
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
@@ -683,7 +1030,7 @@ func TestTaskHandler_CurlExecutorFailsHideSecret(t *testing.T) {
 	curlExecutorMock.CurlFunc = func(curlCmd string) (string, error) {
 		return "", errors.New("unable to execute curl call containing secret my-secret-value")
 	}
-	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, secretReaderMock)
+	taskHandler := handler.NewTaskHandler(templateEngineMock, curlExecutorMock, &fake.IHTTPExecutorMock{}, secretReaderMock)
 
 	fakeKeptn := sdk.NewFakeKeptn(
 		"test-webhook-svc")
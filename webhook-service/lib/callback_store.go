@@ -0,0 +1,123 @@
+package lib
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/keptn/keptn/go-sdk/pkg/sdk"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const callbackConfigMapPrefix = "webhook-callback-"
+const callbackConfigMapKey = "callback"
+const callbackManagedByLabel = "app.kubernetes.io/managed-by"
+const callbackManagedByValue = "keptn-webhook-service"
+const callbackComponentLabel = "app.kubernetes.io/component"
+const callbackComponentValue = "callback"
+
+// StoredCallback is a pending callback of an asynchronous webhook whose requests have already been executed
+type StoredCallback struct {
+	ID string `json:"id"`
+	// TokenHash is the hex encoded SHA-256 hash of the callback token. The token itself is never stored
+	TokenHash string                 `json:"tokenHash"`
+	Event     sdk.KeptnEvent         `json:"event"`
+	TaskName  string                 `json:"taskName"`
+	Result    map[string]interface{} `json:"result"`
+	Timeout   time.Duration          `json:"timeout"`
+	Deadline  time.Time              `json:"deadline"`
+}
+
+// ICallbackStore persists the pending callbacks of asynchronous webhooks
+type ICallbackStore interface {
+	// Save stores the given callback
+	Save(callback StoredCallback) error
+	// Get returns the callback with the given ID, or nil if it does not exist
+	Get(id string) (*StoredCallback, error)
+	// Delete removes the callback with the given ID and returns false if it did not exist,
+	// e.g. because it has already been handled by another replica
+	Delete(id string) (bool, error)
+	// List returns all stored callbacks
+	List() ([]StoredCallback, error)
+}
+
+// K8sCallbackStore stores the pending callbacks as config maps in the namespace of the webhook-service
+type K8sCallbackStore struct {
+	k8sClient kubernetes.Interface
+}
+
+func NewK8sCallbackStore(k8sClient kubernetes.Interface) *K8sCallbackStore {
+	return &K8sCallbackStore{k8sClient: k8sClient}
+}
+
+func (cs *K8sCallbackStore) Save(callback StoredCallback) error {
+	data, err := json.Marshal(callback)
+	if err != nil {
+		return fmt.Errorf("could not marshal callback: %w", err)
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      callbackConfigMapPrefix + callback.ID,
+			Namespace: GetNamespaceFromEnvVar(),
+			Labels: map[string]string{
+				callbackManagedByLabel: callbackManagedByValue,
+				callbackComponentLabel: callbackComponentValue,
+			},
+		},
+		Data: map[string]string{callbackConfigMapKey: string(data)},
+	}
+	if _, err := cs.k8sClient.CoreV1().ConfigMaps(GetNamespaceFromEnvVar()).Create(context.TODO(), configMap, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("could not store callback %s: %w", callback.ID, err)
+	}
+	return nil
+}
+
+func (cs *K8sCallbackStore) Get(id string) (*StoredCallback, error) {
+	configMap, err := cs.k8sClient.CoreV1().ConfigMaps(GetNamespaceFromEnvVar()).Get(context.TODO(), callbackConfigMapPrefix+id, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("could not read callback %s: %w", id, err)
+	}
+	return decodeCallback(configMap)
+}
+
+func (cs *K8sCallbackStore) Delete(id string) (bool, error) {
+	err := cs.k8sClient.CoreV1().ConfigMaps(GetNamespaceFromEnvVar()).Delete(context.TODO(), callbackConfigMapPrefix+id, metav1.DeleteOptions{})
+	if k8serrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("could not delete callback %s: %w", id, err)
+	}
+	return true, nil
+}
+
+func (cs *K8sCallbackStore) List() ([]StoredCallback, error) {
+	configMaps, err := cs.k8sClient.CoreV1().ConfigMaps(GetNamespaceFromEnvVar()).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s,%s=%s", callbackManagedByLabel, callbackManagedByValue, callbackComponentLabel, callbackComponentValue),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not list callbacks: %w", err)
+	}
+	callbacks := []StoredCallback{}
+	for i := range configMaps.Items {
+		callback, err := decodeCallback(&configMaps.Items[i])
+		if err != nil {
+			return nil, err
+		}
+		callbacks = append(callbacks, *callback)
+	}
+	return callbacks, nil
+}
+
+func decodeCallback(configMap *corev1.ConfigMap) (*StoredCallback, error) {
+	callback := &StoredCallback{}
+	if err := json.Unmarshal([]byte(configMap.Data[callbackConfigMapKey]), callback); err != nil {
+		return nil, fmt.Errorf("could not decode callback stored in %s: %w", configMap.Name, err)
+	}
+	return callback, nil
+}
@@ -0,0 +1,49 @@
+package lib_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/go-sdk/pkg/sdk"
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestK8sCallbackStore(t *testing.T) {
+	_ = os.Setenv("POD_NAMESPACE", "keptn")
+	store := lib.NewK8sCallbackStore(fake.NewSimpleClientset())
+
+	callback := lib.StoredCallback{
+		ID:        "my-id",
+		TokenHash: "my-hash",
+		Event:     sdk.KeptnEvent{ID: "my-event-id"},
+		TaskName:  "webhook",
+		Result:    map[string]interface{}{"project": "my-project"},
+		Timeout:   time.Hour,
+		Deadline:  time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC),
+	}
+	require.Nil(t, store.Save(callback))
+	require.NotNil(t, store.Save(callback))
+
+	storedCallback, err := store.Get("my-id")
+	require.Nil(t, err)
+	require.Equal(t, callback, *storedCallback)
+
+	storedCallbacks, err := store.List()
+	require.Nil(t, err)
+	require.Equal(t, []lib.StoredCallback{callback}, storedCallbacks)
+
+	deleted, err := store.Delete("my-id")
+	require.Nil(t, err)
+	require.True(t, deleted)
+
+	deleted, err = store.Delete("my-id")
+	require.Nil(t, err)
+	require.False(t, deleted)
+
+	storedCallback, err = store.Get("my-id")
+	require.Nil(t, err)
+	require.Nil(t, storedCallback)
+}
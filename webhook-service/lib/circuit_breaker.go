@@ -0,0 +1,84 @@
+package lib
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CircuitOpenError indicates that a request has not been executed, because the circuit of its target is open
+type CircuitOpenError struct {
+	target string
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker for %s is open due to previous failures", e.target)
+}
+
+func IsCircuitOpenError(err error) bool {
+	var circuitOpenErr *CircuitOpenError
+	return errors.As(err, &circuitOpenErr)
+}
+
+type circuit struct {
+	failures int
+	openedAt time.Time
+	// probing is set while a single request checks whether the target has recovered
+	probing bool
+}
+
+// CircuitBreaker keeps track of the failed requests per target. After the given number of consecutive failures, the circuit
+// of a target is opened and requests to it are rejected. Once the open duration has passed, a single request is let through to
+// check whether the target has recovered, which either closes the circuit again or keeps it open for another open duration
+type CircuitBreaker struct {
+	failureThreshold int
+	openDuration     time.Duration
+	mutex            sync.Mutex
+	circuits         map[string]*circuit
+	now              func() time.Time
+}
+
+func NewCircuitBreaker(failureThreshold int, openDuration time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		failureThreshold: failureThreshold,
+		openDuration:     openDuration,
+		circuits:         map[string]*circuit{},
+		now:              time.Now,
+	}
+}
+
+// Allow returns a CircuitOpenError if requests to the target are currently rejected
+func (cb *CircuitBreaker) Allow(target string) error {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	c, ok := cb.circuits[target]
+	if !ok || c.failures < cb.failureThreshold {
+		return nil
+	}
+	if c.probing || cb.now().Sub(c.openedAt) < cb.openDuration {
+		return &CircuitOpenError{target: target}
+	}
+	c.probing = true
+	return nil
+}
+
+// Record stores the outcome of a request to the target
+func (cb *CircuitBreaker) Record(target string, failed bool) {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	if !failed {
+		delete(cb.circuits, target)
+		return
+	}
+	c, ok := cb.circuits[target]
+	if !ok {
+		c = &circuit{}
+		cb.circuits[target] = c
+	}
+	c.failures = c.failures + 1
+	c.probing = false
+	if c.failures >= cb.failureThreshold {
+		c.openedAt = cb.now()
+	}
+}
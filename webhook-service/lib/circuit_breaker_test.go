@@ -0,0 +1,54 @@
+package lib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	cb := NewCircuitBreaker(2, time.Minute)
+	cb.now = func() time.Time {
+		return now
+	}
+
+	// the circuit stays closed until the failure threshold is reached
+	require.Nil(t, cb.Allow("my.hook.com"))
+	cb.Record("my.hook.com", true)
+	require.Nil(t, cb.Allow("my.hook.com"))
+	cb.Record("my.hook.com", true)
+
+	err := cb.Allow("my.hook.com")
+	require.NotNil(t, err)
+	require.True(t, IsCircuitOpenError(err))
+
+	// other targets are not affected
+	require.Nil(t, cb.Allow("other.hook.com"))
+
+	// after the open duration, a single request is let through
+	now = now.Add(time.Minute)
+	require.Nil(t, cb.Allow("my.hook.com"))
+	require.True(t, IsCircuitOpenError(cb.Allow("my.hook.com")))
+
+	// if it fails, the circuit is opened again
+	cb.Record("my.hook.com", true)
+	require.True(t, IsCircuitOpenError(cb.Allow("my.hook.com")))
+
+	// if it succeeds, the circuit is closed
+	now = now.Add(time.Minute)
+	require.Nil(t, cb.Allow("my.hook.com"))
+	cb.Record("my.hook.com", false)
+	require.Nil(t, cb.Allow("my.hook.com"))
+	require.Nil(t, cb.Allow("my.hook.com"))
+}
+
+func TestCircuitBreaker_SuccessResetsFailures(t *testing.T) {
+	cb := NewCircuitBreaker(2, time.Minute)
+
+	cb.Record("my.hook.com", true)
+	cb.Record("my.hook.com", false)
+	cb.Record("my.hook.com", true)
+	require.Nil(t, cb.Allow("my.hook.com"))
+}
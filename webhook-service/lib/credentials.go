@@ -0,0 +1,85 @@
+package lib
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// SignatureHeader is the header containing the timestamp and the signature of a signed request
+const SignatureHeader = "X-Keptn-Signature"
+
+// Signing defines the secret containing the key the payloads of requests are signed with
+type Signing struct {
+	SecretRef WebHookSecretRef `yaml:"secretRef"`
+}
+
+// TLS defines the secrets containing the PEM encoded client certificate and key used for mTLS, as well as the optional
+// CA certificate used to verify the certificate of the called system
+type TLS struct {
+	ClientCertRef WebHookSecretRef  `yaml:"clientCertRef"`
+	ClientKeyRef  WebHookSecretRef  `yaml:"clientKeyRef"`
+	CARef         *WebHookSecretRef `yaml:"caRef,omitempty"`
+}
+
+// RequestCredentials contain the key the payload of a request is signed with, and the TLS configuration of the client
+type RequestCredentials struct {
+	SigningKey string
+	TLSConfig  *tls.Config
+}
+
+// NewTLSConfig creates a TLS configuration presenting the given client certificate. If a CA certificate is given,
+// only server certificates issued by it are trusted
+func NewTLSConfig(clientCert, clientKey, caCert string) (*tls.Config, error) {
+	certificate, err := tls.X509KeyPair([]byte(clientCert), []byte(clientKey))
+	if err != nil {
+		return nil, fmt.Errorf("could not load client certificate: %w", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if caCert != "" {
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM([]byte(caCert)) {
+			return nil, errors.New("could not load CA certificate")
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+	return tlsConfig, nil
+}
+
+// Sign returns the value of the signature header for the payload. The signature is the hex encoded HMAC-SHA256 of
+// '<timestamp>.<payload>', using the unix timestamp in seconds, and is sent as 't=<timestamp>,v1=<signature>'
+func Sign(key, payload string, timestamp time.Time) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(t + "." + payload))
+	return fmt.Sprintf("t=%s,v1=%s", t, hex.EncodeToString(mac.Sum(nil)))
+}
+
+func (s Signing) validate() error {
+	if s.SecretRef.Name == "" || s.SecretRef.Key == "" {
+		return errors.New("Webhook configuration invalid: missing 'webhooks[].Signing.SecretRef' part")
+	}
+	return nil
+}
+
+func (t TLS) validate() error {
+	if t.ClientCertRef.Name == "" || t.ClientCertRef.Key == "" {
+		return errors.New("Webhook configuration invalid: missing 'webhooks[].TLS.ClientCertRef' part")
+	}
+	if t.ClientKeyRef.Name == "" || t.ClientKeyRef.Key == "" {
+		return errors.New("Webhook configuration invalid: missing 'webhooks[].TLS.ClientKeyRef' part")
+	}
+	if t.CARef != nil && (t.CARef.Name == "" || t.CARef.Key == "") {
+		return errors.New("Webhook configuration invalid: missing 'webhooks[].TLS.CARef' part")
+	}
+	return nil
+}
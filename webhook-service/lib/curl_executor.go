@@ -3,9 +3,14 @@ package lib
 import (
 	"errors"
 	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
 	"strings"
 )
 
+var curlStatusCodeRegex = regexp.MustCompile(`returned error: (\d{3})`)
+
 type errType int
 
 const (
@@ -18,6 +23,8 @@ const (
 type CurlError struct {
 	err    error
 	reason errType
+	// statusCode is the status code of the response of a failed request, or 0 if no response has been received
+	statusCode int
 }
 
 func (c *CurlError) Error() string {
@@ -31,6 +38,15 @@ func NewCurlError(err error, reason errType) *CurlError {
 	}
 }
 
+// NewRequestError creates an error for a request that failed with the given status code, or 0 if no response has been received
+func NewRequestError(err error, statusCode int) *CurlError {
+	return &CurlError{
+		err:        err,
+		reason:     RequestError,
+		statusCode: statusCode,
+	}
+}
+
 func IsNoCommandError(err error) bool {
 	var curlErr *CurlError
 	if errors.As(err, &curlErr) {
@@ -105,11 +121,25 @@ func (ce *CmdCurlExecutor) Curl(curlCmd string) (string, error) {
 
 	resp, err := ce.commandExecutor.ExecuteCommand("curl", args[1:]...)
 	if err != nil {
-		return "", &CurlError{err: fmt.Errorf("error during curl request execution: %s.\nResponse: \n%s", err.Error(), resp), reason: RequestError}
+		return "", &CurlError{err: fmt.Errorf("error during curl request execution: %s.\nResponse: \n%s", err.Error(), resp), reason: RequestError, statusCode: getCurlStatusCode(err)}
 	}
 	return resp, nil
 }
 
+// getCurlStatusCode extracts the status code from the error message curl writes to stderr if a request failed due to its status code
+func getCurlStatusCode(err error) int {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return 0
+	}
+	matches := curlStatusCodeRegex.FindStringSubmatch(string(exitErr.Stderr))
+	if len(matches) != 2 {
+		return 0
+	}
+	statusCode, _ := strconv.Atoi(matches[1])
+	return statusCode
+}
+
 func (ce *CmdCurlExecutor) parseArgs(curlCmd string) ([]string, error) {
 	cmdArr := strings.Split(curlCmd, " ")
 	if len(cmdArr) == 0 || len(cmdArr) == 1 && cmdArr[0] == "" {
@@ -6,6 +6,7 @@ import (
 	"github.com/keptn/keptn/webhook-service/lib/fake"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"os/exec"
 	"testing"
 )
 
@@ -42,6 +43,29 @@ func TestNewCmdCurlExecutor_EmptyCommand(t *testing.T) {
 	require.Empty(t, output)
 }
 
+func TestCmdCurlExecutor_CurlStatusCode(t *testing.T) {
+	executor := lib.NewCmdCurlExecutor(&fake.ICommandExecutorMock{ExecuteCommandFunc: func(cmd string, args ...string) (string, error) {
+		return "bad gateway", &exec.ExitError{Stderr: []byte("curl: (22) The requested URL returned error: 502")}
+	}})
+
+	_, err := executor.Curl("curl https://my.hook.com/foo")
+
+	require.NotNil(t, err)
+	require.True(t, lib.IsRequestError(err))
+	require.True(t, lib.IsTargetError(err))
+	require.True(t, (&lib.Retry{}).IsRetryable(err))
+
+	executor = lib.NewCmdCurlExecutor(&fake.ICommandExecutorMock{ExecuteCommandFunc: func(cmd string, args ...string) (string, error) {
+		return "not found", &exec.ExitError{Stderr: []byte("curl: (22) The requested URL returned error: 404")}
+	}})
+
+	_, err = executor.Curl("curl https://my.hook.com/foo")
+
+	require.NotNil(t, err)
+	require.False(t, lib.IsTargetError(err))
+	require.False(t, (&lib.Retry{}).IsRetryable(err))
+}
+
 func TestCmdCurlExecutor_Curl(t *testing.T) {
 	type fields struct {
 		commandExecutor *fake.ICommandExecutorMock
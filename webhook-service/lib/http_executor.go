@@ -0,0 +1,335 @@
+package lib
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const defaultHTTPRequestTimeout = 30 * time.Second
+
+const maxRedirects = 10
+
+// maxResponseSize is the maximum size of a response body that is read
+const maxResponseSize = 1024 * 1024
+
+// allHosts allows requests to all hosts if it is contained in the allowed hosts
+const allHosts = "*"
+
+// internalNetworks are the networks connections are not established to, unless they are explicitly allowed,
+// in addition to loopback, unspecified and link-local addresses
+var internalNetworks = parseNetworks([]string{
+	"0.0.0.0/8",      // this network
+	"10.0.0.0/8",     // private network
+	"100.64.0.0/10",  // shared address space
+	"172.16.0.0/12",  // private network
+	"192.168.0.0/16", // private network
+	"fc00::/7",       // unique local addresses
+})
+
+//go:generate moq  -pkg fake -out ./fake/http_executor_mock.go . IHTTPExecutor
+type IHTTPExecutor interface {
+	Execute(request Request) (*HTTPResponse, error)
+}
+
+// HTTPResponse contains the status code and the body of an executed request
+type HTTPResponse struct {
+	StatusCode int
+	Body       string
+}
+
+// HTTPExecutor executes structured webhook requests using net/http
+type HTTPExecutor struct {
+	allowedSchemes  []string
+	allowedHosts    []string
+	unAllowedHosts  []string
+	allowedNetworks []*net.IPNet
+	defaultTimeout  time.Duration
+	client          *http.Client
+	transport       *http.Transport
+}
+
+type HTTPExecutorOption func(executor *HTTPExecutor)
+
+// WithAllowedHosts sets the hosts requests can be sent to. Entries starting with '*.' match all subdomains of a domain,
+// and the entry '*' matches all hosts. If no allowed hosts are set, no requests can be executed
+func WithAllowedHosts(hosts []string) HTTPExecutorOption {
+	return func(executor *HTTPExecutor) {
+		executor.allowedHosts = hosts
+	}
+}
+
+// WithUnAllowedHosts prevents requests to the given hosts, which can either be defined as '<host>' or '<host>:<port>'.
+// The hosts are checked for the URL of a request, as well as for the address a connection is established to
+func WithUnAllowedHosts(hosts []string) HTTPExecutorOption {
+	return func(executor *HTTPExecutor) {
+		executor.unAllowedHosts = hosts
+	}
+}
+
+// WithAllowedNetworks allows connections to the given internal networks in CIDR notation, e.g. the network of the services
+// of the cluster. Invalid entries are ignored
+func WithAllowedNetworks(networks []string) HTTPExecutorOption {
+	return func(executor *HTTPExecutor) {
+		executor.allowedNetworks = parseNetworks(networks)
+	}
+}
+
+func WithDefaultTimeout(timeout time.Duration) HTTPExecutorOption {
+	return func(executor *HTTPExecutor) {
+		executor.defaultTimeout = timeout
+	}
+}
+
+func NewHTTPExecutor(opts ...HTTPExecutorOption) *HTTPExecutor {
+	executor := &HTTPExecutor{
+		allowedSchemes: []string{"http", "https"},
+		defaultTimeout: defaultHTTPRequestTimeout,
+	}
+	for _, o := range opts {
+		o(executor)
+	}
+
+	dialer := &net.Dialer{
+		Timeout: executor.defaultTimeout,
+		// check the resolved address right before connecting to avoid circumventing the unallowed hosts and networks via DNS
+		Control: func(network, address string, c syscall.RawConn) error {
+			return executor.validateAddress(address)
+		},
+	}
+	executor.transport = &http.Transport{
+		// requests are never sent via a proxy, since the address validated when connecting would be the one of the proxy
+		// instead of the one of the target
+		Proxy:       nil,
+		DialContext: dialer.DialContext,
+	}
+	executor.client = &http.Client{
+		Transport: executor.transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			return executor.validateURL(req.URL)
+		},
+	}
+	return executor
+}
+
+func (he *HTTPExecutor) Execute(request Request) (*HTTPResponse, error) {
+	requestURL, err := url.Parse(request.URL)
+	if err != nil {
+		return nil, &CurlError{err: fmt.Errorf("could not parse URL: %s", err.Error()), reason: InvalidCommandError}
+	}
+	if err := he.validateURL(requestURL); err != nil {
+		return nil, &CurlError{err: err, reason: UnallowedURLError}
+	}
+
+	timeout := he.defaultTimeout
+	if request.Timeout != "" {
+		timeout, err = time.ParseDuration(request.Timeout)
+		if err != nil {
+			return nil, &CurlError{err: fmt.Errorf("could not parse timeout: %s", err.Error()), reason: InvalidCommandError}
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	method := strings.ToUpper(request.Method)
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	var body io.Reader
+	if request.Payload != "" {
+		body = strings.NewReader(request.Payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, requestURL.String(), body)
+	if err != nil {
+		return nil, &CurlError{err: fmt.Errorf("could not create request: %s", err.Error()), reason: InvalidCommandError}
+	}
+	for _, header := range request.Headers {
+		req.Header.Add(header.Key, header.Value)
+	}
+
+	client := he.client
+	if request.Credentials != nil {
+		if request.Credentials.SigningKey != "" {
+			// the signature is created for each attempt, so that the timestamp reflects when the request has been sent
+			req.Header.Set(SignatureHeader, Sign(request.Credentials.SigningKey, request.Payload, time.Now()))
+		}
+		if request.Credentials.TLSConfig != nil {
+			client = he.clientWithTLSConfig(request.Credentials.TLSConfig)
+			defer client.CloseIdleConnections()
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, &CurlError{err: fmt.Errorf("error during request execution: %s", err.Error()), reason: RequestError}
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize+1))
+	if err != nil {
+		return nil, &CurlError{err: fmt.Errorf("could not read response: %s", err.Error()), reason: RequestError}
+	}
+	if len(respBody) > maxResponseSize {
+		return nil, &CurlError{err: fmt.Errorf("response exceeds the maximum size of %d bytes", maxResponseSize), reason: RequestError, statusCode: resp.StatusCode}
+	}
+
+	// the status codes of the success condition are accepted, so that they are checked by the success condition
+	// instead of failing the request, e.g. if a 404 response is the expected result of a request
+	if !isExpectedStatusCode(resp.StatusCode, request.ExpectedStatusCodes) && !request.SuccessCondition.containsStatusCode(resp.StatusCode) {
+		return nil, &CurlError{err: fmt.Errorf("request returned unexpected status code %d.\nResponse: \n%s", resp.StatusCode, string(respBody)), reason: RequestError, statusCode: resp.StatusCode}
+	}
+	return &HTTPResponse{StatusCode: resp.StatusCode, Body: string(respBody)}, nil
+}
+
+// clientWithTLSConfig returns a client using the given TLS configuration, while keeping the validation of the addresses and redirects
+func (he *HTTPExecutor) clientWithTLSConfig(tlsConfig *tls.Config) *http.Client {
+	transport := he.transport.Clone()
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{
+		Transport:     transport,
+		CheckRedirect: he.client.CheckRedirect,
+	}
+}
+
+// isExpectedStatusCode checks the status code against the expected ones. Without expected status codes,
+// every status code below 400 is considered successful
+func isExpectedStatusCode(statusCode int, expectedStatusCodes []int) bool {
+	if len(expectedStatusCodes) == 0 {
+		return statusCode < 400
+	}
+	for _, expected := range expectedStatusCodes {
+		if statusCode == expected {
+			return true
+		}
+	}
+	return false
+}
+
+func (he *HTTPExecutor) validateURL(requestURL *url.URL) error {
+	if !containsString(he.allowedSchemes, strings.ToLower(requestURL.Scheme)) {
+		return fmt.Errorf("URL scheme '%s' is not allowed", requestURL.Scheme)
+	}
+	hostname := strings.ToLower(requestURL.Hostname())
+	if hostname == "" {
+		return errors.New("URL does not contain a host")
+	}
+	port := requestURL.Port()
+	if port == "" {
+		port = defaultPort(requestURL.Scheme)
+	}
+
+	if !he.isAllowedHost(hostname) {
+		return fmt.Errorf("host %s is not allowed", hostname)
+	}
+	return he.validateHost(hostname, port)
+}
+
+func (he *HTTPExecutor) validateAddress(address string) error {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if err := he.validateHost(host, port); err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return he.validateIP(ip)
+	}
+	return nil
+}
+
+// validateIP rejects connections to loopback, unspecified, link-local and internal addresses that are not explicitly allowed
+func (he *HTTPExecutor) validateIP(ip net.IP) error {
+	// IPv4-mapped IPv6 addresses, e.g. ::ffff:127.0.0.1, are checked as IPv4 addresses
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	if containsIP(he.allowedNetworks, ip) {
+		return nil
+	}
+	if ip.IsLoopback() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || containsIP(internalNetworks, ip) {
+		return fmt.Errorf("request to internal address %s is not allowed", ip.String())
+	}
+	return nil
+}
+
+func (he *HTTPExecutor) validateHost(hostname, port string) error {
+	for _, unAllowedHost := range he.unAllowedHosts {
+		host, unAllowedPort, err := net.SplitHostPort(unAllowedHost)
+		if err != nil {
+			// the entry does not contain a port
+			host = unAllowedHost
+			unAllowedPort = ""
+		}
+		host = strings.ToLower(strings.Trim(host, "[]"))
+		if host == hostname && (unAllowedPort == "" || unAllowedPort == port) {
+			return fmt.Errorf("request to unallowed host %s", unAllowedHost)
+		}
+	}
+	return nil
+}
+
+func (he *HTTPExecutor) isAllowedHost(hostname string) bool {
+	for _, allowedHost := range he.allowedHosts {
+		allowedHost = strings.ToLower(allowedHost)
+		if allowedHost == allHosts {
+			return true
+		}
+		if strings.HasPrefix(allowedHost, "*.") {
+			if strings.HasSuffix(hostname, allowedHost[1:]) {
+				return true
+			}
+		} else if hostname == allowedHost {
+			return true
+		}
+	}
+	return false
+}
+
+func defaultPort(scheme string) string {
+	if strings.ToLower(scheme) == "https" {
+		return "443"
+	}
+	return "80"
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func parseNetworks(cidrs []string) []*net.IPNet {
+	networks := []*net.IPNet{}
+	for _, cidr := range cidrs {
+		if _, network, err := net.ParseCIDR(strings.TrimSpace(cidr)); err == nil {
+			networks = append(networks, network)
+		}
+	}
+	return networks
+}
+
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,383 @@
+package lib_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestHTTPExecutor returns an executor allowing requests to all hosts, including the local test servers
+func newTestHTTPExecutor(opts ...lib.HTTPExecutorOption) *lib.HTTPExecutor {
+	return lib.NewHTTPExecutor(append([]lib.HTTPExecutorOption{
+		lib.WithAllowedHosts([]string{"*"}),
+		lib.WithAllowedNetworks([]string{"127.0.0.0/8", "::1/128"}),
+	}, opts...)...)
+}
+
+func TestHTTPExecutor_Execute(t *testing.T) {
+	var receivedRequest *http.Request
+	var receivedBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedRequest = r
+		body, _ := ioutil.ReadAll(r.Body)
+		receivedBody = string(body)
+		if r.URL.Path == "/created" {
+			w.WriteHeader(http.StatusCreated)
+		} else if r.URL.Path == "/error" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		_, _ = w.Write([]byte("response"))
+	}))
+	defer server.Close()
+
+	executor := newTestHTTPExecutor()
+
+	response, err := executor.Execute(lib.Request{
+		Method:  "post",
+		URL:     server.URL + "/foo",
+		Headers: []lib.Header{{Key: "Content-Type", Value: "application/json"}},
+		Payload: `{"text":"Hello, World!"}`,
+	})
+	require.Nil(t, err)
+	require.Equal(t, &lib.HTTPResponse{StatusCode: http.StatusOK, Body: "response"}, response)
+	require.Equal(t, http.MethodPost, receivedRequest.Method)
+	require.Equal(t, "/foo", receivedRequest.URL.Path)
+	require.Equal(t, "application/json", receivedRequest.Header.Get("Content-Type"))
+	require.Equal(t, `{"text":"Hello, World!"}`, receivedBody)
+
+	// GET is used by default
+	_, err = executor.Execute(lib.Request{URL: server.URL})
+	require.Nil(t, err)
+	require.Equal(t, http.MethodGet, receivedRequest.Method)
+
+	// error status codes fail the request and return the response body in the error
+	_, err = executor.Execute(lib.Request{URL: server.URL + "/error"})
+	require.NotNil(t, err)
+	require.True(t, lib.IsRequestError(err))
+	require.Contains(t, err.Error(), "500")
+	require.Contains(t, err.Error(), "response")
+
+	// only the expected status codes are accepted
+	_, err = executor.Execute(lib.Request{URL: server.URL + "/created", ExpectedStatusCodes: []int{200}})
+	require.NotNil(t, err)
+	response, err = executor.Execute(lib.Request{URL: server.URL + "/created", ExpectedStatusCodes: []int{200, 201}})
+	require.Nil(t, err)
+	require.Equal(t, &lib.HTTPResponse{StatusCode: http.StatusCreated, Body: "response"}, response)
+
+	// error status codes of the success condition are returned to be checked by the success condition
+	request := lib.Request{URL: server.URL + "/error", SuccessCondition: &lib.SuccessCondition{StatusCodes: []int{500}}}
+	response, err = executor.Execute(request)
+	require.Nil(t, err)
+	require.Equal(t, &lib.HTTPResponse{StatusCode: http.StatusInternalServerError, Body: "response"}, response)
+	require.Nil(t, request.CheckSuccessCondition(response))
+}
+
+func TestHTTPExecutor_ExecuteTimeout(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	executor := newTestHTTPExecutor()
+
+	_, err := executor.Execute(lib.Request{URL: server.URL, Timeout: "10ms"})
+	require.NotNil(t, err)
+	require.True(t, lib.IsRequestError(err))
+}
+
+func TestHTTPExecutor_ExecuteUnAllowedHosts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+
+	redirectServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://kube-api/api", http.StatusFound)
+	}))
+	defer redirectServer.Close()
+
+	tests := []struct {
+		name    string
+		opts    []lib.HTTPExecutorOption
+		url     string
+		wantErr string
+	}{
+		{
+			name: "allowed host",
+			opts: []lib.HTTPExecutorOption{lib.WithUnAllowedHosts([]string{"kube-api"})},
+			url:  server.URL,
+		},
+		{
+			name:    "unallowed host",
+			opts:    []lib.HTTPExecutorOption{lib.WithUnAllowedHosts([]string{"kube-api"})},
+			url:     "http://kube-api/api",
+			wantErr: "unallowed host",
+		},
+		{
+			name:    "unallowed host with default port",
+			opts:    []lib.HTTPExecutorOption{lib.WithUnAllowedHosts([]string{"kube-api:443"})},
+			url:     "https://KUBE-API/api",
+			wantErr: "unallowed host",
+		},
+		{
+			name: "unallowed host on other port",
+			opts: []lib.HTTPExecutorOption{lib.WithUnAllowedHosts([]string{serverURL.Hostname() + ":1"})},
+			url:  server.URL,
+		},
+		{
+			name:    "unallowed address",
+			opts:    []lib.HTTPExecutorOption{lib.WithUnAllowedHosts([]string{serverURL.Host})},
+			url:     "http://localhost:" + serverURL.Port(),
+			wantErr: "unallowed host",
+		},
+		{
+			name:    "unallowed scheme",
+			url:     "file:///etc/passwd",
+			wantErr: "scheme 'file' is not allowed",
+		},
+		{
+			name:    "redirect to unallowed host",
+			opts:    []lib.HTTPExecutorOption{lib.WithUnAllowedHosts([]string{"kube-api"})},
+			url:     redirectServer.URL,
+			wantErr: "unallowed host",
+		},
+		{
+			name: "host matching the allowed hosts",
+			opts: []lib.HTTPExecutorOption{lib.WithAllowedHosts([]string{"my.hook.com", serverURL.Hostname()})},
+			url:  server.URL,
+		},
+		{
+			name:    "host not matching the allowed hosts",
+			opts:    []lib.HTTPExecutorOption{lib.WithAllowedHosts([]string{"my.hook.com"})},
+			url:     server.URL,
+			wantErr: "is not allowed",
+		},
+		{
+			name:    "host not matching the allowed subdomains",
+			opts:    []lib.HTTPExecutorOption{lib.WithAllowedHosts([]string{"*.hook.com"})},
+			url:     "http://evilhook.com",
+			wantErr: "is not allowed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := newTestHTTPExecutor(tt.opts...)
+			_, err := executor.Execute(lib.Request{URL: tt.url})
+			if tt.wantErr != "" {
+				require.NotNil(t, err)
+				require.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				require.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestHTTPExecutor_ExecuteWithoutAllowedHosts(t *testing.T) {
+	executor := lib.NewHTTPExecutor()
+
+	_, err := executor.Execute(lib.Request{URL: "https://my.hook.com"})
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "host my.hook.com is not allowed")
+}
+
+func TestHTTPExecutor_ExecuteInternalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+
+	executor := lib.NewHTTPExecutor(lib.WithAllowedHosts([]string{"*"}))
+
+	urls := []string{
+		server.URL,
+		"http://127.0.0.2:" + serverURL.Port(),
+		"http://0.0.0.0:" + serverURL.Port(),
+		"http://[::ffff:127.0.0.1]:" + serverURL.Port(),
+		"http://[::1]:" + serverURL.Port(),
+		"http://10.0.0.1:" + serverURL.Port(),
+		"http://169.254.169.254/latest/meta-data",
+	}
+	for _, requestURL := range urls {
+		t.Run(requestURL, func(t *testing.T) {
+			_, err := executor.Execute(lib.Request{URL: requestURL, Timeout: "1s"})
+			require.NotNil(t, err)
+			require.Contains(t, err.Error(), "internal address")
+		})
+	}
+
+	// internal networks can be allowed explicitly
+	executor = lib.NewHTTPExecutor(lib.WithAllowedHosts([]string{"*"}), lib.WithAllowedNetworks([]string{"127.0.0.0/8"}))
+	_, err := executor.Execute(lib.Request{URL: server.URL})
+	require.Nil(t, err)
+}
+
+func TestHTTPExecutor_ExecuteIgnoresProxy(t *testing.T) {
+	proxyUsed := false
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxyUsed = true
+	}))
+	defer proxy.Close()
+
+	os.Setenv("HTTP_PROXY", proxy.URL)
+	os.Setenv("HTTPS_PROXY", proxy.URL)
+	defer os.Unsetenv("HTTP_PROXY")
+	defer os.Unsetenv("HTTPS_PROXY")
+
+	// the network of the proxy is allowed, which must not allow requests to internal addresses via the proxy
+	executor := lib.NewHTTPExecutor(lib.WithAllowedHosts([]string{"*"}), lib.WithAllowedNetworks([]string{"127.0.0.0/8"}))
+
+	for _, requestURL := range []string{"http://10.0.0.1:8080", "https://192.168.0.1"} {
+		t.Run(requestURL, func(t *testing.T) {
+			_, err := executor.Execute(lib.Request{URL: requestURL, Timeout: "1s"})
+			require.NotNil(t, err)
+			require.Contains(t, err.Error(), "internal address")
+			require.False(t, proxyUsed)
+		})
+	}
+}
+
+func TestHTTPExecutor_ExecuteLargeResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat("a", 2*1024*1024)))
+	}))
+	defer server.Close()
+
+	executor := newTestHTTPExecutor()
+
+	_, err := executor.Execute(lib.Request{URL: server.URL})
+	require.NotNil(t, err)
+	require.True(t, lib.IsRequestError(err))
+	require.Contains(t, err.Error(), "exceeds the maximum size")
+}
+
+func TestHTTPExecutor_ExecuteSigned(t *testing.T) {
+	var receivedSignature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedSignature = r.Header.Get(lib.SignatureHeader)
+	}))
+	defer server.Close()
+
+	executor := newTestHTTPExecutor()
+
+	before := time.Now()
+	_, err := executor.Execute(lib.Request{
+		Method:      "POST",
+		URL:         server.URL,
+		Payload:     `{"text":"Hello, World!"}`,
+		Credentials: &lib.RequestCredentials{SigningKey: "my-key"},
+	})
+	require.Nil(t, err)
+
+	// the receiver can verify the signature using the timestamp of the header and the shared key
+	parts := strings.Split(receivedSignature, ",")
+	require.Len(t, parts, 2)
+	require.True(t, strings.HasPrefix(parts[0], "t="))
+	timestamp, err := strconv.ParseInt(strings.TrimPrefix(parts[0], "t="), 10, 64)
+	require.Nil(t, err)
+	require.GreaterOrEqual(t, timestamp, before.Unix())
+
+	mac := hmac.New(sha256.New, []byte("my-key"))
+	mac.Write([]byte(fmt.Sprintf("%d.%s", timestamp, `{"text":"Hello, World!"}`)))
+	require.Equal(t, "v1="+hex.EncodeToString(mac.Sum(nil)), parts[1])
+	require.Equal(t, receivedSignature, lib.Sign("my-key", `{"text":"Hello, World!"}`, time.Unix(timestamp, 0)))
+
+	// unsigned requests do not contain the header
+	_, err = executor.Execute(lib.Request{URL: server.URL})
+	require.Nil(t, err)
+	require.Empty(t, receivedSignature)
+}
+
+func TestHTTPExecutor_ExecuteMutualTLS(t *testing.T) {
+	clientCert, clientKey := generateCertificate(t)
+	clientCAs := x509.NewCertPool()
+	require.True(t, clientCAs.AppendCertsFromPEM([]byte(clientCert)))
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	defer server.Close()
+	serverCA := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	executor := newTestHTTPExecutor()
+
+	tlsConfig, err := lib.NewTLSConfig(clientCert, clientKey, serverCA)
+	require.Nil(t, err)
+	response, err := executor.Execute(lib.Request{URL: server.URL, Credentials: &lib.RequestCredentials{TLSConfig: tlsConfig}})
+	require.Nil(t, err)
+	require.Equal(t, "keptn-webhook-service", response.Body)
+
+	// without a client certificate, the server rejects the connection
+	tlsConfig = &tls.Config{RootCAs: x509.NewCertPool()}
+	tlsConfig.RootCAs.AppendCertsFromPEM([]byte(serverCA))
+	_, err = executor.Execute(lib.Request{URL: server.URL, Credentials: &lib.RequestCredentials{TLSConfig: tlsConfig}})
+	require.NotNil(t, err)
+	require.True(t, lib.IsRequestError(err))
+
+	// the certificate of the server is not trusted without the CA certificate
+	tlsConfig, err = lib.NewTLSConfig(clientCert, clientKey, "")
+	require.Nil(t, err)
+	_, err = executor.Execute(lib.Request{URL: server.URL, Credentials: &lib.RequestCredentials{TLSConfig: tlsConfig}})
+	require.NotNil(t, err)
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	clientCert, clientKey := generateCertificate(t)
+
+	_, err := lib.NewTLSConfig(clientCert, clientKey, "")
+	require.Nil(t, err)
+
+	_, err = lib.NewTLSConfig(clientCert, "invalid", "")
+	require.NotNil(t, err)
+
+	_, err = lib.NewTLSConfig(clientCert, clientKey, "invalid")
+	require.NotNil(t, err)
+}
+
+// generateCertificate creates a self-signed PEM encoded client certificate and its key
+func generateCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "keptn-webhook-service"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.Nil(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.Nil(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+}
@@ -0,0 +1,115 @@
+package lib
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/keptn/keptn/go-sdk/pkg/sdk"
+)
+
+// hiddenValuePlaceholder replaces the values of secrets and callback tokens that are not available when rendering a webhook locally
+const hiddenValuePlaceholder = "***"
+
+// Render returns a copy of the request with the templates of the curl command, or of the URL, header values and payload
+// of a structured request, rendered using the given data
+func (r Request) Render(templateEngine ITemplateEngine, data map[string]interface{}) (*Request, error) {
+	renderedRequest := r
+	if r.IsCurlCommand() {
+		renderedCurlCommand, err := templateEngine.ParseTemplate(data, r.Curl)
+		if err != nil {
+			return nil, err
+		}
+		renderedRequest.Curl = renderedCurlCommand
+		return &renderedRequest, nil
+	}
+
+	renderedURL, err := templateEngine.ParseTemplate(data, r.URL)
+	if err != nil {
+		return nil, err
+	}
+	renderedRequest.URL = renderedURL
+
+	renderedRequest.Headers = make([]Header, 0, len(r.Headers))
+	for _, header := range r.Headers {
+		renderedValue, err := templateEngine.ParseTemplate(data, header.Value)
+		if err != nil {
+			return nil, err
+		}
+		renderedRequest.Headers = append(renderedRequest.Headers, Header{Key: header.Key, Value: renderedValue})
+	}
+
+	if r.Payload != "" {
+		renderedPayload, err := templateEngine.ParseTemplate(data, r.Payload)
+		if err != nil {
+			return nil, err
+		}
+		renderedRequest.Payload = renderedPayload
+	}
+	return &renderedRequest, nil
+}
+
+// RenderWebhook renders the requests of a webhook against a sample event without executing them. The webhook is selected by
+// the subscription ID, or by the type of the event if no subscription ID is given. Secrets are never read from the cluster,
+// instead the given values are used, or '***' if no value is given for a secret
+func RenderWebhook(webhookConfig []byte, event sdk.KeptnEvent, subscriptionID string, secrets map[string]string) ([]Request, error) {
+	whConfig, err := DecodeWebHookConfigYAML(webhookConfig)
+	if err != nil {
+		return nil, err
+	}
+	webhook, err := getWebhookToRender(whConfig, event, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	eventAdapter, err := NewEventDataAdapter(event)
+	if err != nil {
+		return nil, err
+	}
+	env := map[string]string{}
+	for _, envFrom := range webhook.EnvFrom {
+		env[envFrom.Name] = hiddenValuePlaceholder
+		if value, ok := secrets[envFrom.Name]; ok {
+			env[envFrom.Name] = value
+		}
+	}
+	eventAdapter.Add("env", env)
+	if webhook.Callback != nil {
+		eventAdapter.Add("callback", map[string]interface{}{
+			"url":   "<callback-url>",
+			"token": hiddenValuePlaceholder,
+		})
+	}
+
+	templateEngine := &TemplateEngine{}
+	renderedRequests := make([]Request, 0, len(webhook.Requests))
+	for _, req := range webhook.Requests {
+		renderedRequest, err := req.Render(templateEngine, eventAdapter.Get())
+		if err != nil {
+			return nil, fmt.Errorf("could not render request '%s': %w", req, err)
+		}
+		renderedRequests = append(renderedRequests, *renderedRequest)
+	}
+	return renderedRequests, nil
+}
+
+func getWebhookToRender(whConfig *WebHookConfig, event sdk.KeptnEvent, subscriptionID string) (*Webhook, error) {
+	var matchingWebhooks []Webhook
+	for _, webhook := range whConfig.Spec.Webhooks {
+		if subscriptionID != "" && webhook.SubscriptionID == subscriptionID {
+			return &webhook, nil
+		}
+		if subscriptionID == "" && event.Type != nil && webhook.Type == *event.Type {
+			matchingWebhooks = append(matchingWebhooks, webhook)
+		}
+	}
+	if subscriptionID != "" {
+		return nil, fmt.Errorf("no webhook with subscription ID %s found", subscriptionID)
+	}
+	if len(matchingWebhooks) == 0 {
+		return nil, errors.New("no webhook found for the type of the event")
+	}
+	if len(matchingWebhooks) > 1 {
+		return nil, errors.New("multiple webhooks found for the type of the event, a subscription ID is required")
+	}
+	return &matchingWebhooks[0], nil
+}
@@ -0,0 +1,85 @@
+package lib_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/keptn/keptn/go-sdk/pkg/sdk"
+	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/stretchr/testify/require"
+)
+
+const renderWebhookConfig = `apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      envFrom:
+        - name: token
+          secretRef:
+            name: my-secret
+            key: token
+        - name: user
+          secretRef:
+            name: my-secret
+            key: user
+      callback:
+        deadline: 1h
+      requests:
+        - "curl --fail-with-body -H 'Authorization: {{.env.token}}' https://my.hook.com/{{.data.project | urlEncode}}"
+        - method: POST
+          url: "https://my.hook.com/{{.data.stage}}"
+          headers:
+            - key: X-User
+              value: "{{.env.user}}"
+          payload: '{"service": "{{.data.service | upper}}", "callback": "{{.callback.url}}"}'
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "other-subscription-id"
+      requests:
+        - "curl --fail-with-body https://other.hook.com/{{.data.unknown}}"`
+
+func newRenderEvent(t *testing.T) sdk.KeptnEvent {
+	event := sdk.KeptnEvent{}
+	require.Nil(t, json.Unmarshal([]byte(`{
+		"type": "sh.keptn.event.webhook.triggered",
+		"specversion": "1.0",
+		"source": "test",
+		"data": {"project": "my project", "stage": "dev", "service": "carts"}
+	}`), &event))
+	return event
+}
+
+func TestRenderWebhook(t *testing.T) {
+	requests, err := lib.RenderWebhook([]byte(renderWebhookConfig), newRenderEvent(t), "my-subscription-id", map[string]string{"user": "keptn"})
+	require.Nil(t, err)
+	require.Equal(t, []lib.Request{
+		{Curl: "curl --fail-with-body -H 'Authorization: ***' https://my.hook.com/my+project"},
+		{
+			Method:  "POST",
+			URL:     "https://my.hook.com/dev",
+			Headers: []lib.Header{{Key: "X-User", Value: "keptn"}},
+			Payload: `{"service": "CARTS", "callback": "<callback-url>"}`,
+		},
+	}, requests)
+}
+
+func TestRenderWebhook_Errors(t *testing.T) {
+	// without a subscription ID, the webhook is selected by the event type, which is ambiguous here
+	_, err := lib.RenderWebhook([]byte(renderWebhookConfig), newRenderEvent(t), "", nil)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "subscription ID is required")
+
+	_, err = lib.RenderWebhook([]byte(renderWebhookConfig), newRenderEvent(t), "unknown-subscription-id", nil)
+	require.NotNil(t, err)
+
+	_, err = lib.RenderWebhook([]byte(renderWebhookConfig), newRenderEvent(t), "other-subscription-id", nil)
+	require.NotNil(t, err)
+	require.Contains(t, err.Error(), "unknown")
+
+	_, err = lib.RenderWebhook([]byte("invalid"), newRenderEvent(t), "my-subscription-id", nil)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,169 @@
+package lib
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// SuccessConditionError indicates that a request has been executed, but the response did not meet the success condition of the request
+type SuccessConditionError struct {
+	err error
+}
+
+func (e *SuccessConditionError) Error() string {
+	return e.err.Error()
+}
+
+func IsSuccessConditionError(err error) bool {
+	var successConditionErr *SuccessConditionError
+	return errors.As(err, &successConditionErr)
+}
+
+// MapResponse applies the response mappings of the request to the given response body and stores the mapped values in the target map
+func (r Request) MapResponse(body string, target map[string]interface{}) error {
+	if len(r.ResponseMappings) == 0 {
+		return nil
+	}
+	data, err := decodeResponseBody(body)
+	if err != nil {
+		return err
+	}
+	for _, mapping := range r.ResponseMappings {
+		value, err := getJSONPathValue(mapping.Path, data)
+		if err != nil {
+			return fmt.Errorf("could not map response value '%s': %s", mapping.Path, err.Error())
+		}
+		setField(target, mapping.Field, value)
+	}
+	return nil
+}
+
+// CheckSuccessCondition returns a SuccessConditionError if the response does not meet the success condition of the request
+func (r Request) CheckSuccessCondition(response *HTTPResponse) error {
+	condition := r.SuccessCondition
+	if condition == nil {
+		return nil
+	}
+	if len(condition.StatusCodes) > 0 && !isExpectedStatusCode(response.StatusCode, condition.StatusCodes) {
+		return &SuccessConditionError{err: fmt.Errorf("response status code %d does not meet the success condition", response.StatusCode)}
+	}
+	if condition.Body == nil {
+		return nil
+	}
+
+	value := response.Body
+	if condition.Body.Path != "" {
+		data, err := decodeResponseBody(response.Body)
+		if err != nil {
+			return &SuccessConditionError{err: err}
+		}
+		pathValue, err := getJSONPathValue(condition.Body.Path, data)
+		if err != nil {
+			return &SuccessConditionError{err: fmt.Errorf("could not evaluate success condition '%s': %s", condition.Body.Path, err.Error())}
+		}
+		value = toString(pathValue)
+	}
+	matched, err := regexp.MatchString(condition.Body.Matches, value)
+	if err != nil {
+		return err
+	}
+	if !matched {
+		return &SuccessConditionError{err: fmt.Errorf("response value '%s' does not match '%s'", value, condition.Body.Matches)}
+	}
+	return nil
+}
+
+// containsStatusCode returns true if the given status code is one of the status codes of the condition
+func (c *SuccessCondition) containsStatusCode(statusCode int) bool {
+	if c == nil {
+		return false
+	}
+	for _, conditionStatusCode := range c.StatusCodes {
+		if statusCode == conditionStatusCode {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeResponseBody(body string) (interface{}, error) {
+	var data interface{}
+	if err := json.Unmarshal([]byte(body), &data); err != nil {
+		return nil, fmt.Errorf("could not decode response as JSON: %s", err.Error())
+	}
+	return data, nil
+}
+
+// parseJSONPath parses a JSON path, which can be defined with or without enclosing braces, e.g. '.items[0].id' or '{.items[0].id}'
+func parseJSONPath(path string) (*jsonpath.JSONPath, error) {
+	if path == "" {
+		return nil, errors.New("empty JSON path")
+	}
+	if !strings.HasPrefix(path, "{") {
+		path = "{" + path + "}"
+	}
+	jp := jsonpath.New("")
+	if err := jp.Parse(path); err != nil {
+		return nil, err
+	}
+	return jp, nil
+}
+
+// getJSONPathValue returns the value at the given JSON path. If the path matches multiple values, they are returned as a list
+func getJSONPathValue(path string, data interface{}) (interface{}, error) {
+	jp, err := parseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+	results, err := jp.FindResults(data)
+	if err != nil {
+		return nil, err
+	}
+	values := []interface{}{}
+	for _, result := range results {
+		for _, value := range result {
+			values = append(values, value.Interface())
+		}
+	}
+	if len(values) == 0 {
+		return nil, errors.New("no value found")
+	}
+	if len(values) == 1 {
+		return values[0], nil
+	}
+	return values, nil
+}
+
+// setField sets the value at the given field of the target map. Nested fields are separated by dots, e.g. 'ticket.id'
+func setField(target map[string]interface{}, field string, value interface{}) {
+	keys := strings.Split(field, ".")
+	current := target
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			current[key] = next
+		}
+		current = next
+	}
+	current[keys[len(keys)-1]] = value
+}
+
+func toString(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return strings.TrimSpace(buf.String())
+}
@@ -0,0 +1,137 @@
+package lib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const ticketResponse = `{"id": "TICKET-1", "status": "open", "links": {"self": "https://tickets/TICKET-1"}, "assignees": [{"name": "a"}, {"name": "b"}], "priority": 2}`
+
+func TestRequest_MapResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		mappings []ResponseMapping
+		body     string
+		target   map[string]interface{}
+		want     map[string]interface{}
+		wantErr  bool
+	}{
+		{
+			name:   "no mappings",
+			body:   "no json",
+			target: map[string]interface{}{},
+			want:   map[string]interface{}{},
+		},
+		{
+			name: "map values",
+			mappings: []ResponseMapping{
+				{Path: ".id", Field: "ticketID"},
+				{Path: "{.links.self}", Field: "ticket.url"},
+				{Path: ".assignees[*].name", Field: "ticket.assignees"},
+				{Path: ".priority", Field: "priority"},
+			},
+			body:   ticketResponse,
+			target: map[string]interface{}{"ticket": map[string]interface{}{"system": "jira"}},
+			want: map[string]interface{}{
+				"ticketID": "TICKET-1",
+				"ticket": map[string]interface{}{
+					"system":    "jira",
+					"url":       "https://tickets/TICKET-1",
+					"assignees": []interface{}{"a", "b"},
+				},
+				"priority": float64(2),
+			},
+		},
+		{
+			name:     "value not found",
+			mappings: []ResponseMapping{{Path: ".unknown", Field: "ticketID"}},
+			body:     ticketResponse,
+			target:   map[string]interface{}{},
+			wantErr:  true,
+		},
+		{
+			name:     "no json response",
+			mappings: []ResponseMapping{{Path: ".id", Field: "ticketID"}},
+			body:     "TICKET-1",
+			target:   map[string]interface{}{},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Request{URL: "http://tickets", ResponseMappings: tt.mappings}
+			err := r.MapResponse(tt.body, tt.target)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, tt.target)
+		})
+	}
+}
+
+func TestRequest_CheckSuccessCondition(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition *SuccessCondition
+		response  *HTTPResponse
+		wantErr   bool
+	}{
+		{
+			name:     "no condition",
+			response: &HTTPResponse{StatusCode: 500},
+		},
+		{
+			name:      "status code matches",
+			condition: &SuccessCondition{StatusCodes: []int{200, 201}},
+			response:  &HTTPResponse{StatusCode: 201},
+		},
+		{
+			name:      "status code does not match",
+			condition: &SuccessCondition{StatusCodes: []int{200}},
+			response:  &HTTPResponse{StatusCode: 202},
+			wantErr:   true,
+		},
+		{
+			name:      "body matches",
+			condition: &SuccessCondition{Body: &BodyCondition{Matches: "TICKET-[0-9]+"}},
+			response:  &HTTPResponse{StatusCode: 200, Body: ticketResponse},
+		},
+		{
+			name:      "value at path matches",
+			condition: &SuccessCondition{Body: &BodyCondition{Path: ".status", Matches: "^(open|new)$"}},
+			response:  &HTTPResponse{StatusCode: 200, Body: ticketResponse},
+		},
+		{
+			name:      "numeric value at path matches",
+			condition: &SuccessCondition{Body: &BodyCondition{Path: ".priority", Matches: "^2$"}},
+			response:  &HTTPResponse{StatusCode: 200, Body: ticketResponse},
+		},
+		{
+			name:      "value at path does not match",
+			condition: &SuccessCondition{Body: &BodyCondition{Path: ".status", Matches: "^closed$"}},
+			response:  &HTTPResponse{StatusCode: 200, Body: ticketResponse},
+			wantErr:   true,
+		},
+		{
+			name:      "value at path not found",
+			condition: &SuccessCondition{Body: &BodyCondition{Path: ".result", Matches: "pass"}},
+			response:  &HTTPResponse{StatusCode: 200, Body: ticketResponse},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Request{URL: "http://tickets", SuccessCondition: tt.condition}
+			err := r.CheckSuccessCondition(tt.response)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				require.True(t, IsSuccessConditionError(err))
+				return
+			}
+			require.Nil(t, err)
+		})
+	}
+}
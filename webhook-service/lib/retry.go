@@ -0,0 +1,123 @@
+package lib
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const defaultRetryAttempts = 3
+const defaultInitialBackoff = time.Second
+const defaultMaxBackoff = 30 * time.Second
+
+var defaultRetryableStatusCodes = []int{429, 502, 503, 504}
+
+// GetAttempts returns the maximum number of attempts of a request, including the first one
+func (r *Retry) GetAttempts() int {
+	if r == nil {
+		return 1
+	}
+	if r.Attempts <= 0 {
+		return defaultRetryAttempts
+	}
+	return r.Attempts
+}
+
+// GetBackoff returns the time to wait before the given attempt. The backoff starts with the initial backoff and is doubled
+// for each further attempt, up to the max backoff
+func (r *Retry) GetBackoff(attempt int) time.Duration {
+	backoff := parseDurationOrDefault(r.InitialBackoff, defaultInitialBackoff)
+	maxBackoff := parseDurationOrDefault(r.MaxBackoff, defaultMaxBackoff)
+	for i := 2; i < attempt && backoff < maxBackoff; i++ {
+		backoff = backoff * 2
+	}
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+	return backoff
+}
+
+// GetDeadline returns the overall duration after which no further attempts are made, or 0 if no deadline is set
+func (r *Retry) GetDeadline() time.Duration {
+	return parseDurationOrDefault(r.Deadline, 0)
+}
+
+// IsRetryable returns true if the request failed due to a connection problem, a timeout, or a retryable status code
+func (r *Retry) IsRetryable(err error) bool {
+	var curlErr *CurlError
+	if r == nil || !errors.As(err, &curlErr) || curlErr.reason != RequestError {
+		return false
+	}
+	if curlErr.statusCode == 0 {
+		return true
+	}
+	retryableStatusCodes := r.RetryableStatusCodes
+	if len(retryableStatusCodes) == 0 {
+		retryableStatusCodes = defaultRetryableStatusCodes
+	}
+	for _, statusCode := range retryableStatusCodes {
+		if curlErr.statusCode == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Retry) validate() error {
+	if r.Attempts < 0 {
+		return errors.New("Webhook configuration invalid: 'webhooks[].Retry.Attempts' must not be negative")
+	}
+	for name, duration := range map[string]string{"InitialBackoff": r.InitialBackoff, "MaxBackoff": r.MaxBackoff, "Deadline": r.Deadline} {
+		if duration == "" {
+			continue
+		}
+		if _, err := time.ParseDuration(duration); err != nil {
+			return fmt.Errorf("Webhook configuration invalid: could not parse 'webhooks[].Retry.%s': %s", name, err.Error())
+		}
+	}
+	for _, statusCode := range r.RetryableStatusCodes {
+		if statusCode < 100 || statusCode > 599 {
+			return fmt.Errorf("Webhook configuration invalid: invalid status code %d in 'webhooks[].Retry.RetryableStatusCodes'", statusCode)
+		}
+	}
+	return nil
+}
+
+// IsTargetError returns true if the request failed because the target could not be reached, or responded with a server error
+func IsTargetError(err error) bool {
+	var curlErr *CurlError
+	if !errors.As(err, &curlErr) || curlErr.reason != RequestError {
+		return false
+	}
+	return curlErr.statusCode == 0 || curlErr.statusCode >= 500
+}
+
+// Target returns the host a request is sent to, or an empty string if the host cannot be determined
+func (r Request) Target() string {
+	if !r.IsCurlCommand() {
+		return getHost(r.URL)
+	}
+	for _, arg := range strings.Fields(r.Curl) {
+		if host := getHost(strings.Trim(arg, `'"`)); host != "" {
+			return host
+		}
+	}
+	return ""
+}
+
+func getHost(rawURL string) string {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
+		return ""
+	}
+	return strings.ToLower(parsedURL.Host)
+}
+
+func parseDurationOrDefault(duration string, defaultDuration time.Duration) time.Duration {
+	if parsed, err := time.ParseDuration(duration); err == nil {
+		return parsed
+	}
+	return defaultDuration
+}
@@ -0,0 +1,107 @@
+package lib
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetry_GetAttempts(t *testing.T) {
+	var noRetry *Retry
+	require.Equal(t, 1, noRetry.GetAttempts())
+	require.Equal(t, defaultRetryAttempts, (&Retry{}).GetAttempts())
+	require.Equal(t, 5, (&Retry{Attempts: 5}).GetAttempts())
+}
+
+func TestRetry_GetBackoff(t *testing.T) {
+	retry := &Retry{InitialBackoff: "100ms", MaxBackoff: "1s"}
+	require.Equal(t, 100*time.Millisecond, retry.GetBackoff(2))
+	require.Equal(t, 200*time.Millisecond, retry.GetBackoff(3))
+	require.Equal(t, 400*time.Millisecond, retry.GetBackoff(4))
+	require.Equal(t, 800*time.Millisecond, retry.GetBackoff(5))
+	require.Equal(t, time.Second, retry.GetBackoff(6))
+	require.Equal(t, time.Second, retry.GetBackoff(20))
+
+	retry = &Retry{}
+	require.Equal(t, defaultInitialBackoff, retry.GetBackoff(2))
+	require.Equal(t, defaultMaxBackoff, retry.GetBackoff(10))
+}
+
+func TestRetry_IsRetryable(t *testing.T) {
+	tests := []struct {
+		name  string
+		retry *Retry
+		err   error
+		want  bool
+	}{
+		{
+			name:  "no retry settings",
+			retry: nil,
+			err:   NewRequestError(errors.New("oops"), 0),
+			want:  false,
+		},
+		{
+			name:  "connection error",
+			retry: &Retry{},
+			err:   NewRequestError(errors.New("connection refused"), 0),
+			want:  true,
+		},
+		{
+			name:  "default retryable status code",
+			retry: &Retry{},
+			err:   NewRequestError(errors.New("bad gateway"), 502),
+			want:  true,
+		},
+		{
+			name:  "non retryable status code",
+			retry: &Retry{},
+			err:   NewRequestError(errors.New("bad request"), 400),
+			want:  false,
+		},
+		{
+			name:  "configured retryable status code",
+			retry: &Retry{RetryableStatusCodes: []int{409}},
+			err:   NewRequestError(errors.New("conflict"), 409),
+			want:  true,
+		},
+		{
+			name:  "status code not within configured retryable status codes",
+			retry: &Retry{RetryableStatusCodes: []int{409}},
+			err:   NewRequestError(errors.New("bad gateway"), 502),
+			want:  false,
+		},
+		{
+			name:  "unallowed URL",
+			retry: &Retry{},
+			err:   NewCurlError(errors.New("oops"), UnallowedURLError),
+			want:  false,
+		},
+		{
+			name:  "any error",
+			retry: &Retry{},
+			err:   errors.New("oops"),
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.retry.IsRetryable(tt.err))
+		})
+	}
+}
+
+func TestIsTargetError(t *testing.T) {
+	require.True(t, IsTargetError(NewRequestError(errors.New("connection refused"), 0)))
+	require.True(t, IsTargetError(NewRequestError(errors.New("internal server error"), 500)))
+	require.False(t, IsTargetError(NewRequestError(errors.New("not found"), 404)))
+	require.False(t, IsTargetError(NewCurlError(errors.New("oops"), InvalidCommandError)))
+	require.False(t, IsTargetError(nil))
+}
+
+func TestRequest_Target(t *testing.T) {
+	require.Equal(t, "my.hook.com:8080", Request{URL: "https://My.Hook.com:8080/foo"}.Target())
+	require.Equal(t, "my.hook.com", Request{Curl: `curl -X POST -H 'Content-type: application/json' 'https://my.hook.com/foo'`}.Target())
+	require.Equal(t, "", Request{Curl: "curl --version"}.Target())
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/keptn/keptn/webhook-service/lib"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTemplateEngine_ParseTemplate(t1 *testing.T) {
@@ -93,3 +94,58 @@ func TestTemplateEngine_ParseTemplate(t1 *testing.T) {
 		})
 	}
 }
+
+func TestTemplateEngine_ParseTemplateFunctions(t *testing.T) {
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"project": "my project",
+			"message": "line 1\n\"quoted\"",
+			"empty":   "",
+			"labels": map[string]interface{}{
+				"owner": "team-a",
+				"app":   "podtato-head",
+			},
+		},
+		"time": "2021-12-20T10:30:00Z",
+	}
+	tests := []struct {
+		name        string
+		templateStr string
+		want        string
+		wantErr     bool
+	}{
+		{name: "urlEncode", templateStr: `{{ .data.project | urlEncode }}`, want: "my+project"},
+		{name: "urlPathEncode", templateStr: `{{ .data.project | urlPathEncode }}`, want: "my%20project"},
+		{name: "jsonEscape", templateStr: `{"text": "{{ .data.message | jsonEscape }}"}`, want: `{"text": "line 1\n\"quoted\""}`},
+		{name: "toJson", templateStr: `{{ .data.labels | toJson }}`, want: `{"app":"podtato-head","owner":"team-a"}`},
+		{name: "b64enc", templateStr: `{{ b64enc "user:password" }}`, want: "dXNlcjpwYXNzd29yZA=="},
+		{name: "b64dec", templateStr: `{{ b64dec "dXNlcjpwYXNzd29yZA==" }}`, want: "user:password"},
+		{name: "invalid b64dec", templateStr: `{{ b64dec "%" }}`, wantErr: true},
+		{name: "strings", templateStr: `{{ .data.project | upper | replace " " "-" | quote }}`, want: `"MY-PROJECT"`},
+		{name: "split and join", templateStr: `{{ split " " .data.project | join "," }}`, want: "my,project"},
+		{name: "date", templateStr: `{{ .time | date "2006-01-02" }}`, want: "2021-12-20"},
+		{name: "toDate", templateStr: `{{ toDate "2006-01-02T15:04:05Z07:00" .time | unixEpoch }}`, want: "1639996200"},
+		{name: "dateModify", templateStr: `{{ .time | dateModify "-1h30m" | date "15:04" }}`, want: "09:00"},
+		{name: "invalid date", templateStr: `{{ .data.project | date "2006-01-02" }}`, wantErr: true},
+		{name: "default for empty value", templateStr: `{{ .data.empty | default "none" }}`, want: "none"},
+		{name: "default for set value", templateStr: `{{ .data.project | default "none" }}`, want: "my project"},
+		{name: "coalesce", templateStr: `{{ coalesce .data.empty .data.project }}`, want: "my project"},
+		{name: "dig existing key", templateStr: `{{ dig "labels" "owner" "unknown" .data }}`, want: "team-a"},
+		{name: "dig missing key", templateStr: `{{ dig "labels" "team" "unknown" .data }}`, want: "unknown"},
+		{name: "range over sorted keys", templateStr: `{{ range $key := keys .data.labels }}{{ $key }}={{ index $.data.labels $key }};{{ end }}`, want: "app=podtato-head;owner=team-a;"},
+		{name: "dict and list", templateStr: `{{ dict "stages" (list "dev" "prod") | toJson }}`, want: `{"stages":["dev","prod"]}`},
+		{name: "env is not available", templateStr: `{{ env "HOME" }}`, wantErr: true},
+		{name: "files are not available", templateStr: `{{ readFile "/etc/passwd" }}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&lib.TemplateEngine{}).ParseTemplate(data, tt.templateStr)
+			if tt.wantErr {
+				require.NotNil(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package lib
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// templateFunctions contains the functions available within webhook templates. In contrast to general purpose function
+// libraries, only functions without access to the environment, files or the network of the webhook service are included
+var templateFunctions = template.FuncMap{
+	// encoding
+	"urlEncode":     url.QueryEscape,
+	"urlPathEncode": url.PathEscape,
+	"jsonEscape":    jsonEscape,
+	"toJson":        toJSON,
+	"b64enc":        b64enc,
+	"b64dec":        b64dec,
+
+	// strings
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"trim":  strings.TrimSpace,
+	"trimPrefix": func(prefix, s string) string {
+		return strings.TrimPrefix(s, prefix)
+	},
+	"trimSuffix": func(suffix, s string) string {
+		return strings.TrimSuffix(s, suffix)
+	},
+	"replace": func(old, new, s string) string {
+		return strings.ReplaceAll(s, old, new)
+	},
+	"contains": func(substr, s string) bool {
+		return strings.Contains(s, substr)
+	},
+	"hasPrefix": func(prefix, s string) bool {
+		return strings.HasPrefix(s, prefix)
+	},
+	"hasSuffix": func(suffix, s string) bool {
+		return strings.HasSuffix(s, suffix)
+	},
+	"split": func(sep, s string) []string {
+		return strings.Split(s, sep)
+	},
+	"join":  join,
+	"quote": quote,
+
+	// dates
+	"now":        time.Now,
+	"date":       formatDate,
+	"toDate":     toDate,
+	"unixEpoch":  unixEpoch,
+	"dateModify": dateModify,
+
+	// defaults
+	"default":  defaultValue,
+	"empty":    isEmpty,
+	"coalesce": coalesce,
+	"dig":      dig,
+
+	// lists and dictionaries
+	"list": func(values ...interface{}) []interface{} {
+		return values
+	},
+	"dict": dict,
+	"keys": keys,
+}
+
+// jsonEscape escapes the value to be used within a JSON string, e.g., '{"message": "{{ .data.message | jsonEscape }}"}'
+func jsonEscape(value interface{}) (string, error) {
+	encoded, err := json.Marshal(fmt.Sprint(value))
+	if err != nil {
+		return "", err
+	}
+	return string(encoded[1 : len(encoded)-1]), nil
+}
+
+func toJSON(value interface{}) (string, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+func b64enc(value string) string {
+	return base64.StdEncoding.EncodeToString([]byte(value))
+}
+
+func b64dec(value string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", err
+	}
+	return string(decoded), nil
+}
+
+// join concatenates the elements of a list, e.g., the values of labels, using the separator
+func join(sep string, values interface{}) string {
+	v := reflect.ValueOf(values)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return fmt.Sprint(values)
+	}
+	elements := make([]string, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		elements = append(elements, fmt.Sprint(v.Index(i).Interface()))
+	}
+	return strings.Join(elements, sep)
+}
+
+func quote(value interface{}) string {
+	return fmt.Sprintf("%q", fmt.Sprint(value))
+}
+
+// formatDate formats a time, or a unix timestamp in seconds, using the Go layout, e.g., '{{ now | date "2006-01-02" }}'
+func formatDate(layout string, value interface{}) (string, error) {
+	t, err := asTime(value)
+	if err != nil {
+		return "", err
+	}
+	return t.Format(layout), nil
+}
+
+// toDate parses a string, e.g., the time of the event, using the Go layout
+func toDate(layout, value string) (time.Time, error) {
+	return time.Parse(layout, value)
+}
+
+func unixEpoch(value interface{}) (string, error) {
+	t, err := asTime(value)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprint(t.Unix()), nil
+}
+
+// dateModify adds a duration, e.g., '-1h30m', to a time
+func dateModify(duration string, value interface{}) (time.Time, error) {
+	t, err := asTime(value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	d, err := time.ParseDuration(duration)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.Add(d), nil
+}
+
+func asTime(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		return time.Parse(time.RFC3339, v)
+	case int:
+		return time.Unix(int64(v), 0), nil
+	case int64:
+		return time.Unix(v, 0), nil
+	case float64:
+		return time.Unix(int64(v), 0), nil
+	}
+	return time.Time{}, fmt.Errorf("could not convert %v to a time", value)
+}
+
+// defaultValue returns the default value if the given value is empty, e.g., '{{ .data.message | default "none" }}'.
+// Since missing keys lead to an error, optional keys need to be accessed using dig
+func defaultValue(defaultVal interface{}, value ...interface{}) interface{} {
+	if len(value) == 0 || isEmpty(value[0]) {
+		return defaultVal
+	}
+	return value[0]
+}
+
+func coalesce(values ...interface{}) interface{} {
+	for _, value := range values {
+		if !isEmpty(value) {
+			return value
+		}
+	}
+	return nil
+}
+
+func isEmpty(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	}
+	return v.IsZero()
+}
+
+// dig returns the value at the path of keys within nested maps, or the default value if any of the keys does not exist.
+// In contrast to accessing missing keys directly, this does not lead to an error, e.g., '{{ dig "labels" "owner" "unknown" .data }}'
+func dig(args ...interface{}) (interface{}, error) {
+	if len(args) < 3 {
+		return nil, errors.New("dig requires at least one key, a default value and a map")
+	}
+	current := args[len(args)-1]
+	defaultVal := args[len(args)-2]
+	for _, key := range args[:len(args)-2] {
+		k, ok := key.(string)
+		if !ok {
+			return nil, fmt.Errorf("dig requires string keys, got %v", key)
+		}
+		value, ok := getMapValue(current, k)
+		if !ok {
+			return defaultVal, nil
+		}
+		current = value
+	}
+	return current, nil
+}
+
+func getMapValue(m interface{}, key string) (interface{}, bool) {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	value := v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key()))
+	if !value.IsValid() {
+		return nil, false
+	}
+	return value.Interface(), true
+}
+
+func dict(values ...interface{}) (map[string]interface{}, error) {
+	if len(values)%2 != 0 {
+		return nil, errors.New("dict requires an even number of arguments")
+	}
+	result := map[string]interface{}{}
+	for i := 0; i < len(values); i += 2 {
+		result[fmt.Sprint(values[i])] = values[i+1]
+	}
+	return result, nil
+}
+
+// keys returns the sorted keys of a map, e.g., to iterate over the labels of an event in a stable order
+func keys(m interface{}) ([]string, error) {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Map {
+		return nil, fmt.Errorf("keys requires a map, got %T", m)
+	}
+	result := make([]string, 0, v.Len())
+	for _, key := range v.MapKeys() {
+		result = append(result, fmt.Sprint(key.Interface()))
+	}
+	sort.Strings(result)
+	return result, nil
+}
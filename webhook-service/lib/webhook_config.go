@@ -2,10 +2,19 @@ package lib
 
 import (
 	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
+const defaultCallbackDeadline = time.Hour
+
+// ResponsesField is the field of the task's data containing the raw responses of the requests of a webhook
+const ResponsesField = "responses"
+
 type WebHookConfig struct {
 	ApiVersion string            `yaml:"apiVersion"`
 	Kind       string            `yaml:"kind"`
@@ -27,7 +36,199 @@ type Webhook struct {
 	SubscriptionID string    `yaml:"subscriptionID"`
 	SendFinished   bool      `yaml:"sendFinished"`
 	EnvFrom        []EnvFrom `yaml:"envFrom"`
-	Requests       []string  `yaml:"requests"`
+	Requests       []Request `yaml:"requests"`
+	// Callback enables asynchronous webhooks, which are finished by a callback of the called system
+	Callback *Callback `yaml:"callback,omitempty"`
+	// Retry defines how failed requests of the webhook are retried
+	Retry *Retry `yaml:"retry,omitempty"`
+	// Signing enables the signature of the request payloads, allowing the called system to verify their origin
+	Signing *Signing `yaml:"signing,omitempty"`
+	// TLS defines the client certificate used for mTLS
+	TLS *TLS `yaml:"tls,omitempty"`
+}
+
+// Retry defines the number of attempts of a request, the exponential backoff between them, which status codes can be retried,
+// and the deadline after which no further attempts are made
+type Retry struct {
+	Attempts             int    `yaml:"attempts,omitempty"`
+	InitialBackoff       string `yaml:"initialBackoff,omitempty"`
+	MaxBackoff           string `yaml:"maxBackoff,omitempty"`
+	RetryableStatusCodes []int  `yaml:"retryableStatusCodes,omitempty"`
+	Deadline             string `yaml:"deadline,omitempty"`
+}
+
+// Callback defines the deadline until which the callback of an asynchronous webhook needs to be received
+type Callback struct {
+	Deadline string `yaml:"deadline,omitempty"`
+}
+
+// GetDeadline returns the deadline of the callback, or the default deadline if none is set
+func (c Callback) GetDeadline() time.Duration {
+	return parseDurationOrDefault(c.Deadline, defaultCallbackDeadline)
+}
+
+// Request is a request that is executed by a webhook. It can either be defined as a curl command, or as a structured
+// HTTP request containing the method, URL, headers, payload, timeout and expected status codes
+type Request struct {
+	// Curl contains the curl command, if the request has been defined as a string
+	Curl                string   `yaml:"-"`
+	Method              string   `yaml:"method,omitempty"`
+	URL                 string   `yaml:"url,omitempty"`
+	Headers             []Header `yaml:"headers,omitempty"`
+	Payload             string   `yaml:"payload,omitempty"`
+	Timeout             string   `yaml:"timeout,omitempty"`
+	ExpectedStatusCodes []int    `yaml:"expectedStatusCodes,omitempty"`
+	// ResponseMappings define which values of the response are added to the data of the .finished event
+	ResponseMappings []ResponseMapping `yaml:"responseMappings,omitempty"`
+	// SuccessCondition defines the condition the response needs to meet for the task to be successful
+	SuccessCondition *SuccessCondition `yaml:"successCondition,omitempty"`
+	// Credentials are read from the secrets referenced by the webhook before the request is executed
+	Credentials *RequestCredentials `yaml:"-"`
+}
+
+// ResponseMapping maps the value at the JSON path of a response body to a field of the task's event data
+type ResponseMapping struct {
+	Path  string `yaml:"path"`
+	Field string `yaml:"field"`
+}
+
+// SuccessCondition is met if the status code is one of the given ones, and if the body matches the given condition
+type SuccessCondition struct {
+	StatusCodes []int          `yaml:"statusCodes,omitempty"`
+	Body        *BodyCondition `yaml:"body,omitempty"`
+}
+
+// BodyCondition is met if the value at the JSON path of a response body, or the whole body if no path is set, matches the regular expression
+type BodyCondition struct {
+	Path    string `yaml:"path,omitempty"`
+	Matches string `yaml:"matches"`
+}
+
+// Header is a HTTP header of a request
+type Header struct {
+	Key   string `yaml:"key"`
+	Value string `yaml:"value"`
+}
+
+// UnmarshalYAML decodes a request that is either defined as a curl command string, or as a structured request
+func (r *Request) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		return value.Decode(&r.Curl)
+	}
+	type plainRequest Request
+	return value.Decode((*plainRequest)(r))
+}
+
+// MarshalYAML encodes a request defined as a curl command as string
+func (r Request) MarshalYAML() (interface{}, error) {
+	if r.IsCurlCommand() {
+		return r.Curl, nil
+	}
+	type plainRequest Request
+	return plainRequest(r), nil
+}
+
+// IsCurlCommand returns true if the request has been defined as a curl command string
+func (r Request) IsCurlCommand() bool {
+	return r.Curl != ""
+}
+
+func (r Request) String() string {
+	if r.IsCurlCommand() {
+		return r.Curl
+	}
+	return strings.TrimSpace(r.Method + " " + r.URL)
+}
+
+func (w Webhook) validateCallback() error {
+	if !strings.HasSuffix(w.Type, ".triggered") {
+		return errors.New("Webhook configuration invalid: 'webhooks[].Callback' can only be used for .triggered events")
+	}
+	if !w.SendFinished {
+		return errors.New("Webhook configuration invalid: 'webhooks[].Callback' requires 'webhooks[].SendFinished' to be enabled")
+	}
+	if w.Callback.Deadline != "" {
+		if _, err := time.ParseDuration(w.Callback.Deadline); err != nil {
+			return fmt.Errorf("Webhook configuration invalid: could not parse 'webhooks[].Callback.Deadline': %s", err.Error())
+		}
+	}
+	return nil
+}
+
+func (w Webhook) validateCredentials() error {
+	for _, request := range w.Requests {
+		if request.IsCurlCommand() {
+			return errors.New("Webhook configuration invalid: 'webhooks[].Signing' and 'webhooks[].TLS' can only be used for structured requests")
+		}
+	}
+	if w.Signing != nil {
+		if err := w.Signing.validate(); err != nil {
+			return err
+		}
+	}
+	if w.TLS != nil {
+		return w.TLS.validate()
+	}
+	return nil
+}
+
+func (r Request) validate() error {
+	if r.IsCurlCommand() {
+		return nil
+	}
+	if r.URL == "" {
+		return errors.New("Webhook configuration invalid: missing 'webhooks[].Requests[].URL' part")
+	}
+	if r.Timeout != "" {
+		if _, err := time.ParseDuration(r.Timeout); err != nil {
+			return fmt.Errorf("Webhook configuration invalid: could not parse 'webhooks[].Requests[].Timeout': %s", err.Error())
+		}
+	}
+	for _, header := range r.Headers {
+		if header.Key == "" {
+			return errors.New("Webhook configuration invalid: missing 'webhooks[].Requests[].Headers[].Key' part")
+		}
+	}
+	for _, statusCode := range r.ExpectedStatusCodes {
+		if statusCode < 100 || statusCode > 599 {
+			return fmt.Errorf("Webhook configuration invalid: invalid status code %d in 'webhooks[].Requests[].ExpectedStatusCodes'", statusCode)
+		}
+	}
+	for _, mapping := range r.ResponseMappings {
+		if mapping.Field == "" {
+			return errors.New("Webhook configuration invalid: missing 'webhooks[].Requests[].ResponseMappings[].Field' part")
+		}
+		if strings.Split(mapping.Field, ".")[0] == ResponsesField {
+			return fmt.Errorf("Webhook configuration invalid: 'webhooks[].Requests[].ResponseMappings[].Field' must not start with '%s', since it contains the raw responses", ResponsesField)
+		}
+		if _, err := parseJSONPath(mapping.Path); err != nil {
+			return fmt.Errorf("Webhook configuration invalid: could not parse 'webhooks[].Requests[].ResponseMappings[].Path': %s", err.Error())
+		}
+	}
+	if r.SuccessCondition != nil {
+		return r.SuccessCondition.validate()
+	}
+	return nil
+}
+
+func (c SuccessCondition) validate() error {
+	for _, statusCode := range c.StatusCodes {
+		if statusCode < 100 || statusCode > 599 {
+			return fmt.Errorf("Webhook configuration invalid: invalid status code %d in 'webhooks[].Requests[].SuccessCondition.StatusCodes'", statusCode)
+		}
+	}
+	if c.Body == nil {
+		return nil
+	}
+	if c.Body.Path != "" {
+		if _, err := parseJSONPath(c.Body.Path); err != nil {
+			return fmt.Errorf("Webhook configuration invalid: could not parse 'webhooks[].Requests[].SuccessCondition.Body.Path': %s", err.Error())
+		}
+	}
+	if _, err := regexp.Compile(c.Body.Matches); err != nil {
+		return fmt.Errorf("Webhook configuration invalid: could not parse 'webhooks[].Requests[].SuccessCondition.Body.Matches': %s", err.Error())
+	}
+	return nil
 }
 
 type EnvFrom struct {
@@ -65,6 +266,30 @@ func DecodeWebHookConfigYAML(webhookConfigYaml []byte) (*WebHookConfig, error) {
 		if len(webhook.Requests) == 0 {
 			return nil, errors.New("Webhook configuration invalid: missing 'webhooks[].Requests[]' part")
 		}
+
+		for _, request := range webhook.Requests {
+			if err := request.validate(); err != nil {
+				return nil, err
+			}
+		}
+
+		if webhook.Callback != nil {
+			if err := webhook.validateCallback(); err != nil {
+				return nil, err
+			}
+		}
+
+		if webhook.Retry != nil {
+			if err := webhook.Retry.validate(); err != nil {
+				return nil, err
+			}
+		}
+
+		if webhook.Signing != nil || webhook.TLS != nil {
+			if err := webhook.validateCredentials(); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	return webHookConfig, nil
@@ -49,8 +49,298 @@ spec:
 									Name: "mysecret",
 								},
 							},
-							Requests: []string{
-								"curl http://localhost:8080 {{.data.project}} {{.env.mysecret}}",
+							Requests: []Request{
+								{
+									Curl: "curl http://localhost:8080 {{.data.project}} {{.env.mysecret}}",
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid input with structured request",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - "curl http://localhost:8080 {{.data.project}}"
+        - method: POST
+          url: "http://localhost:8080/{{.data.project}}"
+          headers:
+            - key: Content-Type
+              value: application/json
+          payload: '{"project": "{{.data.project}}"}'
+          timeout: 10s
+          expectedStatusCodes:
+            - 200
+            - 201`),
+			},
+			want: &WebHookConfig{
+				ApiVersion: "webhookconfig.keptn.sh/v1alpha1",
+				Kind:       "WebhookConfig",
+				Metadata: Metadata{
+					Name: "webhook-configuration",
+				},
+				Spec: WebHookConfigSpec{
+					Webhooks: []Webhook{
+						{
+							Type:           "sh.keptn.event.webhook.triggered",
+							SubscriptionID: "my-subscription-id",
+							Requests: []Request{
+								{
+									Curl: "curl http://localhost:8080 {{.data.project}}",
+								},
+								{
+									Method: "POST",
+									URL:    "http://localhost:8080/{{.data.project}}",
+									Headers: []Header{
+										{
+											Key:   "Content-Type",
+											Value: "application/json",
+										},
+									},
+									Payload:             `{"project": "{{.data.project}}"}`,
+									Timeout:             "10s",
+									ExpectedStatusCodes: []int{200, 201},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "structured request without URL",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - method: POST`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "structured request with invalid timeout",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          timeout: soon`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "structured request with invalid success condition",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          responseMappings:
+            - path: ".id"
+              field: "ticketID"
+          successCondition:
+            body:
+              path: ".status"
+              matches: "(open"`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "structured request with invalid response mapping",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          responseMappings:
+            - path: ".id"`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "structured request with response mapping to the raw responses",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      requests:
+        - url: http://localhost:8080
+          responseMappings:
+            - path: ".id"
+              field: "responses.id"`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "callback without sendFinished",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      callback:
+        deadline: 2h
+      requests:
+        - url: http://localhost:8080`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "callback for finished event",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.finished"
+      subscriptionID: "my-subscription-id"
+      sendFinished: true
+      callback:
+        deadline: 2h
+      requests:
+        - url: http://localhost:8080`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "negative retry attempts",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      retry:
+        attempts: -1
+      requests:
+        - url: http://localhost:8080`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "invalid retry backoff",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      retry:
+        initialBackoff: soon
+      requests:
+        - url: http://localhost:8080`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "valid input with signing and tls",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      signing:
+        secretRef:
+          name: signing-secret
+          key: key
+      tls:
+        clientCertRef:
+          name: tls-secret
+          key: tls.crt
+        clientKeyRef:
+          name: tls-secret
+          key: tls.key
+      requests:
+        - url: https://localhost:8443`),
+			},
+			want: &WebHookConfig{
+				ApiVersion: "webhookconfig.keptn.sh/v1alpha1",
+				Kind:       "WebhookConfig",
+				Metadata: Metadata{
+					Name: "webhook-configuration",
+				},
+				Spec: WebHookConfigSpec{
+					Webhooks: []Webhook{
+						{
+							Type:           "sh.keptn.event.webhook.triggered",
+							SubscriptionID: "my-subscription-id",
+							Requests: []Request{
+								{
+									URL: "https://localhost:8443",
+								},
+							},
+							Signing: &Signing{
+								SecretRef: WebHookSecretRef{Name: "signing-secret", Key: "key"},
+							},
+							TLS: &TLS{
+								ClientCertRef: WebHookSecretRef{Name: "tls-secret", Key: "tls.crt"},
+								ClientKeyRef:  WebHookSecretRef{Name: "tls-secret", Key: "tls.key"},
 							},
 						},
 					},
@@ -58,6 +348,48 @@ spec:
 			},
 			wantErr: false,
 		},
+		{
+			name: "signing with curl command",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      signing:
+        secretRef:
+          name: signing-secret
+          key: key
+      requests:
+        - "curl http://localhost:8080"`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "tls without client key",
+			args: args{
+				webhookConfigYaml: []byte(`apiVersion: webhookconfig.keptn.sh/v1alpha1
+kind: WebhookConfig
+metadata:
+  name: webhook-configuration
+spec:
+  webhooks:
+    - type: "sh.keptn.event.webhook.triggered"
+      subscriptionID: "my-subscription-id"
+      tls:
+        clientCertRef:
+          name: tls-secret
+          key: tls.crt
+      requests:
+        - url: https://localhost:8443`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "invalid input",
 			args: args{
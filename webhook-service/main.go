@@ -1,13 +1,18 @@
 package main
 
 import (
+	"fmt"
 	"github.com/keptn/keptn/go-sdk/pkg/sdk"
 	"github.com/keptn/keptn/webhook-service/handler"
 	"github.com/keptn/keptn/webhook-service/lib"
 	log "github.com/sirupsen/logrus"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 const eventTypeWildcard = "*"
@@ -15,6 +20,18 @@ const serviceName = "webhook-service"
 
 const envVarLogLevel = "LOG_LEVEL"
 
+const envVarAllowedHosts = "ALLOWED_HOSTS"
+const envVarAllowedNetworks = "ALLOWED_NETWORKS"
+
+const envVarCallbackPort = "CALLBACK_PORT"
+const envVarCallbackBaseURL = "CALLBACK_BASE_URL"
+const defaultCallbackPort = "8082"
+
+const envVarCircuitBreakerFailureThreshold = "CIRCUIT_BREAKER_FAILURE_THRESHOLD"
+const envVarCircuitBreakerOpenDuration = "CIRCUIT_BREAKER_OPEN_DURATION"
+const defaultCircuitBreakerFailureThreshold = 5
+const defaultCircuitBreakerOpenDuration = 30 * time.Second
+
 func main() {
 	if os.Getenv(envVarLogLevel) != "" {
 		logLevel, err := log.ParseLevel(os.Getenv(envVarLogLevel))
@@ -33,23 +50,44 @@ func main() {
 	kubeAPIHostIP := os.Getenv("KUBERNETES_SERVICE_HOST")
 	kubeAPIPort := os.Getenv("KUBERNETES_SERVICE_PORT")
 
+	unAllowedURLs := []string{
+		kubeAPIHostIP + ":" + kubeAPIPort,
+		"kubernetes" + ":" + kubeAPIPort,
+		"kubernetes.default" + ":" + kubeAPIPort,
+		"kubernetes.default.svc.cluster.local" + ":" + kubeAPIPort,
+		"localhost",
+		"127.0.0.1",
+		"::1",
+	}
+
 	curlExecutor := lib.NewCmdCurlExecutor(
 		&lib.OSCmdExecutor{},
-		lib.WithUnAllowedURLs(
-			[]string{
-				kubeAPIHostIP + ":" + kubeAPIPort,
-				"kubernetes" + ":" + kubeAPIPort,
-				"kubernetes.default" + ":" + kubeAPIPort,
-				"kubernetes.default.svc.cluster.local" + ":" + kubeAPIPort,
-				"localhost",
-				"127.0.0.1",
-				"::1",
-			},
-		),
+		lib.WithUnAllowedURLs(unAllowedURLs),
+	)
+	httpExecutor := lib.NewHTTPExecutor(
+		lib.WithUnAllowedHosts(unAllowedURLs),
+		lib.WithAllowedHosts(getCommaSeparatedValues(envVarAllowedHosts)),
+		lib.WithAllowedNetworks(getCommaSeparatedValues(envVarAllowedNetworks)),
+	)
+	callbackPort := os.Getenv(envVarCallbackPort)
+	if callbackPort == "" {
+		callbackPort = defaultCallbackPort
+	}
+	callbackRegistry := handler.NewCallbackRegistry(
+		getCallbackBaseURL(callbackPort),
+		handler.WithCallbackStore(lib.NewK8sCallbackStore(kubeAPI)),
+	)
+
+	taskHandler := handler.NewTaskHandler(
+		&lib.TemplateEngine{},
+		curlExecutor,
+		httpExecutor,
+		secretReader,
+		handler.WithCallbackRegistry(callbackRegistry),
+		handler.WithCircuitBreaker(newCircuitBreaker()),
 	)
-	taskHandler := handler.NewTaskHandler(&lib.TemplateEngine{}, curlExecutor, secretReader)
 
-	log.Fatal(sdk.NewKeptn(
+	keptn := sdk.NewKeptn(
 		serviceName,
 		sdk.WithTaskHandler(
 			eventTypeWildcard,
@@ -57,7 +95,58 @@ func main() {
 		),
 		sdk.WithAutomaticResponse(false),
 		sdk.WithLogger(log.New()),
-	).Start())
+	)
+
+	// restore the callbacks that were pending before the webhook-service has been restarted, before callbacks are accepted
+	if err := callbackRegistry.Restore(keptn); err != nil {
+		log.WithError(err).Error("could not restore pending callbacks")
+	}
+	go func() {
+		log.Fatal(http.ListenAndServe(":"+callbackPort, callbackRegistry))
+	}()
+
+	log.Fatal(keptn.Start())
+}
+
+// getCommaSeparatedValues returns the comma separated list of values provided by the given env var,
+// e.g. the allowed hosts of the 'ALLOWED_HOSTS' env var
+func getCommaSeparatedValues(envVar string) []string {
+	values := []string{}
+	for _, value := range strings.Split(os.Getenv(envVar), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getCallbackBaseURL returns the URL provided by the 'CALLBACK_BASE_URL' env var, or the URL of the service within the cluster
+func getCallbackBaseURL(callbackPort string) string {
+	if baseURL := os.Getenv(envVarCallbackBaseURL); baseURL != "" {
+		return baseURL
+	}
+	return fmt.Sprintf("http://%s.%s:%s", serviceName, lib.GetNamespaceFromEnvVar(), callbackPort)
+}
+
+// newCircuitBreaker creates the circuit breaker for the webhook targets, configured by the 'CIRCUIT_BREAKER_FAILURE_THRESHOLD' and 'CIRCUIT_BREAKER_OPEN_DURATION' env vars
+func newCircuitBreaker() *lib.CircuitBreaker {
+	failureThreshold := defaultCircuitBreakerFailureThreshold
+	if value := os.Getenv(envVarCircuitBreakerFailureThreshold); value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed > 0 {
+			failureThreshold = parsed
+		} else {
+			log.Errorf("could not parse %s, using default of %d", envVarCircuitBreakerFailureThreshold, defaultCircuitBreakerFailureThreshold)
+		}
+	}
+	openDuration := defaultCircuitBreakerOpenDuration
+	if value := os.Getenv(envVarCircuitBreakerOpenDuration); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil {
+			openDuration = parsed
+		} else {
+			log.Errorf("could not parse %s, using default of %s", envVarCircuitBreakerOpenDuration, defaultCircuitBreakerOpenDuration)
+		}
+	}
+	return lib.NewCircuitBreaker(failureThreshold, openDuration)
 }
 
 func createKubeAPI() (*kubernetes.Clientset, error) {